@@ -7,11 +7,37 @@ import (
 	"fmt"
 	"os/exec"
 	"strings"
+	"time"
 
 	m "github.com/IAL32/az-tui/internal/models"
 )
 
+// subscriptionKey is the context key of the subscription az commands run against
+type subscriptionKey struct{}
+
+// WithSubscription returns a context whose az commands run against the given
+// subscription instead of the az CLI default. An empty ID clears the scope.
+func WithSubscription(ctx context.Context, subscriptionID string) context.Context {
+	return context.WithValue(ctx, subscriptionKey{}, subscriptionID)
+}
+
+// SubscriptionFromContext returns the subscription set with WithSubscription, if any
+func SubscriptionFromContext(ctx context.Context) string {
+	subscriptionID, _ := ctx.Value(subscriptionKey{}).(string)
+	return subscriptionID
+}
+
+// SubscriptionArgs returns the arguments scoping an az command to a subscription
+func SubscriptionArgs(subscriptionID string) []string {
+	if subscriptionID == "" {
+		return nil
+	}
+	return []string{"--subscription", subscriptionID}
+}
+
+// RunAz runs an az command, scoped to the subscription of ctx if one is set
 func RunAz(ctx context.Context, args ...string) (string, error) {
+	args = append(args, SubscriptionArgs(SubscriptionFromContext(ctx))...)
 	cmd := exec.CommandContext(ctx, "az", args...)
 	var out, errb bytes.Buffer
 	cmd.Stdout = &out
@@ -23,12 +49,26 @@ func RunAz(ctx context.Context, args ...string) (string, error) {
 }
 
 func ListContainerApps(ctx context.Context, rg string) ([]m.ContainerApp, error) {
+	var filter []string
+	if rg != "" {
+		filter = []string{"-g", rg}
+	}
+	return listContainerApps(ctx, filter...)
+}
+
+// ListEnvironmentApps lists the container apps hosted in a managed environment, in any resource group
+func ListEnvironmentApps(ctx context.Context, environmentID string) ([]m.ContainerApp, error) {
+	return listContainerApps(ctx, "--environment", environmentID)
+}
+
+func listContainerApps(ctx context.Context, filter ...string) ([]m.ContainerApp, error) {
 	q := `[].{
 		name:name,
 		resourceGroup:resourceGroup,
 		environmentId:managedEnvironmentId,
 		location:location,
 		latestRevisionName:latestRevisionName,
+		revisionMode:properties.configuration.activeRevisionsMode,
 		ingressFqdn:properties.configuration.ingress.fqdn,
 		provisioningState:properties.provisioningState,
 		runningStatus:properties.runningStatus,
@@ -43,10 +83,7 @@ func ListContainerApps(ctx context.Context, rg string) ([]m.ContainerApp, error)
 		createdAt:systemData.createdAt,
 		lastModifiedAt:systemData.lastModifiedAt
 	}`
-	args := []string{"containerapp", "list", "-o", "json", "--query", q}
-	if rg != "" {
-		args = append(args, "-g", rg)
-	}
+	args := append([]string{"containerapp", "list", "-o", "json", "--query", q}, filter...)
 	raw, err := RunAz(ctx, args...)
 	if err != nil {
 		return nil, err
@@ -88,6 +125,38 @@ func ListRevisions(ctx context.Context, name string, rg string) ([]m.Revision, e
 	}
 	return TransformRevisionsFromJSON(raw)
 }
+
+// ListTrafficWeights lists how an app's ingress traffic is split between its revisions
+func ListTrafficWeights(ctx context.Context, name string, rg string) ([]m.TrafficWeight, error) {
+	raw, err := RunAz(ctx, "containerapp", "ingress", "traffic", "show", "-n", name, "-g", rg, "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	return TransformTrafficWeightsFromJSON(raw)
+}
+
+// ListReplicas lists the replicas of a revision with the state of their containers
+func ListReplicas(ctx context.Context, ct m.ContainerApp, revName string) ([]m.Replica, error) {
+	q := `[].{
+		name:name,
+		runningState:properties.runningState,
+		createdAt:properties.createdTime,
+		containers:properties.containers[].{
+			name:name,
+			ready:ready,
+			started:started,
+			restartCount:restartCount,
+			runningState:runningState
+		}
+	}`
+	raw, err := RunAz(ctx, "containerapp", "replica", "list",
+		"-n", ct.Name, "-g", ct.ResourceGroup, "--revision", revName, "-o", "json", "--query", q)
+	if err != nil {
+		return nil, err
+	}
+	return TransformReplicasFromJSON(raw)
+}
+
 func ListContainersCmd(ctx context.Context, ct m.ContainerApp, revName string) ([]m.Container, error) {
 	// az containerapp revision show --name <app> --resource-group <rg> --revision <rev>
 	raw, err := RunAz(ctx, "containerapp", "revision", "show",
@@ -98,6 +167,187 @@ func ListContainersCmd(ctx context.Context, ct m.ContainerApp, revName string) (
 	return TransformContainersFromJSON(raw)
 }
 
+// ListSecrets lists the secrets of an app without their values
+func ListSecrets(ctx context.Context, ct m.ContainerApp) ([]m.Secret, error) {
+	raw, err := RunAz(ctx, "containerapp", "secret", "list",
+		"-n", ct.Name, "-g", ct.ResourceGroup, "-o", "json")
+	if err != nil {
+		return nil, err
+	}
+	return TransformSecretsFromJSON(raw)
+}
+
+// ShowSecret shows a secret of an app with its value
+func ShowSecret(ctx context.Context, ct m.ContainerApp, secretName string) (m.Secret, error) {
+	raw, err := RunAz(ctx, "containerapp", "secret", "show",
+		"-n", ct.Name, "-g", ct.ResourceGroup, "--secret-name", secretName, "-o", "json")
+	if err != nil {
+		return m.Secret{}, err
+	}
+	return TransformSecretFromJSON(raw)
+}
+
+// GetScale shows the replica bounds and scale rules of the revision template of an app
+func GetScale(ctx context.Context, ct m.ContainerApp) (m.Scale, error) {
+	raw, err := RunAz(ctx, "containerapp", "show",
+		"-n", ct.Name, "-g", ct.ResourceGroup, "--query", "properties.template.scale", "-o", "json")
+	if err != nil {
+		return m.Scale{}, err
+	}
+	return TransformScaleFromJSON(raw)
+}
+
+// ListAppMetrics lists the CPU, memory, request and restart metrics of an app over a
+// time window, only counting the given revision unless it is empty
+func ListAppMetrics(ctx context.Context, ct m.ContainerApp, revName string, window m.MetricsWindow) (m.MetricSet, error) {
+	args := []string{"monitor", "metrics", "list",
+		"--resource", ct.Name, "-g", ct.ResourceGroup, "--resource-type", "Microsoft.App/containerApps",
+		"--metrics", m.MetricCPU, m.MetricMemory, m.MetricRequests, m.MetricRestarts,
+		"--aggregation", "Average", "Total", "Maximum",
+		"--offset", formatMetricsDuration(window.Duration),
+		"--interval", formatMetricsDuration(window.Interval),
+		"-o", "json"}
+	if revName != "" {
+		args = append(args, "--filter", fmt.Sprintf("revisionName eq '%s'", revName))
+	}
+	raw, err := RunAz(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	return TransformMetricsFromJSON(raw)
+}
+
+// formatMetricsDuration formats a duration the way az monitor expects offsets and
+// intervals, e.g. 7d, 6h or 15m
+func formatMetricsDuration(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("%dd", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("%dh", d/time.Hour)
+	default:
+		return fmt.Sprintf("%dm", d/time.Minute)
+	}
+}
+
+// RunLogQuery runs a KQL query over the last timespan of logs in the Log
+// Analytics workspace of a managed environment
+func RunLogQuery(ctx context.Context, environmentID, query string, timespan time.Duration) (m.LogQueryResult, error) {
+	workspace, err := RunAz(ctx, "containerapp", "env", "show", "--ids", environmentID,
+		"--query", "properties.appLogsConfiguration.logAnalyticsConfiguration.customerId", "-o", "tsv")
+	if err != nil {
+		return m.LogQueryResult{}, err
+	}
+	workspace = strings.TrimSpace(workspace)
+	if workspace == "" {
+		return m.LogQueryResult{}, fmt.Errorf("environment %s does not send its logs to a Log Analytics workspace", environmentID)
+	}
+
+	raw, err := RunAz(ctx, "monitor", "log-analytics", "query", "-w", workspace,
+		"--analytics-query", query, "--timespan", formatQueryTimespan(timespan), "-o", "json")
+	if err != nil {
+		return m.LogQueryResult{}, err
+	}
+	return TransformLogQueryResultFromJSON(raw)
+}
+
+// formatQueryTimespan formats a duration as the ISO 8601 duration az monitor
+// log-analytics query expects, e.g. P7D, PT6H or PT30M
+func formatQueryTimespan(d time.Duration) string {
+	switch {
+	case d%(24*time.Hour) == 0:
+		return fmt.Sprintf("P%dD", d/(24*time.Hour))
+	case d%time.Hour == 0:
+		return fmt.Sprintf("PT%dH", d/time.Hour)
+	default:
+		return fmt.Sprintf("PT%dM", d/time.Minute)
+	}
+}
+
+func ListJobs(ctx context.Context, rg string) ([]m.Job, error) {
+	q := `[].{
+		name:name,
+		resourceGroup:resourceGroup,
+		location:location,
+		environmentId:properties.environmentId,
+		triggerType:properties.configuration.triggerType,
+		cronExpression:properties.configuration.scheduleTriggerConfig.cronExpression,
+		parallelism:(properties.configuration.scheduleTriggerConfig.parallelism||properties.configuration.manualTriggerConfig.parallelism||properties.configuration.eventTriggerConfig.parallelism),
+		replicaCompletionCount:(properties.configuration.scheduleTriggerConfig.replicaCompletionCount||properties.configuration.manualTriggerConfig.replicaCompletionCount||properties.configuration.eventTriggerConfig.replicaCompletionCount),
+		replicaRetryLimit:properties.configuration.replicaRetryLimit,
+		replicaTimeout:properties.configuration.replicaTimeout,
+		provisioningState:properties.provisioningState,
+		image:properties.template.containers[0].image,
+		cpu:properties.template.containers[0].resources.cpu,
+		memory:properties.template.containers[0].resources.memory,
+		workloadProfile:properties.workloadProfileName,
+		createdAt:systemData.createdAt,
+		lastModifiedAt:systemData.lastModifiedAt
+	}`
+	args := []string{"containerapp", "job", "list", "-o", "json", "--query", q}
+	if rg != "" {
+		args = append(args, "-g", rg)
+	}
+	raw, err := RunAz(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	return TransformJobsFromJSON(raw)
+}
+
+func ListJobExecutions(ctx context.Context, jobName, rg string) ([]m.JobExecution, error) {
+	q := `[].{
+		name:name,
+		status:properties.status,
+		startTime:properties.startTime,
+		endTime:properties.endTime
+	}`
+	raw, err := RunAz(ctx, "containerapp", "job", "execution", "list", "-n", jobName, "-g", rg, "-o", "json", "--query", q)
+	if err != nil {
+		return nil, err
+	}
+	return TransformJobExecutionsFromJSON(raw)
+}
+
+func ListManagedEnvironments(ctx context.Context, rg string) ([]m.ManagedEnvironment, error) {
+	q := `[].{
+		id:id,
+		name:name,
+		resourceGroup:resourceGroup,
+		location:location,
+		provisioningState:properties.provisioningState,
+		workloadProfiles:properties.workloadProfiles[].name,
+		infrastructureSubnetId:properties.vnetConfiguration.infrastructureSubnetId,
+		internal:properties.vnetConfiguration.internal,
+		staticIp:properties.staticIp,
+		defaultDomain:properties.defaultDomain
+	}`
+	args := []string{"containerapp", "env", "list", "-o", "json", "--query", q}
+	if rg != "" {
+		args = append(args, "-g", rg)
+	}
+	raw, err := RunAz(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	envs, err := TransformManagedEnvironmentsFromJSON(raw)
+	if err != nil {
+		return nil, err
+	}
+
+	// Apps may live in other resource groups than their environment, so count across the subscription
+	raw, err = RunAz(ctx, "containerapp", "list", "-o", "json", "--query", "[].properties.managedEnvironmentId")
+	if err != nil {
+		return nil, err
+	}
+	var appEnvironmentIDs []string
+	if err := json.Unmarshal([]byte(raw), &appEnvironmentIDs); err != nil {
+		return nil, err
+	}
+	CountAppsByEnvironment(envs, appEnvironmentIDs)
+	return envs, nil
+}
+
 func ListResourceGroups(ctx context.Context) ([]m.ResourceGroup, error) {
 	q := `[].{
 		name:name,
@@ -111,3 +361,19 @@ func ListResourceGroups(ctx context.Context) ([]m.ResourceGroup, error) {
 	}
 	return TransformResourceGroupsFromJSON(raw)
 }
+
+func ListSubscriptions(ctx context.Context) ([]m.Subscription, error) {
+	q := `[].{
+		id:id,
+		name:name,
+		tenantId:tenantId,
+		state:state,
+		isDefault:isDefault
+	}`
+	// az account list does not accept --subscription
+	raw, err := RunAz(WithSubscription(ctx, ""), "account", "list", "-o", "json", "--query", q)
+	if err != nil {
+		return nil, err
+	}
+	return TransformSubscriptionsFromJSON(raw)
+}
@@ -0,0 +1,67 @@
+package azure
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// TestSubscriptionScope tests scoping az commands to a subscription through the context
+func TestSubscriptionScope(t *testing.T) {
+	ctx := context.Background()
+	if got := SubscriptionFromContext(ctx); got != "" {
+		t.Errorf("Expected no subscription on a plain context, got %q", got)
+	}
+	if args := SubscriptionArgs(SubscriptionFromContext(ctx)); len(args) != 0 {
+		t.Errorf("Expected no arguments without a subscription, got %v", args)
+	}
+
+	ctx = WithSubscription(ctx, "00000000-0000-0000-0000-000000000002")
+	if got := strings.Join(SubscriptionArgs(SubscriptionFromContext(ctx)), " "); got != "--subscription 00000000-0000-0000-0000-000000000002" {
+		t.Errorf("Unexpected subscription arguments %q", got)
+	}
+
+	// An empty ID clears the scope
+	if got := SubscriptionFromContext(WithSubscription(ctx, "")); got != "" {
+		t.Errorf("Expected cleared subscription, got %q", got)
+	}
+}
+
+// TestFormatMetricsDuration tests formatting metrics windows for az monitor
+func TestFormatMetricsDuration(t *testing.T) {
+	expected := map[string][2]string{
+		"1h":  {"1h", "5m"},
+		"6h":  {"6h", "15m"},
+		"24h": {"1d", "1h"},
+		"7d":  {"7d", "6h"},
+	}
+	for _, window := range models.MetricsWindows {
+		want := expected[window.Name]
+		if got := formatMetricsDuration(window.Duration); got != want[0] {
+			t.Errorf("Expected %s offset %q, got %q", window.Name, want[0], got)
+		}
+		if got := formatMetricsDuration(window.Interval); got != want[1] {
+			t.Errorf("Expected %s interval %q, got %q", window.Name, want[1], got)
+		}
+	}
+}
+
+// TestFormatQueryTimespan tests formatting log query time ranges for az monitor log-analytics
+func TestFormatQueryTimespan(t *testing.T) {
+	expected := map[string]string{
+		"30m": "PT30M",
+		"1h":  "PT1H",
+		"6h":  "PT6H",
+		"24h": "P1D",
+		"3d":  "P3D",
+		"7d":  "P7D",
+		"30d": "P30D",
+	}
+	for _, timeRange := range models.LogQueryRanges {
+		if got := formatQueryTimespan(timeRange.Duration); got != expected[timeRange.Name] {
+			t.Errorf("Expected %s timespan %q, got %q", timeRange.Name, expected[timeRange.Name], got)
+		}
+	}
+}
@@ -1,8 +1,12 @@
 package azure
 
 import (
+	"bytes"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/IAL32/az-tui/internal/models"
@@ -29,6 +33,24 @@ func TransformRevisionsFromJSON(rawJSON string) ([]models.Revision, error) {
 	return revs, nil
 }
 
+// TransformTrafficWeightsFromJSON transforms raw Azure JSON to TrafficWeight models
+func TransformTrafficWeightsFromJSON(rawJSON string) ([]models.TrafficWeight, error) {
+	var weights []models.TrafficWeight
+	if err := json.Unmarshal([]byte(rawJSON), &weights); err != nil {
+		return nil, err
+	}
+	return weights, nil
+}
+
+// TransformReplicasFromJSON transforms raw Azure JSON to Replica models
+func TransformReplicasFromJSON(rawJSON string) ([]models.Replica, error) {
+	var replicas []models.Replica
+	if err := json.Unmarshal([]byte(rawJSON), &replicas); err != nil {
+		return nil, err
+	}
+	return replicas, nil
+}
+
 // TransformContainersFromJSON transforms raw Azure revision JSON to Container models
 func TransformContainersFromJSON(rawJSON string) ([]models.Container, error) {
 	// Parse the full Azure revision response structure
@@ -45,8 +67,9 @@ func TransformContainersFromJSON(rawJSON string) ([]models.Container, error) {
 						Memory string  `json:"memory"`
 					} `json:"resources"`
 					Env []struct {
-						Name  string `json:"name"`
-						Value string `json:"value"`
+						Name      string `json:"name"`
+						Value     string `json:"value"`
+						SecretRef string `json:"secretRef"`
 					} `json:"env"`
 					Probes []struct {
 						Type string `json:"type"`
@@ -66,10 +89,14 @@ func TransformContainersFromJSON(rawJSON string) ([]models.Container, error) {
 
 	containers := make([]models.Container, 0, len(resp.Properties.Template.Containers))
 	for _, c := range resp.Properties.Template.Containers {
-		// Convert env vars to map
+		// Convert env vars to map, keeping the secret each secret-backed var references
 		envMap := make(map[string]string)
+		envSecrets := make(map[string]string)
 		for _, env := range c.Env {
 			envMap[env.Name] = env.Value
+			if env.SecretRef != "" {
+				envSecrets[env.Name] = env.SecretRef
+			}
 		}
 
 		// Extract probe types
@@ -92,6 +119,7 @@ func TransformContainersFromJSON(rawJSON string) ([]models.Container, error) {
 			CPU:          c.Resources.CPU,
 			Memory:       c.Resources.Memory,
 			Env:          envMap,
+			EnvSecrets:   envSecrets,
 			Probes:       probes,
 			VolumeMounts: volumeMounts,
 		}
@@ -100,6 +128,138 @@ func TransformContainersFromJSON(rawJSON string) ([]models.Container, error) {
 	return containers, nil
 }
 
+// TransformSecretsFromJSON transforms the output of az containerapp secret list to Secret models
+func TransformSecretsFromJSON(rawJSON string) ([]models.Secret, error) {
+	var secrets []models.Secret
+	if err := json.Unmarshal([]byte(rawJSON), &secrets); err != nil {
+		return nil, err
+	}
+	return secrets, nil
+}
+
+// TransformSecretFromJSON transforms the output of az containerapp secret show to a Secret model
+func TransformSecretFromJSON(rawJSON string) (models.Secret, error) {
+	var secret models.Secret
+	if err := json.Unmarshal([]byte(rawJSON), &secret); err != nil {
+		return models.Secret{}, err
+	}
+	return secret, nil
+}
+
+// defaultMaxReplicas is the max replicas of a revision whose template leaves it unset
+const defaultMaxReplicas = 10
+
+// azureScale is the scale section of a revision template as az prints it
+type azureScale struct {
+	MinReplicas *int             `json:"minReplicas"`
+	MaxReplicas *int             `json:"maxReplicas"`
+	Rules       []azureScaleRule `json:"rules"`
+}
+
+// azureScaleRule is a scale rule, with the trigger of its type set
+type azureScaleRule struct {
+	Name       string             `json:"name"`
+	HTTP       *azureScaleTrigger `json:"http,omitempty"`
+	TCP        *azureScaleTrigger `json:"tcp,omitempty"`
+	AzureQueue *azureQueueTrigger `json:"azureQueue,omitempty"`
+	Custom     *azureScaleTrigger `json:"custom,omitempty"`
+}
+
+type azureScaleTrigger struct {
+	Type     string                 `json:"type,omitempty"`
+	Metadata map[string]string      `json:"metadata,omitempty"`
+	Auth     []models.ScaleRuleAuth `json:"auth,omitempty"`
+}
+
+type azureQueueTrigger struct {
+	AccountName string                 `json:"accountName,omitempty"`
+	QueueName   string                 `json:"queueName"`
+	QueueLength int                    `json:"queueLength"`
+	Auth        []models.ScaleRuleAuth `json:"auth,omitempty"`
+}
+
+// TransformScaleFromJSON transforms the scale section of a revision template to a
+// Scale model. A template without one scales between 0 and 10 replicas.
+func TransformScaleFromJSON(rawJSON string) (models.Scale, error) {
+	var raw azureScale
+	if trimmed := strings.TrimSpace(rawJSON); trimmed != "" && trimmed != "null" {
+		if err := json.Unmarshal([]byte(rawJSON), &raw); err != nil {
+			return models.Scale{}, err
+		}
+	}
+
+	scale := models.Scale{MaxReplicas: defaultMaxReplicas}
+	if raw.MinReplicas != nil {
+		scale.MinReplicas = *raw.MinReplicas
+	}
+	if raw.MaxReplicas != nil {
+		scale.MaxReplicas = *raw.MaxReplicas
+	}
+	for _, r := range raw.Rules {
+		rule := models.ScaleRule{Name: r.Name, Metadata: map[string]string{}}
+		var trigger *azureScaleTrigger
+		switch {
+		case r.HTTP != nil:
+			rule.Type, trigger = models.ScaleRuleHTTP, r.HTTP
+		case r.TCP != nil:
+			rule.Type, trigger = models.ScaleRuleTCP, r.TCP
+		case r.Custom != nil:
+			rule.Type, rule.CustomType, trigger = models.ScaleRuleCustom, r.Custom.Type, r.Custom
+		case r.AzureQueue != nil:
+			rule.Type, rule.Auth = models.ScaleRuleQueue, r.AzureQueue.Auth
+			rule.Metadata[models.ScaleQueueName] = r.AzureQueue.QueueName
+			rule.Metadata[models.ScaleQueueLength] = strconv.Itoa(r.AzureQueue.QueueLength)
+			if r.AzureQueue.AccountName != "" {
+				rule.Metadata[models.ScaleQueueAccountName] = r.AzureQueue.AccountName
+			}
+		}
+		if trigger != nil {
+			maps.Copy(rule.Metadata, trigger.Metadata)
+			rule.Auth = trigger.Auth
+		}
+		scale.Rules = append(scale.Rules, rule)
+	}
+	return scale, nil
+}
+
+// TransformScaleToJSON transforms a Scale model back to the scale section of a
+// revision template, as az containerapp update --yaml reads it
+func TransformScaleToJSON(scale models.Scale) (json.RawMessage, error) {
+	raw := azureScale{
+		MinReplicas: &scale.MinReplicas,
+		MaxReplicas: &scale.MaxReplicas,
+		Rules:       []azureScaleRule{},
+	}
+	for _, rule := range scale.Rules {
+		r := azureScaleRule{Name: rule.Name}
+		trigger := &azureScaleTrigger{Metadata: rule.Metadata, Auth: rule.Auth}
+		switch rule.Type {
+		case models.ScaleRuleHTTP:
+			r.HTTP = trigger
+		case models.ScaleRuleTCP:
+			r.TCP = trigger
+		case models.ScaleRuleCustom:
+			trigger.Type = rule.CustomType
+			r.Custom = trigger
+		case models.ScaleRuleQueue:
+			length, err := strconv.Atoi(rule.Metadata[models.ScaleQueueLength])
+			if err != nil {
+				return nil, fmt.Errorf("scale rule %q: %s must be a number", rule.Name, models.ScaleQueueLength)
+			}
+			r.AzureQueue = &azureQueueTrigger{
+				AccountName: rule.Metadata[models.ScaleQueueAccountName],
+				QueueName:   rule.Metadata[models.ScaleQueueName],
+				QueueLength: length,
+				Auth:        rule.Auth,
+			}
+		default:
+			return nil, fmt.Errorf("scale rule %q has unknown type %q", rule.Name, rule.Type)
+		}
+		raw.Rules = append(raw.Rules, r)
+	}
+	return json.Marshal(raw)
+}
+
 // TransformResourceGroupsFromJSON transforms raw Azure JSON to ResourceGroup models
 func TransformResourceGroupsFromJSON(rawJSON string) ([]models.ResourceGroup, error) {
 	// TODO: In a future implementation, we could apply JMESPath queries here
@@ -110,6 +270,179 @@ func TransformResourceGroupsFromJSON(rawJSON string) ([]models.ResourceGroup, er
 	return rgs, nil
 }
 
+// metricAggregations is the aggregation each metric is read with
+var metricAggregations = map[string]string{
+	models.MetricCPU:      "average",
+	models.MetricMemory:   "average",
+	models.MetricRequests: "total",
+	models.MetricRestarts: "maximum",
+}
+
+// TransformMetricsFromJSON transforms the output of az monitor metrics list to metric series.
+// Time series split by a dimension are added up point by point.
+func TransformMetricsFromJSON(rawJSON string) (models.MetricSet, error) {
+	var response struct {
+		Value []struct {
+			Name struct {
+				Value string `json:"value"`
+			} `json:"name"`
+			Unit       string `json:"unit"`
+			Timeseries []struct {
+				Data []map[string]any `json:"data"`
+			} `json:"timeseries"`
+		} `json:"value"`
+	}
+	if err := json.Unmarshal([]byte(rawJSON), &response); err != nil {
+		return nil, err
+	}
+
+	var metrics models.MetricSet
+	for _, metric := range response.Value {
+		aggregation, ok := metricAggregations[metric.Name.Value]
+		if !ok {
+			aggregation = "average"
+		}
+
+		series := models.MetricSeries{Name: metric.Name.Value, Unit: metric.Unit}
+		for _, timeseries := range metric.Timeseries {
+			for i, data := range timeseries.Data {
+				value, _ := data[aggregation].(float64) // missing values are null
+				if i < len(series.Points) {
+					series.Points[i].Value += value
+					continue
+				}
+
+				timestamp, _ := data["timeStamp"].(string)
+				t, err := ParseTimeFromAzure(timestamp)
+				if err != nil {
+					return nil, fmt.Errorf("invalid timestamp of %s: %w", metric.Name.Value, err)
+				}
+				series.Points = append(series.Points, models.MetricPoint{Time: t, Value: value})
+			}
+		}
+		metrics = append(metrics, series)
+	}
+	return metrics, nil
+}
+
+// TransformSubscriptionsFromJSON transforms raw Azure JSON to Subscription models
+func TransformSubscriptionsFromJSON(rawJSON string) ([]models.Subscription, error) {
+	var subs []models.Subscription
+	if err := json.Unmarshal([]byte(rawJSON), &subs); err != nil {
+		return nil, err
+	}
+	return subs, nil
+}
+
+// TransformManagedEnvironmentsFromJSON transforms raw Azure JSON to ManagedEnvironment models
+func TransformManagedEnvironmentsFromJSON(rawJSON string) ([]models.ManagedEnvironment, error) {
+	var envs []models.ManagedEnvironment
+	if err := json.Unmarshal([]byte(rawJSON), &envs); err != nil {
+		return nil, err
+	}
+	return envs, nil
+}
+
+// CountAppsByEnvironment sets the app count of each environment from the
+// environment IDs of the apps. Azure resource IDs are compared case-insensitively.
+func CountAppsByEnvironment(envs []models.ManagedEnvironment, appEnvironmentIDs []string) {
+	counts := make(map[string]int)
+	for _, id := range appEnvironmentIDs {
+		counts[strings.ToLower(id)]++
+	}
+	for i := range envs {
+		envs[i].AppCount = counts[strings.ToLower(envs[i].ID)]
+	}
+}
+
+// TransformJobsFromJSON transforms raw Azure JSON to Job models
+func TransformJobsFromJSON(rawJSON string) ([]models.Job, error) {
+	var jobs []models.Job
+	if err := json.Unmarshal([]byte(rawJSON), &jobs); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// TransformJobExecutionsFromJSON transforms raw Azure JSON to JobExecution models
+func TransformJobExecutionsFromJSON(rawJSON string) ([]models.JobExecution, error) {
+	var execs []models.JobExecution
+	if err := json.Unmarshal([]byte(rawJSON), &execs); err != nil {
+		return nil, err
+	}
+	return execs, nil
+}
+
+// TransformLogQueryResultFromJSON transforms the rows az monitor log-analytics
+// query prints, one object per row, to a LogQueryResult. The columns keep the
+// order of the query, and the TableName az adds to every row is left out.
+func TransformLogQueryResultFromJSON(rawJSON string) (models.LogQueryResult, error) {
+	var rows []json.RawMessage
+	if err := json.Unmarshal([]byte(rawJSON), &rows); err != nil {
+		return models.LogQueryResult{}, err
+	}
+
+	result := models.LogQueryResult{Rows: make([]map[string]string, 0, len(rows))}
+	seen := make(map[string]bool)
+	for _, raw := range rows {
+		keys, values, err := decodeOrderedObject(raw)
+		if err != nil {
+			return models.LogQueryResult{}, err
+		}
+		row := make(map[string]string, len(keys))
+		for i, key := range keys {
+			if key == "TableName" {
+				continue
+			}
+			if !seen[key] {
+				seen[key] = true
+				result.Columns = append(result.Columns, key)
+			}
+			row[key] = values[i]
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+// decodeOrderedObject decodes a JSON object into its keys, in order, and their
+// values as text. Strings are unquoted, null is empty and other values are kept as JSON.
+func decodeOrderedObject(raw json.RawMessage) ([]string, []string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return nil, nil, fmt.Errorf("expected a JSON object, got %s", raw)
+	}
+
+	var keys, values []string
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := token.(string)
+
+		var value any
+		if err := decoder.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		var text string
+		switch v := value.(type) {
+		case nil:
+		case string:
+			text = v
+		case json.Number:
+			text = v.String()
+		default:
+			b, _ := json.Marshal(v)
+			text = string(b)
+		}
+		keys = append(keys, key)
+		values = append(values, text)
+	}
+	return keys, values, nil
+}
+
 // ParseTimeFromAzure parses Azure timestamp format
 func ParseTimeFromAzure(timeStr string) (time.Time, error) {
 	if timeStr == "" {
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
 )
 
 // loadTestData loads test data from the mock testdata files
@@ -328,6 +331,481 @@ func TestTransformRevisionsFromJSON(t *testing.T) {
 	})
 }
 
+// TestTransformMetricsFromJSON tests the az monitor metrics transformation
+func TestTransformMetricsFromJSON(t *testing.T) {
+	t.Run("valid metrics", func(t *testing.T) {
+		raw := `{
+			"interval": "PT5M",
+			"value": [
+				{
+					"name": {"localizedValue": "CPU Usage", "value": "UsageNanoCores"},
+					"unit": "NanoCores",
+					"timeseries": [{"data": [
+						{"timeStamp": "2024-01-20T10:00:00Z", "average": 250000000, "total": 500000000, "maximum": 300000000},
+						{"timeStamp": "2024-01-20T10:05:00Z", "average": 150000000, "total": 300000000, "maximum": 200000000}
+					]}]
+				},
+				{
+					"name": {"localizedValue": "Requests", "value": "Requests"},
+					"unit": "Count",
+					"timeseries": [
+						{"data": [{"timeStamp": "2024-01-20T10:00:00Z", "total": 40}, {"timeStamp": "2024-01-20T10:05:00Z", "total": null}]},
+						{"data": [{"timeStamp": "2024-01-20T10:00:00Z", "total": 2}, {"timeStamp": "2024-01-20T10:05:00Z", "total": 5}]}
+					]
+				}
+			]
+		}`
+
+		result, err := TransformMetricsFromJSON(raw)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) != 2 {
+			t.Fatalf("Expected 2 metrics, got %d", len(result))
+		}
+
+		// CPU is read as an average
+		cpu := result.Get(models.MetricCPU)
+		if cpu.Unit != "NanoCores" || len(cpu.Points) != 2 || cpu.Points[0].Value != 250000000 {
+			t.Errorf("Unexpected CPU series %+v", cpu)
+		}
+		if cpu.Points[1].Time.Minute() != 5 {
+			t.Errorf("Expected the second point at 10:05, got %v", cpu.Points[1].Time)
+		}
+
+		// Requests are totals, added up across time series; null points count as zero
+		requests := result.Get(models.MetricRequests).Values()
+		if len(requests) != 2 || requests[0] != 42 || requests[1] != 5 {
+			t.Errorf("Expected requests [42 5], got %v", requests)
+		}
+
+		// Metrics that were not returned are empty
+		if restarts := result.Get(models.MetricRestarts); len(restarts.Points) != 0 {
+			t.Errorf("Expected no restart points, got %+v", restarts)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformMetricsFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestTransformJobsFromJSON tests the container app jobs transformation
+func TestTransformTrafficWeightsFromJSON(t *testing.T) {
+	t.Run("valid traffic weights from mock data", func(t *testing.T) {
+		data, err := loadTestData("traffic.json")
+		if err != nil {
+			t.Fatalf("Failed to load test data: %v", err)
+		}
+
+		var trafficMap map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(data), &trafficMap); err != nil {
+			t.Fatalf("Failed to parse traffic data: %v", err)
+		}
+
+		result, err := TransformTrafficWeightsFromJSON(string(trafficMap["web-frontend-prod"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) != 2 {
+			t.Fatalf("Expected 2 traffic weights, got %d", len(result))
+		}
+		if result[0].Label != "stable" || result[0].Weight != 80 {
+			t.Errorf("Expected stable label with weight 80, got %+v", result[0])
+		}
+
+		// Entries following the latest revision have no revision name
+		latest, err := TransformTrafficWeightsFromJSON(string(trafficMap["api-backend-prod"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if len(latest) != 1 || !latest[0].LatestRevision || latest[0].Target() != "latest" {
+			t.Errorf("Expected a single latest revision entry, got %+v", latest)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformTrafficWeightsFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestTransformScaleFromJSON tests the scale transformation and its reverse
+func TestTransformScaleFromJSON(t *testing.T) {
+	data, err := loadTestData("scale.json")
+	if err != nil {
+		t.Fatalf("Failed to load test data: %v", err)
+	}
+
+	var scaleMap map[string]json.RawMessage
+	if err := json.Unmarshal([]byte(data), &scaleMap); err != nil {
+		t.Fatalf("Failed to parse scale data: %v", err)
+	}
+
+	t.Run("rules of every type", func(t *testing.T) {
+		result, err := TransformScaleFromJSON(string(scaleMap["api-backend-prod"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.MinReplicas != 3 || result.MaxReplicas != 15 || len(result.Rules) != 2 {
+			t.Fatalf("Unexpected scale %+v", result)
+		}
+		if http := result.Rules[0]; http.Type != models.ScaleRuleHTTP || http.Metadata["concurrentRequests"] != "50" {
+			t.Errorf("Expected an http rule, got %+v", http)
+		}
+		custom := result.Rules[1]
+		if custom.Type != models.ScaleRuleCustom || custom.Trigger() != "azure-servicebus" || len(custom.Auth) != 1 {
+			t.Errorf("Expected a custom servicebus rule with auth, got %+v", custom)
+		}
+
+		// Queue rules keep their queue in the metadata
+		queue, err := TransformScaleFromJSON(string(scaleMap["worker-service-prod"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		rule := queue.Rules[0]
+		if rule.Type != models.ScaleRuleQueue || rule.Metadata["queueName"] != "work-items" || rule.Metadata["queueLength"] != "25" {
+			t.Errorf("Expected a queue rule, got %+v", rule)
+		}
+		if rule.Auth[0].SecretRef != "azure-client-secret" || rule.Auth[0].TriggerParameter != "connection" {
+			t.Errorf("Unexpected auth %+v", rule.Auth)
+		}
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, app := range []string{"api-backend-prod", "worker-service-prod", "monitoring-dashboard"} {
+			scale, err := TransformScaleFromJSON(string(scaleMap[app]))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			raw, err := TransformScaleToJSON(scale)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			again, err := TransformScaleFromJSON(string(raw))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !reflect.DeepEqual(scale, again) {
+				t.Errorf("Expected %s to round trip, got %+v and %+v", app, scale, again)
+			}
+		}
+
+		invalid := models.Scale{MaxReplicas: 1, Rules: []models.ScaleRule{
+			{Name: "queue", Type: models.ScaleRuleQueue, Metadata: map[string]string{"queueLength": "many"}},
+		}}
+		if _, err := TransformScaleToJSON(invalid); err == nil {
+			t.Error("Expected an error for a non-numeric queue length")
+		}
+	})
+
+	t.Run("no scale section", func(t *testing.T) {
+		result, err := TransformScaleFromJSON("null")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.MinReplicas != 0 || result.MaxReplicas != 10 || len(result.Rules) != 0 {
+			t.Errorf("Expected the default bounds, got %+v", result)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		if _, err := TransformScaleFromJSON(`{"invalid": json}`); err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestTransformSecretsFromJSON tests the secrets transformation
+func TestTransformSecretsFromJSON(t *testing.T) {
+	t.Run("valid secrets from mock data", func(t *testing.T) {
+		data, err := loadTestData("secrets.json")
+		if err != nil {
+			t.Fatalf("Failed to load test data: %v", err)
+		}
+
+		var secretsMap map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(data), &secretsMap); err != nil {
+			t.Fatalf("Failed to parse secrets data: %v", err)
+		}
+
+		result, err := TransformSecretsFromJSON(string(secretsMap["api-backend-prod"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) != 3 {
+			t.Fatalf("Expected 3 secrets, got %d", len(result))
+		}
+		if result[0].Name != "jwt-secret" || result[0].IsKeyVaultRef() {
+			t.Errorf("Expected jwt-secret to be stored in the app, got %+v", result[0])
+		}
+
+		// Key Vault references carry the secret URL and the identity reading it
+		keyVault := result[1]
+		if !keyVault.IsKeyVaultRef() || keyVault.Identity != "system" {
+			t.Errorf("Expected a Key Vault reference read by the system identity, got %+v", keyVault)
+		}
+	})
+
+	t.Run("single secret", func(t *testing.T) {
+		result, err := TransformSecretFromJSON(`{"name": "jwt-secret", "value": "s3cr3t"}`)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Name != "jwt-secret" || result.Value != "s3cr3t" {
+			t.Errorf("Unexpected secret %+v", result)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformSecretsFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestTransformReplicasFromJSON tests the replicas transformation
+func TestTransformReplicasFromJSON(t *testing.T) {
+	t.Run("valid replicas from mock data", func(t *testing.T) {
+		data, err := loadTestData("replicas.json")
+		if err != nil {
+			t.Fatalf("Failed to load test data: %v", err)
+		}
+
+		var replicasMap map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(data), &replicasMap); err != nil {
+			t.Fatalf("Failed to parse replicas data: %v", err)
+		}
+
+		result, err := TransformReplicasFromJSON(string(replicasMap["api-backend-prod-api-backend-prod--v1-8"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) != 5 {
+			t.Fatalf("Expected 5 replicas, got %d", len(result))
+		}
+
+		replica := result[2]
+		if replica.CreatedAt.IsZero() {
+			t.Error("Expected the creation time to be parsed")
+		}
+		if len(replica.Containers) != 2 {
+			t.Fatalf("Expected 2 containers, got %d", len(replica.Containers))
+		}
+		if replica.ReadyCount() != 1 || replica.RestartCount() != 7 {
+			t.Errorf("Expected 1 ready container and 7 restarts, got %d and %d", replica.ReadyCount(), replica.RestartCount())
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformReplicasFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+func TestTransformJobsFromJSON(t *testing.T) {
+	t.Run("valid jobs from mock data", func(t *testing.T) {
+		data, err := loadTestData("jobs.json")
+		if err != nil {
+			t.Fatalf("Failed to load test data: %v", err)
+		}
+
+		result, err := TransformJobsFromJSON(data)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) == 0 {
+			t.Fatal("Expected at least one job")
+		}
+
+		// Every job should carry its identity and trigger type
+		for _, job := range result {
+			if job.Name == "" {
+				t.Error("Expected job name to be set")
+			}
+			if job.ResourceGroup == "" {
+				t.Errorf("Expected resource group to be set for job %s", job.Name)
+			}
+			if job.TriggerType == "" {
+				t.Errorf("Expected trigger type to be set for job %s", job.Name)
+			}
+		}
+
+		// Scheduled jobs should expose their cron expression
+		for _, job := range result {
+			if job.TriggerType == "Schedule" && job.CronExpression == "" {
+				t.Errorf("Expected cron expression for scheduled job %s", job.Name)
+			}
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformJobsFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestTransformJobExecutionsFromJSON tests the job executions transformation
+func TestTransformJobExecutionsFromJSON(t *testing.T) {
+	t.Run("valid executions from mock data", func(t *testing.T) {
+		data, err := loadTestData("job_executions.json")
+		if err != nil {
+			t.Fatalf("Failed to load test data: %v", err)
+		}
+
+		var executionsMap map[string]json.RawMessage
+		if err := json.Unmarshal([]byte(data), &executionsMap); err != nil {
+			t.Fatalf("Failed to parse executions data: %v", err)
+		}
+
+		result, err := TransformJobExecutionsFromJSON(string(executionsMap["queue-processor-prod"]))
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) == 0 {
+			t.Fatal("Expected at least one execution")
+		}
+
+		// A running execution has a start time but no end time
+		running := result[0]
+		if running.Status != "Running" {
+			t.Errorf("Expected first execution to be Running, got %s", running.Status)
+		}
+		if running.StartTime.IsZero() {
+			t.Error("Expected start time to be set")
+		}
+		if !running.EndTime.IsZero() {
+			t.Error("Expected end time to be zero for a running execution")
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformJobExecutionsFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestTransformSubscriptionsFromJSON tests the subscriptions transformation
+func TestTransformSubscriptionsFromJSON(t *testing.T) {
+	t.Run("valid subscriptions from mock data", func(t *testing.T) {
+		data, err := loadTestData("subscriptions.json")
+		if err != nil {
+			t.Fatalf("Failed to load test data: %v", err)
+		}
+
+		result, err := TransformSubscriptionsFromJSON(data)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) == 0 {
+			t.Fatal("Expected at least one subscription")
+		}
+
+		defaults := 0
+		for _, sub := range result {
+			if sub.ID == "" || sub.Name == "" {
+				t.Errorf("Expected subscription ID and name to be set, got %+v", sub)
+			}
+			if sub.IsDefault {
+				defaults++
+			}
+		}
+		if defaults != 1 {
+			t.Errorf("Expected exactly one default subscription, got %d", defaults)
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformSubscriptionsFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestTransformManagedEnvironmentsFromJSON tests the managed environments transformation
+func TestTransformManagedEnvironmentsFromJSON(t *testing.T) {
+	t.Run("valid managed environments from mock data", func(t *testing.T) {
+		data, err := loadTestData("managed_environments.json")
+		if err != nil {
+			t.Fatalf("Failed to load test data: %v", err)
+		}
+
+		result, err := TransformManagedEnvironmentsFromJSON(data)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(result) == 0 {
+			t.Fatal("Expected at least one managed environment")
+		}
+
+		prod := result[0]
+		if prod.Name != "env-prod" {
+			t.Fatalf("Expected first environment to be env-prod, got %q", prod.Name)
+		}
+		if !prod.HasVNetIntegration() || !prod.Internal {
+			t.Error("Expected env-prod to be integrated into an internal VNet")
+		}
+		if len(prod.WorkloadProfiles) != 2 {
+			t.Errorf("Expected 2 workload profiles, got %v", prod.WorkloadProfiles)
+		}
+
+		// Null properties are left empty
+		for _, env := range result {
+			if env.Name == "env-dev" && (env.HasVNetIntegration() || env.WorkloadProfiles != nil) {
+				t.Errorf("Expected env-dev without VNet or workload profiles, got %+v", env)
+			}
+		}
+	})
+
+	t.Run("invalid JSON", func(t *testing.T) {
+		_, err := TransformManagedEnvironmentsFromJSON(`{"invalid": json}`)
+		if err == nil {
+			t.Error("Expected error for invalid JSON")
+		}
+	})
+}
+
+// TestCountAppsByEnvironment tests that apps are counted case-insensitively
+func TestCountAppsByEnvironment(t *testing.T) {
+	envs := []models.ManagedEnvironment{
+		{ID: "/subscriptions/1/resourceGroups/rg/providers/Microsoft.App/managedEnvironments/env-a"},
+		{ID: "/subscriptions/1/resourceGroups/rg/providers/Microsoft.App/managedEnvironments/env-b"},
+	}
+	CountAppsByEnvironment(envs, []string{
+		"/subscriptions/1/resourceGroups/rg/providers/Microsoft.App/managedEnvironments/env-a",
+		"/subscriptions/1/resourcegroups/RG/providers/Microsoft.App/managedEnvironments/env-a",
+		"",
+	})
+
+	if envs[0].AppCount != 2 {
+		t.Errorf("Expected 2 apps in env-a, got %d", envs[0].AppCount)
+	}
+	if envs[1].AppCount != 0 {
+		t.Errorf("Expected no apps in env-b, got %d", envs[1].AppCount)
+	}
+}
+
 // TestParseTimeFromAzure tests the Azure time parsing function
 func TestParseTimeFromAzure(t *testing.T) {
 	tests := []struct {
@@ -510,7 +988,7 @@ func TestTransformContainersFromJSON_EnvVars(t *testing.T) {
 		if name == "" {
 			t.Error("Environment variable name should not be empty")
 		}
-		// Value can be empty (like secrets marked as "***")
+		// Value is empty for variables backed by a secret
 		t.Logf("Found env var: %s=%s", name, value)
 	}
 
@@ -518,4 +996,52 @@ func TestTransformContainersFromJSON_EnvVars(t *testing.T) {
 	if _, exists := container.Env["NODE_ENV"]; !exists {
 		t.Error("Expected NODE_ENV environment variable from mock data")
 	}
+
+	// Secret-backed variables reference their secret instead of a value
+	if secretName, ok := container.SecretRef("JWT_SECRET"); !ok || secretName != "jwt-secret" {
+		t.Errorf("Expected JWT_SECRET to reference jwt-secret, got %q", secretName)
+	}
+	if _, ok := container.SecretRef("NODE_ENV"); ok {
+		t.Error("Expected NODE_ENV not to reference a secret")
+	}
+}
+
+// TestTransformLogQueryResultFromJSON tests keeping the column order of query results
+func TestTransformLogQueryResultFromJSON(t *testing.T) {
+	data := `[
+		{"TableName": "PrimaryResult", "TimeGenerated": "2024-01-20T10:00:00Z", "RevisionName_s": "web--v2", "Count": 42, "Extra": null},
+		{"TableName": "PrimaryResult", "TimeGenerated": "2024-01-20T09:55:00Z", "RevisionName_s": "web--v1", "Count": 7.5, "Tags": {"a": 1}}
+	]`
+
+	result, err := TransformLogQueryResultFromJSON(data)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	wantColumns := []string{"TimeGenerated", "RevisionName_s", "Count", "Extra", "Tags"}
+	if len(result.Columns) != len(wantColumns) {
+		t.Fatalf("Expected columns %v, got %v", wantColumns, result.Columns)
+	}
+	for i, column := range wantColumns {
+		if result.Columns[i] != column {
+			t.Errorf("Expected column %d to be %s, got %s", i, column, result.Columns[i])
+		}
+	}
+
+	if len(result.Rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(result.Rows))
+	}
+	if got := result.Rows[0]["Count"]; got != "42" {
+		t.Errorf("Expected numbers kept as written, got %q", got)
+	}
+	if got := result.Rows[0]["Extra"]; got != "" {
+		t.Errorf("Expected null as empty, got %q", got)
+	}
+	if got := result.Rows[1]["Tags"]; got != `{"a":1}` {
+		t.Errorf("Expected objects as JSON, got %q", got)
+	}
+
+	if _, err := TransformLogQueryResultFromJSON(`[1]`); err == nil {
+		t.Error("Expected an error for rows that are not objects")
+	}
 }
@@ -0,0 +1,153 @@
+package mock
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// Patterns reading what a query asks for, as written by the log query templates
+var (
+	queryTablePattern     = regexp.MustCompile(`^\s*(\w+)`)
+	queryAppPattern       = regexp.MustCompile(`ContainerAppName_s\s*==\s*'([^']*)'`)
+	queryRevisionPattern  = regexp.MustCompile(`RevisionName_s\s*==\s*'([^']*)'`)
+	queryContainerPattern = regexp.MustCompile(`ContainerName_s\s*==\s*'([^']*)'`)
+	queryTakePattern      = regexp.MustCompile(`\b(?:take|limit)\s+(\d+)`)
+	querySummarizePattern = regexp.MustCompile(`\bsummarize\b`)
+	queryWarningPattern   = regexp.MustCompile(`Type_s\s*==\s*'Warning'`)
+	queryErrorPattern     = regexp.MustCompile(`\bhas_any\b`)
+)
+
+// mockQueryRows is how many rows a mock query returns without a take operator
+const mockQueryRows = 100
+
+// mockConsoleMessages are the console log lines mock query results cycle
+// through, the errors being the last two
+var mockConsoleMessages = []string{
+	"GET /api/items 200 in 12ms",
+	"Processing request batch",
+	"High memory usage detected: 87%",
+	"GET /api/orders 200 in 48ms",
+	"ERROR Request to /api/orders failed: upstream timed out after 30s",
+	"Cache refreshed in 230ms",
+	"Unhandled exception: connection refused",
+}
+
+// RunLogQuery returns generated results shaped like those of the query: console
+// or system log rows of the app, revision and container the query filters on,
+// or hourly line counts for queries that summarize. Queries for errors or
+// warnings only get error lines or warning events. Results are deterministic for
+// a given query and point in time.
+func (p *Provider) RunLogQuery(ctx context.Context, environmentID, query string, timespan time.Duration) (models.LogQueryResult, error) {
+	select {
+	case <-ctx.Done():
+		return models.LogQueryResult{}, ctx.Err()
+	default:
+	}
+
+	table := firstMatch(queryTablePattern, query)
+	if table != "ContainerAppConsoleLogs_CL" && table != "ContainerAppSystemLogs_CL" {
+		return models.LogQueryResult{}, fmt.Errorf("mock: unknown table %q, expected ContainerAppConsoleLogs_CL or ContainerAppSystemLogs_CL", table)
+	}
+
+	// Queries over a whole app return the logs of its latest revision
+	app := firstMatch(queryAppPattern, query)
+	revision := firstMatch(queryRevisionPattern, query)
+	if revision == "" {
+		apps, err := p.ListEnvironmentApps(ctx, environmentID)
+		if err != nil {
+			return models.LogQueryResult{}, err
+		}
+		for _, candidate := range apps {
+			if candidate.Name == app {
+				revision = candidate.LatestRevision
+			}
+		}
+	}
+	container := firstMatch(queryContainerPattern, query)
+	if container == "" {
+		container = app
+	}
+
+	end := p.now().UTC().Truncate(time.Second)
+	seed := metricsSeed(app)
+
+	if querySummarizePattern.MatchString(query) {
+		result := models.LogQueryResult{Columns: []string{"TimeGenerated", "RevisionName_s", "Lines"}}
+		for t := end.Truncate(time.Hour); !t.Before(end.Add(-timespan)); t = t.Add(-time.Hour) {
+			lines := 200 + int(800*unit(seed, uint64(t.Unix())))
+			result.Rows = append(result.Rows, map[string]string{
+				"TimeGenerated":  t.Format(time.RFC3339),
+				"RevisionName_s": revision,
+				"Lines":          strconv.Itoa(lines),
+			})
+		}
+		return result, nil
+	}
+
+	count := mockQueryRows
+	if take := firstMatch(queryTakePattern, query); take != "" {
+		count, _ = strconv.Atoi(take)
+	}
+	step := max(time.Second, timespan/time.Duration(max(1, count)))
+
+	messages, events := mockConsoleMessages, mockSystemEvents
+	if queryErrorPattern.MatchString(query) {
+		messages = messages[len(messages)-2:]
+	}
+	if queryWarningPattern.MatchString(query) {
+		events = events[2:4]
+	}
+
+	var result models.LogQueryResult
+	if table == "ContainerAppSystemLogs_CL" {
+		result.Columns = []string{"TimeGenerated", "RevisionName_s", "ReplicaName_s", "Type_s", "Reason_s", "Log_s"}
+	} else {
+		result.Columns = []string{"TimeGenerated", "RevisionName_s", "ContainerGroupName_s", "ContainerName_s", "Log_s"}
+	}
+	for i := range count {
+		t := end.Add(-time.Duration(i) * step)
+		k := int(unit(seed, uint64(i)) * 1000)
+		row := map[string]string{
+			"TimeGenerated":  t.Format(time.RFC3339),
+			"RevisionName_s": revision,
+		}
+		replica := fmt.Sprintf("%s-%d", revision, k%3)
+		if table == "ContainerAppSystemLogs_CL" {
+			event := events[k%len(events)]
+			row["ReplicaName_s"] = replica
+			row["Type_s"] = event.kind
+			row["Reason_s"] = event.reason
+			row["Log_s"] = event.message
+		} else {
+			row["ContainerGroupName_s"] = replica
+			row["ContainerName_s"] = container
+			row["Log_s"] = messages[k%len(messages)]
+		}
+		result.Rows = append(result.Rows, row)
+	}
+	return result, nil
+}
+
+// mockSystemEvents are the system log events mock query results cycle through,
+// the warnings being the third and fourth
+var mockSystemEvents = []struct{ kind, reason, message string }{
+	{"Normal", "RevisionCreation", "Creating new revision"},
+	{"Normal", "PullingImage", "Pulling image"},
+	{"Warning", "ProbeFailed", "Probe of StartUp failed with status code: 1"},
+	{"Warning", "ContainerTerminated", "Container was terminated with exit code '1'"},
+	{"Normal", "ContainerStarted", "Started container"},
+	{"Normal", "RevisionReady", "Successfully provisioned revision"},
+}
+
+// firstMatch returns the first group of the first match of a pattern, empty if it does not match
+func firstMatch(pattern *regexp.Regexp, s string) string {
+	if match := pattern.FindStringSubmatch(s); match != nil {
+		return match[1]
+	}
+	return ""
+}
@@ -0,0 +1,106 @@
+package mock
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// GetAppMetrics returns generated metrics of an app, or of one of its revisions.
+// The series are deterministic: the same app, revision and point in time always
+// yield the same value, so the sparklines stay stable across refreshes.
+func (p *Provider) GetAppMetrics(ctx context.Context, app models.ContainerApp, revisionName string, window models.MetricsWindow) (models.MetricSet, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Only active revisions have replicas, and they get their share of the requests
+	load, requestShare := 1.0, 1.0
+	if revisionName != "" {
+		revisions, err := p.ListRevisions(ctx, app.Name, app.ResourceGroup)
+		if err != nil {
+			return nil, err
+		}
+		load = 0
+		for _, rev := range revisions {
+			if rev.Name == revisionName && rev.Active {
+				load, requestShare = 1, float64(rev.Traffic)/100
+			}
+		}
+	}
+	if app.IngressFQDN == "" {
+		requestShare = 0
+	}
+
+	seed := metricsSeed(app.Name)
+	cpuCores := app.CPU
+	if cpuCores == 0 {
+		cpuCores = 0.5
+	}
+	// Requests are generated per 5 minutes and scaled to the interval
+	perInterval := window.Interval.Minutes() / 5
+
+	specs := []struct {
+		name, unit string
+		base       float64
+		value      func(level, noise float64) float64
+	}{
+		{models.MetricCPU, "NanoCores", cpuCores * 1e9 * (0.2 + 0.4*unit(seed, 1)), func(level, noise float64) float64 {
+			return level * (0.8 + 0.4*noise)
+		}},
+		{models.MetricMemory, "Bytes", (256 + 512*unit(seed, 2)) * 1024 * 1024, func(level, noise float64) float64 {
+			return level * (0.95 + 0.1*noise)
+		}},
+		{models.MetricRequests, "Count", (50 + 450*unit(seed, 3)) * perInterval * requestShare, func(level, noise float64) float64 {
+			return math.Round(level * (0.7 + 0.6*noise))
+		}},
+		{models.MetricRestarts, "Count", 1, func(level, noise float64) float64 {
+			// Rare restarts, more likely over longer intervals
+			if noise > 1-0.01*perInterval {
+				return level
+			}
+			return 0
+		}},
+	}
+
+	end := p.now().Truncate(window.Interval)
+	count := window.Points()
+
+	metrics := make(models.MetricSet, 0, len(specs))
+	for i, spec := range specs {
+		series := models.MetricSeries{Name: spec.name, Unit: spec.unit, Points: make([]models.MetricPoint, count)}
+		for j := range count {
+			t := end.Add(-time.Duration(count-1-j) * window.Interval)
+			// Daily cycle peaking in the afternoon
+			daily := 1 + 0.3*math.Sin(2*math.Pi*(float64(t.Hour())-8)/24)
+			noise := unit(seed+uint64(i), uint64(t.Unix()))
+			series.Points[j] = models.MetricPoint{Time: t, Value: load * spec.value(spec.base*daily, noise)}
+		}
+		metrics = append(metrics, series)
+	}
+	return metrics, nil
+}
+
+// metricsSeed derives the seed of the metrics of an app from its name
+func metricsSeed(name string) uint64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return h.Sum64()
+}
+
+// unit returns a deterministic pseudo-random number in [0, 1) for a seed and key
+func unit(seed, key uint64) float64 {
+	h := fnv.New64a()
+	var b [16]byte
+	for i := range 8 {
+		b[i] = byte(seed >> (8 * i))
+		b[8+i] = byte(key >> (8 * i))
+	}
+	h.Write(b[:])
+	return float64(h.Sum64()>>11) / (1 << 53)
+}
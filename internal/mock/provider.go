@@ -5,7 +5,12 @@ import (
 	"embed"
 	"encoding/json"
 	"fmt"
+	"maps"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/IAL32/az-tui/internal/azure"
 	"github.com/IAL32/az-tui/internal/models"
@@ -14,12 +19,84 @@ import (
 //go:embed testdata/*.json
 var testDataFS embed.FS
 
-// Provider implements the DataProvider interface using mock data
-type Provider struct{}
+// jobExecutionDuration is how long an execution started in mock mode runs
+// before it is reported as succeeded.
+const jobExecutionDuration = 20 * time.Second
+
+// revisionProvisioningDuration is how long a revision created in mock mode
+// provisions before it is reported as running.
+const revisionProvisioningDuration = 8 * time.Second
+
+// brokenImageTag is the image tag that makes a revision created in mock mode
+// fail to provision, to try out failed rollouts.
+const brokenImageTag = "broken"
+
+// Provider implements the DataProvider, MetricsProvider and LogQueryRunner
+// interfaces using mock data
+type Provider struct {
+	mu sync.Mutex
+
+	// jobExecutions holds the executions of each job once they have been
+	// loaded, so that start and stop operations persist for the session.
+	jobExecutions map[string][]models.JobExecution
+
+	// jobCompletions records when executions started in mock mode finish
+	jobCompletions map[string]time.Time
+
+	// traffic holds the traffic split of each app once it has been loaded,
+	// so that traffic changes persist for the session.
+	traffic map[string][]models.TrafficWeight
+
+	// revisions holds the revisions created during the session by app, oldest
+	// first, and revisionContainers their containers by GetContainerKey.
+	revisions          map[string][]models.Revision
+	revisionContainers map[string][]models.Container
+
+	// scale holds the scale of each app once it has been updated
+	scale map[string]models.Scale
+
+	// revisionActive holds whether the revisions activated or deactivated
+	// during the session are active, by GetContainerKey, and revisionMode the
+	// revision mode of the apps whose mode was switched
+	revisionActive map[string]bool
+	revisionMode   map[string]string
+
+	// now returns the current time; overridable for tests
+	now func() time.Time
+}
 
 // NewProvider creates a new mock data provider
 func NewProvider() (*Provider, error) {
-	return &Provider{}, nil
+	return &Provider{
+		jobExecutions:      make(map[string][]models.JobExecution),
+		jobCompletions:     make(map[string]time.Time),
+		traffic:            make(map[string][]models.TrafficWeight),
+		revisions:          make(map[string][]models.Revision),
+		revisionContainers: make(map[string][]models.Container),
+		scale:              make(map[string]models.Scale),
+		revisionActive:     make(map[string]bool),
+		revisionMode:       make(map[string]string),
+		now:                time.Now,
+	}, nil
+}
+
+// ListSubscriptions returns all available subscriptions.
+// The mock data is the same in every subscription.
+func (p *Provider) ListSubscriptions(ctx context.Context) ([]models.Subscription, error) {
+	// Simulate some processing time
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Load raw JSON data and transform it using shared helpers
+	subsData, err := testDataFS.ReadFile("testdata/subscriptions.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read subscriptions: %w", err)
+	}
+
+	return azure.TransformSubscriptionsFromJSON(string(subsData))
 }
 
 // ListResourceGroups returns all available resource groups
@@ -60,6 +137,22 @@ func (p *Provider) ListContainerApps(ctx context.Context, resourceGroup string)
 		return nil, fmt.Errorf("failed to transform container apps: %w", err)
 	}
 
+	// Revisions created during the session are the latest of their app, scale
+	// updates change its replica bounds and revision mode switches its mode
+	p.mu.Lock()
+	for i := range apps {
+		if created := p.revisions[apps[i].Name]; len(created) > 0 {
+			apps[i].LatestRevision = created[len(created)-1].Name
+		}
+		if scale, changed := p.scale[apps[i].Name]; changed {
+			apps[i].MinReplicas, apps[i].MaxReplicas = scale.MinReplicas, scale.MaxReplicas
+		}
+		if mode, changed := p.revisionMode[apps[i].Name]; changed {
+			apps[i].RevisionMode = mode
+		}
+	}
+	p.mu.Unlock()
+
 	if resourceGroup == "" {
 		return apps, nil
 	}
@@ -75,6 +168,70 @@ func (p *Provider) ListContainerApps(ctx context.Context, resourceGroup string)
 	return filtered, nil
 }
 
+// ListEnvironmentApps returns the container apps hosted in a managed environment
+func (p *Provider) ListEnvironmentApps(ctx context.Context, environmentID string) ([]models.ContainerApp, error) {
+	apps, err := p.ListContainerApps(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	// Filter by environment
+	var filtered []models.ContainerApp
+	for _, app := range apps {
+		if strings.EqualFold(app.EnvironmentID, environmentID) {
+			filtered = append(filtered, app)
+		}
+	}
+
+	return filtered, nil
+}
+
+// ListManagedEnvironments returns managed environments, optionally filtered by resource group
+func (p *Provider) ListManagedEnvironments(ctx context.Context, resourceGroup string) ([]models.ManagedEnvironment, error) {
+	// Simulate some processing time
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Load raw JSON data and transform it using shared helpers
+	envsData, err := testDataFS.ReadFile("testdata/managed_environments.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read managed environments: %w", err)
+	}
+
+	envs, err := azure.TransformManagedEnvironmentsFromJSON(string(envsData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform managed environments: %w", err)
+	}
+
+	// Count apps across resource groups, like the Azure CLI provider does
+	apps, err := p.ListContainerApps(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+	appEnvironmentIDs := make([]string, len(apps))
+	for i, app := range apps {
+		appEnvironmentIDs[i] = app.EnvironmentID
+	}
+	azure.CountAppsByEnvironment(envs, appEnvironmentIDs)
+
+	if resourceGroup == "" {
+		return envs, nil
+	}
+
+	// Filter by resource group
+	var filtered []models.ManagedEnvironment
+	for _, env := range envs {
+		if env.ResourceGroup == resourceGroup {
+			filtered = append(filtered, env)
+		}
+	}
+
+	return filtered, nil
+}
+
 // GetAppDetails returns detailed JSON information for a specific app
 func (p *Provider) GetAppDetails(ctx context.Context, name, resourceGroup string) (string, error) {
 	// Simulate some processing time
@@ -130,7 +287,343 @@ func (p *Provider) ListRevisions(ctx context.Context, appName, resourceGroup str
 		return []models.Revision{}, nil
 	}
 
-	return azure.TransformRevisionsFromJSON(string(appRevisions))
+	revisions, err := azure.TransformRevisionsFromJSON(string(appRevisions))
+	if err != nil {
+		return nil, err
+	}
+
+	// Reflect the revisions created and traffic changes made during the session
+	p.mu.Lock()
+	created := make([]models.Revision, len(p.revisions[appName]))
+	for i, rev := range p.revisions[appName] {
+		created[i] = p.rolloutLocked(appName, rev)
+	}
+	weights, changed := p.traffic[appName]
+	for _, rev := range created {
+		revisions = append([]models.Revision{rev}, revisions...)
+	}
+	for i := range revisions {
+		if active, toggled := p.revisionActive[GetContainerKey(appName, revisions[i].Name)]; toggled {
+			setRevisionActive(&revisions[i], active)
+		}
+	}
+	p.mu.Unlock()
+	if !changed && len(created) > 0 {
+		// A new revision takes the traffic that follows the latest revision
+		weights, changed, err = loadTrafficWeights(appName)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if changed {
+		applyTrafficWeights(revisions, weights)
+	}
+
+	return revisions, nil
+}
+
+// setRevisionActive reports a revision as activated, with a replica running
+// healthy, or as deactivated without replicas
+func setRevisionActive(rev *models.Revision, active bool) {
+	if rev.Active == active {
+		return
+	}
+	rev.Active = active
+	if active {
+		rev.RunningState = "Running"
+		rev.HealthState = models.RevisionHealthy
+		rev.Replicas = max(1, rev.MinReplicas)
+		return
+	}
+	rev.RunningState = "Stopped"
+	rev.HealthState = models.RevisionHealthNone
+	rev.Replicas = 0
+}
+
+// ActivateRevision activates an inactive revision of an app
+func (p *Provider) ActivateRevision(ctx context.Context, app models.ContainerApp, revisionName string) error {
+	rev, err := p.findRevision(ctx, app, revisionName)
+	if err != nil {
+		return err
+	}
+	if rev.Active {
+		return fmt.Errorf("revision %s is already active", revisionName)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.revisionActive[GetContainerKey(app.Name, revisionName)] = true
+	return nil
+}
+
+// DeactivateRevision deactivates an active revision of an app. Like in Azure,
+// revisions receiving traffic cannot be deactivated.
+func (p *Provider) DeactivateRevision(ctx context.Context, app models.ContainerApp, revisionName string) error {
+	rev, err := p.findRevision(ctx, app, revisionName)
+	if err != nil {
+		return err
+	}
+	if !rev.Active {
+		return fmt.Errorf("revision %s is already inactive", revisionName)
+	}
+	if rev.Traffic > 0 {
+		return fmt.Errorf("revision %s receives %d%% of the traffic", revisionName, rev.Traffic)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.revisionActive[GetContainerKey(app.Name, revisionName)] = false
+	return nil
+}
+
+// findRevision returns a revision of an app
+func (p *Provider) findRevision(ctx context.Context, app models.ContainerApp, revisionName string) (models.Revision, error) {
+	revisions, err := p.ListRevisions(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		return models.Revision{}, err
+	}
+	for _, rev := range revisions {
+		if rev.Name == revisionName {
+			return rev, nil
+		}
+	}
+	return models.Revision{}, fmt.Errorf("revision %s not found in app %s", revisionName, app.Name)
+}
+
+// AddRevisionLabel labels a revision in the traffic split of an app, moving
+// the label from the revision it was on. Revisions without traffic are added
+// to the split with a weight of zero.
+func (p *Provider) AddRevisionLabel(ctx context.Context, app models.ContainerApp, revisionName, label string) error {
+	if err := models.ValidateRevisionLabel(label); err != nil {
+		return err
+	}
+	if _, err := p.findRevision(ctx, app, revisionName); err != nil {
+		return err
+	}
+	weights, err := p.ListTrafficWeights(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		return err
+	}
+
+	labelled := false
+	for i := range weights {
+		if weights[i].Label == label {
+			weights[i].Label = ""
+		}
+	}
+	for i := range weights {
+		if !labelled && weights[i].RevisionName == revisionName && weights[i].Label == "" && !weights[i].LatestRevision {
+			weights[i].Label = label
+			labelled = true
+		}
+	}
+	if !labelled {
+		weights = append(weights, models.TrafficWeight{RevisionName: revisionName, Label: label})
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.traffic[app.Name] = compactTrafficWeights(weights)
+	return nil
+}
+
+// RemoveRevisionLabel removes a label from the traffic split of an app
+func (p *Provider) RemoveRevisionLabel(ctx context.Context, app models.ContainerApp, label string) error {
+	weights, err := p.ListTrafficWeights(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		return err
+	}
+
+	found := false
+	for i := range weights {
+		if weights[i].Label == label {
+			weights[i].Label = ""
+			found = true
+		}
+	}
+	if !found {
+		return fmt.Errorf("label %s not found in app %s", label, app.Name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.traffic[app.Name] = compactTrafficWeights(weights)
+	return nil
+}
+
+// compactTrafficWeights drops the entries of a traffic split that neither
+// route traffic nor hold a label
+func compactTrafficWeights(weights []models.TrafficWeight) []models.TrafficWeight {
+	return slices.DeleteFunc(weights, func(w models.TrafficWeight) bool {
+		return w.Weight == 0 && w.Label == ""
+	})
+}
+
+// SetRevisionMode switches an app between single and multiple revision mode
+func (p *Provider) SetRevisionMode(ctx context.Context, app models.ContainerApp, mode string) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	default:
+	}
+
+	switch {
+	case strings.EqualFold(mode, models.RevisionModeSingle):
+		mode = models.RevisionModeSingle
+	case strings.EqualFold(mode, models.RevisionModeMultiple):
+		mode = models.RevisionModeMultiple
+	default:
+		return fmt.Errorf("invalid revision mode %q: use single or multiple", mode)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.revisionMode[app.Name] = mode
+	return nil
+}
+
+// ListTrafficWeights returns how the traffic of a container app is split between its revisions
+func (p *Provider) ListTrafficWeights(ctx context.Context, appName, resourceGroup string) ([]models.TrafficWeight, error) {
+	// Simulate some processing time
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	p.mu.Lock()
+	weights, loaded := p.traffic[appName]
+	p.mu.Unlock()
+	if loaded {
+		result := make([]models.TrafficWeight, len(weights))
+		copy(result, weights)
+		return result, nil
+	}
+
+	weights, exists, err := loadTrafficWeights(appName)
+	if err != nil || exists {
+		return weights, err
+	}
+
+	// Apps without explicit traffic rules route by revision weight
+	revisions, err := p.ListRevisions(ctx, appName, resourceGroup)
+	if err != nil {
+		return nil, err
+	}
+
+	weights = []models.TrafficWeight{}
+	for _, rev := range revisions {
+		if rev.Traffic > 0 {
+			weights = append(weights, models.TrafficWeight{RevisionName: rev.Name, Weight: rev.Traffic})
+		}
+	}
+	return weights, nil
+}
+
+// loadTrafficWeights loads the traffic rules of an app from the mock data,
+// reporting whether the app has any
+func loadTrafficWeights(appName string) ([]models.TrafficWeight, bool, error) {
+	// Load raw JSON data and transform it using shared helpers
+	trafficData, err := testDataFS.ReadFile("testdata/traffic.json")
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read traffic: %w", err)
+	}
+
+	var allTraffic map[string]json.RawMessage
+	if err := json.Unmarshal(trafficData, &allTraffic); err != nil {
+		return nil, false, fmt.Errorf("failed to unmarshal traffic: %w", err)
+	}
+
+	appTraffic, exists := allTraffic[appName]
+	if !exists {
+		return nil, false, nil
+	}
+	weights, err := azure.TransformTrafficWeightsFromJSON(string(appTraffic))
+	return weights, err == nil, err
+}
+
+// SetTraffic replaces the traffic split of a container app. The weights must
+// add up to 100 and may only route traffic to active revisions.
+func (p *Provider) SetTraffic(ctx context.Context, appName, resourceGroup string, weights []models.TrafficWeight) error {
+	revisions, err := p.ListRevisions(ctx, appName, resourceGroup)
+	if err != nil {
+		return err
+	}
+
+	active := make(map[string]bool)
+	for _, rev := range revisions {
+		active[rev.Name] = rev.Active
+	}
+
+	total := 0
+	for _, weight := range weights {
+		if weight.Weight < 0 || weight.Weight > 100 {
+			return fmt.Errorf("invalid weight %d for %s", weight.Weight, weight.Target())
+		}
+		if !weight.LatestRevision && weight.Weight > 0 && !active[weight.RevisionName] {
+			return fmt.Errorf("revision %s is not active in app %s", weight.RevisionName, appName)
+		}
+		total += weight.Weight
+	}
+	if total != 100 {
+		return fmt.Errorf("traffic weights must add up to 100, got %d", total)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	stored := make([]models.TrafficWeight, len(weights))
+	copy(stored, weights)
+	p.traffic[appName] = stored
+	return nil
+}
+
+// applyTrafficWeights sets the traffic of each revision from a traffic split.
+// Weights following the latest revision apply to the newest revision.
+func applyTrafficWeights(revisions []models.Revision, weights []models.TrafficWeight) {
+	latest := -1
+	for i := range revisions {
+		revisions[i].Traffic = 0
+		if latest < 0 || revisions[i].CreatedAt.After(revisions[latest].CreatedAt) {
+			latest = i
+		}
+	}
+
+	for _, weight := range weights {
+		for i := range revisions {
+			if revisions[i].Name == weight.RevisionName || (weight.LatestRevision && i == latest) {
+				revisions[i].Traffic += weight.Weight
+			}
+		}
+	}
+}
+
+// ListReplicas returns the replicas of a specific app revision
+func (p *Provider) ListReplicas(ctx context.Context, app models.ContainerApp, revisionName string) ([]models.Replica, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	replicasData, err := testDataFS.ReadFile("testdata/replicas.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read replicas: %w", err)
+	}
+
+	var allReplicas map[string]json.RawMessage
+	if err := json.Unmarshal(replicasData, &allReplicas); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal replicas: %w", err)
+	}
+
+	key := fmt.Sprintf("%s-%s", app.Name, revisionName)
+	replicas, exists := allReplicas[key]
+	if !exists {
+		// Inactive revisions have no replicas
+		return []models.Replica{}, nil
+	}
+
+	return azure.TransformReplicasFromJSON(string(replicas))
 }
 
 // ListContainers returns all containers for a specific app revision
@@ -142,6 +635,14 @@ func (p *Provider) ListContainers(ctx context.Context, app models.ContainerApp,
 	default:
 	}
 
+	// Revisions created during the session keep their containers in memory
+	p.mu.Lock()
+	containers, created := p.revisionContainers[GetContainerKey(app.Name, revisionName)]
+	p.mu.Unlock()
+	if created {
+		return copyContainers(containers), nil
+	}
+
 	// Load raw JSON data for the specific revision
 	containersData, err := testDataFS.ReadFile("testdata/revision_details.json")
 	if err != nil {
@@ -154,7 +655,7 @@ func (p *Provider) ListContainers(ctx context.Context, app models.ContainerApp,
 		return nil, fmt.Errorf("failed to unmarshal revision details: %w", err)
 	}
 
-	key := fmt.Sprintf("%s-%s", app.Name, revisionName)
+	key := GetContainerKey(app.Name, revisionName)
 	revisionDetail, exists := allRevisionDetails[key]
 	if !exists {
 		return []models.Container{}, nil
@@ -163,6 +664,483 @@ func (p *Provider) ListContainers(ctx context.Context, app models.ContainerApp,
 	return azure.TransformContainersFromJSON(string(revisionDetail))
 }
 
+// UpdateContainerEnv sets and removes env vars of a container of an app.
+// Like in Azure, this creates a new revision from the latest one, whose name
+// is returned.
+func (p *Provider) UpdateContainerEnv(ctx context.Context, app models.ContainerApp, update models.EnvUpdate) (string, error) {
+	latest, containers, err := p.latestRevision(ctx, app)
+	if err != nil {
+		return "", err
+	}
+	found := false
+	for i := range containers {
+		if containers[i].Name == update.Container {
+			applyEnvUpdate(&containers[i], update)
+			found = true
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("container %s not found in revision %s", update.Container, latest.Name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.createRevision(app, latest, containers).Name, nil
+}
+
+// latestRevision returns the latest revision of an app with a copy of its containers
+func (p *Provider) latestRevision(ctx context.Context, app models.ContainerApp) (models.Revision, []models.Container, error) {
+	revisions, err := p.ListRevisions(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		return models.Revision{}, nil, err
+	}
+	if len(revisions) == 0 {
+		return models.Revision{}, nil, fmt.Errorf("app %s has no revisions", app.Name)
+	}
+	latest := revisions[0]
+	for _, rev := range revisions[1:] {
+		if rev.CreatedAt.After(latest.CreatedAt) {
+			latest = rev
+		}
+	}
+
+	containers, err := p.ListContainers(ctx, app, latest.Name)
+	if err != nil {
+		return models.Revision{}, nil, err
+	}
+	return latest, copyContainers(containers), nil
+}
+
+// createRevision records a new revision of an app copied from the latest one,
+// running the given containers without traffic. The caller must hold p.mu.
+func (p *Provider) createRevision(app models.ContainerApp, latest models.Revision, containers []models.Container) models.Revision {
+	now := p.now()
+	rev := latest
+	rev.Name = fmt.Sprintf("%s--%07x", app.Name, now.UnixNano()&0xfffffff)
+	rev.FQDN = strings.Replace(rev.FQDN, latest.Name+".", rev.Name+".", 1)
+	rev.CreatedAt = now
+	rev.Active = true
+	rev.Traffic = 0
+	if scale, changed := p.scale[app.Name]; changed {
+		rev.MinReplicas, rev.MaxReplicas = scale.MinReplicas, scale.MaxReplicas
+	}
+	p.revisions[app.Name] = append(p.revisions[app.Name], rev)
+	p.revisionContainers[GetContainerKey(app.Name, rev.Name)] = containers
+	return rev
+}
+
+// rolloutLocked returns a revision created during the session in the state
+// of its rollout: provisioning until revisionProvisioningDuration has passed,
+// then running, or failed when a container runs an image tagged brokenImageTag.
+// The caller must hold p.mu.
+func (p *Provider) rolloutLocked(appName string, rev models.Revision) models.Revision {
+	if p.now().Before(rev.CreatedAt.Add(revisionProvisioningDuration)) {
+		rev.ProvisioningState = models.RevisionProvisioning
+		rev.HealthState = models.RevisionHealthNone
+		rev.RunningState = "Activating"
+		rev.Replicas = 0
+		return rev
+	}
+	for _, container := range p.revisionContainers[GetContainerKey(appName, rev.Name)] {
+		if _, tag := models.SplitImage(container.Image); tag == brokenImageTag {
+			rev.ProvisioningState = models.RevisionFailed
+			rev.HealthState = models.RevisionUnhealthy
+			rev.RunningState = "Failed"
+			rev.Replicas = 0
+			return rev
+		}
+	}
+	rev.ProvisioningState = models.RevisionProvisioned
+	rev.HealthState = models.RevisionHealthy
+	rev.RunningState = "Running"
+	rev.Replicas = max(1, rev.MinReplicas)
+	return rev
+}
+
+// UpdateContainerImage replaces the image of a container of an app. Like in
+// Azure, this creates a new revision from the latest one, whose name is returned.
+func (p *Provider) UpdateContainerImage(ctx context.Context, app models.ContainerApp, container, image string) (string, error) {
+	if strings.TrimSpace(image) == "" {
+		return "", fmt.Errorf("an image is required")
+	}
+	latest, containers, err := p.latestRevision(ctx, app)
+	if err != nil {
+		return "", err
+	}
+	found := false
+	for i := range containers {
+		if containers[i].Name == container {
+			containers[i].Image = image
+			found = true
+		}
+	}
+	if !found {
+		return "", fmt.Errorf("container %s not found in revision %s", container, latest.Name)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.createRevision(app, latest, containers).Name, nil
+}
+
+// applyEnvUpdate sets and removes the env vars of a container. Values
+// starting with models.SecretRefPrefix reference an app secret.
+func applyEnvUpdate(container *models.Container, update models.EnvUpdate) {
+	if container.Env == nil {
+		container.Env = make(map[string]string)
+	}
+	if container.EnvSecrets == nil {
+		container.EnvSecrets = make(map[string]string)
+	}
+	for name, value := range update.Set {
+		if secretName, ok := strings.CutPrefix(value, models.SecretRefPrefix); ok {
+			container.Env[name] = ""
+			container.EnvSecrets[name] = secretName
+			continue
+		}
+		container.Env[name] = value
+		delete(container.EnvSecrets, name)
+	}
+	for _, name := range update.Remove {
+		delete(container.Env, name)
+		delete(container.EnvSecrets, name)
+	}
+}
+
+// copyContainers copies containers so that their env vars can be changed
+// without changing the originals
+func copyContainers(containers []models.Container) []models.Container {
+	copied := make([]models.Container, len(containers))
+	for i, container := range containers {
+		copied[i] = container
+		copied[i].Env = maps.Clone(container.Env)
+		copied[i].EnvSecrets = maps.Clone(container.EnvSecrets)
+	}
+	return copied
+}
+
+// GetScale returns the replica bounds and scale rules of an app
+func (p *Provider) GetScale(ctx context.Context, app models.ContainerApp) (models.Scale, error) {
+	select {
+	case <-ctx.Done():
+		return models.Scale{}, ctx.Err()
+	default:
+	}
+
+	p.mu.Lock()
+	scale, changed := p.scale[app.Name]
+	p.mu.Unlock()
+	if changed {
+		return scale.Clone(), nil
+	}
+	return loadScale(app)
+}
+
+// UpdateScale replaces the replica bounds and scale rules of an app. Like in
+// Azure, this creates a new revision from the latest one, whose name is returned.
+func (p *Provider) UpdateScale(ctx context.Context, app models.ContainerApp, scale models.Scale) (string, error) {
+	if err := scale.Validate(); err != nil {
+		return "", err
+	}
+	latest, containers, err := p.latestRevision(ctx, app)
+	if err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.scale[app.Name] = scale.Clone()
+	return p.createRevision(app, latest, containers).Name, nil
+}
+
+// loadScale loads the scale of an app. Apps without scale rules only have the
+// replica bounds of their listing.
+func loadScale(app models.ContainerApp) (models.Scale, error) {
+	scaleData, err := testDataFS.ReadFile("testdata/scale.json")
+	if err != nil {
+		return models.Scale{}, fmt.Errorf("failed to read scale: %w", err)
+	}
+
+	var allScale map[string]json.RawMessage
+	if err := json.Unmarshal(scaleData, &allScale); err != nil {
+		return models.Scale{}, fmt.Errorf("failed to unmarshal scale: %w", err)
+	}
+
+	scale, exists := allScale[app.Name]
+	if !exists {
+		return models.Scale{MinReplicas: app.MinReplicas, MaxReplicas: app.MaxReplicas}, nil
+	}
+
+	return azure.TransformScaleFromJSON(string(scale))
+}
+
+// ListSecrets returns the secrets of an app without their values
+func (p *Provider) ListSecrets(ctx context.Context, app models.ContainerApp) ([]models.Secret, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	secrets, err := loadSecrets(app)
+	if err != nil {
+		return nil, err
+	}
+
+	// Like az containerapp secret list, values are only returned by show
+	for i := range secrets {
+		secrets[i].Value = ""
+	}
+	return secrets, nil
+}
+
+// ShowSecret returns a secret of an app with its value
+func (p *Provider) ShowSecret(ctx context.Context, app models.ContainerApp, secretName string) (models.Secret, error) {
+	select {
+	case <-ctx.Done():
+		return models.Secret{}, ctx.Err()
+	default:
+	}
+
+	secrets, err := loadSecrets(app)
+	if err != nil {
+		return models.Secret{}, err
+	}
+
+	for _, secret := range secrets {
+		if secret.Name == secretName {
+			return secret, nil
+		}
+	}
+	return models.Secret{}, fmt.Errorf("secret %s not found in app %s", secretName, app.Name)
+}
+
+// loadSecrets loads the secrets of an app with their values
+func loadSecrets(app models.ContainerApp) ([]models.Secret, error) {
+	secretsData, err := testDataFS.ReadFile("testdata/secrets.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read secrets: %w", err)
+	}
+
+	var allSecrets map[string]json.RawMessage
+	if err := json.Unmarshal(secretsData, &allSecrets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal secrets: %w", err)
+	}
+
+	secrets, exists := allSecrets[app.Name]
+	if !exists {
+		return []models.Secret{}, nil
+	}
+
+	return azure.TransformSecretsFromJSON(string(secrets))
+}
+
+// ListJobs returns container app jobs, optionally filtered by resource group
+func (p *Provider) ListJobs(ctx context.Context, resourceGroup string) ([]models.Job, error) {
+	// Simulate some processing time
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	// Load raw JSON data and transform it using shared helpers
+	jobsData, err := testDataFS.ReadFile("testdata/jobs.json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read jobs: %w", err)
+	}
+
+	jobs, err := azure.TransformJobsFromJSON(string(jobsData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to transform jobs: %w", err)
+	}
+
+	if resourceGroup == "" {
+		return jobs, nil
+	}
+
+	// Filter by resource group
+	var filtered []models.Job
+	for _, job := range jobs {
+		if job.ResourceGroup == resourceGroup {
+			filtered = append(filtered, job)
+		}
+	}
+
+	return filtered, nil
+}
+
+// ListJobExecutions returns all executions for a specific container app job
+func (p *Provider) ListJobExecutions(ctx context.Context, jobName, resourceGroup string) ([]models.JobExecution, error) {
+	// Simulate some processing time
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	executions, err := p.jobExecutionsLocked(jobName)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]models.JobExecution, len(executions))
+	copy(result, executions)
+	return result, nil
+}
+
+// StartJobExecution starts a new execution of a job. The execution reports
+// Running until jobExecutionDuration has passed and Succeeded afterwards.
+func (p *Provider) StartJobExecution(ctx context.Context, jobName, resourceGroup string) (models.JobExecution, error) {
+	if err := p.findJob(ctx, jobName, resourceGroup); err != nil {
+		return models.JobExecution{}, err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	executions, err := p.jobExecutionsLocked(jobName)
+	if err != nil {
+		return models.JobExecution{}, err
+	}
+
+	now := p.now()
+	execution := models.JobExecution{
+		Name:      fmt.Sprintf("%s-%07x", jobName, now.UnixNano()&0xfffffff),
+		Status:    "Running",
+		StartTime: now,
+	}
+	p.jobCompletions[execution.Name] = now.Add(jobExecutionDuration)
+	p.jobExecutions[jobName] = append([]models.JobExecution{execution}, executions...)
+
+	return execution, nil
+}
+
+// StopJobExecution stops a running execution of a job
+func (p *Provider) StopJobExecution(ctx context.Context, jobName, resourceGroup, executionName string) error {
+	if err := p.findJob(ctx, jobName, resourceGroup); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	executions, err := p.jobExecutionsLocked(jobName)
+	if err != nil {
+		return err
+	}
+
+	for i := range executions {
+		if executions[i].Name != executionName {
+			continue
+		}
+		if executions[i].Status != "Running" {
+			return fmt.Errorf("execution %s is not running (status: %s)", executionName, executions[i].Status)
+		}
+		executions[i].Status = "Stopped"
+		executions[i].EndTime = p.now()
+		delete(p.jobCompletions, executionName)
+		return nil
+	}
+
+	return fmt.Errorf("execution %s not found for job %s", executionName, jobName)
+}
+
+// RerunJobExecution starts a new execution of a job to replace a finished one
+func (p *Provider) RerunJobExecution(ctx context.Context, jobName, resourceGroup, executionName string) (models.JobExecution, error) {
+	p.mu.Lock()
+	executions, err := p.jobExecutionsLocked(jobName)
+	if err != nil {
+		p.mu.Unlock()
+		return models.JobExecution{}, err
+	}
+
+	found := false
+	for _, execution := range executions {
+		if execution.Name == executionName {
+			if execution.Status == "Running" {
+				p.mu.Unlock()
+				return models.JobExecution{}, fmt.Errorf("execution %s is still running", executionName)
+			}
+			found = true
+			break
+		}
+	}
+	p.mu.Unlock()
+
+	if !found {
+		return models.JobExecution{}, fmt.Errorf("execution %s not found for job %s", executionName, jobName)
+	}
+
+	return p.StartJobExecution(ctx, jobName, resourceGroup)
+}
+
+// findJob returns an error if the job does not exist in the resource group
+func (p *Provider) findJob(ctx context.Context, jobName, resourceGroup string) error {
+	jobs, err := p.ListJobs(ctx, resourceGroup)
+	if err != nil {
+		return err
+	}
+
+	for _, job := range jobs {
+		if job.Name == jobName {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("job %s not found in resource group %s", jobName, resourceGroup)
+}
+
+// jobExecutionsLocked returns the executions of a job, loading them from the
+// mock data on first access and completing executions whose time has come.
+// The caller must hold p.mu.
+func (p *Provider) jobExecutionsLocked(jobName string) ([]models.JobExecution, error) {
+	executions, loaded := p.jobExecutions[jobName]
+	if !loaded {
+		// Load raw JSON data and transform it using shared helpers
+		execsData, err := testDataFS.ReadFile("testdata/job_executions.json")
+		if err != nil {
+			return nil, fmt.Errorf("failed to read job executions: %w", err)
+		}
+
+		// Parse the JSON structure to get executions for the specific job
+		var allExecutions map[string]json.RawMessage
+		if err := json.Unmarshal(execsData, &allExecutions); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal job executions: %w", err)
+		}
+
+		executions = []models.JobExecution{}
+		if jobExecutions, exists := allExecutions[jobName]; exists {
+			executions, err = azure.TransformJobExecutionsFromJSON(string(jobExecutions))
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		// Keep the newest executions first
+		sort.SliceStable(executions, func(i, j int) bool {
+			return executions[i].StartTime.After(executions[j].StartTime)
+		})
+		p.jobExecutions[jobName] = executions
+	}
+
+	// Complete executions started in mock mode that have run their course
+	now := p.now()
+	for i := range executions {
+		completesAt, simulated := p.jobCompletions[executions[i].Name]
+		if simulated && !now.Before(completesAt) {
+			executions[i].Status = "Succeeded"
+			executions[i].EndTime = completesAt
+			delete(p.jobCompletions, executions[i].Name)
+		}
+	}
+
+	return executions, nil
+}
+
 // GetContainerKey returns the key used to store containers in the mock data
 func GetContainerKey(appName, revisionName string) string {
 	return fmt.Sprintf("%s-%s", appName, revisionName)
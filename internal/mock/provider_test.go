@@ -0,0 +1,577 @@
+package mock
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// newTestProvider creates a provider whose clock is controlled by the test
+func newTestProvider(t *testing.T, now *time.Time) *Provider {
+	t.Helper()
+	p, err := NewProvider()
+	if err != nil {
+		t.Fatalf("Failed to create provider: %v", err)
+	}
+	p.now = func() time.Time { return *now }
+	return p
+}
+
+func TestJobExecutionLifecycle(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+
+	before, err := p.ListJobExecutions(ctx, "db-migration-prod", "rg-production-eastus")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	started, err := p.StartJobExecution(ctx, "db-migration-prod", "rg-production-eastus")
+	if err != nil {
+		t.Fatalf("Unexpected error starting job: %v", err)
+	}
+	if started.Status != "Running" {
+		t.Errorf("Expected new execution to be Running, got %s", started.Status)
+	}
+
+	executions, err := p.ListJobExecutions(ctx, "db-migration-prod", "rg-production-eastus")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(executions) != len(before)+1 {
+		t.Fatalf("Expected %d executions, got %d", len(before)+1, len(executions))
+	}
+	if executions[0].Name != started.Name {
+		t.Errorf("Expected newest execution first, got %s", executions[0].Name)
+	}
+
+	// The execution completes once its simulated duration has passed
+	now = now.Add(jobExecutionDuration)
+	executions, _ = p.ListJobExecutions(ctx, "db-migration-prod", "rg-production-eastus")
+	if executions[0].Status != "Succeeded" {
+		t.Errorf("Expected execution to have Succeeded, got %s", executions[0].Status)
+	}
+	if executions[0].EndTime.IsZero() {
+		t.Error("Expected completed execution to have an end time")
+	}
+
+	// Finished executions cannot be stopped
+	if err := p.StopJobExecution(ctx, "db-migration-prod", "rg-production-eastus", started.Name); err == nil {
+		t.Error("Expected error stopping a finished execution")
+	}
+}
+
+func TestStopJobExecution(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+
+	started, err := p.StartJobExecution(ctx, "nightly-report-prod", "rg-production-eastus")
+	if err != nil {
+		t.Fatalf("Unexpected error starting job: %v", err)
+	}
+
+	now = now.Add(5 * time.Second)
+	if err := p.StopJobExecution(ctx, "nightly-report-prod", "rg-production-eastus", started.Name); err != nil {
+		t.Fatalf("Unexpected error stopping execution: %v", err)
+	}
+
+	// A stopped execution stays stopped after its simulated duration
+	now = now.Add(jobExecutionDuration)
+	executions, _ := p.ListJobExecutions(ctx, "nightly-report-prod", "rg-production-eastus")
+	if executions[0].Status != "Stopped" {
+		t.Errorf("Expected execution to be Stopped, got %s", executions[0].Status)
+	}
+	if got := executions[0].EndTime.Sub(executions[0].StartTime); got != 5*time.Second {
+		t.Errorf("Expected execution to have run for 5s, got %s", got)
+	}
+}
+
+func TestRerunJobExecution(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+
+	// Re-running a failed execution starts a new one
+	rerun, err := p.RerunJobExecution(ctx, "nightly-report-prod", "rg-production-eastus", "nightly-report-prod-7hq3m2p")
+	if err != nil {
+		t.Fatalf("Unexpected error re-running execution: %v", err)
+	}
+	if rerun.Status != "Running" {
+		t.Errorf("Expected re-run execution to be Running, got %s", rerun.Status)
+	}
+
+	// Running executions cannot be re-run
+	if _, err := p.RerunJobExecution(ctx, "nightly-report-prod", "rg-production-eastus", rerun.Name); err == nil {
+		t.Error("Expected error re-running a running execution")
+	}
+
+	// Unknown jobs are rejected
+	if _, err := p.StartJobExecution(ctx, "missing-job", "rg-production-eastus"); err == nil {
+		t.Error("Expected error starting an unknown job")
+	}
+}
+
+func TestManagedEnvironments(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	p := newTestProvider(t, &now)
+
+	envs, err := p.ListManagedEnvironments(ctx, "rg-production-eastus")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(envs) != 1 || envs[0].Name != "env-prod" {
+		t.Fatalf("Expected env-prod in rg-production-eastus, got %+v", envs)
+	}
+	if envs[0].AppCount != 3 {
+		t.Errorf("Expected 3 apps in env-prod, got %d", envs[0].AppCount)
+	}
+
+	apps, err := p.ListEnvironmentApps(ctx, envs[0].ID)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(apps) != envs[0].AppCount {
+		t.Errorf("Expected %d apps in env-prod, got %d", envs[0].AppCount, len(apps))
+	}
+}
+
+func TestSetTraffic(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	p := newTestProvider(t, &now)
+
+	weights, err := p.ListTrafficWeights(ctx, "web-frontend-prod", "rg-production-eastus")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(weights) != 2 || weights[0].Weight != 80 || weights[1].Weight != 20 {
+		t.Fatalf("Expected an 80/20 split, got %+v", weights)
+	}
+
+	// Weights that do not add up to 100 are rejected
+	weights[0].Weight = 50
+	if err := p.SetTraffic(ctx, "web-frontend-prod", "rg-production-eastus", weights); err == nil {
+		t.Error("Expected an error for weights adding up to 70")
+	}
+
+	weights[1].Weight = 50
+	if err := p.SetTraffic(ctx, "web-frontend-prod", "rg-production-eastus", weights); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	revisions, err := p.ListRevisions(ctx, "web-frontend-prod", "rg-production-eastus")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, rev := range revisions {
+		if rev.Traffic != 50 {
+			t.Errorf("Expected revision %s to receive 50%% of traffic, got %d", rev.Name, rev.Traffic)
+		}
+	}
+
+	// Traffic cannot be routed to inactive revisions
+	inactive := []models.TrafficWeight{
+		{RevisionName: "api-backend-prod--v1-8", Weight: 50},
+		{RevisionName: "api-backend-prod--v1-7", Weight: 50},
+	}
+	if err := p.SetTraffic(ctx, "api-backend-prod", "rg-production-eastus", inactive); err == nil {
+		t.Error("Expected an error routing traffic to an inactive revision")
+	}
+}
+
+func TestUpdateContainerEnv(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 25, 10, 0, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+	app := models.ContainerApp{Name: "api-backend-prod", ResourceGroup: "rg-production-eastus"}
+
+	update := models.EnvUpdate{
+		Container: "api-server",
+		Set:       map[string]string{"LOG_LEVEL": "debug", "FEATURE_FLAGS": "orders-v2", "PORT": "secretref:jwt-secret"},
+		Remove:    []string{"RATE_LIMIT_MAX"},
+	}
+	if _, err := p.UpdateContainerEnv(ctx, app, models.EnvUpdate{Container: "missing"}); err == nil {
+		t.Error("Expected an error updating a missing container")
+	}
+	revName, err := p.UpdateContainerEnv(ctx, app, update)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The new revision is the latest and takes the traffic following the latest revision
+	revisions, err := p.ListRevisions(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(revisions) != 3 || revisions[0].Name != revName || !revisions[0].CreatedAt.Equal(now) {
+		t.Fatalf("Expected revision %s to be created, got %+v", revName, revisions)
+	}
+	if revisions[0].Traffic != 100 || revisions[1].Traffic != 0 {
+		t.Errorf("Expected the new revision to receive all traffic, got %d and %d", revisions[0].Traffic, revisions[1].Traffic)
+	}
+	apps, err := p.ListContainerApps(ctx, app.ResourceGroup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, a := range apps {
+		if a.Name == app.Name && a.LatestRevision != revName {
+			t.Errorf("Expected the latest revision to be %s, got %s", revName, a.LatestRevision)
+		}
+	}
+
+	containers, err := p.ListContainers(ctx, app, revName)
+	if err != nil || len(containers) == 0 {
+		t.Fatalf("Expected the containers of the new revision, got %v, %v", containers, err)
+	}
+	env := containers[0].Env
+	if env["LOG_LEVEL"] != "debug" || env["FEATURE_FLAGS"] != "orders-v2" || env["NODE_ENV"] != "production" {
+		t.Errorf("Unexpected env vars %v", env)
+	}
+	if _, ok := env["RATE_LIMIT_MAX"]; ok {
+		t.Error("Expected RATE_LIMIT_MAX to be removed")
+	}
+	if secret, ok := containers[0].SecretRef("PORT"); !ok || secret != "jwt-secret" {
+		t.Errorf("Expected PORT to reference jwt-secret, got %q", secret)
+	}
+
+	// The revision the update was made from is unchanged
+	previous, err := p.ListContainers(ctx, app, "api-backend-prod--v1-8")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if previous[0].Env["LOG_LEVEL"] == "debug" {
+		t.Error("Expected the previous revision to keep its env vars")
+	}
+}
+
+func TestUpdateScale(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 25, 10, 0, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+	app := models.ContainerApp{Name: "worker-service-prod", ResourceGroup: "rg-production-eastus"}
+
+	scale, err := p.GetScale(ctx, app)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if scale.MinReplicas != 1 || scale.MaxReplicas != 5 || len(scale.Rules) != 1 {
+		t.Fatalf("Unexpected scale %+v", scale)
+	}
+	queue := scale.Rules[0]
+	if queue.Type != models.ScaleRuleQueue || queue.Metadata["queueLength"] != "25" || len(queue.Auth) != 1 {
+		t.Fatalf("Expected a queue rule with auth, got %+v", queue)
+	}
+
+	invalid := scale.Clone()
+	invalid.MinReplicas = 6
+	if _, err := p.UpdateScale(ctx, app, invalid); err == nil {
+		t.Error("Expected an error for min replicas above max replicas")
+	}
+	invalid = scale.Clone()
+	invalid.Rules[0].Metadata["queueLength"] = "many"
+	if _, err := p.UpdateScale(ctx, app, invalid); err == nil {
+		t.Error("Expected an error for a non-numeric queue length")
+	}
+
+	updated := scale.Clone()
+	updated.MinReplicas, updated.MaxReplicas = 2, 8
+	updated.Rules[0].Metadata["queueLength"] = "50"
+	revName, err := p.UpdateScale(ctx, app, updated)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The new scale is returned, and changing it does not change the stored one
+	got, err := p.GetScale(ctx, app)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got.MinReplicas != 2 || got.MaxReplicas != 8 || got.Rules[0].Metadata["queueLength"] != "50" {
+		t.Errorf("Expected the updated scale, got %+v", got)
+	}
+	got.Rules[0].Metadata["queueLength"] = "1"
+	if again, _ := p.GetScale(ctx, app); again.Rules[0].Metadata["queueLength"] != "50" {
+		t.Error("Expected the stored scale to be unchanged by callers")
+	}
+
+	// The update created a revision with the new bounds, also shown on the app
+	revisions, err := p.ListRevisions(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if revisions[0].Name != revName || revisions[0].MinReplicas != 2 || revisions[0].MaxReplicas != 8 {
+		t.Errorf("Expected revision %s with 2 to 8 replicas, got %+v", revName, revisions[0])
+	}
+	apps, err := p.ListContainerApps(ctx, app.ResourceGroup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, a := range apps {
+		if a.Name == app.Name && (a.MinReplicas != 2 || a.MaxReplicas != 8 || a.LatestRevision != revName) {
+			t.Errorf("Expected the app to scale between 2 and 8 replicas as of %s, got %+v", revName, a)
+		}
+	}
+}
+
+func TestUpdateContainerImage(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 25, 10, 0, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+	app := models.ContainerApp{Name: "web-frontend-prod", ResourceGroup: "rg-production-eastus"}
+
+	if _, err := p.UpdateContainerImage(ctx, app, "missing", "myregistry.azurecr.io/web-frontend:v2.4"); err == nil {
+		t.Error("Expected an error updating a missing container")
+	}
+	revName, err := p.UpdateContainerImage(ctx, app, "web-app", "myregistry.azurecr.io/web-frontend:v2.4")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	containers, err := p.ListContainers(ctx, app, revName)
+	if err != nil || len(containers) == 0 || containers[0].Image != "myregistry.azurecr.io/web-frontend:v2.4" {
+		t.Fatalf("Expected the new revision to run the new image, got %+v, %v", containers, err)
+	}
+
+	// The revision provisions, then runs healthy
+	rollout := func() models.Revision {
+		t.Helper()
+		revisions, err := p.ListRevisions(ctx, app.Name, app.ResourceGroup)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if revisions[0].Name != revName {
+			t.Fatalf("Expected revision %s to be the latest, got %s", revName, revisions[0].Name)
+		}
+		return revisions[0]
+	}
+	if rev := rollout(); rev.ProvisioningState != models.RevisionProvisioning || rev.RolloutDone() {
+		t.Errorf("Expected the revision to be provisioning, got %+v", rev)
+	}
+	now = now.Add(revisionProvisioningDuration)
+	if rev := rollout(); !rev.RolloutDone() || !rev.IsHealthy() || rev.Replicas == 0 {
+		t.Errorf("Expected the revision to be healthy, got %+v", rev)
+	}
+
+	// An image tagged broken fails to provision
+	revName, err = p.UpdateContainerImage(ctx, app, "web-app", "myregistry.azurecr.io/web-frontend:"+brokenImageTag)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	now = now.Add(revisionProvisioningDuration)
+	if rev := rollout(); !rev.RolloutDone() || rev.IsHealthy() || rev.ProvisioningState != models.RevisionFailed {
+		t.Errorf("Expected the revision to fail, got %+v", rev)
+	}
+}
+
+func TestRevisionActivationLabelsAndMode(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 25, 10, 0, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+	app := models.ContainerApp{Name: "web-frontend-prod", ResourceGroup: "rg-production-eastus"}
+
+	revision := func(name string) models.Revision {
+		t.Helper()
+		rev, err := p.findRevision(ctx, app, name)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return rev
+	}
+
+	// Revisions receiving traffic cannot be deactivated
+	if err := p.DeactivateRevision(ctx, app, "web-frontend-prod--v2-2"); err == nil {
+		t.Error("Expected an error deactivating a revision receiving traffic")
+	}
+	weights := []models.TrafficWeight{{RevisionName: "web-frontend-prod--v2-3", Label: "stable", Weight: 100}}
+	if err := p.SetTraffic(ctx, app.Name, app.ResourceGroup, weights); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := p.DeactivateRevision(ctx, app, "web-frontend-prod--v2-2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rev := revision("web-frontend-prod--v2-2"); rev.Active || rev.Replicas != 0 {
+		t.Errorf("Expected the revision to be inactive, got %+v", rev)
+	}
+	if err := p.ActivateRevision(ctx, app, "web-frontend-prod--v2-2"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if rev := revision("web-frontend-prod--v2-2"); !rev.Active || rev.Replicas == 0 {
+		t.Errorf("Expected the revision to be active, got %+v", rev)
+	}
+	if err := p.ActivateRevision(ctx, app, "web-frontend-prod--v2-2"); err == nil {
+		t.Error("Expected an error activating an active revision")
+	}
+
+	// Labels are added to revisions without traffic and moved between revisions
+	if err := p.AddRevisionLabel(ctx, app, "web-frontend-prod--v2-2", "Canary"); err == nil {
+		t.Error("Expected an error for an upper case label")
+	}
+	if err := p.AddRevisionLabel(ctx, app, "web-frontend-prod--v2-2", "stable"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	got, err := p.ListTrafficWeights(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	want := []models.TrafficWeight{
+		{RevisionName: "web-frontend-prod--v2-3", Weight: 100},
+		{RevisionName: "web-frontend-prod--v2-2", Label: "stable"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected the label to move, got %+v", got)
+	}
+	if err := p.RemoveRevisionLabel(ctx, app, "stable"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got, _ := p.ListTrafficWeights(ctx, app.Name, app.ResourceGroup); len(got) != 1 || got[0].Label != "" {
+		t.Errorf("Expected the label and the entry without traffic to be removed, got %+v", got)
+	}
+	if err := p.RemoveRevisionLabel(ctx, app, "stable"); err == nil {
+		t.Error("Expected an error removing a missing label")
+	}
+
+	// The revision mode is shown on the app
+	if err := p.SetRevisionMode(ctx, app, "sometimes"); err == nil {
+		t.Error("Expected an error for an invalid revision mode")
+	}
+	if err := p.SetRevisionMode(ctx, app, "single"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	apps, err := p.ListContainerApps(ctx, app.ResourceGroup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for _, a := range apps {
+		if a.Name == app.Name && (a.RevisionMode != models.RevisionModeSingle || a.IsMultipleRevisionMode()) {
+			t.Errorf("Expected the app to be in single revision mode, got %q", a.RevisionMode)
+		}
+	}
+}
+
+func TestSecrets(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	p := newTestProvider(t, &now)
+	app := models.ContainerApp{Name: "worker-service-prod", ResourceGroup: "rg-production-eastus"}
+
+	secrets, err := p.ListSecrets(ctx, app)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(secrets) != 1 || !secrets[0].IsKeyVaultRef() {
+		t.Fatalf("Expected a single Key Vault secret, got %+v", secrets)
+	}
+	if secrets[0].Value != "" {
+		t.Error("Expected listed secrets to have no value")
+	}
+
+	secret, err := p.ShowSecret(ctx, app, "azure-client-secret")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if secret.Value == "" {
+		t.Error("Expected the shown secret to have a value")
+	}
+
+	if _, err := p.ShowSecret(ctx, app, "missing"); err == nil {
+		t.Error("Expected an error for a missing secret")
+	}
+}
+
+func TestGetAppMetrics(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 22, 12, 3, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+
+	app := models.ContainerApp{
+		Name:          "web-frontend-prod",
+		ResourceGroup: "rg-production-eastus",
+		IngressFQDN:   "web-frontend-prod.proudocean-12345.eastus.azurecontainerapps.io",
+		CPU:           1.0,
+	}
+	window := models.MetricsWindows[0]
+
+	metrics, err := p.GetAppMetrics(ctx, app, "", window)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cpu := metrics.Get(models.MetricCPU)
+	if len(cpu.Points) != window.Points() {
+		t.Fatalf("Expected %d points, got %d", window.Points(), len(cpu.Points))
+	}
+	if last := cpu.Points[len(cpu.Points)-1].Time; !last.Equal(time.Date(2024, 1, 22, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the last point at the start of the current interval, got %v", last)
+	}
+	if _, _, average, _ := metrics.Get(models.MetricRequests).Stats(); average == 0 {
+		t.Error("Expected requests for an app with ingress")
+	}
+
+	// The series are deterministic
+	again, _ := p.GetAppMetrics(ctx, app, "", window)
+	for i, point := range again.Get(models.MetricCPU).Points {
+		if point.Value != cpu.Points[i].Value {
+			t.Fatalf("Expected the same CPU series on every call, point %d differs", i)
+		}
+	}
+
+	// Inactive revisions have no load
+	inactive, err := p.GetAppMetrics(ctx, models.ContainerApp{Name: "api-backend-prod", ResourceGroup: "rg-production-eastus"}, "api-backend-prod--v1-7", window)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, _, _, maximum := inactive.Get(models.MetricCPU).Stats(); maximum != 0 {
+		t.Errorf("Expected no CPU usage for an inactive revision, got %v", maximum)
+	}
+}
+
+func TestRunLogQuery(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2024, 1, 22, 12, 30, 0, 0, time.UTC)
+	p := newTestProvider(t, &now)
+
+	query := "ContainerAppConsoleLogs_CL\n| where ContainerAppName_s == 'web-frontend-prod' and RevisionName_s == 'web-frontend-prod--v2'\n| order by TimeGenerated desc\n| take 20"
+	result, err := p.RunLogQuery(ctx, "env", query, time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(result.Rows) != 20 || len(result.Columns) != 5 {
+		t.Fatalf("Expected 20 rows of 5 columns, got %d of %d", len(result.Rows), len(result.Columns))
+	}
+	if got := result.Rows[0]["RevisionName_s"]; got != "web-frontend-prod--v2" {
+		t.Errorf("Expected rows of the queried revision, got %s", got)
+	}
+	if got := result.Rows[0]["TimeGenerated"]; got != "2024-01-22T12:30:00Z" {
+		t.Errorf("Expected the newest row first, got %s", got)
+	}
+
+	again, _ := p.RunLogQuery(ctx, "env", query, time.Hour)
+	for i, row := range again.Rows {
+		if row["Log_s"] != result.Rows[i]["Log_s"] {
+			t.Fatalf("Expected the same rows on every run, row %d differs", i)
+		}
+	}
+
+	warnings, _ := p.RunLogQuery(ctx, "env", "ContainerAppSystemLogs_CL | where ContainerAppName_s == 'web-frontend-prod' and Type_s == 'Warning'", time.Hour)
+	for _, row := range warnings.Rows {
+		if row["Type_s"] != "Warning" {
+			t.Fatalf("Expected only warnings, got %s", row["Type_s"])
+		}
+	}
+
+	volume, err := p.RunLogQuery(ctx, "env", "ContainerAppSystemLogs_CL | summarize Lines = count() by bin(TimeGenerated, 1h)", 6*time.Hour)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(volume.Rows) != 6 || volume.Columns[2] != "Lines" {
+		t.Errorf("Expected 6 hourly counts, got %d rows with columns %v", len(volume.Rows), volume.Columns)
+	}
+
+	if _, err := p.RunLogQuery(ctx, "env", "AppRequests | take 5", time.Hour); err == nil {
+		t.Error("Expected an error for a table without mock data")
+	}
+}
@@ -1,6 +1,14 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // ----------------------------- Data -----------------------------
 
@@ -10,6 +18,7 @@ type ContainerApp struct {
 	Location          string  `json:"location"`
 	EnvironmentID     string  `json:"environmentId"`
 	LatestRevision    string  `json:"latestRevisionName"`
+	RevisionMode      string  `json:"revisionMode"`
 	IngressFQDN       string  `json:"ingressFqdn"`
 	ProvisioningState string  `json:"provisioningState"`
 	RunningStatus     string  `json:"runningStatus"`
@@ -25,6 +34,18 @@ type ContainerApp struct {
 	LastModifiedAt    string  `json:"lastModifiedAt"`
 }
 
+// Revision modes of an app: in single mode only its latest revision is
+// active, in multiple mode traffic can be split between active revisions
+const (
+	RevisionModeSingle   = "Single"
+	RevisionModeMultiple = "Multiple"
+)
+
+// IsMultipleRevisionMode reports whether the app runs several active revisions
+func (a ContainerApp) IsMultipleRevisionMode() bool {
+	return strings.EqualFold(a.RevisionMode, RevisionModeMultiple)
+}
+
 type Revision struct {
 	Name              string    `json:"name"`
 	Active            bool      `json:"active"`
@@ -40,6 +61,279 @@ type Revision struct {
 	MaxReplicas       int       `json:"maxReplicas"`
 	CPU               float64   `json:"cpu"`
 	Memory            string    `json:"memory"`
+	Labels            []string  `json:"labels"` // labels of the traffic split routing to the revision
+}
+
+// States a new revision reports while it rolls out
+const (
+	RevisionProvisioning = "Provisioning"
+	RevisionProvisioned  = "Provisioned"
+	RevisionSucceeded    = "Succeeded"
+	RevisionFailed       = "Failed"
+	RevisionHealthy      = "Healthy"
+	RevisionUnhealthy    = "Unhealthy"
+	RevisionHealthNone   = "None"
+)
+
+// RolloutDone reports whether a new revision has finished rolling out: it
+// either failed to provision, or was provisioned and its health is known
+func (r Revision) RolloutDone() bool {
+	switch r.ProvisioningState {
+	case RevisionFailed:
+		return true
+	case RevisionProvisioned, RevisionSucceeded:
+		return r.HealthState != "" && r.HealthState != RevisionHealthNone
+	}
+	return false
+}
+
+// IsHealthy reports whether a revision was provisioned and is healthy, so that
+// traffic can be shifted to it
+func (r Revision) IsHealthy() bool {
+	return r.RolloutDone() && r.ProvisioningState != RevisionFailed && r.HealthState == RevisionHealthy
+}
+
+// TrafficWeight is the share of an app's ingress traffic routed to a revision.
+// An entry with LatestRevision set follows whichever revision is latest.
+type TrafficWeight struct {
+	RevisionName   string `json:"revisionName"`
+	Label          string `json:"label"`
+	Weight         int    `json:"weight"`
+	LatestRevision bool   `json:"latestRevision"`
+}
+
+// ValidateRevisionLabel checks that a label can be used in the traffic split:
+// lower case alphanumerics and hyphens, starting with a letter and ending with
+// a letter or digit
+func ValidateRevisionLabel(label string) error {
+	if label == "" {
+		return fmt.Errorf("a label is required")
+	}
+	for i, r := range label {
+		switch {
+		case r >= 'a' && r <= 'z':
+		case (r >= '0' && r <= '9') || r == '-':
+			if i == 0 {
+				return fmt.Errorf("label %s must start with a lower case letter", label)
+			}
+		default:
+			return fmt.Errorf("label %s may only hold lower case letters, digits and hyphens", label)
+		}
+	}
+	if strings.HasSuffix(label, "-") {
+		return fmt.Errorf("label %s must end with a letter or digit", label)
+	}
+	return nil
+}
+
+// Target returns the revision the weight applies to, "latest" for the latest revision
+func (w TrafficWeight) Target() string {
+	if w.LatestRevision {
+		return "latest"
+	}
+	return w.RevisionName
+}
+
+// Replica is a running instance of a revision
+type Replica struct {
+	Name         string             `json:"name"`
+	RunningState string             `json:"runningState"`
+	CreatedAt    time.Time          `json:"createdAt"`
+	Containers   []ReplicaContainer `json:"containers"`
+}
+
+// ReplicaContainer is the state of a container inside a replica
+type ReplicaContainer struct {
+	Name         string `json:"name"`
+	Ready        bool   `json:"ready"`
+	Started      bool   `json:"started"`
+	RestartCount int    `json:"restartCount"`
+	RunningState string `json:"runningState"`
+}
+
+// RestartCount returns the restarts of all the containers of the replica
+func (r Replica) RestartCount() int {
+	total := 0
+	for _, c := range r.Containers {
+		total += c.RestartCount
+	}
+	return total
+}
+
+// ReadyCount returns how many containers of the replica are ready
+func (r Replica) ReadyCount() int {
+	ready := 0
+	for _, c := range r.Containers {
+		if c.Ready {
+			ready++
+		}
+	}
+	return ready
+}
+
+// Azure Monitor metrics of container apps
+const (
+	MetricCPU      = "UsageNanoCores"  // CPU usage in nanocores
+	MetricMemory   = "WorkingSetBytes" // memory working set in bytes
+	MetricRequests = "Requests"        // HTTP requests per interval
+	MetricRestarts = "RestartCount"    // container restarts per interval
+)
+
+// MetricPoint is the value of a metric over one interval
+type MetricPoint struct {
+	Time  time.Time `json:"time"`
+	Value float64   `json:"value"`
+}
+
+// MetricSeries is a metric sampled at regular intervals over a time window
+type MetricSeries struct {
+	Name   string        `json:"name"`
+	Unit   string        `json:"unit"`
+	Points []MetricPoint `json:"points"`
+}
+
+// Values returns the values of the series in time order
+func (s MetricSeries) Values() []float64 {
+	values := make([]float64, len(s.Points))
+	for i, point := range s.Points {
+		values[i] = point.Value
+	}
+	return values
+}
+
+// Stats returns the latest, minimum, average and maximum value of the series
+func (s MetricSeries) Stats() (latest, minimum, average, maximum float64) {
+	if len(s.Points) == 0 {
+		return 0, 0, 0, 0
+	}
+	minimum, maximum = s.Points[0].Value, s.Points[0].Value
+	for _, point := range s.Points {
+		minimum = min(minimum, point.Value)
+		maximum = max(maximum, point.Value)
+		average += point.Value
+	}
+	return s.Points[len(s.Points)-1].Value, minimum, average / float64(len(s.Points)), maximum
+}
+
+// MetricSet holds the metrics of an app or revision over the same time window
+type MetricSet []MetricSeries
+
+// Get returns the series of the named metric, empty if it was not collected
+func (ms MetricSet) Get(name string) MetricSeries {
+	for _, series := range ms {
+		if series.Name == name {
+			return series
+		}
+	}
+	return MetricSeries{Name: name}
+}
+
+// MetricsWindow is a time window metrics are shown over
+type MetricsWindow struct {
+	Name     string        // e.g. "24h"
+	Duration time.Duration // how far back the window reaches
+	Interval time.Duration // how long each point aggregates
+}
+
+// MetricsWindows are the selectable time windows, shortest first
+var MetricsWindows = []MetricsWindow{
+	{Name: "1h", Duration: time.Hour, Interval: 5 * time.Minute},
+	{Name: "6h", Duration: 6 * time.Hour, Interval: 15 * time.Minute},
+	{Name: "24h", Duration: 24 * time.Hour, Interval: time.Hour},
+	{Name: "7d", Duration: 7 * 24 * time.Hour, Interval: 6 * time.Hour},
+}
+
+// Points returns how many points a series covering the window has
+func (w MetricsWindow) Points() int {
+	return int(w.Duration / w.Interval)
+}
+
+// LogQueryRange is how far back a Log Analytics query looks
+type LogQueryRange struct {
+	Name     string // e.g. "24h"
+	Duration time.Duration
+}
+
+// LogQueryRanges are the selectable query time ranges, shortest first
+var LogQueryRanges = []LogQueryRange{
+	{Name: "30m", Duration: 30 * time.Minute},
+	{Name: "1h", Duration: time.Hour},
+	{Name: "6h", Duration: 6 * time.Hour},
+	{Name: "24h", Duration: 24 * time.Hour},
+	{Name: "3d", Duration: 3 * 24 * time.Hour},
+	{Name: "7d", Duration: 7 * 24 * time.Hour},
+	{Name: "30d", Duration: 30 * 24 * time.Hour},
+}
+
+// LogQueryResult is the table a Log Analytics query returns
+type LogQueryResult struct {
+	Columns []string            // Column names, in the order the query returns them
+	Rows    []map[string]string // Cell values by column name
+}
+
+// LogType is the kind of logs streamed for a container app
+type LogType string
+
+const (
+	// LogTypeConsole streams the output of the containers
+	LogTypeConsole LogType = "console"
+	// LogTypeSystem streams the events of the Container Apps platform, such as
+	// image pulls, probe failures and revision provisioning
+	LogTypeSystem LogType = "system"
+	// LogTypeCombined streams console and system logs interleaved by time
+	LogTypeCombined LogType = "combined"
+)
+
+// LogSource identifies the logs to stream: those of an app, narrowed down to a
+// revision, a replica of it and a container when they are set
+type LogSource struct {
+	App       ContainerApp
+	Revision  string
+	Replica   string
+	Container string
+	Type      LogType // Console logs when empty
+}
+
+// Streams returns the sources of the log streams making up the logs: one
+// console or system stream, or both for combined logs
+func (s LogSource) Streams() []LogSource {
+	switch s.Type {
+	case LogTypeCombined:
+		console, system := s, s
+		console.Type, system.Type = LogTypeConsole, LogTypeSystem
+		return []LogSource{console, system}
+	case LogTypeSystem:
+		return []LogSource{s}
+	default:
+		s.Type = LogTypeConsole
+		return []LogSource{s}
+	}
+}
+
+// String describes the source, e.g. "my-app/my-app--v2/replica-1/main"
+func (s LogSource) String() string {
+	parts := []string{s.App.Name}
+	for _, part := range []string{s.Revision, s.Replica, s.Container} {
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// ExecTarget identifies where `az containerapp exec` runs a command: the latest
+// revision of an app, or a revision, a replica of it and a container when they are set
+type ExecTarget struct {
+	App       ContainerApp
+	Revision  string
+	Replica   string
+	Container string
+	Image     string // image of the container, empty when unknown
+}
+
+// String describes the target, e.g. "my-app/my-app--v2/replica-1/main"
+func (t ExecTarget) String() string {
+	return LogSource{App: t.App, Revision: t.Revision, Replica: t.Replica, Container: t.Container}.String()
 }
 
 type Container struct {
@@ -50,17 +344,243 @@ type Container struct {
 	CPU          float64           `json:"cpu"`
 	Memory       string            `json:"memory"`
 	Env          map[string]string `json:"env"`
+	EnvSecrets   map[string]string `json:"envSecrets"` // env var name -> name of the app secret it references
 	Ports        []ContainerPort   `json:"ports"`
 	Probes       []string          `json:"probes"`
 	VolumeMounts []string          `json:"volumeMounts"`
 }
 
+// SplitImage splits a container image into its repository and tag. Images
+// pinned by digest keep the digest in the repository and have no tag.
+func SplitImage(image string) (repository, tag string) {
+	if strings.Contains(image, "@") {
+		return image, ""
+	}
+	// A colon before the last slash separates a registry host from its port
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, ""
+	}
+	return image[:i], image[i+1:]
+}
+
+// SecretRef returns the name of the app secret an env var references, if any
+func (c Container) SecretRef(envName string) (string, bool) {
+	name, ok := c.EnvSecrets[envName]
+	return name, ok
+}
+
+// Secret is a secret of a container app, either stored in the app or a
+// reference to a Key Vault secret read with a managed identity.
+// Value is only set once the secret has been shown.
+type Secret struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	KeyVaultURL string `json:"keyVaultUrl"`
+	Identity    string `json:"identity"`
+}
+
+// IsKeyVaultRef reports whether the secret references a Key Vault secret
+func (s Secret) IsKeyVaultRef() bool {
+	return s.KeyVaultURL != ""
+}
+
+// SecretRefPrefix starts the value of an env var that references an app
+// secret, as in NAME=secretref:secret-name
+const SecretRefPrefix = "secretref:"
+
+// EnvUpdate changes the environment variables of a container of an app.
+// Set values starting with SecretRefPrefix reference an app secret.
+type EnvUpdate struct {
+	Container string
+	Set       map[string]string
+	Remove    []string
+}
+
+// IsEmpty reports whether the update changes nothing
+func (u EnvUpdate) IsEmpty() bool {
+	return len(u.Set) == 0 && len(u.Remove) == 0
+}
+
+// Types of scale rules
+const (
+	ScaleRuleHTTP   = "http"
+	ScaleRuleTCP    = "tcp"
+	ScaleRuleQueue  = "azure-queue"
+	ScaleRuleCustom = "custom"
+)
+
+// Metadata keys holding the queue of azure-queue scale rules
+const (
+	ScaleQueueAccountName = "accountName"
+	ScaleQueueName        = "queueName"
+	ScaleQueueLength      = "queueLength"
+)
+
+// MaxReplicasLimit is the most replicas a revision can scale out to
+const MaxReplicasLimit = 1000
+
+// Scale holds the replica bounds and the scale rules of the revision template of an app
+type Scale struct {
+	MinReplicas int         `json:"minReplicas"`
+	MaxReplicas int         `json:"maxReplicas"`
+	Rules       []ScaleRule `json:"rules"`
+}
+
+// Validate checks the replica bounds, that every rule is named once and that
+// queue rules name their queue and length
+func (s Scale) Validate() error {
+	if s.MinReplicas < 0 || s.MaxReplicas < 1 || s.MaxReplicas > MaxReplicasLimit {
+		return fmt.Errorf("replicas must be between 0 and %d, with a maximum of at least 1", MaxReplicasLimit)
+	}
+	if s.MinReplicas > s.MaxReplicas {
+		return fmt.Errorf("min replicas %d is above max replicas %d", s.MinReplicas, s.MaxReplicas)
+	}
+	names := make(map[string]bool)
+	for _, rule := range s.Rules {
+		if rule.Name == "" || names[rule.Name] {
+			return fmt.Errorf("scale rule names must be set and unique, got %q", rule.Name)
+		}
+		names[rule.Name] = true
+		if rule.Type != ScaleRuleQueue {
+			continue
+		}
+		if rule.Metadata[ScaleQueueName] == "" {
+			return fmt.Errorf("scale rule %s: %s is required", rule.Name, ScaleQueueName)
+		}
+		if _, err := strconv.Atoi(rule.Metadata[ScaleQueueLength]); err != nil {
+			return fmt.Errorf("scale rule %s: %s must be a number", rule.Name, ScaleQueueLength)
+		}
+	}
+	return nil
+}
+
+// Clone returns a copy of the scale whose rules can be changed without
+// changing the original
+func (s Scale) Clone() Scale {
+	clone := s
+	clone.Rules = make([]ScaleRule, len(s.Rules))
+	for i, rule := range s.Rules {
+		clone.Rules[i] = rule
+		clone.Rules[i].Metadata = maps.Clone(rule.Metadata)
+		clone.Rules[i].Auth = slices.Clone(rule.Auth)
+	}
+	return clone
+}
+
+// ScaleRule is a KEDA scale rule. Queue rules keep their queue in Metadata
+// under the ScaleQueue keys, and custom rules name their KEDA scaler in CustomType.
+type ScaleRule struct {
+	Name       string            `json:"name"`
+	Type       string            `json:"type"`
+	CustomType string            `json:"customType"`
+	Metadata   map[string]string `json:"metadata"`
+	Auth       []ScaleRuleAuth   `json:"auth"`
+}
+
+// Trigger returns the KEDA scaler of the rule, e.g. http or azure-servicebus
+func (r ScaleRule) Trigger() string {
+	if r.Type == ScaleRuleCustom && r.CustomType != "" {
+		return r.CustomType
+	}
+	return r.Type
+}
+
+// ScaleRuleAuth passes a secret of the app to a scale rule as a trigger parameter
+type ScaleRuleAuth struct {
+	SecretRef        string `json:"secretRef"`
+	TriggerParameter string `json:"triggerParameter"`
+}
+
 type ContainerPort struct {
 	Name     string `json:"name"`
 	Port     int    `json:"port"`
 	Protocol string `json:"protocol"`
 }
 
+type Job struct {
+	Name              string  `json:"name"`
+	ResourceGroup     string  `json:"resourceGroup"`
+	Location          string  `json:"location"`
+	EnvironmentID     string  `json:"environmentId"`
+	TriggerType       string  `json:"triggerType"`
+	CronExpression    string  `json:"cronExpression"`
+	Parallelism       int     `json:"parallelism"`
+	CompletionCount   int     `json:"replicaCompletionCount"`
+	ReplicaRetryLimit int     `json:"replicaRetryLimit"`
+	ReplicaTimeout    int     `json:"replicaTimeout"`
+	ProvisioningState string  `json:"provisioningState"`
+	Image             string  `json:"image"`
+	CPU               float64 `json:"cpu"`
+	Memory            string  `json:"memory"`
+	WorkloadProfile   string  `json:"workloadProfile"`
+	CreatedAt         string  `json:"createdAt"`
+	LastModifiedAt    string  `json:"lastModifiedAt"`
+}
+
+type JobExecution struct {
+	Name      string    `json:"name"`
+	Status    string    `json:"status"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+}
+
+// Operation is an entry of the operation log, recording the result of an action
+// that changed Azure resources
+type Operation struct {
+	Time      time.Time `json:"time"`
+	Name      string    `json:"name"`
+	Resource  string    `json:"resource"`
+	Target    string    `json:"target"`
+	Succeeded bool      `json:"succeeded"`
+	Message   string    `json:"message"`
+}
+
+// PortForward is a loopback port forwarded to the ingress of an app, with the
+// traffic forwarded so far
+type PortForward struct {
+	ID            int
+	App           ContainerApp
+	LocalAddr     string
+	Remote        string
+	StartedAt     time.Time
+	Connections   int
+	Active        int
+	BytesSent     int64
+	BytesReceived int64
+	Err           error // why forwarding stopped, nil while it runs
+}
+
+// ProbeRequest is an HTTP request sent to the ingress of an app or revision
+type ProbeRequest struct {
+	Method  string
+	URL     string
+	Headers http.Header
+}
+
+// ProbeResponse is the response to a ProbeRequest. Latency runs from sending
+// the request until the body was read.
+type ProbeResponse struct {
+	Status     string // e.g. "200 OK"
+	StatusCode int
+	Headers    http.Header
+	Body       string
+	Truncated  bool // whether Body holds only the start of a larger body
+	Size       int64
+	Latency    time.Duration
+}
+
+// Probe is a request sent to an app or revision and its response, or the
+// error it failed with
+type Probe struct {
+	ID       int
+	Target   string // the app or revision probed
+	SentAt   time.Time
+	Request  ProbeRequest
+	Response ProbeResponse
+	Err      error
+}
+
 type RevItem struct{ Revision }
 
 func (ri RevItem) Title() string { return ri.Name }
@@ -72,9 +592,53 @@ func (ri RevItem) Description() string {
 }
 func (ri RevItem) FilterValue() string { return ri.Name }
 
+// Subscription is an Azure subscription the signed-in account has access to
+type Subscription struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	TenantID  string `json:"tenantId"`
+	State     string `json:"state"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+// ManagedEnvironment is a Container Apps environment hosting apps and jobs
+type ManagedEnvironment struct {
+	ID                string   `json:"id"`
+	Name              string   `json:"name"`
+	ResourceGroup     string   `json:"resourceGroup"`
+	Location          string   `json:"location"`
+	ProvisioningState string   `json:"provisioningState"`
+	WorkloadProfiles  []string `json:"workloadProfiles"`
+	SubnetID          string   `json:"infrastructureSubnetId"`
+	Internal          bool     `json:"internal"`
+	StaticIP          string   `json:"staticIp"`
+	DefaultDomain     string   `json:"defaultDomain"`
+	AppCount          int      `json:"-"` // apps hosted in the environment, across resource groups
+}
+
+// HasVNetIntegration reports whether the environment is deployed into a custom virtual network
+func (env ManagedEnvironment) HasVNetIntegration() bool {
+	return env.SubnetID != ""
+}
+
 type ResourceGroup struct {
 	Name     string            `json:"name"`
 	Location string            `json:"location"`
 	State    string            `json:"provisioningState"`
 	Tags     map[string]string `json:"tags"`
 }
+
+// IsProduction reports whether the resource group is tagged as a production
+// environment, e.g. environment=production or env=prod
+func (rg ResourceGroup) IsProduction() bool {
+	for key, value := range rg.Tags {
+		switch strings.ToLower(key) {
+		case "environment", "env", "stage":
+			switch strings.ToLower(strings.TrimSpace(value)) {
+			case "production", "prod", "prd":
+				return true
+			}
+		}
+	}
+	return false
+}
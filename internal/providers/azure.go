@@ -2,12 +2,13 @@ package providers
 
 import (
 	"context"
+	"time"
 
 	"github.com/IAL32/az-tui/internal/azure"
 	"github.com/IAL32/az-tui/internal/models"
 )
 
-// AzureProvider implements the DataProvider interface using Azure CLI
+// AzureProvider implements the DataProvider, MetricsProvider and LogQueryRunner interfaces using Azure CLI
 type AzureProvider struct{}
 
 // NewAzureProvider creates a new Azure CLI data provider
@@ -15,6 +16,15 @@ func NewAzureProvider() *AzureProvider {
 	return &AzureProvider{}
 }
 
+// WithSubscription returns a context scoping DataProvider calls to a subscription
+func WithSubscription(ctx context.Context, subscriptionID string) context.Context {
+	return azure.WithSubscription(ctx, subscriptionID)
+}
+
+func (p *AzureProvider) ListSubscriptions(ctx context.Context) ([]models.Subscription, error) {
+	return azure.ListSubscriptions(ctx)
+}
+
 func (p *AzureProvider) ListResourceGroups(ctx context.Context) ([]models.ResourceGroup, error) {
 	return azure.ListResourceGroups(ctx)
 }
@@ -23,6 +33,14 @@ func (p *AzureProvider) ListContainerApps(ctx context.Context, resourceGroup str
 	return azure.ListContainerApps(ctx, resourceGroup)
 }
 
+func (p *AzureProvider) ListManagedEnvironments(ctx context.Context, resourceGroup string) ([]models.ManagedEnvironment, error) {
+	return azure.ListManagedEnvironments(ctx, resourceGroup)
+}
+
+func (p *AzureProvider) ListEnvironmentApps(ctx context.Context, environmentID string) ([]models.ContainerApp, error) {
+	return azure.ListEnvironmentApps(ctx, environmentID)
+}
+
 func (p *AzureProvider) GetAppDetails(ctx context.Context, name, resourceGroup string) (string, error) {
 	return azure.GetAppDetails(ctx, name, resourceGroup)
 }
@@ -31,6 +49,42 @@ func (p *AzureProvider) ListRevisions(ctx context.Context, appName, resourceGrou
 	return azure.ListRevisions(ctx, appName, resourceGroup)
 }
 
+func (p *AzureProvider) ListTrafficWeights(ctx context.Context, appName, resourceGroup string) ([]models.TrafficWeight, error) {
+	return azure.ListTrafficWeights(ctx, appName, resourceGroup)
+}
+
+func (p *AzureProvider) ListReplicas(ctx context.Context, app models.ContainerApp, revisionName string) ([]models.Replica, error) {
+	return azure.ListReplicas(ctx, app, revisionName)
+}
+
 func (p *AzureProvider) ListContainers(ctx context.Context, app models.ContainerApp, revisionName string) ([]models.Container, error) {
 	return azure.ListContainersCmd(ctx, app, revisionName)
 }
+
+func (p *AzureProvider) ListSecrets(ctx context.Context, app models.ContainerApp) ([]models.Secret, error) {
+	return azure.ListSecrets(ctx, app)
+}
+
+func (p *AzureProvider) ShowSecret(ctx context.Context, app models.ContainerApp, secretName string) (models.Secret, error) {
+	return azure.ShowSecret(ctx, app, secretName)
+}
+
+func (p *AzureProvider) GetScale(ctx context.Context, app models.ContainerApp) (models.Scale, error) {
+	return azure.GetScale(ctx, app)
+}
+
+func (p *AzureProvider) GetAppMetrics(ctx context.Context, app models.ContainerApp, revisionName string, window models.MetricsWindow) (models.MetricSet, error) {
+	return azure.ListAppMetrics(ctx, app, revisionName, window)
+}
+
+func (p *AzureProvider) RunLogQuery(ctx context.Context, environmentID, query string, timespan time.Duration) (models.LogQueryResult, error) {
+	return azure.RunLogQuery(ctx, environmentID, query, timespan)
+}
+
+func (p *AzureProvider) ListJobs(ctx context.Context, resourceGroup string) ([]models.Job, error) {
+	return azure.ListJobs(ctx, resourceGroup)
+}
+
+func (p *AzureProvider) ListJobExecutions(ctx context.Context, jobName, resourceGroup string) ([]models.JobExecution, error) {
+	return azure.ListJobExecutions(ctx, jobName, resourceGroup)
+}
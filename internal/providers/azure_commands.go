@@ -1,86 +1,403 @@
 package providers
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
+	"maps"
+	"net/http"
 	"os"
 	"os/exec"
+	"slices"
+	"strings"
 
+	"github.com/IAL32/az-tui/internal/azure"
 	"github.com/IAL32/az-tui/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // AzureCommandProvider implements the CommandProvider interface using Azure CLI
-type AzureCommandProvider struct{}
+type AzureCommandProvider struct {
+	// subscription every az command runs against, empty for the az CLI default
+	subscription string
+}
 
 // NewAzureCommandProvider creates a new Azure CLI command provider
 func NewAzureCommandProvider() *AzureCommandProvider {
 	return &AzureCommandProvider{}
 }
 
-func (az *AzureCommandProvider) ExecIntoApp(app models.ContainerApp) tea.Cmd {
-	return az.execCommand("az", "containerapp", "exec",
-		"-n", app.Name, "-g", app.ResourceGroup, "--command", "/bin/sh")
+// SetSubscription scopes the commands created from now on to a subscription
+func (az *AzureCommandProvider) SetSubscription(subscriptionID string) {
+	az.subscription = subscriptionID
 }
 
-func (az *AzureCommandProvider) ExecIntoRevision(app models.ContainerApp, revision string) tea.Cmd {
-	return az.execCommand("az", "containerapp", "exec",
-		"-n", app.Name, "-g", app.ResourceGroup,
-		"--revision", revision, "--command", "/bin/sh")
+// ExecCommand runs a program in the target with `az containerapp exec`. Without
+// a revision, az picks the latest one, and a replica and container of it.
+func (az *AzureCommandProvider) ExecCommand(target models.ExecTarget, command string) *exec.Cmd {
+	args := []string{"containerapp", "exec", "-n", target.App.Name, "-g", target.App.ResourceGroup}
+	if target.Revision != "" {
+		args = append(args, "--revision", target.Revision)
+	}
+	if target.Replica != "" {
+		args = append(args, "--replica", target.Replica)
+	}
+	if target.Container != "" {
+		args = append(args, "--container", target.Container)
+	}
+	args = append(args, "--command", command)
+	return exec.Command("az", az.azArgs(args...)...)
 }
 
-func (az *AzureCommandProvider) ExecIntoContainer(app models.ContainerApp, revision, container string) tea.Cmd {
-	return az.execCommand("az", "containerapp", "exec",
-		"-n", app.Name, "-g", app.ResourceGroup,
-		"--revision", revision, "--container", container, "--command", "/bin/sh")
+// PortForward forwards a loopback port to the HTTPS ingress of an app. The
+// requests are sent from this machine, so an internal ingress is only reached
+// from a network connected to the environment, such as over a VPN.
+func (az *AzureCommandProvider) PortForward(app models.ContainerApp, localPort int) (Tunnel, error) {
+	remote, err := ingressURL(app.IngressFQDN)
+	if err != nil {
+		return nil, fmt.Errorf("cannot forward to %s: %w", app.Name, err)
+	}
+	return startTunnel(localPort, remote, http.DefaultTransport, nil)
 }
 
-func (az *AzureCommandProvider) ShowAppLogs(app models.ContainerApp) tea.Cmd {
-	fmt.Println("--- Ctrl+C to stop logs ---")
-	return az.execCommand("az", "containerapp", "logs", "show",
-		"-n", app.Name, "-g", app.ResourceGroup, "--follow")
+// StreamLogs follows the logs of a source with `az containerapp logs show --follow`.
+// System logs cover the whole app, so the revision, replica and container are
+// only used for console logs.
+func (az *AzureCommandProvider) StreamLogs(source models.LogSource) (LogStream, error) {
+	args := []string{"containerapp", "logs", "show",
+		"-n", source.App.Name, "-g", source.App.ResourceGroup}
+	if source.Type == models.LogTypeSystem {
+		args = append(args, "--type", "system", "--follow")
+		return streamCommand("az", az.azArgs(args...)...)
+	}
+	if source.Revision != "" {
+		args = append(args, "--revision", source.Revision)
+	}
+	if source.Replica != "" {
+		args = append(args, "--replica", source.Replica)
+	}
+	if source.Container != "" {
+		args = append(args, "--container", source.Container)
+	}
+	args = append(args, "--follow")
+	return streamCommand("az", az.azArgs(args...)...)
 }
 
-func (az *AzureCommandProvider) ShowRevisionLogs(app models.ContainerApp, revision string) tea.Cmd {
-	fmt.Println("--- Ctrl+C to stop logs ---")
-	return az.execCommand("az", "containerapp", "logs", "show",
-		"-n", app.Name, "-g", app.ResourceGroup,
-		"--revision", revision, "--follow")
+func (az *AzureCommandProvider) RestartRevision(app models.ContainerApp, revision string) tea.Cmd {
+	return az.revisionCommand(app, revision, "restart", OperationRestartRevision)
 }
 
-func (az *AzureCommandProvider) ShowContainerLogs(app models.ContainerApp, revision, container string) tea.Cmd {
-	fmt.Println("--- Ctrl+C to stop logs ---")
-	return az.execCommand("az", "containerapp", "logs", "show",
-		"-n", app.Name, "-g", app.ResourceGroup,
-		"--revision", revision, "--container", container, "--follow")
+func (az *AzureCommandProvider) ActivateRevision(app models.ContainerApp, revision string) tea.Cmd {
+	return az.revisionCommand(app, revision, "activate", OperationActivateRevision)
 }
 
-func (az *AzureCommandProvider) RestartRevision(app models.ContainerApp, revision string) tea.Cmd {
+func (az *AzureCommandProvider) DeactivateRevision(app models.ContainerApp, revision string) tea.Cmd {
+	return az.revisionCommand(app, revision, "deactivate", OperationDeactivateRevision)
+}
+
+// revisionCommand runs `az containerapp revision <action>` on a revision
+func (az *AzureCommandProvider) revisionCommand(app models.ContainerApp, revision, action, operation string) tea.Cmd {
+	args := az.azArgs("containerapp", "revision", action,
+		"-n", app.Name, "-g", app.ResourceGroup, "--revision", revision)
+	return func() tea.Msg {
+		cmd := exec.Command("az", args...)
+		b, err := cmd.CombinedOutput()
+		return OperationResultMsg{
+			Operation: operation,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    revision,
+			Err:       err,
+			Out:       string(b),
+		}
+	}
+}
+
+// AddRevisionLabel labels a revision with `az containerapp revision label add`.
+// Without --yes az asks before moving a label from another revision.
+func (az *AzureCommandProvider) AddRevisionLabel(app models.ContainerApp, revision, label string) tea.Cmd {
+	args := az.azArgs("containerapp", "revision", "label", "add",
+		"-n", app.Name, "-g", app.ResourceGroup, "--revision", revision, "--label", label, "--no-prompt", "--yes")
+	return func() tea.Msg {
+		cmd := exec.Command("az", args...)
+		b, err := cmd.CombinedOutput()
+		return OperationResultMsg{
+			Operation: OperationAddLabel,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    label,
+			Result:    revision,
+			Err:       err,
+			Out:       string(b),
+		}
+	}
+}
+
+func (az *AzureCommandProvider) RemoveRevisionLabel(app models.ContainerApp, label string) tea.Cmd {
+	args := az.azArgs("containerapp", "revision", "label", "remove",
+		"-n", app.Name, "-g", app.ResourceGroup, "--label", label)
+	return func() tea.Msg {
+		cmd := exec.Command("az", args...)
+		b, err := cmd.CombinedOutput()
+		return OperationResultMsg{
+			Operation: OperationRemoveLabel,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    label,
+			Err:       err,
+			Out:       string(b),
+		}
+	}
+}
+
+// SetRevisionMode switches the revision mode of an app with
+// `az containerapp revision set-mode`
+func (az *AzureCommandProvider) SetRevisionMode(app models.ContainerApp, mode string) tea.Cmd {
+	args := az.azArgs("containerapp", "revision", "set-mode",
+		"-n", app.Name, "-g", app.ResourceGroup, "--mode", strings.ToLower(mode))
 	return func() tea.Msg {
-		cmd := exec.Command("az", "containerapp", "revision", "restart",
-			"-n", app.Name, "-g", app.ResourceGroup, "--revision", revision)
+		cmd := exec.Command("az", args...)
 		b, err := cmd.CombinedOutput()
-		return revisionRestartedMsg{
-			appID:   fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
-			revName: revision,
-			err:     err,
-			out:     string(b),
+		msg := OperationResultMsg{
+			Operation: OperationSetRevisionMode,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    app.Name,
+			Err:       err,
+			Out:       string(b),
+		}
+		if err == nil {
+			msg.Result = mode
 		}
+		return msg
 	}
 }
 
-// execCommand creates a tea.Cmd that executes the given command with proper I/O setup
-func (az *AzureCommandProvider) execCommand(name string, args ...string) tea.Cmd {
-	cmd := exec.Command(name, args...)
-	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
-	return tea.ExecProcess(cmd, func(error) tea.Msg { return noop{} })
+// SetTraffic replaces the traffic split of an app. Labelled revisions are
+// weighted by label so that the label keeps pointing at its revision.
+func (az *AzureCommandProvider) SetTraffic(app models.ContainerApp, weights []models.TrafficWeight) tea.Cmd {
+	var revisionWeights, labelWeights []string
+	for _, weight := range weights {
+		switch {
+		case weight.LatestRevision:
+			revisionWeights = append(revisionWeights, fmt.Sprintf("latest=%d", weight.Weight))
+		case weight.Label != "":
+			labelWeights = append(labelWeights, fmt.Sprintf("%s=%d", weight.Label, weight.Weight))
+		default:
+			revisionWeights = append(revisionWeights, fmt.Sprintf("%s=%d", weight.RevisionName, weight.Weight))
+		}
+	}
+
+	args := []string{"containerapp", "ingress", "traffic", "set", "-n", app.Name, "-g", app.ResourceGroup}
+	if len(revisionWeights) > 0 {
+		args = append(append(args, "--revision-weight"), revisionWeights...)
+	}
+	if len(labelWeights) > 0 {
+		args = append(append(args, "--label-weight"), labelWeights...)
+	}
+	args = az.azArgs(args...)
+	return func() tea.Msg {
+		cmd := exec.Command("az", args...)
+		b, err := cmd.CombinedOutput()
+		return OperationResultMsg{
+			Operation: OperationSetTraffic,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    app.Name,
+			Err:       err,
+			Out:       string(b),
+		}
+	}
 }
 
-// Message types
-type revisionRestartedMsg struct {
-	appID   string
-	revName string
-	err     error
-	out     string
+// UpdateContainerEnv sets and removes env vars of a container with
+// `az containerapp update`, and reports the revision the update created.
+func (az *AzureCommandProvider) UpdateContainerEnv(app models.ContainerApp, update models.EnvUpdate) tea.Cmd {
+	args := []string{"containerapp", "update", "-n", app.Name, "-g", app.ResourceGroup,
+		"--container-name", update.Container}
+	if len(update.Set) > 0 {
+		args = append(args, "--set-env-vars")
+		for _, name := range slices.Sorted(maps.Keys(update.Set)) {
+			args = append(args, fmt.Sprintf("%s=%s", name, update.Set[name]))
+		}
+	}
+	if len(update.Remove) > 0 {
+		args = append(append(args, "--remove-env-vars"), update.Remove...)
+	}
+	args = az.azArgs(append(args, "-o", "json")...)
+	return func() tea.Msg {
+		stdout, stderr, err := runJSONCommand(args)
+		msg := OperationResultMsg{
+			Operation: OperationUpdateEnv,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    update.Container,
+			Err:       err,
+			Out:       stderr,
+		}
+		if err == nil {
+			msg.Result = latestRevisionName(stdout)
+		}
+		return msg
+	}
 }
 
-type noop struct{}
+// UpdateContainerImage replaces the image of a container with
+// `az containerapp update --image`, and reports the revision the update created.
+func (az *AzureCommandProvider) UpdateContainerImage(app models.ContainerApp, container, image string) tea.Cmd {
+	args := az.azArgs("containerapp", "update", "-n", app.Name, "-g", app.ResourceGroup,
+		"--container-name", container, "--image", image, "-o", "json")
+	return func() tea.Msg {
+		stdout, stderr, err := runJSONCommand(args)
+		msg := OperationResultMsg{
+			Operation: OperationUpdateImage,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    container,
+			Err:       err,
+			Out:       stderr,
+		}
+		if err == nil {
+			msg.Result = latestRevisionName(stdout)
+		}
+		return msg
+	}
+}
+
+// UpdateScale replaces the replica bounds and scale rules of an app. Flags of
+// `az containerapp update` only set a single rule, so the revision template is
+// read, its scale replaced, and written back with `az containerapp update --yaml`.
+func (az *AzureCommandProvider) UpdateScale(app models.ContainerApp, scale models.Scale) tea.Cmd {
+	showArgs := az.azArgs("containerapp", "show", "-n", app.Name, "-g", app.ResourceGroup,
+		"--query", "properties.template", "-o", "json")
+	return func() tea.Msg {
+		msg := OperationResultMsg{
+			Operation: OperationUpdateScale,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    app.Name,
+		}
+		path, err := writeScaleTemplate(showArgs, scale)
+		if err != nil {
+			msg.Err = err
+			return msg
+		}
+		defer os.Remove(path)
+
+		stdout, stderr, err := runJSONCommand(az.azArgs("containerapp", "update",
+			"-n", app.Name, "-g", app.ResourceGroup, "--yaml", path, "-o", "json"))
+		msg.Err, msg.Out = err, stderr
+		if err == nil {
+			msg.Result = latestRevisionName(stdout)
+		}
+		return msg
+	}
+}
+
+// writeScaleTemplate writes the revision template az shows with showArgs, with
+// its scale replaced, to a temporary file read by `az containerapp update --yaml`.
+// JSON being valid YAML, the file is written as JSON.
+func writeScaleTemplate(showArgs []string, scale models.Scale) (string, error) {
+	b, stderr, err := runJSONCommand(showArgs)
+	if err != nil {
+		return "", fmt.Errorf("failed to read the revision template: %w: %s", err, stderr)
+	}
+	var template map[string]json.RawMessage
+	if err := json.Unmarshal(b, &template); err != nil || template == nil {
+		return "", fmt.Errorf("failed to parse the revision template: %s", b)
+	}
+	if template["scale"], err = azure.TransformScaleToJSON(scale); err != nil {
+		return "", err
+	}
+	spec, err := json.Marshal(map[string]any{"properties": map[string]any{"template": template}})
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp("", "az-tui-scale-*.yaml")
+	if err != nil {
+		return "", err
+	}
+	_, err = f.Write(spec)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(f.Name())
+		return "", err
+	}
+	return f.Name(), nil
+}
+
+// latestRevisionName reads the latest revision of an app from the output of
+// `az containerapp update -o json`, empty if it cannot be read
+func latestRevisionName(out []byte) string {
+	var updated struct {
+		Properties struct {
+			LatestRevisionName string `json:"latestRevisionName"`
+		} `json:"properties"`
+	}
+	if json.Unmarshal(out, &updated) != nil {
+		return ""
+	}
+	return updated.Properties.LatestRevisionName
+}
+
+func (az *AzureCommandProvider) StartJob(job models.Job) tea.Cmd {
+	return az.startJobCommand(job, OperationStartJob, job.Name)
+}
+
+func (az *AzureCommandProvider) StopJobExecution(job models.Job, execution string) tea.Cmd {
+	args := az.azArgs("containerapp", "job", "stop",
+		"-n", job.Name, "-g", job.ResourceGroup, "--job-execution-name", execution)
+	return func() tea.Msg {
+		cmd := exec.Command("az", args...)
+		b, err := cmd.CombinedOutput()
+		return OperationResultMsg{
+			Operation: OperationStopExecution,
+			JobID:     fmt.Sprintf("%s/%s", job.ResourceGroup, job.Name),
+			Target:    execution,
+			Err:       err,
+			Out:       string(b),
+		}
+	}
+}
+
+// RerunJobExecution starts a new execution from the job's current template.
+// The Azure CLI cannot replay an execution, so the execution name is not used.
+func (az *AzureCommandProvider) RerunJobExecution(job models.Job, execution string) tea.Cmd {
+	return az.startJobCommand(job, OperationRerunExecution, execution)
+}
+
+// startJobCommand starts a job execution and reports the name of the new execution
+func (az *AzureCommandProvider) startJobCommand(job models.Job, operation, target string) tea.Cmd {
+	args := az.azArgs("containerapp", "job", "start",
+		"-n", job.Name, "-g", job.ResourceGroup, "-o", "json")
+	return func() tea.Msg {
+		stdout, stderr, err := runJSONCommand(args)
+		msg := OperationResultMsg{
+			Operation: operation,
+			JobID:     fmt.Sprintf("%s/%s", job.ResourceGroup, job.Name),
+			Target:    target,
+			Err:       err,
+			Out:       stderr,
+		}
+		if err == nil {
+			var started struct {
+				Name string `json:"name"`
+			}
+			if json.Unmarshal(stdout, &started) == nil {
+				msg.Result = started.Name
+			}
+		}
+		return msg
+	}
+}
+
+// runJSONCommand runs an az command printing JSON, keeping stdout apart from
+// stderr so that the warnings az prints do not break parsing the JSON
+func runJSONCommand(args []string) (stdout []byte, stderr string, err error) {
+	cmd := exec.Command("az", args...)
+	var out, errb bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &errb
+	err = cmd.Run()
+	return out.Bytes(), errb.String(), err
+}
+
+// azArgs appends the subscription scope to the arguments of an az command
+func (az *AzureCommandProvider) azArgs(args ...string) []string {
+	return append(args, azure.SubscriptionArgs(az.subscription)...)
+}
@@ -0,0 +1,54 @@
+package providers
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// fakeAz puts an az script on PATH that prints a warning on stderr and stdout as JSON
+func fakeAz(t *testing.T, stdout string) {
+	t.Helper()
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake az is a shell script")
+	}
+	dir := t.TempDir()
+	script := "#!/bin/sh\n" +
+		"echo 'WARNING: The behavior of this command has been altered by the following extension: containerapp' >&2\n" +
+		"cat <<'JSON'\n" + stdout + "\nJSON\n"
+	if err := os.WriteFile(filepath.Join(dir, "az"), []byte(script), 0o755); err != nil {
+		t.Fatalf("Failed to write the fake az: %v", err)
+	}
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+}
+
+// Test that warnings az prints on stderr do not hide the revision an update created
+func TestAzureUpdateContainerImageWithWarnings(t *testing.T) {
+	fakeAz(t, `{"name": "web", "properties": {"latestRevisionName": "web--v3"}}`)
+
+	app := models.ContainerApp{Name: "web", ResourceGroup: "rg-test"}
+	msg := NewAzureCommandProvider().UpdateContainerImage(app, "app", "web:v3")().(OperationResultMsg)
+	if msg.Err != nil {
+		t.Fatalf("Unexpected error: %v", msg.Err)
+	}
+	if msg.Result != "web--v3" {
+		t.Errorf("Expected the created revision web--v3, got %q", msg.Result)
+	}
+	if msg.Out == "" {
+		t.Error("Expected the warning to be kept in the output")
+	}
+}
+
+// Test that warnings az prints on stderr do not hide the execution a job start created
+func TestAzureStartJobWithWarnings(t *testing.T) {
+	fakeAz(t, `{"id": "/subscriptions/x/jobs/nightly/executions/nightly-abc12", "name": "nightly-abc12"}`)
+
+	job := models.Job{Name: "nightly", ResourceGroup: "rg-test"}
+	msg := NewAzureCommandProvider().StartJob(job)().(OperationResultMsg)
+	if msg.Err != nil || msg.Result != "nightly-abc12" {
+		t.Errorf("Expected execution nightly-abc12, got %q (err %v)", msg.Result, msg.Err)
+	}
+}
@@ -1,17 +1,77 @@
 package providers
 
 import (
+	"os/exec"
+
 	"github.com/IAL32/az-tui/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // CommandProvider defines the interface for executing Azure CLI operations
 type CommandProvider interface {
-	ExecIntoApp(app models.ContainerApp) tea.Cmd
-	ExecIntoRevision(app models.ContainerApp, revision string) tea.Cmd
-	ExecIntoContainer(app models.ContainerApp, revision, container string) tea.Cmd
-	ShowAppLogs(app models.ContainerApp) tea.Cmd
-	ShowRevisionLogs(app models.ContainerApp, revision string) tea.Cmd
-	ShowContainerLogs(app models.ContainerApp, revision, container string) tea.Cmd
+	// SetSubscription scopes the commands created afterwards to a subscription; empty uses the az CLI default
+	SetSubscription(subscriptionID string)
+	// ExecCommand creates the command that runs a program, such as /bin/sh, in a
+	// container of the target. It is started in an embedded terminal.
+	ExecCommand(target models.ExecTarget, command string) *exec.Cmd
+	// StreamLogs starts following the logs of a source: the console logs of an app,
+	// revision, replica or container, or the system logs of an app when its type
+	// is system. Combined sources are split into one stream per type with Streams.
+	StreamLogs(source models.LogSource) (LogStream, error)
+	// PortForward starts forwarding a loopback port, any free one when localPort
+	// is 0, to the ingress of an app
+	PortForward(app models.ContainerApp, localPort int) (Tunnel, error)
 	RestartRevision(app models.ContainerApp, revision string) tea.Cmd
+	ActivateRevision(app models.ContainerApp, revision string) tea.Cmd
+	DeactivateRevision(app models.ContainerApp, revision string) tea.Cmd
+	// AddRevisionLabel labels a revision in the traffic split of an app, moving
+	// the label from the revision it was on, if any
+	AddRevisionLabel(app models.ContainerApp, revision, label string) tea.Cmd
+	RemoveRevisionLabel(app models.ContainerApp, label string) tea.Cmd
+	// SetRevisionMode switches an app between single and multiple revision
+	// mode; the mode set is reported as the Result
+	SetRevisionMode(app models.ContainerApp, mode string) tea.Cmd
+	SetTraffic(app models.ContainerApp, weights []models.TrafficWeight) tea.Cmd
+	// UpdateContainerEnv sets and removes env vars of a container, which
+	// creates a new revision of the app reported as the Result
+	UpdateContainerEnv(app models.ContainerApp, update models.EnvUpdate) tea.Cmd
+	// UpdateContainerImage replaces the image of a container, which creates a
+	// new revision of the app reported as the Result
+	UpdateContainerImage(app models.ContainerApp, container, image string) tea.Cmd
+	// UpdateScale replaces the replica bounds and scale rules of an app, which
+	// creates a new revision of the app reported as the Result
+	UpdateScale(app models.ContainerApp, scale models.Scale) tea.Cmd
+	StartJob(job models.Job) tea.Cmd
+	StopJobExecution(job models.Job, execution string) tea.Cmd
+	RerunJobExecution(job models.Job, execution string) tea.Cmd
+}
+
+// Operations reported by OperationResultMsg
+const (
+	OperationRestartRevision    = "restart revision"
+	OperationActivateRevision   = "activate revision"
+	OperationDeactivateRevision = "deactivate revision"
+	OperationAddLabel           = "add label"
+	OperationRemoveLabel        = "remove label"
+	OperationSetRevisionMode    = "set revision mode"
+	OperationSetTraffic         = "set traffic"
+	OperationUpdateEnv          = "update env vars"
+	OperationUpdateImage        = "update image"
+	OperationUpdateScale        = "update scale"
+	OperationStartJob           = "start job"
+	OperationStopExecution      = "stop execution"
+	OperationRerunExecution     = "re-run execution"
+)
+
+// OperationResultMsg is returned by every CommandProvider operation that changes
+// Azure resources. The affected app or job is identified by AppID or JobID so
+// that the UI can report the result and reload whatever page is showing it.
+type OperationResultMsg struct {
+	Operation string // one of the Operation constants
+	AppID     string // resourceGroup/appName, for app operations
+	JobID     string // resourceGroup/jobName, for job operations
+	Target    string // the revision, job or execution acted upon, or the app
+	Result    string // the execution or revision created by the operation, if any
+	Err       error
+	Out       string // combined output of the command
 }
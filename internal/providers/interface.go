@@ -2,15 +2,58 @@ package providers
 
 import (
 	"context"
+	"time"
 
 	"github.com/IAL32/az-tui/internal/models"
 )
 
 // DataProvider defines the interface for fetching Azure Container Apps data
+// Every call runs against the subscription set on ctx with WithSubscription, or the az CLI default.
 type DataProvider interface {
+	ListSubscriptions(ctx context.Context) ([]models.Subscription, error)
 	ListResourceGroups(ctx context.Context) ([]models.ResourceGroup, error)
+	ListManagedEnvironments(ctx context.Context, resourceGroup string) ([]models.ManagedEnvironment, error)
 	ListContainerApps(ctx context.Context, resourceGroup string) ([]models.ContainerApp, error)
+	ListEnvironmentApps(ctx context.Context, environmentID string) ([]models.ContainerApp, error)
 	GetAppDetails(ctx context.Context, name, resourceGroup string) (string, error)
 	ListRevisions(ctx context.Context, appName, resourceGroup string) ([]models.Revision, error)
+	ListTrafficWeights(ctx context.Context, appName, resourceGroup string) ([]models.TrafficWeight, error)
+	ListReplicas(ctx context.Context, app models.ContainerApp, revisionName string) ([]models.Replica, error)
 	ListContainers(ctx context.Context, app models.ContainerApp, revisionName string) ([]models.Container, error)
+	ListSecrets(ctx context.Context, app models.ContainerApp) ([]models.Secret, error)
+	ShowSecret(ctx context.Context, app models.ContainerApp, secretName string) (models.Secret, error)
+	// GetScale returns the replica bounds and scale rules of the revision template of an app
+	GetScale(ctx context.Context, app models.ContainerApp) (models.Scale, error)
+	ListJobs(ctx context.Context, resourceGroup string) ([]models.Job, error)
+	ListJobExecutions(ctx context.Context, jobName, resourceGroup string) ([]models.JobExecution, error)
+}
+
+// MetricsProvider defines the interface for fetching Azure Monitor metrics of container apps.
+// Like DataProvider, every call runs against the subscription set on ctx.
+type MetricsProvider interface {
+	// GetAppMetrics returns the CPU, memory, request and restart metrics of an app
+	// over a time window, only counting the given revision unless it is empty
+	GetAppMetrics(ctx context.Context, app models.ContainerApp, revisionName string, window models.MetricsWindow) (models.MetricSet, error)
+}
+
+// LogQueryRunner runs KQL queries against the Log Analytics workspace a managed
+// environment sends its logs to. Like DataProvider, every call runs against the
+// subscription set on ctx.
+type LogQueryRunner interface {
+	// RunLogQuery runs a query over the logs of the last timespan
+	RunLogQuery(ctx context.Context, environmentID, query string, timespan time.Duration) (models.LogQueryResult, error)
+}
+
+// LogQueryRunnerFunc adapts a function to a LogQueryRunner, e.g. to return canned results
+type LogQueryRunnerFunc func(ctx context.Context, environmentID, query string, timespan time.Duration) (models.LogQueryResult, error)
+
+// RunLogQuery calls f
+func (f LogQueryRunnerFunc) RunLogQuery(ctx context.Context, environmentID, query string, timespan time.Duration) (models.LogQueryResult, error) {
+	return f(ctx, environmentID, query, timespan)
+}
+
+// Prober sends HTTP requests to the ingress of apps and revisions
+type Prober interface {
+	// Probe sends a request and reads its response
+	Probe(ctx context.Context, request models.ProbeRequest) (models.ProbeResponse, error)
 }
@@ -0,0 +1,136 @@
+package providers
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// logStreamBuffer is how many lines a stream reads ahead of the UI
+	logStreamBuffer = 1024
+	// maxLogLineSize is the longest log line a command stream accepts
+	maxLogLineSize = 1024 * 1024
+)
+
+// LogStream is a running stream of log lines, such as the output of
+// `az containerapp logs show --follow`
+type LogStream interface {
+	// Lines returns the channel the log lines are delivered on. It is closed
+	// when the stream ends, after which Err reports why.
+	Lines() <-chan string
+	// Err returns the error the stream ended with, nil while it is running or
+	// when it ended normally or was closed
+	Err() error
+	// Close stops the stream, its lines channel is closed shortly after
+	Close() error
+}
+
+// lineStream is a LogStream fed by a goroutine writing to its lines channel
+type lineStream struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+	lines  chan string
+
+	mu  sync.Mutex
+	err error
+}
+
+// newLineStream creates a stream that is stopped by closing it
+func newLineStream() *lineStream {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &lineStream{
+		ctx:    ctx,
+		cancel: cancel,
+		lines:  make(chan string, logStreamBuffer),
+	}
+}
+
+func (s *lineStream) Lines() <-chan string {
+	return s.lines
+}
+
+func (s *lineStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+func (s *lineStream) Close() error {
+	s.cancel()
+	return nil
+}
+
+// send delivers a line, returning false once the stream is closed
+func (s *lineStream) send(line string) bool {
+	select {
+	case s.lines <- line:
+		return true
+	case <-s.ctx.Done():
+		return false
+	}
+}
+
+// finish records the error the stream ended with and closes its lines channel
+func (s *lineStream) finish(err error) {
+	s.mu.Lock()
+	s.err = err
+	s.mu.Unlock()
+	close(s.lines)
+}
+
+// streamCommand starts a command and streams its standard output line by line.
+// The command is killed when the stream is closed; if it fails on its own, the
+// stream ends with its standard error.
+func streamCommand(name string, args ...string) (LogStream, error) {
+	stream := newLineStream()
+	cmd := exec.CommandContext(stream.ctx, name, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		stream.cancel()
+		return nil, err
+	}
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	// Do not wait on children of the killed command that keep its output open
+	cmd.WaitDelay = time.Second
+	if err := cmd.Start(); err != nil {
+		stream.cancel()
+		return nil, err
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 64*1024), maxLogLineSize)
+		for scanner.Scan() {
+			if !stream.send(scanner.Text()) {
+				break
+			}
+		}
+		scanErr := scanner.Err()
+		if scanErr != nil {
+			// Stop the command, nothing reads its output anymore
+			stream.cancel()
+		}
+
+		err := cmd.Wait()
+		switch {
+		case scanErr != nil:
+			err = fmt.Errorf("reading logs: %w", scanErr)
+		case stream.ctx.Err() != nil:
+			err = nil // closed by the UI
+		case err != nil:
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				err = fmt.Errorf("%w: %s", err, msg)
+			}
+		}
+		stream.finish(err)
+	}()
+
+	return stream, nil
+}
@@ -1,45 +1,131 @@
 package providers
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
-	"os"
+	"net"
+	"net/http"
+	"net/url"
 	"os/exec"
+	"strings"
 	"time"
 
+	"github.com/IAL32/az-tui/internal/mock"
 	"github.com/IAL32/az-tui/internal/models"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
-// MockCommandProvider implements the CommandProvider interface using mock operations
-type MockCommandProvider struct{}
-
-// NewMockCommandProvider creates a new mock command provider
-func NewMockCommandProvider() *MockCommandProvider {
-	return &MockCommandProvider{}
+// MockCommandProvider implements the CommandProvider interface using mock operations.
+// Operations that change state are applied to the shared mock data provider so
+// that subsequent listings reflect them.
+type MockCommandProvider struct {
+	data *mock.Provider
 }
 
-func (m *MockCommandProvider) ExecIntoApp(app models.ContainerApp) tea.Cmd {
-	return m.mockExecCommand(fmt.Sprintf("Executing shell into app '%s' (latest revision)", app.Name))
+// NewMockCommandProvider creates a new mock command provider backed by the given mock data
+func NewMockCommandProvider(data *mock.Provider) *MockCommandProvider {
+	return &MockCommandProvider{data: data}
 }
 
-func (m *MockCommandProvider) ExecIntoRevision(app models.ContainerApp, revision string) tea.Cmd {
-	return m.mockExecCommand(fmt.Sprintf("Executing shell into app '%s', revision '%s'", app.Name, revision))
-}
+// SetSubscription does nothing, the mock data is the same in every subscription
+func (m *MockCommandProvider) SetSubscription(subscriptionID string) {}
 
-func (m *MockCommandProvider) ExecIntoContainer(app models.ContainerApp, revision, container string) tea.Cmd {
-	return m.mockExecCommand(fmt.Sprintf("Executing shell into container '%s' in app '%s', revision '%s'", container, app.Name, revision))
+// ExecCommand runs the program locally after a banner describing the target,
+// standing in for a shell in the container
+func (m *MockCommandProvider) ExecCommand(target models.ExecTarget, command string) *exec.Cmd {
+	where := target.String()
+	if target.Revision == "" {
+		where += " (latest revision)"
+	}
+	// The command is left unquoted so that custom commands can take arguments
+	script := `
+echo "=== MOCK MODE ==="
+echo "Executing '$2' in $1"
+echo "This is a local shell standing in for the container."
+echo "In real mode, this would connect to the actual container."
+echo "=== MOCK MODE ==="
+echo ""
+exec $2
+`
+	return exec.Command("sh", "-c", script, "sh", where, command)
 }
 
-func (m *MockCommandProvider) ShowAppLogs(app models.ContainerApp) tea.Cmd {
-	return m.mockLogsCommand(fmt.Sprintf("Showing logs for app '%s'", app.Name))
+// PortForward forwards a loopback port to a fake backend of the app, itself
+// listening on another loopback port, which describes the requests it receives
+func (m *MockCommandProvider) PortForward(app models.ContainerApp, localPort int) (Tunnel, error) {
+	if _, err := ingressURL(app.IngressFQDN); err != nil {
+		return nil, fmt.Errorf("cannot forward to %s: %w", app.Name, err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	backend := &http.Server{Handler: mockBackend(app), ReadHeaderTimeout: tunnelHeaderTimeout}
+	go func() { _ = backend.Serve(listener) }()
+
+	remote := &url.URL{Scheme: "http", Host: listener.Addr().String()}
+	tunnel, err := startTunnel(localPort, remote, http.DefaultTransport, func() { _ = backend.Close() })
+	if err != nil {
+		_ = backend.Close()
+		return nil, err
+	}
+	return tunnel, nil
 }
 
-func (m *MockCommandProvider) ShowRevisionLogs(app models.ContainerApp, revision string) tea.Cmd {
-	return m.mockLogsCommand(fmt.Sprintf("Showing logs for app '%s', revision '%s'", app.Name, revision))
+// mockBackend answers every request with a JSON description of it and of the app
+func mockBackend(app models.ContainerApp) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"mock":       true,
+			"app":        app.Name,
+			"revision":   app.LatestRevision,
+			"ingress":    app.IngressFQDN,
+			"targetPort": app.TargetPort,
+			"method":     r.Method,
+			"path":       r.URL.RequestURI(),
+			"host":       r.Host,
+		})
+	})
 }
 
-func (m *MockCommandProvider) ShowContainerLogs(app models.ContainerApp, revision, container string) tea.Cmd {
-	return m.mockLogsCommand(fmt.Sprintf("Showing logs for container '%s' in app '%s', revision '%s'", container, app.Name, revision))
+// StreamLogs streams generated log lines: a backlog of recent lines first, as
+// az does, then a new line every mockLogInterval until the stream is closed.
+// System logs are generated less often than console logs.
+func (m *MockCommandProvider) StreamLogs(source models.LogSource) (LogStream, error) {
+	generate, interval := mockLogLine, mockLogInterval
+	if source.Type == models.LogTypeSystem {
+		generate, interval = mockSystemLogLine, mockSystemLogInterval
+	}
+
+	stream := newLineStream()
+	go func() {
+		defer stream.finish(nil)
+
+		now := time.Now()
+		for i := range mockLogBacklog {
+			t := now.Add(-time.Duration(mockLogBacklog-i) * interval)
+			if !stream.send(generate(source, i, t)) {
+				return
+			}
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for i := mockLogBacklog; ; i++ {
+			select {
+			case t := <-ticker.C:
+				if !stream.send(generate(source, i, t)) {
+					return
+				}
+			case <-stream.ctx.Done():
+				return
+			}
+		}
+	}()
+	return stream, nil
 }
 
 func (m *MockCommandProvider) RestartRevision(app models.ContainerApp, revision string) tea.Cmd {
@@ -47,67 +133,302 @@ func (m *MockCommandProvider) RestartRevision(app models.ContainerApp, revision
 		// Simulate the restart operation
 		time.Sleep(2 * time.Second)
 
-		return revisionRestartedMsg{
-			appID:   fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
-			revName: revision,
-			err:     nil,
-			out:     fmt.Sprintf("Mock: Successfully restarted revision '%s' for app '%s'", revision, app.Name),
+		return OperationResultMsg{
+			Operation: OperationRestartRevision,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    revision,
+			Out:       fmt.Sprintf("Mock: Successfully restarted revision '%s' for app '%s'", revision, app.Name),
 		}
 	}
 }
 
-// mockExecCommand creates a mock exec command that shows a message and simulates a shell
-func (m *MockCommandProvider) mockExecCommand(message string) tea.Cmd {
-	// Create a mock shell session
-	cmd := exec.Command("sh", "-c", fmt.Sprintf(`
-echo "=== MOCK MODE ==="
-echo "%s"
-echo "This is a simulated shell session."
-echo "In real mode, this would connect to the actual container."
-echo "Press Ctrl+C to exit this mock session."
-echo "=== MOCK MODE ==="
-echo ""
-echo "Mock shell session started..."
-while true; do
-    echo "Mock shell session running..."
-    sleep 1
-done
-echo "Mock shell session ended."
-`, message))
-
-	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
-	return tea.ExecProcess(cmd, func(error) tea.Msg { return noop{} })
-}
-
-// mockLogsCommand creates a mock logs command that shows sample log output
-func (m *MockCommandProvider) mockLogsCommand(message string) tea.Cmd {
-	// Create a mock logs session with sample log output
-	cmd := exec.Command("sh", "-c", fmt.Sprintf(`
-echo "=== MOCK MODE ==="
-echo "%s"
-echo "This is simulated log output."
-echo "In real mode, this would show actual container logs."
-echo "Press Ctrl+C to stop following logs."
-echo "=== MOCK MODE ==="
-echo ""
+func (m *MockCommandProvider) ActivateRevision(app models.ContainerApp, revision string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the activation
+		time.Sleep(1 * time.Second)
+
+		err := m.data.ActivateRevision(context.Background(), app, revision)
+		return OperationResultMsg{
+			Operation: OperationActivateRevision,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    revision,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Activated revision '%s' of app '%s'", revision, app.Name),
+		}
+	}
+}
+
+func (m *MockCommandProvider) DeactivateRevision(app models.ContainerApp, revision string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the deactivation
+		time.Sleep(1 * time.Second)
+
+		err := m.data.DeactivateRevision(context.Background(), app, revision)
+		return OperationResultMsg{
+			Operation: OperationDeactivateRevision,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    revision,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Deactivated revision '%s' of app '%s'", revision, app.Name),
+		}
+	}
+}
+
+func (m *MockCommandProvider) AddRevisionLabel(app models.ContainerApp, revision, label string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the label update
+		time.Sleep(1 * time.Second)
+
+		err := m.data.AddRevisionLabel(context.Background(), app, revision, label)
+		return OperationResultMsg{
+			Operation: OperationAddLabel,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    label,
+			Result:    revision,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Added label '%s' to revision '%s'", label, revision),
+		}
+	}
+}
+
+func (m *MockCommandProvider) RemoveRevisionLabel(app models.ContainerApp, label string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the label update
+		time.Sleep(1 * time.Second)
+
+		err := m.data.RemoveRevisionLabel(context.Background(), app, label)
+		return OperationResultMsg{
+			Operation: OperationRemoveLabel,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    label,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Removed label '%s' from app '%s'", label, app.Name),
+		}
+	}
+}
+
+func (m *MockCommandProvider) SetRevisionMode(app models.ContainerApp, mode string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the mode switch
+		time.Sleep(1 * time.Second)
+
+		msg := OperationResultMsg{
+			Operation: OperationSetRevisionMode,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    app.Name,
+			Err:       m.data.SetRevisionMode(context.Background(), app, mode),
+			Out:       fmt.Sprintf("Mock: Set revision mode of app '%s' to '%s'", app.Name, mode),
+		}
+		if msg.Err == nil {
+			msg.Result = mode
+		}
+		return msg
+	}
+}
+
+func (m *MockCommandProvider) SetTraffic(app models.ContainerApp, weights []models.TrafficWeight) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the traffic update
+		time.Sleep(1 * time.Second)
+
+		err := m.data.SetTraffic(context.Background(), app.Name, app.ResourceGroup, weights)
+		return OperationResultMsg{
+			Operation: OperationSetTraffic,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    app.Name,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Updated traffic split of app '%s'", app.Name),
+		}
+	}
+}
+
+func (m *MockCommandProvider) UpdateContainerEnv(app models.ContainerApp, update models.EnvUpdate) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the update
+		time.Sleep(1 * time.Second)
+
+		revision, err := m.data.UpdateContainerEnv(context.Background(), app, update)
+		return OperationResultMsg{
+			Operation: OperationUpdateEnv,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    update.Container,
+			Result:    revision,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Updated env vars of container '%s' as revision '%s'", update.Container, revision),
+		}
+	}
+}
+
+func (m *MockCommandProvider) UpdateContainerImage(app models.ContainerApp, container, image string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the update
+		time.Sleep(1 * time.Second)
+
+		revision, err := m.data.UpdateContainerImage(context.Background(), app, container, image)
+		return OperationResultMsg{
+			Operation: OperationUpdateImage,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    container,
+			Result:    revision,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Updated image of container '%s' to '%s' as revision '%s'", container, image, revision),
+		}
+	}
+}
+
+func (m *MockCommandProvider) UpdateScale(app models.ContainerApp, scale models.Scale) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the update
+		time.Sleep(1 * time.Second)
+
+		revision, err := m.data.UpdateScale(context.Background(), app, scale)
+		return OperationResultMsg{
+			Operation: OperationUpdateScale,
+			AppID:     fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name),
+			Target:    app.Name,
+			Result:    revision,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Updated scale of app '%s' as revision '%s'", app.Name, revision),
+		}
+	}
+}
+
+func (m *MockCommandProvider) StartJob(job models.Job) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the start operation
+		time.Sleep(1 * time.Second)
+
+		execution, err := m.data.StartJobExecution(context.Background(), job.Name, job.ResourceGroup)
+		return OperationResultMsg{
+			Operation: OperationStartJob,
+			JobID:     fmt.Sprintf("%s/%s", job.ResourceGroup, job.Name),
+			Target:    job.Name,
+			Result:    execution.Name,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Started execution '%s' of job '%s'", execution.Name, job.Name),
+		}
+	}
+}
+
+func (m *MockCommandProvider) StopJobExecution(job models.Job, execution string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the stop operation
+		time.Sleep(1 * time.Second)
+
+		err := m.data.StopJobExecution(context.Background(), job.Name, job.ResourceGroup, execution)
+		return OperationResultMsg{
+			Operation: OperationStopExecution,
+			JobID:     fmt.Sprintf("%s/%s", job.ResourceGroup, job.Name),
+			Target:    execution,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Stopped execution '%s' of job '%s'", execution, job.Name),
+		}
+	}
+}
+
+func (m *MockCommandProvider) RerunJobExecution(job models.Job, execution string) tea.Cmd {
+	return func() tea.Msg {
+		// Simulate the re-run operation
+		time.Sleep(1 * time.Second)
+
+		started, err := m.data.RerunJobExecution(context.Background(), job.Name, job.ResourceGroup, execution)
+		return OperationResultMsg{
+			Operation: OperationRerunExecution,
+			JobID:     fmt.Sprintf("%s/%s", job.ResourceGroup, job.Name),
+			Target:    execution,
+			Result:    started.Name,
+			Err:       err,
+			Out:       fmt.Sprintf("Mock: Re-ran execution '%s' of job '%s' as '%s'", execution, job.Name, started.Name),
+		}
+	}
+}
+
+const (
+	// mockLogBacklog is how many recent lines a mock log stream starts with
+	mockLogBacklog = 20
+	// mockLogInterval is how often a mock log stream emits a new line
+	mockLogInterval = 500 * time.Millisecond
+	// mockSystemLogInterval is how often a mock system log stream emits a new event
+	mockSystemLogInterval = 3 * time.Second
+)
+
+// mockSystemEvents are the events a mock system log stream cycles through. The
+// message takes the container name for container events, the revision name otherwise.
+var mockSystemEvents = []struct {
+	kind, reason, message string
+	container             bool
+}{
+	{"Normal", "RevisionCreation", "Creating new revision '%s'", false},
+	{"Normal", "PullingImage", "Pulling image for revision '%s'", false},
+	{"Normal", "ContainerCreated", "Created container '%s'", true},
+	{"Warning", "ProbeFailed", "Probe of StartUp failed for container '%s' with status code: 1", true},
+	{"Warning", "ContainerTerminated", "Container '%s' was terminated with exit code '1' and reason 'ProcessExited'", true},
+	{"Normal", "ContainerStarted", "Started container '%s'", true},
+	{"Normal", "RevisionReady", "Successfully provisioned revision '%s'", false},
+}
+
+// mockSystemLogLine generates the i-th event of a mock system log stream, in
+// the format of az containerapp logs show --type system
+func mockSystemLogLine(source models.LogSource, i int, t time.Time) string {
+	revision := source.Revision
+	if revision == "" {
+		revision = source.App.LatestRevision
+	}
+	container := source.Container
+	if container == "" {
+		container = source.App.Name
+	}
+
+	event := mockSystemEvents[i%len(mockSystemEvents)]
+	subject := revision
+	if event.container {
+		subject = container
+	}
+	line, _ := json.Marshal(map[string]any{
+		"TimeStamp":        t.UTC().Format(time.RFC3339Nano),
+		"Type":             event.kind,
+		"ContainerAppName": source.App.Name,
+		"RevisionName":     revision,
+		"ReplicaName":      source.Replica,
+		"Msg":              fmt.Sprintf(event.message, subject),
+		"Reason":           event.reason,
+		"EventSource":      "ContainerAppController",
+		"Count":            1,
+	})
+	return string(line)
+}
+
+// mockLogLine generates the i-th line of a mock log stream, mixing levels
+func mockLogLine(source models.LogSource, i int, t time.Time) string {
+	container := source.Container
+	if container == "" {
+		container = source.App.Name
+	}
+
+	level, message := "INFO", fmt.Sprintf("GET /api/items/%d 200 in %dms", i, 5+i*37%120)
+	switch {
+	case i%17 == 16:
+		level, message = "ERROR", "Request to /api/orders failed: upstream timed out after 30s"
+	case i%7 == 6:
+		level, message = "WARN", fmt.Sprintf("High memory usage detected: %d%%", 60+i%35)
+	case i%3 == 2:
+		level, message = "DEBUG", fmt.Sprintf("Processing request batch %d", i)
+	}
+	if i%2 == 0 {
+		return fmt.Sprintf("%s %-5s [%s] %s", t.UTC().Format(time.RFC3339), level, container, message)
+	}
 
-# Simulate streaming logs
-i=0
-while true; do
-    timestamp=$(date '+%%Y-%%m-%%d %%H:%%M:%%S')
-    echo "[$timestamp] INFO: Mock log entry $i - Application is running normally"
-    sleep 1
-    
-    if [ $((i %% 3)) -eq 0 ]; then
-        echo "[$timestamp] DEBUG: Mock debug message - Processing request batch $i"
-    fi
-    
-    if [ $((i %% 5)) -eq 0 ]; then
-        echo "[$timestamp] WARN: Mock warning - High memory usage detected: $((60 + i * 2))%%"
-    fi
-done
-`, message))
-
-	cmd.Stdin, cmd.Stdout, cmd.Stderr = os.Stdin, os.Stdout, os.Stderr
-	return tea.ExecProcess(cmd, func(error) tea.Msg { return noop{} })
+	// Every other line is structured, wrapped in the envelope of az containerapp logs show,
+	// with groups of lines sharing a trace id
+	log, _ := json.Marshal(map[string]string{
+		"level":   strings.ToLower(level),
+		"msg":     message,
+		"traceId": fmt.Sprintf("%016x", uint64(i/4+1)*0x9e3779b97f4a7c15),
+	})
+	envelope, _ := json.Marshal(map[string]string{
+		"TimeStamp":     t.UTC().Format(time.RFC3339Nano),
+		"Log":           string(log),
+		"ContainerName": container,
+	})
+	return string(envelope)
 }
@@ -0,0 +1,56 @@
+package providers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/mock"
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+func TestMockUpdateScale(t *testing.T) {
+	ctx := context.Background()
+	data, err := mock.NewProvider()
+	if err != nil {
+		t.Fatalf("Failed to create mock provider: %v", err)
+	}
+	provider := NewMockCommandProvider(data)
+	app := models.ContainerApp{Name: "api-backend-prod", ResourceGroup: "rg-production-eastus"}
+
+	scale, err := data.GetScale(ctx, app)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	scale.MaxReplicas = 30
+	scale.Rules[1].Metadata["messageCount"] = "40"
+
+	msg, ok := provider.UpdateScale(app, scale)().(OperationResultMsg)
+	if !ok || msg.Err != nil {
+		t.Fatalf("Expected a successful operation result, got %+v", msg)
+	}
+	if msg.Operation != OperationUpdateScale || msg.AppID != "rg-production-eastus/api-backend-prod" || msg.Result == "" {
+		t.Errorf("Unexpected operation result %+v", msg)
+	}
+
+	// The operation changed the state the data provider reports
+	updated, err := data.GetScale(ctx, app)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.MaxReplicas != 30 || updated.Rules[1].Metadata["messageCount"] != "40" {
+		t.Errorf("Expected the updated scale, got %+v", updated)
+	}
+	revisions, err := data.ListRevisions(ctx, app.Name, app.ResourceGroup)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if revisions[0].Name != msg.Result || revisions[0].MaxReplicas != 30 {
+		t.Errorf("Expected revision %s scaling to 30 replicas, got %+v", msg.Result, revisions[0])
+	}
+
+	// Invalid bounds are reported as the error of the operation
+	scale.MinReplicas = 31
+	if msg := provider.UpdateScale(app, scale)().(OperationResultMsg); msg.Err == nil {
+		t.Error("Expected an error for min replicas above max replicas")
+	}
+}
@@ -0,0 +1,186 @@
+package providers
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tunnelHeaderTimeout bounds how long a client of a tunnel may take to send request headers
+const tunnelHeaderTimeout = 30 * time.Second
+
+// Tunnel is a listener on a local port forwarding the requests it receives
+// to the ingress of an app
+type Tunnel interface {
+	// LocalAddr returns the loopback address the tunnel listens on, e.g. 127.0.0.1:8080
+	LocalAddr() string
+	// Remote returns the URL requests are forwarded to
+	Remote() string
+	// Stats returns the connections and bytes the tunnel handled so far
+	Stats() TunnelStats
+	// Done returns a channel that is closed when the tunnel stops, after which Err reports why
+	Done() <-chan struct{}
+	// Err returns the error the tunnel stopped with, nil while it runs or once it was closed
+	Err() error
+	// Close stops listening and drops the open connections
+	Close() error
+}
+
+// TunnelStats counts the traffic of a tunnel. Bytes are counted on the local
+// side, sent by the local clients and received by them.
+type TunnelStats struct {
+	Connections   int
+	Active        int
+	BytesSent     int64
+	BytesReceived int64
+}
+
+// httpTunnel is a Tunnel proxying HTTP requests, including upgraded
+// connections such as WebSockets, to a remote URL
+type httpTunnel struct {
+	listener net.Listener
+	server   *http.Server
+	remote   *url.URL
+	onClose  func()
+
+	connections   atomic.Int64
+	active        atomic.Int64
+	bytesSent     atomic.Int64
+	bytesReceived atomic.Int64
+
+	done      chan struct{}
+	err       error // set before done is closed
+	closeOnce sync.Once
+}
+
+// startTunnel listens on a loopback port, any free one when port is 0, and
+// forwards the requests received to remote. onClose, if set, runs once the
+// tunnel is closed.
+func startTunnel(port int, remote *url.URL, transport http.RoundTripper, onClose func()) (*httpTunnel, error) {
+	listener, err := net.Listen("tcp", net.JoinHostPort("127.0.0.1", strconv.Itoa(port)))
+	if err != nil {
+		return nil, err
+	}
+
+	t := &httpTunnel{
+		listener: listener,
+		remote:   remote,
+		onClose:  onClose,
+		done:     make(chan struct{}),
+	}
+	proxy := &httputil.ReverseProxy{
+		Rewrite: func(r *httputil.ProxyRequest) {
+			// The ingress routes on the host name of the app, not the local address
+			r.SetURL(remote)
+			r.SetXForwarded()
+		},
+		Transport: transport,
+	}
+	t.server = &http.Server{Handler: proxy, ReadHeaderTimeout: tunnelHeaderTimeout}
+
+	go func() {
+		err := t.server.Serve(&countingListener{Listener: listener, tunnel: t})
+		if !errors.Is(err, http.ErrServerClosed) {
+			t.err = err
+		}
+		close(t.done)
+	}()
+	return t, nil
+}
+
+func (t *httpTunnel) LocalAddr() string {
+	return t.listener.Addr().String()
+}
+
+func (t *httpTunnel) Remote() string {
+	return t.remote.String()
+}
+
+func (t *httpTunnel) Stats() TunnelStats {
+	return TunnelStats{
+		Connections:   int(t.connections.Load()),
+		Active:        int(t.active.Load()),
+		BytesSent:     t.bytesSent.Load(),
+		BytesReceived: t.bytesReceived.Load(),
+	}
+}
+
+func (t *httpTunnel) Done() <-chan struct{} {
+	return t.done
+}
+
+func (t *httpTunnel) Err() error {
+	select {
+	case <-t.done:
+		return t.err
+	default:
+		return nil
+	}
+}
+
+func (t *httpTunnel) Close() error {
+	var err error
+	t.closeOnce.Do(func() {
+		err = t.server.Close()
+		<-t.done
+		if t.onClose != nil {
+			t.onClose()
+		}
+	})
+	return err
+}
+
+// countingListener counts the connections a tunnel accepts and their bytes
+type countingListener struct {
+	net.Listener
+	tunnel *httpTunnel
+}
+
+func (l *countingListener) Accept() (net.Conn, error) {
+	conn, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	l.tunnel.connections.Add(1)
+	l.tunnel.active.Add(1)
+	return &countingConn{Conn: conn, tunnel: l.tunnel}, nil
+}
+
+// countingConn counts the bytes of a connection to a tunnel
+type countingConn struct {
+	net.Conn
+	tunnel    *httpTunnel
+	closeOnce sync.Once
+}
+
+func (c *countingConn) Read(b []byte) (int, error) {
+	n, err := c.Conn.Read(b)
+	c.tunnel.bytesSent.Add(int64(n))
+	return n, err
+}
+
+func (c *countingConn) Write(b []byte) (int, error) {
+	n, err := c.Conn.Write(b)
+	c.tunnel.bytesReceived.Add(int64(n))
+	return n, err
+}
+
+func (c *countingConn) Close() error {
+	c.closeOnce.Do(func() { c.tunnel.active.Add(-1) })
+	return c.Conn.Close()
+}
+
+// ingressURL returns the URL of the ingress of an app
+func ingressURL(fqdn string) (*url.URL, error) {
+	if fqdn == "" {
+		return nil, fmt.Errorf("no ingress is enabled")
+	}
+	return &url.URL{Scheme: "https", Host: fqdn}, nil
+}
@@ -0,0 +1,67 @@
+package providers
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+func TestMockPortForward(t *testing.T) {
+	provider := NewMockCommandProvider(nil)
+	app := models.ContainerApp{
+		Name:        "api-backend",
+		IngressFQDN: "api-backend.internal.example.azurecontainerapps.io",
+		TargetPort:  8080,
+	}
+
+	tunnel, err := provider.PortForward(app, 0)
+	if err != nil {
+		t.Fatalf("Failed to forward: %v", err)
+	}
+	defer tunnel.Close()
+	if !strings.HasPrefix(tunnel.LocalAddr(), "127.0.0.1:") || !strings.HasPrefix(tunnel.Remote(), "http://127.0.0.1:") {
+		t.Errorf("Expected loopback addresses, got %s to %s", tunnel.LocalAddr(), tunnel.Remote())
+	}
+
+	resp, err := http.Get("http://" + tunnel.LocalAddr() + "/healthz?full=1")
+	if err != nil {
+		t.Fatalf("Request through the tunnel failed: %v", err)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	var reply map[string]any
+	if err := json.Unmarshal(body, &reply); err != nil {
+		t.Fatalf("Expected a JSON reply, got %q", body)
+	}
+	if reply["app"] != "api-backend" || reply["path"] != "/healthz?full=1" {
+		t.Errorf("Expected the request to reach the backend of the app, got %v", reply)
+	}
+
+	stats := tunnel.Stats()
+	if stats.Connections != 1 || stats.BytesSent == 0 || stats.BytesReceived < int64(len(body)) {
+		t.Errorf("Expected the request to be counted, got %+v", stats)
+	}
+
+	if err := tunnel.Close(); err != nil {
+		t.Errorf("Unexpected error closing: %v", err)
+	}
+	<-tunnel.Done()
+	if tunnel.Err() != nil {
+		t.Errorf("Expected no error once closed, got %v", tunnel.Err())
+	}
+	if _, err := http.Get("http://" + tunnel.LocalAddr()); err == nil {
+		t.Error("Expected the tunnel to stop listening")
+	}
+}
+
+func TestPortForwardWithoutIngress(t *testing.T) {
+	for _, provider := range []CommandProvider{NewMockCommandProvider(nil), NewAzureCommandProvider()} {
+		if _, err := provider.PortForward(models.ContainerApp{Name: "worker"}, 0); err == nil {
+			t.Errorf("Expected %T to refuse apps without ingress", provider)
+		}
+	}
+}
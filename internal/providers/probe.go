@@ -0,0 +1,153 @@
+package providers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// probeBodyLimit is how much of a response body is kept, the rest is only counted
+const probeBodyLimit = 64 * 1024
+
+// probeTimeout bounds a probe with the default client, from sending the
+// request until the body was read
+const probeTimeout = 30 * time.Second
+
+// mockProbeLatency is how long the mock ingress takes to answer
+const mockProbeLatency = 40 * time.Millisecond
+
+// HTTPProber is a Prober sending requests with an HTTP client
+type HTTPProber struct {
+	client *http.Client
+}
+
+// NewHTTPProber creates a prober sending requests with client. A nil client
+// uses one that times out after probeTimeout and does not follow redirects,
+// so that they are shown as they are answered.
+func NewHTTPProber(client *http.Client) *HTTPProber {
+	if client == nil {
+		client = &http.Client{
+			Timeout: probeTimeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		}
+	}
+	return &HTTPProber{client: client}
+}
+
+// NewMockProber creates a prober answering requests in process, as the
+// ingress of a mock app would, so that probes work without network access.
+// Paths such as /status/503 are answered with that status.
+func NewMockProber() *HTTPProber {
+	return NewHTTPProber(&http.Client{Transport: handlerTransport{mockIngress()}})
+}
+
+// Probe sends a request and reads its response. A Host header replaces the
+// host the request is sent to, as ingresses route on it.
+func (p *HTTPProber) Probe(ctx context.Context, request models.ProbeRequest) (models.ProbeResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, request.Method, request.URL, nil)
+	if err != nil {
+		return models.ProbeResponse{}, err
+	}
+	for name, values := range request.Headers {
+		for _, value := range values {
+			req.Header.Add(name, value)
+		}
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+		req.Header.Del("Host")
+	}
+
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return models.ProbeResponse{}, err
+	}
+	defer resp.Body.Close()
+
+	body := &headBuffer{limit: probeBodyLimit}
+	if _, err := io.Copy(body, resp.Body); err != nil {
+		return models.ProbeResponse{}, fmt.Errorf("reading the response body: %w", err)
+	}
+	return models.ProbeResponse{
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+		Headers:    resp.Header,
+		Body:       string(body.data),
+		Truncated:  body.size > int64(len(body.data)),
+		Size:       body.size,
+		Latency:    time.Since(start),
+	}, nil
+}
+
+// headBuffer keeps the first limit bytes written to it and counts all of them
+type headBuffer struct {
+	data  []byte
+	limit int
+	size  int64
+}
+
+func (b *headBuffer) Write(p []byte) (int, error) {
+	if keep := b.limit - len(b.data); keep > 0 {
+		b.data = append(b.data, p[:min(keep, len(p))]...)
+	}
+	b.size += int64(len(p))
+	return len(p), nil
+}
+
+// handlerTransport answers requests with a handler instead of sending them
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	recorder := httptest.NewRecorder()
+	t.handler.ServeHTTP(recorder, req)
+	resp := recorder.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+// mockIngress answers every request with a JSON description of it, with the
+// status given by paths such as /status/404 and 200 otherwise. HEAD requests
+// get the headers only.
+func mockIngress() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(mockProbeLatency):
+		case <-r.Context().Done():
+			return
+		}
+
+		status := http.StatusOK
+		if code, ok := strings.CutPrefix(r.URL.Path, "/status/"); ok {
+			if n, err := strconv.Atoi(code); err == nil && n >= 200 && n <= 599 {
+				status = n
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Mock", "true")
+		w.WriteHeader(status)
+		if r.Method == http.MethodHead {
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"mock":    true,
+			"host":    r.Host,
+			"method":  r.Method,
+			"path":    r.URL.RequestURI(),
+			"status":  status,
+			"headers": r.Header,
+		})
+	})
+}
@@ -0,0 +1,80 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+func TestHTTPProber(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Host", r.Host)
+		w.Header().Set("X-Debug", r.Header.Get("X-Debug"))
+		w.WriteHeader(http.StatusTeapot)
+		_, _ = w.Write([]byte(strings.Repeat("a", probeBodyLimit+10)))
+	}))
+	defer server.Close()
+
+	prober := NewHTTPProber(server.Client())
+	resp, err := prober.Probe(context.Background(), models.ProbeRequest{
+		Method:  http.MethodGet,
+		URL:     server.URL + "/healthz",
+		Headers: http.Header{"X-Debug": {"1"}, "Host": {"web-frontend.example.io"}},
+	})
+	if err != nil {
+		t.Fatalf("Probe failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusTeapot || resp.Status != "418 I'm a teapot" {
+		t.Errorf("Expected status 418, got %q", resp.Status)
+	}
+	if resp.Headers.Get("X-Debug") != "1" || resp.Headers.Get("X-Host") != "web-frontend.example.io" {
+		t.Errorf("Expected the headers and host to be sent, got %v", resp.Headers)
+	}
+	if !resp.Truncated || len(resp.Body) != probeBodyLimit || resp.Size != probeBodyLimit+10 {
+		t.Errorf("Expected the body to be truncated to %d of %d bytes, got %d of %d", probeBodyLimit, probeBodyLimit+10, len(resp.Body), resp.Size)
+	}
+	if resp.Latency <= 0 {
+		t.Error("Expected the latency to be measured")
+	}
+}
+
+func TestHTTPProberFailure(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	url := server.URL
+	server.Close()
+
+	if _, err := NewHTTPProber(nil).Probe(context.Background(), models.ProbeRequest{Method: http.MethodGet, URL: url}); err == nil {
+		t.Error("Expected probing a closed server to fail")
+	}
+	if _, err := NewHTTPProber(nil).Probe(context.Background(), models.ProbeRequest{Method: "GET /", URL: url}); err == nil {
+		t.Error("Expected an invalid method to fail")
+	}
+}
+
+func TestMockProber(t *testing.T) {
+	prober := NewMockProber()
+	resp, err := prober.Probe(context.Background(), models.ProbeRequest{
+		Method: http.MethodPost,
+		URL:    "https://api-backend.internal.example.io/status/503?retry=1",
+	})
+	if err != nil {
+		t.Fatalf("Probe failed: %v", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected status 503, got %d", resp.StatusCode)
+	}
+	for _, want := range []string{`"host":"api-backend.internal.example.io"`, `"method":"POST"`, `"path":"/status/503?retry=1"`} {
+		if !strings.Contains(resp.Body, want) {
+			t.Errorf("Expected the body to contain %s, got %s", want, resp.Body)
+		}
+	}
+
+	resp, err = prober.Probe(context.Background(), models.ProbeRequest{Method: http.MethodHead, URL: "https://web-frontend.example.io/"})
+	if err != nil || resp.StatusCode != http.StatusOK || resp.Body != "" {
+		t.Errorf("Expected an empty 200 response to HEAD, got %+v, %v", resp, err)
+	}
+}
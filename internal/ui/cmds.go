@@ -9,20 +9,20 @@ import (
 )
 
 // -------------------------- Commands ----------------------------
-// These functions now use the new core message system
+// These functions now use the new core message system and run against the az CLI default subscription
 
 func LoadAppsCmd(provider providers.DataProvider, rg string) tea.Cmd {
-	return core.CreateLoadAppsCmd(provider, rg)
+	return core.CreateLoadAppsCmd(provider, "", rg)
 }
 
 func LoadRevsCmd(provider providers.DataProvider, a models.ContainerApp) tea.Cmd {
-	return core.CreateLoadRevisionsCmd(provider, a)
+	return core.CreateLoadRevisionsCmd(provider, "", a)
 }
 
 func LoadResourceGroupsCmd(provider providers.DataProvider) tea.Cmd {
-	return core.CreateLoadResourceGroupsCmd(provider)
+	return core.CreateLoadResourceGroupsCmd(provider, "")
 }
 
 func LoadContainersCmd(provider providers.DataProvider, a models.ContainerApp, revName string) tea.Cmd {
-	return core.CreateLoadContainersCmd(provider, a, revName)
+	return core.CreateLoadContainersCmd(provider, "", a, revName)
 }
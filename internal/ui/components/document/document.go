@@ -0,0 +1,184 @@
+// Package document provides a navigable tree view of JSON documents that can
+// be rendered as JSON or YAML.
+package document
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+// Kind identifies the type of a document node
+type Kind int
+
+const (
+	KindObject Kind = iota
+	KindArray
+	KindString
+	KindNumber
+	KindBool
+	KindNull
+)
+
+// Node is a single value in a document. Objects and arrays keep their
+// children in document order.
+type Node struct {
+	Key       string // Key in the parent object; empty for array elements and the root
+	Index     int    // Index in the parent array; -1 otherwise
+	Kind      Kind
+	Value     string // Scalar value; strings are unquoted
+	Children  []*Node
+	Parent    *Node
+	Collapsed bool
+}
+
+// IsContainer returns true for objects and arrays
+func (n *Node) IsContainer() bool {
+	return n.Kind == KindObject || n.Kind == KindArray
+}
+
+// identifierPattern matches keys that can use dot notation in a JSONPath
+var identifierPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Path returns the JSONPath of the node, e.g. $.properties.template.containers[0].image
+func (n *Node) Path() string {
+	if n.Parent == nil {
+		return "$"
+	}
+
+	var segment string
+	switch {
+	case n.Parent.Kind == KindArray:
+		segment = fmt.Sprintf("[%d]", n.Index)
+	case identifierPattern.MatchString(n.Key):
+		segment = "." + n.Key
+	default:
+		escaped := strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(n.Key)
+		segment = "['" + escaped + "']"
+	}
+
+	return n.Parent.Path() + segment
+}
+
+// Parse parses a JSON document into a node tree, preserving key order
+func Parse(raw string) (*Node, error) {
+	decoder := json.NewDecoder(bytes.NewReader([]byte(raw)))
+	decoder.UseNumber()
+
+	root, err := parseValue(decoder, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// Reject trailing content after the document
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, fmt.Errorf("unexpected content after JSON document")
+	}
+
+	return root, nil
+}
+
+// parseValue reads the next value from the decoder
+func parseValue(decoder *json.Decoder, parent *Node) (*Node, error) {
+	token, err := decoder.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	node := &Node{Parent: parent, Index: -1}
+
+	switch value := token.(type) {
+	case json.Delim:
+		switch value {
+		case '{':
+			node.Kind = KindObject
+			for decoder.More() {
+				keyToken, err := decoder.Token()
+				if err != nil {
+					return nil, err
+				}
+				key, ok := keyToken.(string)
+				if !ok {
+					return nil, fmt.Errorf("unexpected object key %v", keyToken)
+				}
+				child, err := parseValue(decoder, node)
+				if err != nil {
+					return nil, err
+				}
+				child.Key = key
+				node.Children = append(node.Children, child)
+			}
+		case '[':
+			node.Kind = KindArray
+			for i := 0; decoder.More(); i++ {
+				child, err := parseValue(decoder, node)
+				if err != nil {
+					return nil, err
+				}
+				child.Index = i
+				node.Children = append(node.Children, child)
+			}
+		default:
+			return nil, fmt.Errorf("unexpected delimiter %v", value)
+		}
+		// Consume the closing delimiter
+		if _, err := decoder.Token(); err != nil {
+			return nil, err
+		}
+	case string:
+		node.Kind = KindString
+		node.Value = value
+	case json.Number:
+		node.Kind = KindNumber
+		node.Value = value.String()
+	case bool:
+		node.Kind = KindBool
+		node.Value = fmt.Sprintf("%t", value)
+	case nil:
+		node.Kind = KindNull
+		node.Value = "null"
+	default:
+		return nil, fmt.Errorf("unexpected token %v", token)
+	}
+
+	return node, nil
+}
+
+// Walk calls fn for the node and all of its descendants in document order
+func (n *Node) Walk(fn func(*Node)) {
+	fn(n)
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// SetCollapsed collapses or expands every container below and including the node
+func (n *Node) SetCollapsed(collapsed bool) {
+	n.Walk(func(node *Node) {
+		if node.IsContainer() {
+			node.Collapsed = collapsed
+		}
+	})
+}
+
+// Expose expands all ancestors of the node so that it becomes visible
+func (n *Node) Expose() {
+	for parent := n.Parent; parent != nil; parent = parent.Parent {
+		parent.Collapsed = false
+	}
+}
+
+// Matches returns true if the node's key or scalar value contains the query (case-insensitive)
+func (n *Node) Matches(query string) bool {
+	if query == "" {
+		return false
+	}
+	query = strings.ToLower(query)
+	if strings.Contains(strings.ToLower(n.Key), query) {
+		return true
+	}
+	return !n.IsContainer() && strings.Contains(strings.ToLower(n.Value), query)
+}
@@ -0,0 +1,182 @@
+package document
+
+import (
+	"strings"
+	"testing"
+)
+
+const testDocument = `{
+  "name": "my-app",
+  "properties": {
+    "provisioningState": "Succeeded",
+    "template": {
+      "containers": [
+        {"name": "web", "image": "nginx:latest"}
+      ]
+    },
+    "weird key": null
+  },
+  "tags": []
+}`
+
+func TestParsePreservesOrder(t *testing.T) {
+	root, err := Parse(testDocument)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	var keys []string
+	for _, child := range root.Children {
+		keys = append(keys, child.Key)
+	}
+	if got := strings.Join(keys, ","); got != "name,properties,tags" {
+		t.Errorf("Expected keys in document order, got %s", got)
+	}
+}
+
+func TestParseRejectsInvalidJSON(t *testing.T) {
+	for _, raw := range []string{`{"a": }`, `{"a": 1} trailing`, ``} {
+		if _, err := Parse(raw); err == nil {
+			t.Errorf("Expected error parsing %q", raw)
+		}
+	}
+}
+
+func TestNodePath(t *testing.T) {
+	root, err := Parse(testDocument)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	properties := root.Children[1]
+	image := properties.Children[1].Children[0].Children[0].Children[1]
+	weird := properties.Children[2]
+
+	tests := []struct {
+		node *Node
+		want string
+	}{
+		{root, "$"},
+		{properties, "$.properties"},
+		{image, "$.properties.template.containers[0].image"},
+		{weird, "$.properties['weird key']"},
+	}
+	for _, tt := range tests {
+		if got := tt.node.Path(); got != tt.want {
+			t.Errorf("Expected path %s, got %s", tt.want, got)
+		}
+	}
+}
+
+func TestViewerJSONLines(t *testing.T) {
+	root, err := Parse(`{"a": 1, "b": {"c": "x"}, "d": []}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	v := NewViewer(root)
+	want := []string{
+		`{`,
+		`  "a": 1,`,
+		`  "b": {`,
+		`    "c": "x"`,
+		`  },`,
+		`  "d": []`,
+		`}`,
+	}
+	if got := v.Lines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected JSON rendering:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestViewerYAMLLines(t *testing.T) {
+	root, err := Parse(`{"a": 1, "b": {"c": "x: y"}, "d": [true, null]}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	v := NewViewer(root)
+	v.SetFormat(FormatYAML)
+	want := []string{
+		`a: 1`,
+		`b:`,
+		`  c: "x: y"`,
+		`d:`,
+		`  - true`,
+		`  - null`,
+	}
+	if got := v.Lines(); strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Unexpected YAML rendering:\n%s", strings.Join(got, "\n"))
+	}
+}
+
+func TestViewerCollapse(t *testing.T) {
+	root, err := Parse(`{"a": 1, "b": {"c": "x", "d": "y"}}`)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	v := NewViewer(root)
+	v.MoveCursor(2)
+	if v.CursorNode().Key != "b" {
+		t.Fatalf("Expected cursor on b, got %s", v.CursorNode().Path())
+	}
+
+	v.ToggleCollapse()
+	if v.LineCount() != 4 {
+		t.Errorf("Expected 4 lines after collapsing b, got %d: %v", v.LineCount(), v.Lines())
+	}
+	if !strings.Contains(v.Lines()[2], "2 keys") {
+		t.Errorf("Expected collapsed summary, got %q", v.Lines()[2])
+	}
+
+	v.ToggleCollapse()
+	if v.LineCount() != 7 {
+		t.Errorf("Expected 7 lines after expanding b, got %d", v.LineCount())
+	}
+
+	v.CollapseAll()
+	if v.LineCount() != 4 {
+		t.Errorf("Expected 4 lines after collapsing all, got %d", v.LineCount())
+	}
+}
+
+func TestViewerSearch(t *testing.T) {
+	root, err := Parse(testDocument)
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	v := NewViewer(root)
+	v.CollapseAll()
+
+	if count := v.Search("NAME"); count != 2 {
+		t.Fatalf("Expected 2 matches for name, got %d", count)
+	}
+	if path := v.CursorNode().Path(); path != "$.name" {
+		t.Errorf("Expected first match $.name, got %s", path)
+	}
+
+	// The second match is inside a collapsed object and must be revealed
+	v.NextMatch()
+	if path := v.CursorNode().Path(); path != "$.properties.template.containers[0].name" {
+		t.Errorf("Expected second match inside containers, got %s", path)
+	}
+	if current, total := v.MatchPosition(); current != 2 || total != 2 {
+		t.Errorf("Expected match 2/2, got %d/%d", current, total)
+	}
+
+	// Matches wrap around in both directions
+	v.NextMatch()
+	if path := v.CursorNode().Path(); path != "$.name" {
+		t.Errorf("Expected next match to wrap to $.name, got %s", path)
+	}
+	v.PrevMatch()
+	if path := v.CursorNode().Path(); path != "$.properties.template.containers[0].name" {
+		t.Errorf("Expected previous match to wrap to containers, got %s", path)
+	}
+
+	if count := v.Search("does-not-exist"); count != 0 || v.NextMatch() {
+		t.Errorf("Expected no matches, got %d", count)
+	}
+}
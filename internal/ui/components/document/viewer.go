@@ -0,0 +1,587 @@
+package document
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Format is the notation used to render a document
+type Format int
+
+const (
+	FormatJSON Format = iota
+	FormatYAML
+)
+
+// String returns the display name of the format
+func (f Format) String() string {
+	if f == FormatYAML {
+		return "YAML"
+	}
+	return "JSON"
+}
+
+// Styles defines the syntax highlighting used by the viewer
+type Styles struct {
+	Key         lipgloss.Style
+	String      lipgloss.Style
+	Number      lipgloss.Style
+	Bool        lipgloss.Style
+	Null        lipgloss.Style
+	Punctuation lipgloss.Style
+	Summary     lipgloss.Style // Child counts shown for collapsed nodes
+	Cursor      lipgloss.Style // Applied to every segment of the cursor line
+	Match       lipgloss.Style // Applied to keys and values matching the search
+}
+
+// DefaultStyles returns the default syntax highlighting styles
+func DefaultStyles() Styles {
+	return Styles{
+		Key:         lipgloss.NewStyle().Foreground(lipgloss.Color("#6495ED")),
+		String:      lipgloss.NewStyle().Foreground(lipgloss.Color("#8c8")),
+		Number:      lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB347")),
+		Bool:        lipgloss.NewStyle().Foreground(lipgloss.Color("#9370DB")),
+		Null:        lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Italic(true),
+		Punctuation: lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")),
+		Summary:     lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")).Italic(true),
+		Cursor: lipgloss.NewStyle().Background(lipgloss.AdaptiveColor{
+			Light: "#D9DCCF",
+			Dark:  "#353533",
+		}),
+		Match: lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Background(lipgloss.Color("#FFB347")),
+	}
+}
+
+// line is a single rendered row of the document
+type line struct {
+	node    *Node
+	depth   int
+	closing bool // Closing bracket of an expanded JSON container
+}
+
+// segment is a styled piece of a rendered line
+type segment struct {
+	text  string
+	style lipgloss.Style
+	match bool
+}
+
+// Viewer is a scrollable, collapsible view of a document with a line cursor
+// and in-document search.
+type Viewer struct {
+	root   *Node
+	format Format
+	styles Styles
+
+	// Visible lines and scrolling state
+	lines  []line
+	cursor int
+	offset int
+	width  int
+	height int
+
+	// Search state
+	query      string
+	matches    []*Node
+	matchIndex int
+}
+
+// NewViewer creates a viewer for the given document
+func NewViewer(root *Node) *Viewer {
+	v := &Viewer{
+		root:       root,
+		format:     FormatJSON,
+		styles:     DefaultStyles(),
+		width:      80,
+		height:     20,
+		matchIndex: -1,
+	}
+	v.rebuild()
+	return v
+}
+
+// SetStyles sets the syntax highlighting styles
+func (v *Viewer) SetStyles(styles Styles) {
+	v.styles = styles
+}
+
+// SetSize sets the dimensions available to the viewer
+func (v *Viewer) SetSize(width, height int) {
+	v.width = max(1, width)
+	v.height = max(1, height)
+	v.scrollToCursor()
+}
+
+// Format returns the current render format
+func (v *Viewer) Format() Format {
+	return v.format
+}
+
+// SetFormat sets the render format, keeping the cursor on the same node
+func (v *Viewer) SetFormat(format Format) {
+	v.format = format
+	v.rebuild()
+}
+
+// ToggleFormat switches between JSON and YAML
+func (v *Viewer) ToggleFormat() {
+	if v.format == FormatJSON {
+		v.SetFormat(FormatYAML)
+	} else {
+		v.SetFormat(FormatJSON)
+	}
+}
+
+// Cursor movement
+
+// CursorNode returns the node under the cursor
+func (v *Viewer) CursorNode() *Node {
+	if len(v.lines) == 0 {
+		return v.root
+	}
+	return v.lines[v.cursor].node
+}
+
+// Cursor returns the index of the cursor line
+func (v *Viewer) Cursor() int {
+	return v.cursor
+}
+
+// LineCount returns the number of visible lines
+func (v *Viewer) LineCount() int {
+	return len(v.lines)
+}
+
+// MoveCursor moves the cursor by delta lines
+func (v *Viewer) MoveCursor(delta int) {
+	v.setCursor(v.cursor + delta)
+}
+
+// GotoTop moves the cursor to the first line
+func (v *Viewer) GotoTop() {
+	v.setCursor(0)
+}
+
+// GotoBottom moves the cursor to the last line
+func (v *Viewer) GotoBottom() {
+	v.setCursor(len(v.lines) - 1)
+}
+
+// PageDown moves the cursor down by one page
+func (v *Viewer) PageDown() {
+	v.setCursor(v.cursor + v.height)
+}
+
+// PageUp moves the cursor up by one page
+func (v *Viewer) PageUp() {
+	v.setCursor(v.cursor - v.height)
+}
+
+// Collapsing
+
+// ToggleCollapse collapses or expands the container under the cursor
+func (v *Viewer) ToggleCollapse() {
+	node := v.CursorNode()
+	if !node.IsContainer() || len(node.Children) == 0 {
+		return
+	}
+	node.Collapsed = !node.Collapsed
+	v.rebuildAt(node, false)
+}
+
+// CollapseAll collapses every container below the root
+func (v *Viewer) CollapseAll() {
+	for _, child := range v.root.Children {
+		child.SetCollapsed(true)
+	}
+	v.rebuild()
+}
+
+// ExpandAll expands every container
+func (v *Viewer) ExpandAll() {
+	v.root.SetCollapsed(false)
+	v.rebuild()
+}
+
+// Search
+
+// Search finds all nodes whose key or value contains the query and moves the
+// cursor to the first match at or after the cursor. It returns the number of matches.
+func (v *Viewer) Search(query string) int {
+	v.query = query
+	v.matches = nil
+	v.matchIndex = -1
+
+	if query == "" {
+		return 0
+	}
+
+	v.root.Walk(func(node *Node) {
+		if node.Matches(query) {
+			v.matches = append(v.matches, node)
+		}
+	})
+
+	if len(v.matches) > 0 {
+		// Start from the first match at or after the cursor
+		cursorNode := v.CursorNode()
+		order := documentOrder(v.root)
+		v.matchIndex = 0
+		for i, match := range v.matches {
+			if order[match] >= order[cursorNode] {
+				v.matchIndex = i
+				break
+			}
+		}
+		v.revealMatch()
+	}
+
+	return len(v.matches)
+}
+
+// Query returns the active search query
+func (v *Viewer) Query() string {
+	return v.query
+}
+
+// NextMatch moves the cursor to the next search match, wrapping around
+func (v *Viewer) NextMatch() bool {
+	if len(v.matches) == 0 {
+		return false
+	}
+	v.matchIndex = (v.matchIndex + 1) % len(v.matches)
+	v.revealMatch()
+	return true
+}
+
+// PrevMatch moves the cursor to the previous search match, wrapping around
+func (v *Viewer) PrevMatch() bool {
+	if len(v.matches) == 0 {
+		return false
+	}
+	v.matchIndex = (v.matchIndex - 1 + len(v.matches)) % len(v.matches)
+	v.revealMatch()
+	return true
+}
+
+// MatchPosition returns the 1-based index of the current match and the total number of matches
+func (v *Viewer) MatchPosition() (int, int) {
+	return v.matchIndex + 1, len(v.matches)
+}
+
+// revealMatch expands the ancestors of the current match and moves the cursor to it
+func (v *Viewer) revealMatch() {
+	match := v.matches[v.matchIndex]
+	match.Expose()
+	v.rebuildAt(match, false)
+}
+
+// Rendering
+
+// View renders the visible portion of the document
+func (v *Viewer) View() string {
+	end := min(v.offset+v.height, len(v.lines))
+	rendered := make([]string, 0, end-v.offset)
+	for i := v.offset; i < end; i++ {
+		rendered = append(rendered, v.renderLine(v.lines[i], i == v.cursor))
+	}
+	return strings.Join(rendered, "\n")
+}
+
+// Lines renders every visible line without styling, mainly for tests and copying
+func (v *Viewer) Lines() []string {
+	plain := make([]string, len(v.lines))
+	for i, l := range v.lines {
+		var b strings.Builder
+		for _, seg := range v.segments(l) {
+			b.WriteString(seg.text)
+		}
+		plain[i] = b.String()
+	}
+	return plain
+}
+
+// renderLine renders a single line with syntax highlighting
+func (v *Viewer) renderLine(l line, isCursor bool) string {
+	var b strings.Builder
+	for _, seg := range v.segments(l) {
+		style := seg.style
+		if seg.match {
+			style = v.styles.Match
+		}
+		if isCursor {
+			style = style.Inherit(v.styles.Cursor)
+		}
+		b.WriteString(style.Render(seg.text))
+	}
+
+	rendered := lipgloss.NewStyle().MaxWidth(v.width).Render(b.String())
+	if isCursor {
+		// Extend the cursor highlight across the full width
+		if padding := v.width - lipgloss.Width(rendered); padding > 0 {
+			rendered += v.styles.Cursor.Render(strings.Repeat(" ", padding))
+		}
+	}
+	return rendered
+}
+
+// segments builds the styled pieces of a line in the current format
+func (v *Viewer) segments(l line) []segment {
+	if v.format == FormatYAML {
+		return v.yamlSegments(l)
+	}
+	return v.jsonSegments(l)
+}
+
+// jsonSegments builds the pieces of a JSON line
+func (v *Viewer) jsonSegments(l line) []segment {
+	n := l.node
+	segs := []segment{{text: strings.Repeat("  ", l.depth)}}
+
+	if l.closing {
+		segs = append(segs, v.punct(closingBracket(n)))
+		if needsComma(n) {
+			segs = append(segs, v.punct(","))
+		}
+		return segs
+	}
+
+	if n.Parent != nil && n.Parent.Kind == KindObject {
+		segs = append(segs, v.keySegment(quoteJSON(n.Key)), v.punct(": "))
+	}
+
+	switch {
+	case n.IsContainer() && len(n.Children) == 0:
+		segs = append(segs, v.punct(openingBracket(n)+closingBracket(n)))
+	case n.IsContainer() && n.Collapsed:
+		segs = append(segs, v.punct(openingBracket(n)+"…"+closingBracket(n)))
+		if needsComma(n) {
+			segs = append(segs, v.punct(","))
+		}
+		segs = append(segs, segment{text: " // " + childSummary(n), style: v.styles.Summary})
+		return segs
+	case n.IsContainer():
+		return append(segs, v.punct(openingBracket(n)))
+	default:
+		text := n.Value
+		if n.Kind == KindString {
+			text = quoteJSON(n.Value)
+		}
+		segs = append(segs, v.valueSegment(n, text))
+	}
+
+	if needsComma(n) {
+		segs = append(segs, v.punct(","))
+	}
+	return segs
+}
+
+// yamlSegments builds the pieces of a YAML line
+func (v *Viewer) yamlSegments(l line) []segment {
+	n := l.node
+	segs := []segment{{text: strings.Repeat("  ", l.depth)}}
+
+	separator := ""
+	if n.Parent != nil {
+		if n.Parent.Kind == KindArray {
+			segs = append(segs, v.punct("- "))
+		} else {
+			segs = append(segs, v.keySegment(quoteYAML(n.Key)), v.punct(":"))
+			separator = " "
+		}
+	}
+
+	switch {
+	case n.IsContainer() && len(n.Children) == 0:
+		segs = append(segs, v.punct(separator+openingBracket(n)+closingBracket(n)))
+	case n.IsContainer() && n.Collapsed:
+		segs = append(segs,
+			v.punct(separator+openingBracket(n)+"…"+closingBracket(n)),
+			segment{text: " # " + childSummary(n), style: v.styles.Summary},
+		)
+	case n.IsContainer():
+		// Children follow on the next lines
+	default:
+		text := n.Value
+		if n.Kind == KindString {
+			text = quoteYAML(n.Value)
+		}
+		segs = append(segs, segment{text: separator}, v.valueSegment(n, text))
+	}
+
+	return segs
+}
+
+// keySegment renders an object key
+func (v *Viewer) keySegment(text string) segment {
+	return segment{text: text, style: v.styles.Key, match: v.containsQuery(text)}
+}
+
+// valueSegment renders a scalar value with the style matching its kind
+func (v *Viewer) valueSegment(n *Node, text string) segment {
+	style := v.styles.String
+	switch n.Kind {
+	case KindNumber:
+		style = v.styles.Number
+	case KindBool:
+		style = v.styles.Bool
+	case KindNull:
+		style = v.styles.Null
+	}
+	return segment{text: text, style: style, match: v.containsQuery(n.Value)}
+}
+
+// punct renders punctuation
+func (v *Viewer) punct(text string) segment {
+	return segment{text: text, style: v.styles.Punctuation}
+}
+
+// containsQuery returns true if text contains the active search query
+func (v *Viewer) containsQuery(text string) bool {
+	return v.query != "" && strings.Contains(strings.ToLower(text), strings.ToLower(v.query))
+}
+
+// Line management
+
+// rebuild recomputes the visible lines, keeping the cursor on the same node
+func (v *Viewer) rebuild() {
+	if len(v.lines) == 0 {
+		v.rebuildAt(v.root, false)
+		return
+	}
+	current := v.lines[v.cursor]
+	v.rebuildAt(current.node, current.closing)
+}
+
+// rebuildAt recomputes the visible lines and places the cursor on the given
+// node, or on its closest visible ancestor
+func (v *Viewer) rebuildAt(target *Node, closing bool) {
+	v.lines = v.lines[:0]
+	if v.format == FormatYAML && v.root.IsContainer() && !v.root.Collapsed && len(v.root.Children) > 0 {
+		// YAML documents have no line for the root container
+		for _, child := range v.root.Children {
+			v.appendLines(child, 0)
+		}
+	} else {
+		v.appendLines(v.root, 0)
+	}
+
+	v.cursor = 0
+	for node := target; node != nil; node = node.Parent {
+		if index := v.indexOf(node, closing && node == target); index >= 0 {
+			v.cursor = index
+			break
+		}
+	}
+	v.scrollToCursor()
+}
+
+// appendLines appends the lines for a node and its visible descendants
+func (v *Viewer) appendLines(n *Node, depth int) {
+	v.lines = append(v.lines, line{node: n, depth: depth})
+	if !n.IsContainer() || n.Collapsed || len(n.Children) == 0 {
+		return
+	}
+
+	for _, child := range n.Children {
+		v.appendLines(child, depth+1)
+	}
+	if v.format == FormatJSON {
+		v.lines = append(v.lines, line{node: n, depth: depth, closing: true})
+	}
+}
+
+// indexOf returns the index of the line showing the node, or -1
+func (v *Viewer) indexOf(n *Node, closing bool) int {
+	for i, l := range v.lines {
+		if l.node == n && l.closing == closing {
+			return i
+		}
+	}
+	return -1
+}
+
+// setCursor moves the cursor to the given line, clamped to the document
+func (v *Viewer) setCursor(index int) {
+	v.cursor = max(0, min(index, len(v.lines)-1))
+	v.scrollToCursor()
+}
+
+// scrollToCursor adjusts the scroll offset so the cursor is visible
+func (v *Viewer) scrollToCursor() {
+	if v.cursor < v.offset {
+		v.offset = v.cursor
+	}
+	if v.cursor >= v.offset+v.height {
+		v.offset = v.cursor - v.height + 1
+	}
+	v.offset = max(0, min(v.offset, len(v.lines)-v.height))
+}
+
+// Helpers
+
+// documentOrder returns the position of every node in document order
+func documentOrder(root *Node) map[*Node]int {
+	order := make(map[*Node]int)
+	root.Walk(func(node *Node) {
+		order[node] = len(order)
+	})
+	return order
+}
+
+// needsComma returns true if the node is followed by a sibling in JSON
+func needsComma(n *Node) bool {
+	return n.Parent != nil && n != n.Parent.Children[len(n.Parent.Children)-1]
+}
+
+// openingBracket returns the opening bracket of a container
+func openingBracket(n *Node) string {
+	if n.Kind == KindArray {
+		return "["
+	}
+	return "{"
+}
+
+// closingBracket returns the closing bracket of a container
+func closingBracket(n *Node) string {
+	if n.Kind == KindArray {
+		return "]"
+	}
+	return "}"
+}
+
+// childSummary describes the number of children of a collapsed container
+func childSummary(n *Node) string {
+	unit := "keys"
+	if n.Kind == KindArray {
+		unit = "items"
+	}
+	if len(n.Children) == 1 {
+		unit = strings.TrimSuffix(unit, "s")
+	}
+	return fmt.Sprintf("%d %s", len(n.Children), unit)
+}
+
+// quoteJSON quotes a string as a JSON string literal
+func quoteJSON(s string) string {
+	b, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Sprintf("%q", s)
+	}
+	return string(b)
+}
+
+// yamlPlainUnsafe matches strings that YAML would not read back as the same plain string
+var yamlPlainUnsafe = regexp.MustCompile(`^$|^[\s\-?:,\[\]{}#&*!|>'"%@` + "`" + `]|\s$|: |\s#|\n|^(?i:true|false|yes|no|on|off|null|~)$|^[-+]?(\d|\.\d)`)
+
+// quoteYAML returns the string as a plain YAML scalar, quoting it when necessary
+func quoteYAML(s string) string {
+	if yamlPlainUnsafe.MatchString(s) {
+		return quoteJSON(s)
+	}
+	return s
+}
@@ -0,0 +1,234 @@
+// Package logexport saves log lines to a file as plain text, NDJSON or CSV,
+// and prompts inline for the file and the lines to save.
+package logexport
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/IAL32/az-tui/internal/ui/components/logview"
+)
+
+// Format is the file format log lines are saved in
+type Format string
+
+const (
+	FormatText   Format = "text"   // The lines as received, after the name of their stream if any
+	FormatNDJSON Format = "ndjson" // A JSON object of the extracted fields per line
+	FormatCSV    Format = "csv"    // A row of the extracted fields per line, after a header
+)
+
+// Formats are the formats the prompt cycles through
+var Formats = []Format{FormatText, FormatNDJSON, FormatCSV}
+
+// Extension returns the file extension of the format
+func (f Format) Extension() string {
+	switch f {
+	case FormatNDJSON:
+		return ".ndjson"
+	case FormatCSV:
+		return ".csv"
+	default:
+		return ".log"
+	}
+}
+
+// FormatFromPath returns the format of a file from its extension, false if
+// the extension is not one of a format
+func FormatFromPath(path string) (Format, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".log", ".txt":
+		return FormatText, true
+	case ".ndjson", ".jsonl", ".json":
+		return FormatNDJSON, true
+	case ".csv":
+		return FormatCSV, true
+	default:
+		return "", false
+	}
+}
+
+// Range selects the lines to save: the last Lines lines, the lines of the last
+// Window, or every line when both are zero
+type Range struct {
+	Name   string
+	Lines  int
+	Window time.Duration
+}
+
+// Ranges are the ranges the prompt cycles through
+var Ranges = []Range{
+	{Name: "all lines"},
+	{Name: "last 100 lines", Lines: 100},
+	{Name: "last 1000 lines", Lines: 1000},
+	{Name: "last 5m", Window: 5 * time.Minute},
+	{Name: "last 15m", Window: 15 * time.Minute},
+	{Name: "last 1h", Window: time.Hour},
+}
+
+// Select returns the entries, oldest first, within the range ending at now.
+// Lines without a timestamp belong with the line before them.
+func (r Range) Select(entries []logview.Entry, now time.Time) []logview.Entry {
+	switch {
+	case r.Lines > 0:
+		return entries[max(0, len(entries)-r.Lines):]
+	case r.Window > 0:
+		since := now.Add(-r.Window)
+		var selected []logview.Entry
+		within := false
+		for _, entry := range entries {
+			if !entry.Time.IsZero() {
+				within = !entry.Time.Before(since)
+			}
+			if within {
+				selected = append(selected, entry)
+			}
+		}
+		return selected
+	default:
+		return entries
+	}
+}
+
+// record is a line as saved in NDJSON
+type record struct {
+	Time      string            `json:"time,omitempty"`
+	Source    string            `json:"source,omitempty"`
+	Container string            `json:"container,omitempty"`
+	Level     string            `json:"level,omitempty"`
+	TraceID   string            `json:"traceId,omitempty"`
+	Message   string            `json:"message"`
+	Fields    map[string]string `json:"fields,omitempty"`
+}
+
+// csvHeader names the columns of lines saved in CSV
+var csvHeader = []string{"time", "source", "container", "level", "trace_id", "message"}
+
+// Write writes entries in a format
+func Write(w io.Writer, entries []logview.Entry, format Format) error {
+	switch format {
+	case FormatNDJSON:
+		encoder := json.NewEncoder(w)
+		for _, entry := range entries {
+			if err := encoder.Encode(record{
+				Time:      entryTime(entry),
+				Source:    entry.Source,
+				Container: entry.Container,
+				Level:     entryLevel(entry),
+				TraceID:   entry.TraceID,
+				Message:   entry.Message,
+				Fields:    entry.Fields,
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case FormatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			row := []string{entryTime(entry), entry.Source, entry.Container, entryLevel(entry), entry.TraceID, entry.Message}
+			if err := writer.Write(row); err != nil {
+				return err
+			}
+		}
+		writer.Flush()
+		return writer.Error()
+	default:
+		for _, entry := range entries {
+			line := entry.Raw
+			if entry.Source != "" {
+				line = "[" + entry.Source + "] " + line
+			}
+			if _, err := fmt.Fprintln(w, line); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// entryTime returns the timestamp of a line in RFC 3339, or as written when its format is unknown
+func entryTime(entry logview.Entry) string {
+	if entry.Time.IsZero() {
+		return entry.Timestamp
+	}
+	return entry.Time.Format(time.RFC3339Nano)
+}
+
+// entryLevel returns the level of a line, named as the viewer shows it when known
+func entryLevel(entry logview.Entry) string {
+	if severity := entry.Severity(); severity != logview.LevelNone {
+		return strings.ToLower(severity.String())
+	}
+	return entry.Level
+}
+
+// Save writes entries to a file in a format, creating its directory and
+// replacing the file if it exists. A leading ~ stands for the home directory.
+func Save(path string, entries []logview.Entry, format Format) error {
+	path, err := expandHome(path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(file, entries, format); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// expandHome replaces a leading ~ of a path with the home directory
+func expandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// SavedMsg reports the lines saved to a file, or the error saving them failed with
+type SavedMsg struct {
+	Path   string
+	Format Format
+	Lines  int
+	Err    error
+}
+
+// SaveCmd creates a command saving entries to a file in the background
+func SaveCmd(path string, entries []logview.Entry, format Format) tea.Cmd {
+	return func() tea.Msg {
+		err := Save(path, entries, format)
+		return SavedMsg{Path: path, Format: format, Lines: len(entries), Err: err}
+	}
+}
+
+// DefaultPath returns the file logs named name are saved to by default, in the
+// working directory, e.g. "my-app_my-app--v2-20240120-100000.log"
+func DefaultPath(name string, format Format, at time.Time) string {
+	name = strings.NewReplacer("/", "_", "\\", "_", " ", "_", ":", "_").Replace(name)
+	if name == "" {
+		name = "logs"
+	}
+	return name + "-" + at.Format("20060102-150405") + format.Extension()
+}
@@ -0,0 +1,110 @@
+package logexport
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/IAL32/az-tui/internal/ui/components/logview"
+)
+
+// testEntries parses a plain line, a structured line and a line without timestamp
+func testEntries() []logview.Entry {
+	config := logview.DefaultFieldConfig()
+	entries := []logview.Entry{
+		logview.ParseLine("2024-01-20T09:50:00Z INFO started", config),
+		logview.ParseLine(`{"TimeStamp":"2024-01-20T09:58:00Z","Log":"{\"level\":\"error\",\"msg\":\"request failed, retrying\",\"traceId\":\"abc\"}","ContainerName":"api"}`, config),
+		logview.ParseLine("    at handler (server.js:10)", config),
+	}
+	entries[1].Source = "web-app"
+	return entries
+}
+
+func TestRangeSelect(t *testing.T) {
+	entries := testEntries()
+	now := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+
+	if got := (Range{Lines: 2}).Select(entries, now); len(got) != 2 || got[0].Message != "request failed, retrying" {
+		t.Errorf("Expected the last 2 lines, got %v", got)
+	}
+	// The line without a timestamp goes with the line before it
+	if got := (Range{Window: 5 * time.Minute}).Select(entries, now); len(got) != 2 || got[1].Raw != "    at handler (server.js:10)" {
+		t.Errorf("Expected the lines of the last 5 minutes, got %v", got)
+	}
+	if got := (Range{}).Select(entries, now); len(got) != 3 {
+		t.Errorf("Expected every line, got %d", len(got))
+	}
+}
+
+func TestWriteFormats(t *testing.T) {
+	tests := map[Format]string{
+		FormatText: "2024-01-20T09:50:00Z INFO started\n" +
+			`[web-app] {"TimeStamp":"2024-01-20T09:58:00Z","Log":"{\"level\":\"error\",\"msg\":\"request failed, retrying\",\"traceId\":\"abc\"}","ContainerName":"api"}` + "\n" +
+			"    at handler (server.js:10)\n",
+		FormatNDJSON: `{"time":"2024-01-20T09:50:00Z","level":"info","message":"INFO started"}` + "\n" +
+			`{"time":"2024-01-20T09:58:00Z","source":"web-app","container":"api","level":"error","traceId":"abc","message":"request failed, retrying","fields":{"level":"error","msg":"request failed, retrying","traceId":"abc"}}` + "\n" +
+			`{"message":"    at handler (server.js:10)"}` + "\n",
+		FormatCSV: "time,source,container,level,trace_id,message\n" +
+			"2024-01-20T09:50:00Z,,,info,,INFO started\n" +
+			`2024-01-20T09:58:00Z,web-app,api,error,abc,"request failed, retrying"` + "\n" +
+			`,,,,,"    at handler (server.js:10)"` + "\n",
+	}
+	for format, want := range tests {
+		var b strings.Builder
+		if err := Write(&b, testEntries(), format); err != nil {
+			t.Fatalf("Write %s: %v", format, err)
+		}
+		if b.String() != want {
+			t.Errorf("Unexpected %s output:\n%s", format, b.String())
+		}
+	}
+}
+
+func TestSave(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "web-app.csv")
+	msg := SaveCmd(path, testEntries(), FormatCSV)().(SavedMsg)
+	if msg.Err != nil || msg.Lines != 3 {
+		t.Fatalf("Expected 3 lines saved, got %d (%v)", msg.Lines, msg.Err)
+	}
+	b, err := os.ReadFile(path)
+	if err != nil || !strings.HasPrefix(string(b), "time,source") {
+		t.Errorf("Expected the CSV file to be created with its directory, got %q (%v)", b, err)
+	}
+
+	at := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	if got := DefaultPath("web-app/web-app--v2", FormatNDJSON, at); got != "web-app_web-app--v2-20240120-100000.ndjson" {
+		t.Errorf("Unexpected default path %s", got)
+	}
+}
+
+func TestPrompt(t *testing.T) {
+	prompt := NewPrompt()
+	prompt.Open("web-app.log")
+	if !prompt.Active() || prompt.Format() != FormatText {
+		t.Fatal("Expected an open prompt saving plain text")
+	}
+
+	// Tab changes the format along with the extension, shift+tab the lines saved
+	prompt.HandleKey(tea.KeyMsg{Type: tea.KeyTab})
+	prompt.HandleKey(tea.KeyMsg{Type: tea.KeyShiftTab})
+	if !strings.Contains(prompt.View(120), "save last 100 lines as ndjson") {
+		t.Errorf("Unexpected prompt %q", prompt.View(120))
+	}
+
+	// Typing another extension switches to its format
+	for range len(".ndjson") {
+		prompt.HandleKey(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	prompt.HandleKey(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(".csv")})
+	request, _ := prompt.HandleKey(tea.KeyMsg{Type: tea.KeyEnter})
+	if request == nil || request.Path != "web-app.csv" || request.Format != FormatCSV || request.Range.Lines != 100 {
+		t.Errorf("Unexpected request %+v", request)
+	}
+	if prompt.Active() {
+		t.Error("Submitting should close the prompt")
+	}
+}
@@ -0,0 +1,118 @@
+package logexport
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Request is what the prompt was submitted with
+type Request struct {
+	Path   string
+	Format Format
+	Range  Range
+}
+
+// Prompt asks inline for the file to save logs to. The path is typed; tab
+// switches to the next format, changing the extension of the path, and
+// shift+tab to the next range of lines. Typing the extension of another
+// format switches to it.
+type Prompt struct {
+	input  textinput.Model
+	format int // Index in Formats
+	rng    int // Index in Ranges
+}
+
+// NewPrompt creates a closed prompt saving every line as plain text
+func NewPrompt() Prompt {
+	input := textinput.New()
+	input.Placeholder = "path of the file to save the logs to"
+	return Prompt{input: input}
+}
+
+// Open opens the prompt with a path, in the format of its extension
+func (p *Prompt) Open(path string) tea.Cmd {
+	p.input.SetValue(path)
+	p.input.CursorEnd()
+	p.syncFormat()
+	p.input.Focus()
+	return textinput.Blink
+}
+
+// Close closes the prompt
+func (p *Prompt) Close() {
+	p.input.Blur()
+}
+
+// Active returns true while the prompt is open
+func (p *Prompt) Active() bool {
+	return p.input.Focused()
+}
+
+// Format returns the format the lines will be saved in
+func (p *Prompt) Format() Format {
+	return Formats[p.format]
+}
+
+// Range returns the lines that will be saved
+func (p *Prompt) Range() Range {
+	return Ranges[p.rng]
+}
+
+// HandleKey handles a key while the prompt is open. It returns the request
+// once the prompt is submitted with enter, which closes it, as esc does.
+func (p *Prompt) HandleKey(msg tea.KeyMsg) (*Request, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		path := strings.TrimSpace(p.input.Value())
+		if path == "" {
+			return nil, nil
+		}
+		p.Close()
+		return &Request{Path: path, Format: p.Format(), Range: p.Range()}, nil
+	case "esc":
+		p.Close()
+		return nil, nil
+	case "tab":
+		p.format = (p.format + 1) % len(Formats)
+		path := p.input.Value()
+		if _, ok := FormatFromPath(path); ok {
+			path = strings.TrimSuffix(path, filepath.Ext(path))
+		}
+		p.input.SetValue(path + p.Format().Extension())
+		p.input.CursorEnd()
+		return nil, nil
+	case "shift+tab":
+		p.rng = (p.rng + 1) % len(Ranges)
+		return nil, nil
+	default:
+		var cmd tea.Cmd
+		p.input, cmd = p.input.Update(msg)
+		p.syncFormat()
+		return nil, cmd
+	}
+}
+
+// syncFormat switches to the format of the extension of the path, if any
+func (p *Prompt) syncFormat() {
+	format, ok := FormatFromPath(p.input.Value())
+	if !ok {
+		return
+	}
+	for i, candidate := range Formats {
+		if candidate == format {
+			p.format = i
+		}
+	}
+}
+
+// View renders the prompt on a line of a width
+func (p *Prompt) View(width int) string {
+	p.input.Prompt = fmt.Sprintf("save %s as %s (tab: format, shift+tab: lines): ", p.Range().Name, p.Format())
+	p.input.Width = max(1, width-lipgloss.Width(p.input.Prompt)-1)
+	return p.input.View()
+}
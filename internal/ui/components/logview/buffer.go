@@ -0,0 +1,102 @@
+// Package logview displays a stream of log lines with level coloring, regex
+// search, field filters, following and pausing, keeping a bounded number of
+// lines in memory. JSON lines are parsed so their fields can be shown as columns.
+package logview
+
+// Buffer is a ring buffer of lines that drops the oldest lines once it is full
+type Buffer[T any] struct {
+	lines   []T
+	start   int // index of the oldest line in lines
+	count   int
+	dropped int // lines dropped since the buffer was created or cleared
+}
+
+// NewBuffer creates a buffer holding at most capacity lines
+func NewBuffer[T any](capacity int) *Buffer[T] {
+	return &Buffer[T]{lines: make([]T, max(1, capacity))}
+}
+
+// Append adds lines, dropping the oldest lines beyond the capacity. It returns
+// how many lines were dropped.
+func (b *Buffer[T]) Append(lines ...T) int {
+	dropped := 0
+	for _, line := range lines {
+		if b.count < len(b.lines) {
+			b.lines[(b.start+b.count)%len(b.lines)] = line
+			b.count++
+			continue
+		}
+		b.lines[b.start] = line
+		b.start = (b.start + 1) % len(b.lines)
+		dropped++
+	}
+	b.dropped += dropped
+	return dropped
+}
+
+// Insert adds a line before the i-th line, dropping the oldest line if the
+// buffer is full. It returns how many lines were dropped, which includes the
+// inserted line itself when it would have been the oldest.
+func (b *Buffer[T]) Insert(i int, line T) int {
+	if i >= b.count {
+		return b.Append(line)
+	}
+
+	dropped := 0
+	if b.count == len(b.lines) {
+		b.dropped++
+		if i == 0 {
+			return 1
+		}
+		b.start = (b.start + 1) % len(b.lines)
+		b.count--
+		i--
+		dropped = 1
+	}
+	for j := b.count; j > i; j-- {
+		b.Set(j, b.Line(j-1))
+	}
+	b.Set(i, line)
+	b.count++
+	return dropped
+}
+
+// Len returns the number of lines in the buffer
+func (b *Buffer[T]) Len() int {
+	return b.count
+}
+
+// Cap returns the maximum number of lines the buffer holds
+func (b *Buffer[T]) Cap() int {
+	return len(b.lines)
+}
+
+// Line returns the i-th line, the oldest being 0
+func (b *Buffer[T]) Line(i int) T {
+	return b.lines[(b.start+i)%len(b.lines)]
+}
+
+// Set replaces the i-th line, the oldest being 0
+func (b *Buffer[T]) Set(i int, line T) {
+	b.lines[(b.start+i)%len(b.lines)] = line
+}
+
+// Lines returns a copy of the lines, oldest first
+func (b *Buffer[T]) Lines() []T {
+	lines := make([]T, b.count)
+	for i := range lines {
+		lines[i] = b.Line(i)
+	}
+	return lines
+}
+
+// Dropped returns how many lines were dropped to stay within the capacity
+func (b *Buffer[T]) Dropped() int {
+	return b.dropped
+}
+
+// Clear removes every line
+func (b *Buffer[T]) Clear() {
+	clear(b.lines)
+	b.start, b.count, b.dropped = 0, 0, 0
+}
@@ -0,0 +1,266 @@
+package logview
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Entry is a log line with the fields extracted from it. Lines holding a JSON
+// object, possibly wrapped in the envelope `az containerapp logs show` prints
+// ({"TimeStamp": ..., "Log": ..., "ContainerName": ...}), have all their fields
+// parsed; plain lines only get a leading timestamp and the level keyword.
+type Entry struct {
+	Raw        string
+	Structured bool // The line, or the log inside its envelope, is a JSON object
+
+	Time      time.Time // Zero if the line has no timestamp
+	Timestamp string    // The timestamp as written in the line
+	Level     string
+	Message   string
+	TraceID   string
+	Container string
+
+	// Source names the stream the line came from when several streams are
+	// interleaved, e.g. "console" or "system"
+	Source string
+
+	// Fields holds every field of a structured line, nested objects flattened
+	// with dots, e.g. "http.status"
+	Fields map[string]string
+}
+
+// Severity returns the level of the entry
+func (e Entry) Severity() Level {
+	if n, err := strconv.Atoi(e.Level); err == nil {
+		// Numeric levels, as written by pino and bunyan
+		switch {
+		case n >= 50:
+			return LevelError
+		case n >= 40:
+			return LevelWarn
+		case n >= 30:
+			return LevelInfo
+		default:
+			return LevelDebug
+		}
+	}
+	return DetectLevel(e.Level)
+}
+
+// Field returns the value of a field, matching its name case insensitively.
+// The extracted fields are available as time, level, message, trace and container.
+func (e Entry) Field(name string) (string, bool) {
+	switch strings.ToLower(name) {
+	case "time", "timestamp":
+		return e.Timestamp, e.Timestamp != ""
+	case "level":
+		return e.Level, e.Level != ""
+	case "message", "msg":
+		return e.Message, true
+	case "trace", "traceid", "trace_id":
+		return e.TraceID, e.TraceID != ""
+	case "container":
+		return e.Container, e.Container != ""
+	}
+	if value, ok := e.Fields[name]; ok {
+		return value, true
+	}
+	for key, value := range e.Fields {
+		if strings.EqualFold(key, name) {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// FieldConfig lists, for each extracted field, the names of the JSON fields it
+// is read from, in order of preference. Names are matched case insensitively.
+type FieldConfig struct {
+	Timestamp []string
+	Level     []string
+	Message   []string
+	TraceID   []string
+}
+
+// DefaultFieldConfig returns the field names used by common logging libraries
+func DefaultFieldConfig() FieldConfig {
+	return FieldConfig{
+		Timestamp: []string{"timestamp", "time", "ts", "@timestamp", "@t", "datetime"},
+		Level:     []string{"level", "severity", "lvl", "loglevel", "@l"},
+		Message:   []string{"message", "msg", "@m", "@mt", "text"},
+		TraceID:   []string{"traceid", "trace_id", "trace.id", "operation_id", "@tr"},
+	}
+}
+
+// ParseFieldConfig overrides the default field names with a specification such
+// as "level=severity,trace=trace_id|traceId", where alternatives are separated
+// by "|". The fields are timestamp, level, message and trace.
+func ParseFieldConfig(spec string) (FieldConfig, error) {
+	config := DefaultFieldConfig()
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		field, names, ok := strings.Cut(part, "=")
+		if !ok || names == "" {
+			return config, fmt.Errorf("invalid log field %q, expected field=name", part)
+		}
+		candidates := strings.Split(names, "|")
+		switch strings.ToLower(strings.TrimSpace(field)) {
+		case "timestamp", "time":
+			config.Timestamp = candidates
+		case "level":
+			config.Level = candidates
+		case "message", "msg":
+			config.Message = candidates
+		case "trace", "traceid":
+			config.TraceID = candidates
+		default:
+			return config, fmt.Errorf("unknown log field %q, expected timestamp, level, message or trace", field)
+		}
+	}
+	return config, nil
+}
+
+// timeLayouts are the timestamp formats recognized in log lines
+var timeLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999Z07:00", "2006-01-02 15:04:05.999999999", "2006-01-02T15:04:05.999999999"}
+
+// ParseLine extracts the fields of a log line
+func ParseLine(line string, config FieldConfig) Entry {
+	entry := Entry{Raw: line, Message: line}
+
+	object, ok := parseObject(line)
+	if !ok {
+		entry.parsePlain(line)
+		return entry
+	}
+
+	// Unwrap the envelope of az containerapp logs show
+	if log, isEnvelope := object["Log"].(string); isEnvelope {
+		if stamp, ok := object["TimeStamp"].(string); ok {
+			entry.setTimestamp(stamp)
+		}
+		entry.Container, _ = object["ContainerName"].(string)
+
+		inner, ok := parseObject(log)
+		if !ok {
+			entry.Message = strings.TrimSpace(log)
+			entry.parsePlain(entry.Message)
+			return entry
+		}
+		object = inner
+	}
+
+	entry.Structured = true
+	entry.Fields = make(map[string]string)
+	flatten("", object, entry.Fields)
+
+	if stamp := lookup(entry.Fields, config.Timestamp); stamp != "" {
+		entry.setTimestamp(stamp)
+	}
+	entry.Level = lookup(entry.Fields, config.Level)
+	if entry.Level == "" && entry.Fields["Reason"] != "" {
+		// Container Apps system events are of type Normal or Warning
+		switch entry.Fields["Type"] {
+		case "Normal":
+			entry.Level = "info"
+		case "Warning":
+			entry.Level = "warn"
+		}
+	}
+	entry.TraceID = lookup(entry.Fields, config.TraceID)
+	if message := lookup(entry.Fields, config.Message); message != "" {
+		entry.Message = message
+	}
+	return entry
+}
+
+// parsePlain extracts a leading timestamp and the level keyword of a plain line
+func (e *Entry) parsePlain(line string) {
+	if first, rest, ok := strings.Cut(line, " "); ok && e.Timestamp == "" {
+		if t, ok := parseTime(first); ok {
+			e.Time, e.Timestamp, e.Message = t, first, strings.TrimSpace(rest)
+		}
+	}
+	e.Level = levelPattern.FindString(line)
+}
+
+// setTimestamp records a timestamp, parsing it when its format is known
+func (e *Entry) setTimestamp(stamp string) {
+	e.Timestamp = stamp
+	e.Time, _ = parseTime(stamp)
+}
+
+// parseTime parses a timestamp in one of the known formats
+func parseTime(s string) (time.Time, bool) {
+	for _, layout := range timeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseObject parses a line holding a single JSON object
+func parseObject(line string) (map[string]any, bool) {
+	line = strings.TrimSpace(line)
+	if !strings.HasPrefix(line, "{") || !strings.HasSuffix(line, "}") {
+		return nil, false
+	}
+	var object map[string]any
+	if err := json.Unmarshal([]byte(line), &object); err != nil {
+		return nil, false
+	}
+	return object, true
+}
+
+// flatten adds the fields of a JSON value, joining the names of nested objects with dots
+func flatten(prefix string, value any, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + key
+			}
+			flatten(name, child, fields)
+		}
+	case string:
+		fields[prefix] = v
+	case nil:
+		fields[prefix] = "null"
+	case float64:
+		fields[prefix] = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		b, _ := json.Marshal(v)
+		fields[prefix] = string(b)
+	}
+}
+
+// lookup returns the value of the first of the named fields present, matching names case insensitively
+func lookup(fields map[string]string, names []string) string {
+	for _, name := range names {
+		if value, ok := fields[name]; ok {
+			return value
+		}
+	}
+	// Fall back to case insensitive matching, in a stable order
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, name := range names {
+		for _, key := range keys {
+			if strings.EqualFold(key, name) {
+				return fields[key]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,63 @@
+package logview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLineEnvelope(t *testing.T) {
+	line := `{"TimeStamp":"2024-01-20T10:00:00.123Z","Log":"{\"level\":\"warn\",\"msg\":\"slow request\",\"traceId\":\"abc123\",\"http\":{\"status\":200}}","ContainerName":"api"}`
+	entry := ParseLine(line, DefaultFieldConfig())
+
+	if !entry.Structured {
+		t.Fatal("Expected the log inside the envelope to be parsed")
+	}
+	want := time.Date(2024, 1, 20, 10, 0, 0, 123000000, time.UTC)
+	if !entry.Time.Equal(want) {
+		t.Errorf("Expected the envelope timestamp, got %v", entry.Time)
+	}
+	if entry.Level != "warn" || entry.Message != "slow request" || entry.TraceID != "abc123" || entry.Container != "api" {
+		t.Errorf("Unexpected fields: level %q, message %q, trace %q, container %q", entry.Level, entry.Message, entry.TraceID, entry.Container)
+	}
+	if status, _ := entry.Field("http.status"); status != "200" {
+		t.Errorf("Expected nested fields flattened, got %q", status)
+	}
+	if entry.Severity() != LevelWarn {
+		t.Errorf("Expected warn severity, got %d", entry.Severity())
+	}
+}
+
+func TestParseLinePlain(t *testing.T) {
+	entry := ParseLine("2024-01-20T10:00:00Z ERROR failed to connect", DefaultFieldConfig())
+
+	if entry.Structured {
+		t.Error("Expected a plain line")
+	}
+	if entry.Timestamp != "2024-01-20T10:00:00Z" || entry.Level != "ERROR" || entry.Message != "ERROR failed to connect" {
+		t.Errorf("Unexpected fields: timestamp %q, level %q, message %q", entry.Timestamp, entry.Level, entry.Message)
+	}
+
+	// The envelope around a plain log keeps the log as the message
+	entry = ParseLine(`{"TimeStamp":"2024-01-20T10:00:00Z","Log":"Listening on port 8080"}`, DefaultFieldConfig())
+	if entry.Structured || entry.Message != "Listening on port 8080" || entry.Timestamp == "" {
+		t.Errorf("Unexpected envelope of a plain log: %+v", entry)
+	}
+}
+
+func TestParseFieldConfig(t *testing.T) {
+	config, err := ParseFieldConfig("level=severity, trace=op_id|requestId")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	entry := ParseLine(`{"severity":50,"level":"ignored","msg":"boom","requestId":"r-1"}`, config)
+	if entry.Level != "50" || entry.Severity() != LevelError || entry.TraceID != "r-1" {
+		t.Errorf("Expected the configured fields, got level %q and trace %q", entry.Level, entry.TraceID)
+	}
+
+	for _, spec := range []string{"level", "color=hue"} {
+		if _, err := ParseFieldConfig(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
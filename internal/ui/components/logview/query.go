@@ -0,0 +1,95 @@
+package logview
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Query selects log entries by field values and text, e.g.
+// `level=error traceId=abc* timeout`. Every term must match:
+//   - field=pattern matches entries whose field matches the glob pattern,
+//     where "*" matches any text and "?" any character
+//   - field!=pattern matches entries whose field is missing or does not match
+//   - any other term matches entries containing the text
+//
+// Matching ignores case.
+type Query struct {
+	text  string
+	terms []queryTerm
+}
+
+// queryTerm is a single condition of a query
+type queryTerm struct {
+	field   string // Empty for a text term
+	negate  bool
+	pattern *regexp.Regexp // Field value pattern
+	text    string         // Lower case text of a text term
+}
+
+// ParseQuery parses a query. An empty query matches every entry.
+func ParseQuery(text string) (*Query, error) {
+	q := &Query{text: strings.TrimSpace(text)}
+	for _, word := range strings.Fields(text) {
+		field, pattern, isField := strings.Cut(word, "=")
+		if !isField {
+			q.terms = append(q.terms, queryTerm{text: strings.ToLower(word)})
+			continue
+		}
+
+		term := queryTerm{field: field}
+		if strings.HasSuffix(field, "!") {
+			term.field, term.negate = strings.TrimSuffix(field, "!"), true
+		}
+		if term.field == "" {
+			return nil, fmt.Errorf("missing field name in %q", word)
+		}
+		term.pattern = globPattern(pattern)
+		q.terms = append(q.terms, term)
+	}
+	return q, nil
+}
+
+// String returns the query as typed
+func (q *Query) String() string {
+	return q.text
+}
+
+// Matches reports whether an entry satisfies every term of the query
+func (q *Query) Matches(entry Entry) bool {
+	for _, term := range q.terms {
+		if !term.matches(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// matches reports whether an entry satisfies the term
+func (t queryTerm) matches(entry Entry) bool {
+	if t.field == "" {
+		return strings.Contains(strings.ToLower(entry.Raw), t.text)
+	}
+	value, ok := entry.Field(t.field)
+	matched := ok && t.pattern.MatchString(value)
+	return matched != t.negate
+}
+
+// globPattern converts a glob pattern into a case insensitive regular expression
+// matching whole values
+func globPattern(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?i)^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
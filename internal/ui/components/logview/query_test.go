@@ -0,0 +1,38 @@
+package logview
+
+import "testing"
+
+func TestQueryMatches(t *testing.T) {
+	config := DefaultFieldConfig()
+	failed := ParseLine(`{"level":"error","msg":"request failed","traceId":"abc123"}`, config)
+	served := ParseLine(`{"level":"info","msg":"request served","traceId":"def456"}`, config)
+	plain := ParseLine("WARN cache miss", config)
+
+	tests := []struct {
+		query string
+		want  []bool // failed, served, plain
+	}{
+		{"level=error", []bool{true, false, false}},
+		{"LEVEL=Error traceId=abc*", []bool{true, false, false}},
+		{"level!=error", []bool{false, true, true}},
+		{"trace=???456", []bool{false, true, false}},
+		{"request", []bool{true, true, false}},
+		{"level=warn miss", []bool{false, false, true}},
+		{"", []bool{true, true, true}},
+	}
+	for _, test := range tests {
+		query, err := ParseQuery(test.query)
+		if err != nil {
+			t.Fatalf("ParseQuery(%q) failed: %v", test.query, err)
+		}
+		for i, entry := range []Entry{failed, served, plain} {
+			if got := query.Matches(entry); got != test.want[i] {
+				t.Errorf("Query %q on %q = %v, want %v", test.query, entry.Raw, got, test.want[i])
+			}
+		}
+	}
+
+	if _, err := ParseQuery("=error"); err == nil {
+		t.Error("Expected an error for a missing field name")
+	}
+}
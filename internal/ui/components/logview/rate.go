@@ -0,0 +1,68 @@
+package logview
+
+import "time"
+
+// DefaultRateWindow is the period a rate meter averages over by default
+const DefaultRateWindow = 10 * time.Second
+
+// RateMeter measures how many lines per second are received, averaged over a
+// sliding window counted in whole seconds
+type RateMeter struct {
+	window  time.Duration
+	seconds []rateSecond // Counts of the seconds within the window, oldest first
+}
+
+// rateSecond counts the lines received within a second
+type rateSecond struct {
+	unix  int64
+	count int
+}
+
+// NewRateMeter creates a meter averaging over a window of at least a second
+func NewRateMeter(window time.Duration) *RateMeter {
+	return &RateMeter{window: max(time.Second, window.Truncate(time.Second))}
+}
+
+// Add counts n lines received at a time
+func (m *RateMeter) Add(n int, at time.Time) {
+	unix := at.Unix()
+	m.forget(unix)
+	if last := len(m.seconds) - 1; last >= 0 && m.seconds[last].unix >= unix {
+		// Lines received out of order are counted in the newest second
+		m.seconds[last].count += n
+		return
+	}
+	m.seconds = append(m.seconds, rateSecond{unix: unix, count: n})
+}
+
+// Rate returns the lines per second received within the window ending at now.
+// Until the window has passed since the first line, the rate is averaged over
+// the seconds since then.
+func (m *RateMeter) Rate(now time.Time) float64 {
+	unix := now.Unix()
+	m.forget(unix)
+	if len(m.seconds) == 0 {
+		return 0
+	}
+	total := 0
+	for _, second := range m.seconds {
+		total += second.count
+	}
+	span := min(int64(m.window/time.Second), unix-m.seconds[0].unix+1)
+	return float64(total) / float64(max(1, span))
+}
+
+// Reset forgets every line counted
+func (m *RateMeter) Reset() {
+	m.seconds = nil
+}
+
+// forget drops the seconds before the window ending at the second unix
+func (m *RateMeter) forget(unix int64) {
+	oldest := unix - int64(m.window/time.Second) + 1
+	i := 0
+	for i < len(m.seconds) && m.seconds[i].unix < oldest {
+		i++
+	}
+	m.seconds = m.seconds[i:]
+}
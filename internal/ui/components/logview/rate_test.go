@@ -0,0 +1,33 @@
+package logview
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateMeter(t *testing.T) {
+	start := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	m := NewRateMeter(10 * time.Second)
+	if rate := m.Rate(start); rate != 0 {
+		t.Errorf("Expected no rate before any line, got %v", rate)
+	}
+
+	// Until the window has passed, the rate is averaged over the seconds since the first line
+	m.Add(10, start)
+	m.Add(20, start.Add(time.Second))
+	if rate := m.Rate(start.Add(time.Second)); rate != 15 {
+		t.Errorf("Expected 15 lines per second, got %v", rate)
+	}
+
+	// Lines older than the window are forgotten
+	m.Add(50, start.Add(9*time.Second))
+	if rate := m.Rate(start.Add(9 * time.Second)); rate != 8 {
+		t.Errorf("Expected 8 lines per second over the window, got %v", rate)
+	}
+	if rate := m.Rate(start.Add(10 * time.Second)); rate != 7 {
+		t.Errorf("Expected the first second forgotten, got %v", rate)
+	}
+	if rate := m.Rate(start.Add(time.Minute)); rate != 0 {
+		t.Errorf("Expected no rate once every line is out of the window, got %v", rate)
+	}
+}
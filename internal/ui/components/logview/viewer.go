@@ -0,0 +1,878 @@
+package logview
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// DefaultCapacity is the number of lines a viewer keeps by default
+const DefaultCapacity = 10000
+
+// Level is the severity of a log line, as detected from its text
+type Level int
+
+const (
+	LevelNone Level = iota
+	LevelDebug
+	LevelInfo
+	LevelWarn
+	LevelError
+)
+
+// levelPattern matches the first severity keyword of a line
+var levelPattern = regexp.MustCompile(`(?i)\b(fatal|panic|crit(?:ical)?|error|warn(?:ing)?|info|debug|trace)\b`)
+
+// ansiPattern matches terminal escape sequences, which would break the layout
+var ansiPattern = regexp.MustCompile(`\x1b\[[0-9;?]*[A-Za-z]`)
+
+// String returns the upper case name of the level
+func (l Level) String() string {
+	switch l {
+	case LevelDebug:
+		return "DEBUG"
+	case LevelInfo:
+		return "INFO"
+	case LevelWarn:
+		return "WARN"
+	case LevelError:
+		return "ERROR"
+	default:
+		return ""
+	}
+}
+
+// DetectLevel returns the level of a line from the first severity keyword in it
+func DetectLevel(line string) Level {
+	match := levelPattern.FindString(line)
+	switch strings.ToLower(match) {
+	case "":
+		return LevelNone
+	case "debug", "trace":
+		return LevelDebug
+	case "info":
+		return LevelInfo
+	case "warn", "warning":
+		return LevelWarn
+	default:
+		return LevelError
+	}
+}
+
+// Styles defines the colors used by the viewer
+type Styles struct {
+	Debug  lipgloss.Style
+	Info   lipgloss.Style
+	Warn   lipgloss.Style
+	Error  lipgloss.Style
+	Match  lipgloss.Style // Applied to the text matching the search
+	Header lipgloss.Style // Applied to the column headers
+
+	// Sources are cycled through to color the names of the streams, in the
+	// order the streams are seen. Names are not colored when empty.
+	Sources []lipgloss.Style
+}
+
+// DefaultStyles returns the default log styles
+func DefaultStyles() Styles {
+	return Styles{
+		Debug:  lipgloss.NewStyle().Foreground(lipgloss.Color("#888888")),
+		Info:   lipgloss.NewStyle(),
+		Warn:   lipgloss.NewStyle().Foreground(lipgloss.Color("#FFB347")),
+		Error:  lipgloss.NewStyle().Foreground(lipgloss.Color("#FF6B6B")),
+		Match:  lipgloss.NewStyle().Foreground(lipgloss.Color("#000000")).Background(lipgloss.Color("#FFB347")),
+		Header: lipgloss.NewStyle().Bold(true).Foreground(lipgloss.Color("#888888")),
+		Sources: []lipgloss.Style{
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#5FAFFF")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#87D787")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#D787D7")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#FFD75F")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#5FD7D7")),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("#FF8787")),
+		},
+	}
+}
+
+// Column widths of the structured view
+const (
+	timeColumnWidth  = 12 // 15:04:05.000
+	levelColumnWidth = 5
+	traceColumnWidth = 8
+)
+
+// Viewer is a scrollable view of a log stream. It follows new lines as they
+// arrive unless scrolled up, and while paused it holds new lines back until
+// resumed. Lines are numbered from the start of the stream, so positions stay
+// valid when the oldest lines are dropped.
+//
+// Once JSON lines are received, the viewer shows the time, level, trace id and
+// message of every line as columns, unless columns are turned off. A filter
+// hides the lines not matching a field query. Lines of several streams can be
+// interleaved by time, each showing the name of its stream in its own color,
+// and the lines of a stream can be muted.
+type Viewer struct {
+	styles Styles
+	fields FieldConfig
+
+	// Stream state
+	sourceColors map[string]int  // Index in the source styles of every stream seen
+	muted        map[string]bool // Streams whose lines are hidden
+	containers   bool            // Stream names are followed by the container of the line
+
+	lines   *Buffer[Entry]
+	pending *Buffer[Entry] // Lines received while paused
+	sorted  bool           // Lines are kept in order of their timestamps
+
+	// Filter state
+	filter  *Query
+	visible []int // Numbers of the lines passing the filter, oldest first
+
+	// Scrolling state
+	width   int
+	height  int
+	top     int // Number of the first visible line when not following
+	follow  bool
+	wrap    bool
+	paused  bool
+	columns bool
+
+	// Whether some lines kept are structured, or have a trace id, and the
+	// width of the widest stream name
+	structured  bool
+	traces      bool
+	sourceWidth int
+
+	// Search state
+	query      string
+	pattern    *regexp.Regexp
+	matches    []int // Numbers of the matching visible lines, oldest first
+	matchIndex int
+}
+
+// NewViewer creates a viewer keeping at most capacity lines
+func NewViewer(capacity int) *Viewer {
+	return &Viewer{
+		styles:       DefaultStyles(),
+		fields:       DefaultFieldConfig(),
+		sourceColors: make(map[string]int),
+		muted:        make(map[string]bool),
+		lines:        NewBuffer[Entry](capacity),
+		pending:      NewBuffer[Entry](capacity),
+		width:        80,
+		height:       20,
+		follow:       true,
+		columns:      true,
+		matchIndex:   -1,
+	}
+}
+
+// SetStyles sets the log styles
+func (v *Viewer) SetStyles(styles Styles) {
+	v.styles = styles
+}
+
+// SetSize sets the dimensions available to the viewer
+func (v *Viewer) SetSize(width, height int) {
+	v.width = max(1, width)
+	v.height = max(1, height)
+	v.clampTop()
+}
+
+// SetFieldConfig sets the JSON fields the time, level, message and trace id
+// are read from, parsing the lines kept again
+func (v *Viewer) SetFieldConfig(config FieldConfig) {
+	v.fields = config
+	for _, buffer := range []*Buffer[Entry]{v.lines, v.pending} {
+		for i := 0; i < buffer.Len(); i++ {
+			entry := buffer.Line(i)
+			parsed := ParseLine(entry.Raw, config)
+			parsed.Source = entry.Source
+			buffer.Set(i, parsed)
+		}
+	}
+	v.refilter()
+}
+
+// SetSorted sets whether lines are kept in order of their timestamps, so that
+// lines of several streams are interleaved. Lines without a timestamp stay
+// where they arrived.
+func (v *Viewer) SetSorted(sorted bool) {
+	v.sorted = sorted
+}
+
+// Sorted returns true if lines are kept in order of their timestamps
+func (v *Viewer) Sorted() bool {
+	return v.sorted
+}
+
+// FieldConfig returns the JSON fields the extracted fields are read from
+func (v *Viewer) FieldConfig() FieldConfig {
+	return v.fields
+}
+
+// Streams
+
+// RegisterSources assigns colors to the names of streams in order, so that
+// streams keep their colors whichever sends lines first
+func (v *Viewer) RegisterSources(sources ...string) {
+	for _, source := range sources {
+		if _, ok := v.sourceColors[source]; !ok && source != "" {
+			v.sourceColors[source] = len(v.sourceColors)
+		}
+	}
+}
+
+// SourceStyle returns the style the name of a stream is shown in
+func (v *Viewer) SourceStyle(source string) lipgloss.Style {
+	index, ok := v.sourceColors[source]
+	if !ok || len(v.styles.Sources) == 0 {
+		return lipgloss.NewStyle()
+	}
+	return v.styles.Sources[index%len(v.styles.Sources)]
+}
+
+// SetMuted hides or shows again the lines of a stream
+func (v *Viewer) SetMuted(source string, muted bool) {
+	if v.muted[source] == muted {
+		return
+	}
+	if muted {
+		v.muted[source] = true
+	} else {
+		delete(v.muted, source)
+	}
+	v.refilter()
+}
+
+// Muted returns true if the lines of a stream are hidden
+func (v *Viewer) Muted(source string) bool {
+	return v.muted[source]
+}
+
+// SetSourceContainers sets whether the names of streams are followed by the
+// container each line was written by, for streams covering several containers
+func (v *Viewer) SetSourceContainers(containers bool) {
+	v.containers = containers
+	v.refilter()
+}
+
+// Lines
+
+// Append adds lines to the log, or holds them back while paused
+func (v *Viewer) Append(lines ...string) {
+	v.AppendFrom("", lines...)
+}
+
+// AppendFrom adds lines of a named stream, shown next to each line when not
+// empty, to the log, or holds them back while paused
+func (v *Viewer) AppendFrom(source string, lines ...string) {
+	v.RegisterSources(source)
+	entries := make([]Entry, len(lines))
+	for i, line := range lines {
+		entries[i] = ParseLine(cleanLine(line), v.fields)
+		entries[i].Source = source
+	}
+	if v.paused {
+		v.pending.Append(entries...)
+		return
+	}
+	v.appendEntries(entries)
+}
+
+// Clear removes every line, including those held back while paused
+func (v *Viewer) Clear() {
+	v.lines.Clear()
+	v.pending.Clear()
+	v.top = 0
+	v.visible = nil
+	v.structured, v.traces, v.sourceWidth = false, false, 0
+	v.matches = nil
+	v.matchIndex = -1
+}
+
+// Len returns the number of lines kept
+func (v *Viewer) Len() int {
+	return v.lines.Len()
+}
+
+// Visible returns the number of lines kept that pass the filter
+func (v *Viewer) Visible() int {
+	return len(v.visible)
+}
+
+// Dropped returns how many of the oldest lines were dropped to stay within the capacity
+func (v *Viewer) Dropped() int {
+	return v.lines.Dropped()
+}
+
+// Lines returns the lines kept, oldest first
+func (v *Viewer) Lines() []string {
+	lines := make([]string, v.lines.Len())
+	for i := range lines {
+		lines[i] = v.lines.Line(i).Raw
+	}
+	return lines
+}
+
+// Entries returns the parsed lines kept, oldest first
+func (v *Viewer) Entries() []Entry {
+	return v.lines.Lines()
+}
+
+// VisibleEntries returns the parsed lines kept that pass the filter and are
+// not muted, oldest first
+func (v *Viewer) VisibleEntries() []Entry {
+	entries := make([]Entry, len(v.visible))
+	for i, number := range v.visible {
+		entries[i] = v.entry(number)
+	}
+	return entries
+}
+
+// appendEntries adds lines to the log and records the ones passing the filter
+// and matching the search
+func (v *Viewer) appendEntries(entries []Entry) {
+	reordered := false
+	for _, entry := range entries {
+		if i := v.insertPosition(entry); i < v.lines.Len() {
+			v.lines.Insert(i, entry)
+			reordered = true
+			continue
+		}
+		v.lines.Append(entry)
+		v.track(v.end()-1, entry)
+	}
+	if reordered {
+		// Lines after the inserted ones were renumbered
+		v.refilter()
+		return
+	}
+
+	// Forget the dropped lines
+	v.visible = v.visible[sort.SearchInts(v.visible, v.start()):]
+	stale := sort.SearchInts(v.matches, v.start())
+	v.matches = v.matches[stale:]
+	v.matchIndex = max(-1, v.matchIndex-stale)
+	if v.matchIndex < 0 && len(v.matches) > 0 {
+		v.matchIndex = 0
+	}
+	v.clampTop()
+}
+
+// insertPosition returns where a line goes among the lines kept: at the end,
+// unless lines are sorted and newer lines were already received
+func (v *Viewer) insertPosition(entry Entry) int {
+	i := v.lines.Len()
+	if !v.sorted || entry.Time.IsZero() {
+		return i
+	}
+	for i > 0 {
+		previous := v.lines.Line(i - 1)
+		if previous.Time.IsZero() || !previous.Time.After(entry.Time) {
+			break
+		}
+		i--
+	}
+	return i
+}
+
+// track records a line as visible and matching when its stream is not muted
+// and it passes the filter and search
+func (v *Viewer) track(number int, entry Entry) {
+	v.structured = v.structured || entry.Structured
+	v.traces = v.traces || entry.TraceID != ""
+	if name := v.sourceName(entry); name != "" {
+		v.sourceWidth = max(v.sourceWidth, len([]rune(name))+2)
+	}
+	if v.muted[entry.Source] {
+		return
+	}
+	if v.filter != nil && !v.filter.Matches(entry) {
+		return
+	}
+	v.visible = append(v.visible, number)
+	if v.pattern != nil && v.pattern.MatchString(entry.Raw) {
+		v.matches = append(v.matches, number)
+	}
+}
+
+// refilter recomputes the visible and matching lines after the filter, the
+// search or the lines changed
+func (v *Viewer) refilter() {
+	v.visible, v.matches = nil, nil
+	v.structured, v.traces, v.sourceWidth = false, false, 0
+	for number := v.start(); number < v.end(); number++ {
+		v.track(number, v.entry(number))
+	}
+	v.matchIndex = min(v.matchIndex, len(v.matches)-1)
+	if v.matchIndex < 0 && len(v.matches) > 0 {
+		v.matchIndex = len(v.matches) - 1
+	}
+	v.clampTop()
+}
+
+// start returns the number of the oldest line kept
+func (v *Viewer) start() int {
+	return v.lines.Dropped()
+}
+
+// end returns the number the next line will get
+func (v *Viewer) end() int {
+	return v.lines.Dropped() + v.lines.Len()
+}
+
+// entry returns the line with the given number
+func (v *Viewer) entry(number int) Entry {
+	return v.lines.Line(number - v.start())
+}
+
+// Filtering
+
+// SetFilter hides the lines not matching a query. A nil or empty query shows every line.
+func (v *Viewer) SetFilter(query *Query) {
+	if query != nil && query.String() == "" {
+		query = nil
+	}
+	v.filter = query
+	v.refilter()
+}
+
+// Filter returns the active filter query, empty if there is none
+func (v *Viewer) Filter() string {
+	if v.filter == nil {
+		return ""
+	}
+	return v.filter.String()
+}
+
+// Pausing and following
+
+// Paused returns true while new lines are held back
+func (v *Viewer) Paused() bool {
+	return v.paused
+}
+
+// SetPaused pauses or resumes the log. Resuming shows the lines held back.
+func (v *Viewer) SetPaused(paused bool) {
+	if v.paused == paused {
+		return
+	}
+	v.paused = paused
+	if !paused {
+		held := v.pending.Lines()
+		v.pending.Clear()
+		v.appendEntries(held)
+	}
+}
+
+// TogglePaused pauses or resumes the log
+func (v *Viewer) TogglePaused() {
+	v.SetPaused(!v.paused)
+}
+
+// Pending returns the number of lines held back while paused
+func (v *Viewer) Pending() int {
+	return v.pending.Len()
+}
+
+// Following returns true while the view sticks to the newest lines
+func (v *Viewer) Following() bool {
+	return v.follow
+}
+
+// SetFollow sets whether the view sticks to the newest lines
+func (v *Viewer) SetFollow(follow bool) {
+	if v.follow && !follow && len(v.visible) > 0 {
+		// Stay where the view was
+		v.top = v.visible[v.bottomPos()]
+	}
+	v.follow = follow
+}
+
+// ToggleFollow toggles following the newest lines
+func (v *Viewer) ToggleFollow() {
+	v.SetFollow(!v.follow)
+}
+
+// Wrapping returns true if long lines are wrapped instead of cut
+func (v *Viewer) Wrapping() bool {
+	return v.wrap
+}
+
+// ToggleWrap toggles wrapping long lines
+func (v *Viewer) ToggleWrap() {
+	v.wrap = !v.wrap
+	v.clampTop()
+}
+
+// Columns returns true if structured lines are shown as columns
+func (v *Viewer) Columns() bool {
+	return v.columns
+}
+
+// SetColumns sets whether structured lines are shown as columns
+func (v *Viewer) SetColumns(columns bool) {
+	v.columns = columns
+	v.clampTop()
+}
+
+// ToggleColumns toggles showing structured lines as columns
+func (v *Viewer) ToggleColumns() {
+	v.SetColumns(!v.columns)
+}
+
+// ShowingColumns returns true if the lines are currently shown as columns,
+// which requires columns to be on and some lines to be structured
+func (v *Viewer) ShowingColumns() bool {
+	return v.columns && v.structured
+}
+
+// Scrolling
+
+// ScrollUp scrolls up by n lines and stops following
+func (v *Viewer) ScrollUp(n int) {
+	v.SetFollow(false)
+	v.scrollTo(v.posOf(v.top) - n)
+}
+
+// ScrollDown scrolls down by n lines
+func (v *Viewer) ScrollDown(n int) {
+	if v.follow {
+		return
+	}
+	v.scrollTo(v.posOf(v.top) + n)
+}
+
+// PageUp scrolls up by one page
+func (v *Viewer) PageUp() {
+	v.ScrollUp(v.rows())
+}
+
+// PageDown scrolls down by one page
+func (v *Viewer) PageDown() {
+	v.ScrollDown(v.rows())
+}
+
+// GotoTop scrolls to the oldest line and stops following
+func (v *Viewer) GotoTop() {
+	v.SetFollow(false)
+	v.top = v.start()
+	v.scrollTo(0)
+}
+
+// GotoBottom scrolls to the newest line and follows new lines again
+func (v *Viewer) GotoBottom() {
+	v.SetFollow(true)
+}
+
+// Position returns the 1-based position of the first visible line among the
+// lines passing the filter
+func (v *Viewer) Position() int {
+	if len(v.visible) == 0 {
+		return 0
+	}
+	if v.follow {
+		return v.bottomPos() + 1
+	}
+	return v.posOf(v.top) + 1
+}
+
+// scrollTo scrolls to the visible line at a position, keeping within the lines
+func (v *Viewer) scrollTo(pos int) {
+	if len(v.visible) == 0 {
+		return
+	}
+	v.top = v.visible[max(0, min(pos, len(v.visible)-1))]
+	v.clampTop()
+}
+
+// clampTop keeps the first visible line on a line passing the filter, without
+// scrolling past the newest line
+func (v *Viewer) clampTop() {
+	if len(v.visible) == 0 {
+		return
+	}
+	v.top = v.visible[min(v.posOf(v.top), v.bottomPos())]
+}
+
+// posOf returns the position among the visible lines of the first one
+// numbered number or later
+func (v *Viewer) posOf(number int) int {
+	return sort.SearchInts(v.visible, number)
+}
+
+// bottomPos returns the position of the first visible line when scrolled to the bottom
+func (v *Viewer) bottomPos() int {
+	rows := 0
+	for pos := len(v.visible) - 1; pos >= 0; pos-- {
+		rows += v.rowCount(v.entry(v.visible[pos]))
+		if rows >= v.rows() {
+			return pos
+		}
+	}
+	return 0
+}
+
+// rows returns the number of rows available to lines, below the column headers if shown
+func (v *Viewer) rows() int {
+	if v.ShowingColumns() && v.height > 1 {
+		return v.height - 1
+	}
+	return v.height
+}
+
+// Search
+
+// Search highlights the lines matching a regular expression and scrolls to the
+// newest match. The search is case insensitive unless the pattern contains
+// upper case letters. An empty pattern clears the search. It returns the
+// number of matching lines among those passing the filter.
+func (v *Viewer) Search(pattern string) (int, error) {
+	if pattern == "" {
+		v.query, v.pattern, v.matches, v.matchIndex = "", nil, nil, -1
+		return 0, nil
+	}
+
+	expr := pattern
+	if strings.ToLower(pattern) == pattern {
+		expr = "(?i)" + pattern
+	}
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		return 0, err
+	}
+
+	v.query, v.pattern, v.matches, v.matchIndex = pattern, re, nil, -1
+	for _, number := range v.visible {
+		if re.MatchString(v.entry(number).Raw) {
+			v.matches = append(v.matches, number)
+		}
+	}
+	if len(v.matches) > 0 {
+		v.matchIndex = len(v.matches) - 1
+		v.revealMatch()
+	}
+	return len(v.matches), nil
+}
+
+// Query returns the active search pattern
+func (v *Viewer) Query() string {
+	return v.query
+}
+
+// NextMatch scrolls to the next newer match, wrapping around
+func (v *Viewer) NextMatch() bool {
+	if len(v.matches) == 0 {
+		return false
+	}
+	v.matchIndex = (v.matchIndex + 1) % len(v.matches)
+	v.revealMatch()
+	return true
+}
+
+// PrevMatch scrolls to the next older match, wrapping around
+func (v *Viewer) PrevMatch() bool {
+	if len(v.matches) == 0 {
+		return false
+	}
+	v.matchIndex = (v.matchIndex - 1 + len(v.matches)) % len(v.matches)
+	v.revealMatch()
+	return true
+}
+
+// MatchPosition returns the 1-based index of the current match and the total number of matches
+func (v *Viewer) MatchPosition() (int, int) {
+	return v.matchIndex + 1, len(v.matches)
+}
+
+// revealMatch stops following and scrolls the current match to the middle of the view
+func (v *Viewer) revealMatch() {
+	v.follow = false
+	v.scrollTo(v.posOf(v.matches[v.matchIndex]) - v.rows()/2)
+}
+
+// Rendering
+
+// View renders the visible lines
+func (v *Viewer) View() string {
+	height := v.rows()
+	var rows []string
+	if v.follow {
+		// Fill the view from the newest line upwards
+		for pos := len(v.visible) - 1; pos >= 0 && len(rows) < height; pos-- {
+			rows = append(v.renderEntry(v.entry(v.visible[pos])), rows...)
+		}
+		rows = rows[max(0, len(rows)-height):]
+	} else {
+		for pos := v.posOf(v.top); pos < len(v.visible) && len(rows) < height; pos++ {
+			rows = append(rows, v.renderEntry(v.entry(v.visible[pos]))...)
+		}
+		rows = rows[:min(len(rows), height)]
+	}
+	if v.ShowingColumns() && v.height > 1 {
+		rows = append([]string{v.renderHeader()}, rows...)
+	}
+	return strings.Join(rows, "\n")
+}
+
+// renderEntry renders the rows of a line, colored by level after the name of
+// its stream in the color of the stream, with the search matches highlighted
+func (v *Viewer) renderEntry(entry Entry) []string {
+	badge := 0
+	if name := v.sourceName(entry); v.sourceWidth > 0 && name != "" {
+		badge = len([]rune(name)) + 2
+	}
+	return v.renderLine(v.text(entry), badge, v.levelStyle(entry.Severity()), v.SourceStyle(entry.Source))
+}
+
+// renderHeader renders the column headers
+func (v *Viewer) renderHeader() string {
+	header := ""
+	if v.sourceWidth > 0 {
+		header = fmt.Sprintf("%-*s ", v.sourceWidth, "SOURCE")
+	}
+	header += fmt.Sprintf("%-*s %-*s ", timeColumnWidth, "TIME", levelColumnWidth, "LEVEL")
+	if v.traces {
+		header += fmt.Sprintf("%-*s ", traceColumnWidth, "TRACE")
+	}
+	header += "MESSAGE"
+	return v.styles.Header.Render(truncate(header, v.width))
+}
+
+// text returns the text shown for a line, either the line itself or its
+// columns, after the name of its stream if any
+func (v *Viewer) text(entry Entry) string {
+	badge := ""
+	if v.sourceWidth > 0 {
+		badge = fmt.Sprintf("%-*s ", v.sourceWidth, "["+v.sourceName(entry)+"]")
+		if entry.Source == "" {
+			badge = strings.Repeat(" ", v.sourceWidth+1)
+		}
+	}
+	if !v.ShowingColumns() {
+		return badge + entry.Raw
+	}
+
+	clock := entry.Timestamp
+	if !entry.Time.IsZero() {
+		clock = entry.Time.Format("15:04:05.000")
+	}
+	level := strings.ToUpper(entry.Level)
+	if severity := entry.Severity(); level != "" && severity != LevelNone {
+		level = severity.String()
+	}
+
+	var b strings.Builder
+	b.WriteString(badge)
+	fmt.Fprintf(&b, "%-*s ", timeColumnWidth, truncate(clock, timeColumnWidth))
+	fmt.Fprintf(&b, "%-*s ", levelColumnWidth, truncate(level, levelColumnWidth))
+	if v.traces {
+		fmt.Fprintf(&b, "%-*s ", traceColumnWidth, truncate(entry.TraceID, traceColumnWidth))
+	}
+	b.WriteString(cleanLine(strings.ReplaceAll(entry.Message, "\n", " ")))
+	return b.String()
+}
+
+// Styles of the runes of a rendered line
+const (
+	styleText = iota
+	styleBadge
+	styleMatch
+)
+
+// renderLine renders the rows of a line in a style, its first badge runes in
+// the badge style, with the search matches highlighted
+func (v *Viewer) renderLine(line string, badge int, style, badgeStyle lipgloss.Style) []string {
+	runes := []rune(line)
+
+	// Flag the runes within the badge and within a search match
+	kinds := make([]int, len(runes))
+	for i := range min(badge, len(runes)) {
+		kinds[i] = styleBadge
+	}
+	if v.pattern != nil {
+		for _, loc := range v.pattern.FindAllStringIndex(line, -1) {
+			start := len([]rune(line[:loc[0]]))
+			end := start + len([]rune(line[loc[0]:loc[1]]))
+			for i := start; i < end; i++ {
+				kinds[i] = styleMatch
+			}
+		}
+	}
+
+	styles := [...]lipgloss.Style{styleText: style, styleBadge: badgeStyle, styleMatch: v.styles.Match}
+	var rows []string
+	for offset := 0; offset == 0 || offset < len(runes); offset += v.width {
+		end := min(offset+v.width, len(runes))
+		rows = append(rows, renderRow(runes[offset:end], kinds[offset:end], styles[:]))
+		if !v.wrap {
+			break
+		}
+	}
+	return rows
+}
+
+// renderRow renders a row, switching styles where the kinds of runes change
+func renderRow(runes []rune, kinds []int, styles []lipgloss.Style) string {
+	var b strings.Builder
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && kinds[end] == kinds[start] {
+			end++
+		}
+		b.WriteString(styles[kinds[start]].Render(string(runes[start:end])))
+		start = end
+	}
+	return b.String()
+}
+
+// sourceName returns the name of the stream of a line shown next to it,
+// followed by its container when asked to
+func (v *Viewer) sourceName(entry Entry) string {
+	if v.containers && entry.Source != "" && entry.Container != "" {
+		return entry.Source + "/" + entry.Container
+	}
+	return entry.Source
+}
+
+// rowCount returns the number of rows a line takes
+func (v *Viewer) rowCount(entry Entry) int {
+	if !v.wrap {
+		return 1
+	}
+	return max(1, (len([]rune(v.text(entry)))+v.width-1)/v.width)
+}
+
+// levelStyle returns the style of lines of a level
+func (v *Viewer) levelStyle(level Level) lipgloss.Style {
+	switch level {
+	case LevelDebug:
+		return v.styles.Debug
+	case LevelWarn:
+		return v.styles.Warn
+	case LevelError:
+		return v.styles.Error
+	default:
+		return v.styles.Info
+	}
+}
+
+// truncate cuts text to at most width runes
+func truncate(text string, width int) string {
+	runes := []rune(text)
+	if len(runes) <= width {
+		return text
+	}
+	return string(runes[:width])
+}
+
+// cleanLine removes escape sequences and expands tabs so that lines render
+// with a predictable width
+func cleanLine(line string) string {
+	line = ansiPattern.ReplaceAllString(line, "")
+	line = strings.TrimRight(line, "\r\n")
+	return strings.ReplaceAll(line, "\t", "    ")
+}
@@ -0,0 +1,332 @@
+package logview
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// plainViewer creates a viewer without styling, so its view can be compared as text
+func plainViewer(capacity, width, height int) *Viewer {
+	v := NewViewer(capacity)
+	v.SetStyles(Styles{})
+	v.SetSize(width, height)
+	return v
+}
+
+// numberedLines generates lines "line 0" to "line n-1" starting at from
+func numberedLines(from, n int) []string {
+	lines := make([]string, n)
+	for i := range lines {
+		lines[i] = fmt.Sprintf("line %d", from+i)
+	}
+	return lines
+}
+
+func TestBufferDropsOldestLines(t *testing.T) {
+	b := NewBuffer[string](3)
+	if dropped := b.Append("a", "b"); dropped != 0 {
+		t.Errorf("Expected no dropped lines, got %d", dropped)
+	}
+	if dropped := b.Append("c", "d", "e"); dropped != 2 {
+		t.Errorf("Expected 2 dropped lines, got %d", dropped)
+	}
+
+	if got := strings.Join(b.Lines(), ","); got != "c,d,e" {
+		t.Errorf("Expected the newest lines, got %s", got)
+	}
+	if b.Len() != 3 || b.Dropped() != 2 {
+		t.Errorf("Expected 3 lines and 2 dropped, got %d and %d", b.Len(), b.Dropped())
+	}
+
+	b.Clear()
+	if b.Len() != 0 || b.Dropped() != 0 {
+		t.Errorf("Expected an empty buffer after clearing")
+	}
+}
+
+func TestBufferInsert(t *testing.T) {
+	b := NewBuffer[string](4)
+	b.Append("a", "c")
+	b.Insert(1, "b")
+	b.Insert(9, "d")
+	if got := strings.Join(b.Lines(), ","); got != "a,b,c,d" {
+		t.Errorf("Expected the lines in order, got %s", got)
+	}
+
+	// Inserting into a full buffer drops the oldest line
+	if dropped := b.Insert(2, "x"); dropped != 1 {
+		t.Errorf("Expected 1 dropped line, got %d", dropped)
+	}
+	if got := strings.Join(b.Lines(), ","); got != "b,x,c,d" {
+		t.Errorf("Expected the oldest line dropped, got %s", got)
+	}
+	if dropped := b.Insert(0, "y"); dropped != 1 || b.Line(0) != "b" {
+		t.Errorf("Expected a line older than every other to be dropped")
+	}
+}
+
+func TestDetectLevel(t *testing.T) {
+	tests := map[string]Level{
+		"2024-01-20T10:00:00Z ERROR failed to connect": LevelError,
+		"[WARN] High memory usage":                     LevelWarn,
+		"level=info msg=started":                       LevelInfo,
+		"DEBUG processing batch":                       LevelDebug,
+		"panic: runtime error":                         LevelError,
+		"Listening on port 8080":                       LevelNone,
+		"errors=0 information":                         LevelNone, // keywords only match whole words
+	}
+	for line, want := range tests {
+		if got := DetectLevel(line); got != want {
+			t.Errorf("DetectLevel(%q) = %d, want %d", line, got, want)
+		}
+	}
+}
+
+func TestViewerFollowsNewLines(t *testing.T) {
+	v := plainViewer(100, 40, 3)
+	v.Append(numberedLines(0, 10)...)
+
+	if got := v.View(); got != "line 7\nline 8\nline 9" {
+		t.Errorf("Expected the newest lines, got %q", got)
+	}
+
+	// Scrolling up stops following, so new lines do not move the view
+	v.ScrollUp(2)
+	v.Append(numberedLines(10, 5)...)
+	if v.Following() {
+		t.Error("Scrolling up should stop following")
+	}
+	if got := v.View(); got != "line 5\nline 6\nline 7" {
+		t.Errorf("Expected the view to stay in place, got %q", got)
+	}
+
+	v.GotoBottom()
+	if got := v.View(); got != "line 12\nline 13\nline 14" {
+		t.Errorf("Expected the newest lines after going to the bottom, got %q", got)
+	}
+}
+
+func TestViewerKeepsBoundedLines(t *testing.T) {
+	v := plainViewer(5, 40, 3)
+	v.GotoTop()
+	v.Append(numberedLines(0, 12)...)
+
+	if v.Len() != 5 || v.Dropped() != 7 {
+		t.Errorf("Expected 5 lines and 7 dropped, got %d and %d", v.Len(), v.Dropped())
+	}
+	// The view moves to the oldest line still kept
+	if got := v.View(); got != "line 7\nline 8\nline 9" {
+		t.Errorf("Expected the oldest lines kept, got %q", got)
+	}
+}
+
+func TestViewerPause(t *testing.T) {
+	v := plainViewer(100, 40, 2)
+	v.Append("first")
+	v.SetPaused(true)
+	v.Append("second", "third")
+
+	if v.Pending() != 2 || v.Len() != 1 {
+		t.Errorf("Expected 2 pending lines and 1 shown, got %d and %d", v.Pending(), v.Len())
+	}
+	if got := v.View(); got != "first" {
+		t.Errorf("Expected paused lines to be held back, got %q", got)
+	}
+
+	v.SetPaused(false)
+	if v.Pending() != 0 || v.Len() != 3 {
+		t.Errorf("Expected the held back lines after resuming, got %d pending and %d shown", v.Pending(), v.Len())
+	}
+	if got := v.View(); got != "second\nthird" {
+		t.Errorf("Expected the newest lines after resuming, got %q", got)
+	}
+}
+
+func TestViewerWrap(t *testing.T) {
+	v := plainViewer(100, 4, 3)
+	v.Append("abcdefghij", "xy")
+
+	if got := v.View(); got != "abcd\nxy" {
+		t.Errorf("Expected cut lines, got %q", got)
+	}
+
+	v.ToggleWrap()
+	if got := v.View(); got != "efgh\nij\nxy" {
+		t.Errorf("Expected the bottom rows of wrapped lines, got %q", got)
+	}
+}
+
+func TestViewerSearch(t *testing.T) {
+	v := plainViewer(100, 40, 1)
+	v.Append("INFO started", "ERROR timeout", "INFO ready", "error: refused")
+
+	count, err := v.Search("error")
+	if err != nil || count != 2 {
+		t.Fatalf("Expected 2 case insensitive matches, got %d (%v)", count, err)
+	}
+	// The search starts at the newest match
+	if current, total := v.MatchPosition(); current != 2 || total != 2 {
+		t.Errorf("Expected match 2/2, got %d/%d", current, total)
+	}
+	if got := v.View(); got != "error: refused" {
+		t.Errorf("Expected the newest match, got %q", got)
+	}
+
+	v.PrevMatch()
+	if got := v.View(); got != "ERROR timeout" {
+		t.Errorf("Expected the older match, got %q", got)
+	}
+
+	// Upper case letters make the search case sensitive
+	if count, _ := v.Search("ERROR"); count != 1 {
+		t.Errorf("Expected 1 case sensitive match, got %d", count)
+	}
+
+	if _, err := v.Search("("); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+}
+
+func TestViewerHighlightsMatches(t *testing.T) {
+	v := NewViewer(100)
+	v.SetStyles(Styles{Match: lipgloss.NewStyle().Bold(true)})
+	v.SetSize(40, 1)
+	v.Append("request timeout\tafter 30s")
+	v.Search("time")
+
+	// Only the match is styled, with tabs expanded
+	want := "request " + lipgloss.NewStyle().Bold(true).Render("time") + "out    after 30s"
+	if got := v.View(); got != want {
+		t.Errorf("Expected the match highlighted, got %q", got)
+	}
+}
+
+func TestViewerColumns(t *testing.T) {
+	v := plainViewer(100, 120, 4)
+	v.Append("plain startup line")
+	if v.ShowingColumns() {
+		t.Error("Columns should only be shown once structured lines are received")
+	}
+
+	v.Append(`{"time":"2024-01-20T10:00:01.5Z","level":"error","msg":"request failed","traceId":"abcdef123456"}`)
+	want := "TIME         LEVEL TRACE    MESSAGE\n" +
+		strings.Repeat(" ", 28) + "plain startup line\n" +
+		"10:00:01.500 ERROR abcdef12 request failed"
+	if got := v.View(); got != want {
+		t.Errorf("Expected columns, got %q", got)
+	}
+
+	v.ToggleColumns()
+	if got := v.View(); got != `plain startup line`+"\n"+`{"time":"2024-01-20T10:00:01.5Z","level":"error","msg":"request failed","traceId":"abcdef123456"}` {
+		t.Errorf("Expected raw lines with columns off, got %q", got)
+	}
+}
+
+func TestViewerFilter(t *testing.T) {
+	v := plainViewer(100, 60, 2)
+	v.SetColumns(false)
+	v.Append(`{"level":"info","msg":"one"}`, `{"level":"error","msg":"two"}`, `{"level":"info","msg":"three"}`)
+
+	filter, _ := ParseQuery("level=info")
+	v.SetFilter(filter)
+	if v.Visible() != 2 || v.Filter() != "level=info" {
+		t.Errorf("Expected 2 visible lines, got %d", v.Visible())
+	}
+
+	// New lines are filtered as they arrive, and the search only counts visible lines
+	v.Append(`{"level":"error","msg":"four"}`, `{"level":"info","msg":"five"}`)
+	if got := v.View(); got != `{"level":"info","msg":"three"}`+"\n"+`{"level":"info","msg":"five"}` {
+		t.Errorf("Expected only info lines, got %q", got)
+	}
+	if count, _ := v.Search("msg"); count != 3 {
+		t.Errorf("Expected 3 matches among visible lines, got %d", count)
+	}
+
+	v.SetFilter(nil)
+	if v.Visible() != 5 {
+		t.Errorf("Expected every line after clearing the filter, got %d", v.Visible())
+	}
+	if _, total := v.MatchPosition(); total != 5 {
+		t.Errorf("Expected the matches recomputed, got %d", total)
+	}
+}
+
+func TestViewerFilterKeepsBoundedLines(t *testing.T) {
+	v := plainViewer(4, 40, 10)
+	filter, _ := ParseQuery("line=odd")
+	v.SetFilter(filter)
+	for i := 0; i < 10; i++ {
+		kind := "even"
+		if i%2 == 1 {
+			kind = "odd"
+		}
+		v.Append(fmt.Sprintf(`{"line":%q,"msg":"%d"}`, kind, i))
+	}
+
+	// Only lines 6 to 9 are kept, of which 7 and 9 pass the filter
+	if v.Visible() != 2 || v.Position() != 1 {
+		t.Errorf("Expected 2 visible lines, got %d at %d", v.Visible(), v.Position())
+	}
+}
+
+func TestViewerInterleavesSources(t *testing.T) {
+	v := plainViewer(100, 120, 5)
+	v.SetSorted(true)
+	v.SetColumns(false)
+	v.AppendFrom("console", "2024-01-20T10:00:01Z started", "2024-01-20T10:00:03Z ready")
+	v.AppendFrom("system", `{"TimeStamp":"2024-01-20T10:00:02Z","Type":"Warning","Reason":"ProbeFailed","Msg":"probe failed"}`)
+
+	want := "[console] 2024-01-20T10:00:01Z started\n" +
+		`[system]  {"TimeStamp":"2024-01-20T10:00:02Z","Type":"Warning","Reason":"ProbeFailed","Msg":"probe failed"}` + "\n" +
+		"[console] 2024-01-20T10:00:03Z ready"
+	if got := v.View(); got != want {
+		t.Errorf("Expected the lines in order of time, got %q", got)
+	}
+
+	v.SetColumns(true)
+	if got := strings.Split(v.View(), "\n")[2]; got != "[system]  10:00:02.000 WARN  probe failed" {
+		t.Errorf("Expected the system event in columns, got %q", got)
+	}
+}
+
+func TestViewerMutesSources(t *testing.T) {
+	v := plainViewer(100, 120, 5)
+	v.SetColumns(false)
+	v.SetSourceContainers(true)
+	v.AppendFrom("web/web--v1", `{"TimeStamp":"2024-01-20T10:00:01Z","Log":"started","ContainerName":"api"}`)
+	v.AppendFrom("worker/worker--v3", "plain line")
+
+	want := `[web/web--v1/api]   {"TimeStamp":"2024-01-20T10:00:01Z","Log":"started","ContainerName":"api"}` + "\n" +
+		"[worker/worker--v3] plain line"
+	if got := v.View(); got != want {
+		t.Errorf("Expected stream names with containers, got %q", got)
+	}
+
+	// Muted streams keep their lines, hidden until unmuted
+	v.SetMuted("web/web--v1", true)
+	v.AppendFrom("web/web--v1", "hidden line")
+	if v.Visible() != 1 || v.Len() != 3 || !v.Muted("web/web--v1") {
+		t.Errorf("Expected 1 visible line of 3, got %d of %d", v.Visible(), v.Len())
+	}
+	v.SetMuted("web/web--v1", false)
+	if v.Visible() != 3 {
+		t.Errorf("Expected every line after unmuting, got %d", v.Visible())
+	}
+}
+
+func TestViewerSourceColors(t *testing.T) {
+	red := lipgloss.NewStyle().Bold(true)
+	v := NewViewer(100)
+	v.SetStyles(Styles{Sources: []lipgloss.Style{lipgloss.NewStyle(), red}})
+	v.SetSize(40, 1)
+
+	// Registered streams keep their colors whichever sends lines first
+	v.RegisterSources("a", "b")
+	v.AppendFrom("b", "line")
+	if got, want := v.View(), red.Render("[b]")+" line"; got != want {
+		t.Errorf("Expected the stream name in its color, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+// Package sparkline renders series of values as one-line bar charts.
+package sparkline
+
+import "strings"
+
+// bars are the block characters of increasing height a sparkline is drawn with
+var bars = []rune("▁▂▃▄▅▆▇█")
+
+// Render draws values as a sparkline of at most width characters, scaled from
+// the lowest to the highest value. Series longer than width are resampled by
+// averaging consecutive values, so that every character covers the same span.
+func Render(values []float64, width int) string {
+	if len(values) == 0 || width <= 0 {
+		return ""
+	}
+	values = resample(values, width)
+
+	lowest, highest := values[0], values[0]
+	for _, v := range values {
+		lowest = min(lowest, v)
+		highest = max(highest, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if highest > lowest {
+			level = int((v - lowest) / (highest - lowest) * float64(len(bars)-1))
+		} else if highest > 0 {
+			// A constant non-zero series is drawn half high, to tell it apart from no activity
+			level = len(bars) / 2
+		}
+		b.WriteRune(bars[level])
+	}
+	return b.String()
+}
+
+// resample reduces values to at most width values by averaging buckets
+func resample(values []float64, width int) []float64 {
+	if len(values) <= width {
+		return values
+	}
+
+	resampled := make([]float64, width)
+	for i := range resampled {
+		start := i * len(values) / width
+		end := (i + 1) * len(values) / width
+		sum := 0.0
+		for _, v := range values[start:end] {
+			sum += v
+		}
+		resampled[i] = sum / float64(end-start)
+	}
+	return resampled
+}
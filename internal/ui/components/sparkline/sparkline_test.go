@@ -0,0 +1,39 @@
+package sparkline
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestRender(t *testing.T) {
+	if got := Render([]float64{0, 1, 2, 3, 4, 5, 6, 7}, 10); got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("Expected a rising sparkline, got %q", got)
+	}
+
+	if got := Render(nil, 10); got != "" {
+		t.Errorf("Expected no sparkline without values, got %q", got)
+	}
+
+	// Flat series tell no activity apart from a steady load
+	if got := Render([]float64{0, 0, 0}, 10); got != "▁▁▁" {
+		t.Errorf("Expected a flat idle sparkline, got %q", got)
+	}
+	if got := Render([]float64{3, 3, 3}, 10); got != "▅▅▅" {
+		t.Errorf("Expected a flat busy sparkline, got %q", got)
+	}
+}
+
+func TestRenderResamples(t *testing.T) {
+	values := make([]float64, 24)
+	for i := range values {
+		values[i] = float64(i / 3) // 0,0,0,1,1,1,...
+	}
+
+	got := Render(values, 8)
+	if n := utf8.RuneCountInString(got); n != 8 {
+		t.Fatalf("Expected 8 characters, got %d in %q", n, got)
+	}
+	if got != "▁▂▃▄▅▆▇█" {
+		t.Errorf("Expected averaged buckets to rise evenly, got %q", got)
+	}
+}
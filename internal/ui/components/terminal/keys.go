@@ -0,0 +1,87 @@
+package terminal
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keySequences are the sequences an xterm sends for special keys. Cursor
+// keys are listed in normal mode, application mode replaces "\x1b[" by "\x1bO".
+var keySequences = map[tea.KeyType]string{
+	tea.KeyUp:             "\x1b[A",
+	tea.KeyDown:           "\x1b[B",
+	tea.KeyRight:          "\x1b[C",
+	tea.KeyLeft:           "\x1b[D",
+	tea.KeyHome:           "\x1b[H",
+	tea.KeyEnd:            "\x1b[F",
+	tea.KeyShiftTab:       "\x1b[Z",
+	tea.KeyPgUp:           "\x1b[5~",
+	tea.KeyPgDown:         "\x1b[6~",
+	tea.KeyDelete:         "\x1b[3~",
+	tea.KeyInsert:         "\x1b[2~",
+	tea.KeySpace:          " ",
+	tea.KeyCtrlUp:         "\x1b[1;5A",
+	tea.KeyCtrlDown:       "\x1b[1;5B",
+	tea.KeyCtrlRight:      "\x1b[1;5C",
+	tea.KeyCtrlLeft:       "\x1b[1;5D",
+	tea.KeyCtrlHome:       "\x1b[1;5H",
+	tea.KeyCtrlEnd:        "\x1b[1;5F",
+	tea.KeyShiftUp:        "\x1b[1;2A",
+	tea.KeyShiftDown:      "\x1b[1;2B",
+	tea.KeyShiftRight:     "\x1b[1;2C",
+	tea.KeyShiftLeft:      "\x1b[1;2D",
+	tea.KeyShiftHome:      "\x1b[1;2H",
+	tea.KeyShiftEnd:       "\x1b[1;2F",
+	tea.KeyCtrlPgUp:       "\x1b[5;5~",
+	tea.KeyCtrlPgDown:     "\x1b[6;5~",
+	tea.KeyF1:             "\x1bOP",
+	tea.KeyF2:             "\x1bOQ",
+	tea.KeyF3:             "\x1bOR",
+	tea.KeyF4:             "\x1bOS",
+	tea.KeyF5:             "\x1b[15~",
+	tea.KeyF6:             "\x1b[17~",
+	tea.KeyF7:             "\x1b[18~",
+	tea.KeyF8:             "\x1b[19~",
+	tea.KeyF9:             "\x1b[20~",
+	tea.KeyF10:            "\x1b[21~",
+	tea.KeyF11:            "\x1b[23~",
+	tea.KeyF12:            "\x1b[24~",
+	tea.KeyCtrlShiftUp:    "\x1b[1;6A",
+	tea.KeyCtrlShiftDown:  "\x1b[1;6B",
+	tea.KeyCtrlShiftRight: "\x1b[1;6C",
+	tea.KeyCtrlShiftLeft:  "\x1b[1;6D",
+}
+
+// cursorKeys are the keys whose sequence depends on the cursor key mode
+var cursorKeys = map[tea.KeyType]bool{
+	tea.KeyUp: true, tea.KeyDown: true, tea.KeyRight: true, tea.KeyLeft: true,
+	tea.KeyHome: true, tea.KeyEnd: true,
+}
+
+// KeyBytes returns what a terminal sends to the program for a key press.
+// appCursor selects application cursor keys and bracketedPaste wraps pastes
+// as the program asked for. Keys with no known sequence return nil.
+func KeyBytes(msg tea.KeyMsg, appCursor, bracketedPaste bool) []byte {
+	var seq string
+	switch {
+	case msg.Type == tea.KeyRunes:
+		seq = string(msg.Runes)
+		if msg.Paste && bracketedPaste {
+			seq = "\x1b[200~" + seq + "\x1b[201~"
+		}
+	case msg.Type >= 0 && msg.Type <= 127:
+		// Control characters, including enter, tab, backspace and escape
+		seq = string(rune(msg.Type))
+	default:
+		var ok bool
+		if seq, ok = keySequences[msg.Type]; !ok {
+			return nil
+		}
+		if appCursor && cursorKeys[msg.Type] {
+			seq = "\x1bO" + seq[2:]
+		}
+	}
+	if msg.Alt {
+		seq = "\x1b" + seq
+	}
+	return []byte(seq)
+}
@@ -0,0 +1,27 @@
+package terminal
+
+import (
+	"errors"
+	"os"
+)
+
+// ErrUnsupported is returned when sessions cannot be started because the
+// platform has no pseudo-terminals
+var ErrUnsupported = errors.New("embedded terminals are not supported on this platform")
+
+// pty is the controlling side of a pseudo-terminal a command runs in
+type pty struct {
+	master *os.File
+}
+
+func (p *pty) Read(b []byte) (int, error) {
+	return p.master.Read(b)
+}
+
+func (p *pty) Write(b []byte) (int, error) {
+	return p.master.Write(b)
+}
+
+func (p *pty) Close() error {
+	return p.master.Close()
+}
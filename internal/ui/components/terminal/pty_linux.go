@@ -0,0 +1,28 @@
+//go:build linux
+
+package terminal
+
+import (
+	"fmt"
+
+	"golang.org/x/sys/unix"
+)
+
+// openPty opens a new pseudo-terminal, returning the file descriptor of the
+// controlling side and the path of the terminal
+func openPty() (int, string, error) {
+	fd, err := unix.Open("/dev/ptmx", unix.O_RDWR|unix.O_NOCTTY|unix.O_CLOEXEC, 0)
+	if err != nil {
+		return -1, "", err
+	}
+	if err := unix.IoctlSetPointerInt(fd, unix.TIOCSPTLCK, 0); err != nil {
+		unix.Close(fd)
+		return -1, "", err
+	}
+	n, err := unix.IoctlGetUint32(fd, unix.TIOCGPTN)
+	if err != nil {
+		unix.Close(fd)
+		return -1, "", err
+	}
+	return fd, fmt.Sprintf("/dev/pts/%d", n), nil
+}
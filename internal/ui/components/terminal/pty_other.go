@@ -0,0 +1,14 @@
+//go:build !linux && !darwin
+
+package terminal
+
+import "os/exec"
+
+func startPty(cmd *exec.Cmd, cols, rows int) (*pty, error) {
+	return nil, ErrUnsupported
+}
+
+// Resize does nothing, no pseudo-terminal is ever started
+func (p *pty) Resize(cols, rows int) error {
+	return nil
+}
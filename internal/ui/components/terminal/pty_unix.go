@@ -0,0 +1,56 @@
+//go:build linux || darwin
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"golang.org/x/sys/unix"
+)
+
+// startPty starts a command in a new session whose controlling terminal is a
+// pseudo-terminal of the given size, returning the controlling side
+func startPty(cmd *exec.Cmd, cols, rows int) (*pty, error) {
+	fd, name, err := openPty()
+	if err != nil {
+		return nil, fmt.Errorf("open pseudo-terminal: %w", err)
+	}
+	// A non-blocking master goes through the runtime poller, so closing it
+	// interrupts a pending read
+	if err := unix.SetNonblock(fd, true); err != nil {
+		unix.Close(fd)
+		return nil, err
+	}
+	p := &pty{master: os.NewFile(uintptr(fd), "/dev/ptmx")}
+
+	tty, err := os.OpenFile(name, os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		p.Close()
+		return nil, fmt.Errorf("open %s: %w", name, err)
+	}
+	defer tty.Close()
+
+	if err := p.Resize(cols, rows); err != nil {
+		p.Close()
+		return nil, err
+	}
+
+	cmd.Stdin, cmd.Stdout, cmd.Stderr = tty, tty, tty
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setsid: true, Setctty: true}
+	if err := cmd.Start(); err != nil {
+		p.Close()
+		return nil, err
+	}
+	return p, nil
+}
+
+// Resize sets the size of the terminal, which signals the command with SIGWINCH
+func (p *pty) Resize(cols, rows int) error {
+	return unix.IoctlSetWinsize(int(p.master.Fd()), unix.TIOCSWINSZ, &unix.Winsize{
+		Col: uint16(cols),
+		Row: uint16(rows),
+	})
+}
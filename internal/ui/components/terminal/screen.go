@@ -0,0 +1,666 @@
+package terminal
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/mattn/go-runewidth"
+)
+
+// Cell attributes set by SGR sequences
+const (
+	attrBold uint8 = 1 << iota
+	attrFaint
+	attrItalic
+	attrUnderline
+	attrBlink
+	attrReverse
+	attrStrike
+)
+
+// Kinds of cell colors
+const (
+	colorDefault uint8 = iota
+	colorIndexed
+	colorRGB
+)
+
+// color is the foreground or background color of a cell
+type color struct {
+	kind  uint8
+	value uint32 // palette index, or 0xRRGGBB
+}
+
+// pen is the style a cell is drawn with
+type pen struct {
+	fg, bg color
+	attrs  uint8
+}
+
+// sgr returns the SGR sequence that switches from the default style to the pen
+func (p pen) sgr() string {
+	params := []string{"0"}
+	for _, a := range []struct {
+		attr uint8
+		code string
+	}{
+		{attrBold, "1"}, {attrFaint, "2"}, {attrItalic, "3"}, {attrUnderline, "4"},
+		{attrBlink, "5"}, {attrReverse, "7"}, {attrStrike, "9"},
+	} {
+		if p.attrs&a.attr != 0 {
+			params = append(params, a.code)
+		}
+	}
+	params = appendColor(params, p.fg, 30)
+	params = appendColor(params, p.bg, 40)
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// appendColor appends the SGR parameters of a color, base being 30 for the
+// foreground and 40 for the background
+func appendColor(params []string, c color, base int) []string {
+	switch c.kind {
+	case colorIndexed:
+		switch {
+		case c.value < 8:
+			return append(params, strconv.Itoa(base+int(c.value)))
+		case c.value < 16:
+			return append(params, strconv.Itoa(base+60+int(c.value)-8))
+		default:
+			return append(params, strconv.Itoa(base+8), "5", strconv.Itoa(int(c.value)))
+		}
+	case colorRGB:
+		return append(params, strconv.Itoa(base+8), "2",
+			strconv.Itoa(int(c.value>>16&0xff)), strconv.Itoa(int(c.value>>8&0xff)), strconv.Itoa(int(c.value&0xff)))
+	}
+	return params
+}
+
+// cell is a character on the screen. The second column of a wide character
+// holds a cell with no rune.
+type cell struct {
+	r rune
+	p pen
+}
+
+// cursor is a cursor position with the pen it draws with, as saved by DECSC
+type cursor struct {
+	x, y int
+	pen  pen
+}
+
+// Screen emulates the screen of a VT100/xterm compatible terminal: the output
+// of a program is written to it and View renders what the terminal would show.
+// It covers what shells and common full-screen programs use: cursor movement,
+// erasing, scroll regions, colors and attributes, and the alternate screen.
+type Screen struct {
+	cols, rows int
+	lines      [][]cell
+
+	// main holds the lines of the main screen while the alternate screen is shown
+	main [][]cell
+	alt  bool
+
+	x, y     int
+	wrapNext bool // the last column was written, the next character wraps
+	pen      pen
+	saved    cursor
+	top, bot int // scroll region, inclusive
+
+	cursorHidden bool
+	appCursor    bool // cursor keys send application sequences (DECCKM)
+	bracketPaste bool // pastes are bracketed (mode 2004)
+
+	title string
+
+	// replies are answers to terminal queries, to be written back to the program
+	replies []byte
+
+	parser *ansi.Parser
+}
+
+// NewScreen creates an empty screen of the given size
+func NewScreen(cols, rows int) *Screen {
+	s := &Screen{}
+	s.parser = ansi.NewParser()
+	s.parser.SetHandler(ansi.Handler{
+		Print:     s.print,
+		Execute:   s.execute,
+		HandleCsi: s.handleCsi,
+		HandleEsc: s.handleEsc,
+		HandleOsc: s.handleOsc,
+	})
+	s.Resize(cols, rows)
+	return s
+}
+
+// Size returns the number of columns and rows of the screen
+func (s *Screen) Size() (int, int) {
+	return s.cols, s.rows
+}
+
+// Title returns the window title last set by the program, if any
+func (s *Screen) Title() string {
+	return s.title
+}
+
+// AppCursorKeys reports whether the program asked for application cursor keys
+func (s *Screen) AppCursorKeys() bool {
+	return s.appCursor
+}
+
+// BracketedPaste reports whether the program asked for bracketed pastes
+func (s *Screen) BracketedPaste() bool {
+	return s.bracketPaste
+}
+
+// Write feeds program output to the screen. Escape sequences may be split
+// across writes.
+func (s *Screen) Write(b []byte) (int, error) {
+	s.parser.Parse(b)
+	return len(b), nil
+}
+
+// Replies returns and clears the answers to terminal queries, such as cursor
+// position reports, that have to be sent back to the program
+func (s *Screen) Replies() []byte {
+	replies := s.replies
+	s.replies = nil
+	return replies
+}
+
+// Resize changes the size of the screen, keeping the lines around the cursor
+func (s *Screen) Resize(cols, rows int) {
+	cols, rows = max(cols, 1), max(rows, 1)
+	if cols == s.cols && rows == s.rows {
+		return
+	}
+	// Drop lines from the top when the cursor would end up below the screen
+	shift := max(s.y-rows+1, 0)
+	s.lines = resizeLines(s.lines, cols, rows, shift)
+	if s.main != nil {
+		s.main = resizeLines(s.main, cols, rows, 0)
+	}
+	s.cols, s.rows = cols, rows
+	s.x, s.y = min(s.x, cols-1), s.y-shift
+	s.saved.x, s.saved.y = min(s.saved.x, cols-1), min(s.saved.y, rows-1)
+	s.top, s.bot = 0, rows-1
+	s.wrapNext = false
+}
+
+// resizeLines copies lines into a grid of the given size, skipping the first shift lines
+func resizeLines(lines [][]cell, cols, rows, shift int) [][]cell {
+	resized := make([][]cell, rows)
+	for y := range resized {
+		resized[y] = blankLine(cols, pen{})
+		if y+shift < len(lines) {
+			copy(resized[y], lines[y+shift])
+		}
+	}
+	return resized
+}
+
+// blankLine returns an empty line erased with the background of a pen
+func blankLine(cols int, p pen) []cell {
+	line := make([]cell, cols)
+	for x := range line {
+		line[x] = blankCell(p)
+	}
+	return line
+}
+
+// blankCell returns an erased cell, which keeps the background color of the pen
+func blankCell(p pen) cell {
+	return cell{r: ' ', p: pen{bg: p.bg}}
+}
+
+// View renders the screen, with the cursor shown when it is visible and showCursor is set
+func (s *Screen) View(showCursor bool) string {
+	var b strings.Builder
+	for y, line := range s.lines {
+		if y > 0 {
+			b.WriteByte('\n')
+		}
+		current := pen{}
+		for x, c := range line {
+			if c.r == 0 {
+				continue // second column of a wide character
+			}
+			p := c.p
+			if showCursor && !s.cursorHidden && x == s.x && y == s.y {
+				p.attrs ^= attrReverse
+			}
+			if p != current {
+				b.WriteString(p.sgr())
+				current = p
+			}
+			b.WriteRune(c.r)
+		}
+		if current != (pen{}) {
+			b.WriteString("\x1b[0m")
+		}
+	}
+	return b.String()
+}
+
+// String returns the text on the screen without styles, trailing spaces trimmed
+func (s *Screen) String() string {
+	rows := make([]string, len(s.lines))
+	for y, line := range s.lines {
+		var b strings.Builder
+		for _, c := range line {
+			if c.r != 0 {
+				b.WriteRune(c.r)
+			}
+		}
+		rows[y] = strings.TrimRight(b.String(), " ")
+	}
+	return strings.TrimRight(strings.Join(rows, "\n"), "\n")
+}
+
+// Cursor returns the position of the cursor
+func (s *Screen) Cursor() (x, y int) {
+	return s.x, s.y
+}
+
+// print writes a character at the cursor
+func (s *Screen) print(r rune) {
+	width := runewidth.RuneWidth(r)
+	if width == 0 {
+		return // combining characters are not supported
+	}
+	if s.wrapNext || s.x+width > s.cols {
+		s.x = 0
+		s.lineFeed()
+	}
+	s.wrapNext = false
+	line := s.lines[s.y]
+	line[s.x] = cell{r: r, p: s.pen}
+	if width == 2 && s.x+1 < s.cols {
+		line[s.x+1] = cell{p: s.pen}
+	}
+	s.x += width
+	if s.x >= s.cols {
+		s.x = s.cols - 1
+		s.wrapNext = true
+	}
+}
+
+// execute handles a control character
+func (s *Screen) execute(b byte) {
+	switch b {
+	case '\b':
+		s.moveTo(s.x-1, s.y)
+	case '\t':
+		s.moveTo((s.x/8+1)*8, s.y)
+	case '\n', '\v', '\f':
+		s.lineFeed()
+	case '\r':
+		s.moveTo(0, s.y)
+	}
+}
+
+// lineFeed moves the cursor down a line, scrolling at the bottom of the scroll region
+func (s *Screen) lineFeed() {
+	s.wrapNext = false
+	switch {
+	case s.y == s.bot:
+		s.scrollUp(1)
+	case s.y < s.rows-1:
+		s.y++
+	}
+}
+
+// reverseIndex moves the cursor up a line, scrolling at the top of the scroll region
+func (s *Screen) reverseIndex() {
+	s.wrapNext = false
+	switch {
+	case s.y == s.top:
+		s.scrollDown(1)
+	case s.y > 0:
+		s.y--
+	}
+}
+
+// scrollUp scrolls the scroll region up by n lines
+func (s *Screen) scrollUp(n int) {
+	s.deleteLines(s.top, n)
+}
+
+// scrollDown scrolls the scroll region down by n lines
+func (s *Screen) scrollDown(n int) {
+	s.insertLines(s.top, n)
+}
+
+// deleteLines deletes n lines at row y, pulling up the rest of the scroll region
+func (s *Screen) deleteLines(y, n int) {
+	if y < s.top || y > s.bot {
+		return
+	}
+	n = min(n, s.bot-y+1)
+	region := s.lines[y : s.bot+1]
+	copy(region, region[n:])
+	for i := len(region) - n; i < len(region); i++ {
+		region[i] = blankLine(s.cols, s.pen)
+	}
+}
+
+// insertLines inserts n blank lines at row y, pushing down the rest of the scroll region
+func (s *Screen) insertLines(y, n int) {
+	if y < s.top || y > s.bot {
+		return
+	}
+	n = min(n, s.bot-y+1)
+	region := s.lines[y : s.bot+1]
+	copy(region[n:], region)
+	for i := 0; i < n; i++ {
+		region[i] = blankLine(s.cols, s.pen)
+	}
+}
+
+// moveTo moves the cursor, keeping it on the screen
+func (s *Screen) moveTo(x, y int) {
+	s.x = min(max(x, 0), s.cols-1)
+	s.y = min(max(y, 0), s.rows-1)
+	s.wrapNext = false
+}
+
+// eraseCells erases the cells from x0 up to x1 excluded on row y
+func (s *Screen) eraseCells(y, x0, x1 int) {
+	line := s.lines[y]
+	for x := max(x0, 0); x < min(x1, s.cols); x++ {
+		line[x] = blankCell(s.pen)
+	}
+}
+
+// handleEsc handles an escape sequence
+func (s *Screen) handleEsc(cmd ansi.Cmd) {
+	if cmd.Intermediate() != 0 {
+		return // character set designations
+	}
+	switch cmd.Final() {
+	case '7':
+		s.saveCursor()
+	case '8':
+		s.restoreCursor()
+	case 'D':
+		s.lineFeed()
+	case 'E':
+		s.x = 0
+		s.lineFeed()
+	case 'M':
+		s.reverseIndex()
+	case 'c':
+		s.reset()
+	}
+}
+
+// handleOsc handles an operating system command, of which only titles are used
+func (s *Screen) handleOsc(cmd int, data []byte) {
+	if cmd != 0 && cmd != 2 {
+		return
+	}
+	// data holds the whole command, "2;title"
+	if _, title, ok := strings.Cut(string(data), ";"); ok {
+		s.title = title
+	}
+}
+
+// handleCsi handles a control sequence
+func (s *Screen) handleCsi(cmd ansi.Cmd, params ansi.Params) {
+	// count returns parameter i as a repeat count, which is at least 1
+	count := func(i int) int {
+		n, _, _ := params.Param(i, 1)
+		return max(n, 1)
+	}
+	param := func(i, def int) int {
+		n, _, _ := params.Param(i, def)
+		return n
+	}
+
+	switch cmd.Prefix() {
+	case '?':
+		switch cmd.Final() {
+		case 'h', 'l':
+			s.setPrivateModes(params, cmd.Final() == 'h')
+		}
+		return
+	case 0:
+	default:
+		return
+	}
+	if cmd.Intermediate() != 0 {
+		return
+	}
+
+	switch cmd.Final() {
+	case 'A':
+		s.moveTo(s.x, s.y-count(0))
+	case 'B', 'e':
+		s.moveTo(s.x, s.y+count(0))
+	case 'C', 'a':
+		s.moveTo(s.x+count(0), s.y)
+	case 'D':
+		s.moveTo(s.x-count(0), s.y)
+	case 'E':
+		s.moveTo(0, s.y+count(0))
+	case 'F':
+		s.moveTo(0, s.y-count(0))
+	case 'G', '`':
+		s.moveTo(count(0)-1, s.y)
+	case 'd':
+		s.moveTo(s.x, count(0)-1)
+	case 'H', 'f':
+		s.moveTo(count(1)-1, count(0)-1)
+	case 'J':
+		s.eraseDisplay(param(0, 0))
+	case 'K':
+		switch param(0, 0) {
+		case 0:
+			s.eraseCells(s.y, s.x, s.cols)
+		case 1:
+			s.eraseCells(s.y, 0, s.x+1)
+		case 2:
+			s.eraseCells(s.y, 0, s.cols)
+		}
+	case 'L':
+		s.insertLines(s.y, count(0))
+	case 'M':
+		s.deleteLines(s.y, count(0))
+	case 'S':
+		s.scrollUp(count(0))
+	case 'T':
+		s.scrollDown(count(0))
+	case '@':
+		line := s.lines[s.y][s.x:]
+		n := min(count(0), len(line))
+		copy(line[n:], line)
+		for x := 0; x < n; x++ {
+			line[x] = blankCell(s.pen)
+		}
+	case 'P':
+		line := s.lines[s.y][s.x:]
+		n := min(count(0), len(line))
+		copy(line, line[n:])
+		for x := len(line) - n; x < len(line); x++ {
+			line[x] = blankCell(s.pen)
+		}
+	case 'X':
+		s.eraseCells(s.y, s.x, s.x+count(0))
+	case 'm':
+		s.setGraphics(params)
+	case 'r':
+		top, bot := count(0)-1, param(1, s.rows)-1
+		if bot <= 0 || bot >= s.rows {
+			bot = s.rows - 1
+		}
+		if top < bot {
+			s.top, s.bot = top, bot
+			s.moveTo(0, 0)
+		}
+	case 's':
+		s.saveCursor()
+	case 'u':
+		s.restoreCursor()
+	case 'n':
+		switch param(0, 0) {
+		case 5:
+			s.replies = append(s.replies, "\x1b[0n"...)
+		case 6:
+			s.replies = append(s.replies, fmt.Sprintf("\x1b[%d;%dR", s.y+1, s.x+1)...)
+		}
+	case 'c':
+		if param(0, 0) == 0 {
+			s.replies = append(s.replies, "\x1b[?62;22c"...)
+		}
+	}
+}
+
+// eraseDisplay erases below the cursor (0), above it (1) or the whole screen (2, 3)
+func (s *Screen) eraseDisplay(mode int) {
+	switch mode {
+	case 0:
+		s.eraseCells(s.y, s.x, s.cols)
+		for y := s.y + 1; y < s.rows; y++ {
+			s.eraseCells(y, 0, s.cols)
+		}
+	case 1:
+		for y := 0; y < s.y; y++ {
+			s.eraseCells(y, 0, s.cols)
+		}
+		s.eraseCells(s.y, 0, s.x+1)
+	case 2, 3:
+		for y := 0; y < s.rows; y++ {
+			s.eraseCells(y, 0, s.cols)
+		}
+	}
+}
+
+// setPrivateModes sets or resets DEC private modes
+func (s *Screen) setPrivateModes(params ansi.Params, set bool) {
+	params.ForEach(0, func(_, mode int, _ bool) {
+		switch mode {
+		case 1:
+			s.appCursor = set
+		case 25:
+			s.cursorHidden = !set
+		case 47, 1047, 1049:
+			if mode == 1049 && set {
+				s.saveCursor()
+			}
+			s.useAltScreen(set)
+			if mode == 1049 && !set {
+				s.restoreCursor()
+			}
+		case 2004:
+			s.bracketPaste = set
+		}
+	})
+}
+
+// useAltScreen switches to a cleared alternate screen, or back to the main screen
+func (s *Screen) useAltScreen(alt bool) {
+	if alt == s.alt {
+		return
+	}
+	if alt {
+		s.main = s.lines
+		s.lines = resizeLines(nil, s.cols, s.rows, 0)
+	} else {
+		s.lines, s.main = s.main, nil
+	}
+	s.alt = alt
+	s.top, s.bot = 0, s.rows-1
+}
+
+func (s *Screen) saveCursor() {
+	s.saved = cursor{x: s.x, y: s.y, pen: s.pen}
+}
+
+func (s *Screen) restoreCursor() {
+	s.moveTo(s.saved.x, s.saved.y)
+	s.pen = s.saved.pen
+}
+
+// reset restores the initial state of the terminal and clears the screen
+func (s *Screen) reset() {
+	cols, rows := s.cols, s.rows
+	parser := s.parser
+	*s = Screen{parser: parser, title: s.title}
+	s.Resize(cols, rows)
+}
+
+// setGraphics applies an SGR sequence to the pen
+func (s *Screen) setGraphics(params ansi.Params) {
+	if len(params) == 0 {
+		s.pen = pen{}
+		return
+	}
+	for i := 0; i < len(params); i++ {
+		n, _, _ := params.Param(i, 0)
+		switch {
+		case n == 0:
+			s.pen = pen{}
+		case n == 1:
+			s.pen.attrs |= attrBold
+		case n == 2:
+			s.pen.attrs |= attrFaint
+		case n == 3:
+			s.pen.attrs |= attrItalic
+		case n == 4:
+			s.pen.attrs |= attrUnderline
+		case n == 5 || n == 6:
+			s.pen.attrs |= attrBlink
+		case n == 7:
+			s.pen.attrs |= attrReverse
+		case n == 9:
+			s.pen.attrs |= attrStrike
+		case n == 22:
+			s.pen.attrs &^= attrBold | attrFaint
+		case n == 23:
+			s.pen.attrs &^= attrItalic
+		case n == 24:
+			s.pen.attrs &^= attrUnderline
+		case n == 25:
+			s.pen.attrs &^= attrBlink
+		case n == 27:
+			s.pen.attrs &^= attrReverse
+		case n == 29:
+			s.pen.attrs &^= attrStrike
+		case n >= 30 && n <= 37:
+			s.pen.fg = color{kind: colorIndexed, value: uint32(n - 30)}
+		case n == 38:
+			s.pen.fg, i = extendedColor(params, i)
+		case n == 39:
+			s.pen.fg = color{}
+		case n >= 40 && n <= 47:
+			s.pen.bg = color{kind: colorIndexed, value: uint32(n - 40)}
+		case n == 48:
+			s.pen.bg, i = extendedColor(params, i)
+		case n == 49:
+			s.pen.bg = color{}
+		case n >= 90 && n <= 97:
+			s.pen.fg = color{kind: colorIndexed, value: uint32(n - 90 + 8)}
+		case n >= 100 && n <= 107:
+			s.pen.bg = color{kind: colorIndexed, value: uint32(n - 100 + 8)}
+		}
+	}
+}
+
+// extendedColor parses the 256 color (38;5;n) or true color (38;2;r;g;b)
+// parameters following parameter i, returning the index of the last one used
+func extendedColor(params ansi.Params, i int) (color, int) {
+	param := func(j int) uint32 {
+		n, _, _ := params.Param(j, 0)
+		return uint32(min(max(n, 0), 255))
+	}
+	switch kind, _, _ := params.Param(i+1, 0); kind {
+	case 5:
+		return color{kind: colorIndexed, value: param(i + 2)}, i + 2
+	case 2:
+		return color{kind: colorRGB, value: param(i+2)<<16 | param(i+3)<<8 | param(i+4)}, i + 4
+	}
+	return color{}, i + 1
+}
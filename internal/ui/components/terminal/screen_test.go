@@ -0,0 +1,171 @@
+package terminal
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// write feeds output to a screen
+func write(s *Screen, output string) {
+	_, _ = s.Write([]byte(output))
+}
+
+func TestScreenPrintsAndWraps(t *testing.T) {
+	s := NewScreen(5, 3)
+	write(s, "hello world\r\nab\tc")
+
+	// The line feed after the wrapped text scrolls the first line out
+	want := " worl\nd\nab  c"
+	if got := s.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+	if x, y := s.Cursor(); x != 4 || y != 2 {
+		t.Errorf("Expected the cursor after the last character, got %d,%d", x, y)
+	}
+}
+
+func TestScreenCursorMovementAndErase(t *testing.T) {
+	s := NewScreen(10, 3)
+	write(s, "1234567890\r\nabcdefghij\r\nABCDEFGHIJ")
+
+	// Erase the end of the middle line and the start of the last one
+	write(s, "\x1b[2;4H\x1b[K\x1b[3;2H\x1b[1K")
+	want := "1234567890\nabc\n  CDEFGHIJ"
+	if got := s.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// Overwrite with relative moves, then clear the screen
+	write(s, "\x1b[H\x1b[2C\x1b[1BX\x1b[2J")
+	if got := s.String(); got != "" {
+		t.Errorf("Expected a cleared screen, got %q", got)
+	}
+	if x, y := s.Cursor(); x != 3 || y != 1 {
+		t.Errorf("Expected clearing to keep the cursor, got %d,%d", x, y)
+	}
+}
+
+func TestScreenScrollRegion(t *testing.T) {
+	s := NewScreen(3, 4)
+	write(s, "a\r\nb\r\nc\r\nd")
+
+	// Scrolling within rows 2-3 keeps the first and last rows
+	write(s, "\x1b[2;3r\x1b[3;1H\nx")
+	want := "a\nc\nx\nd"
+	if got := s.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	// Inserting a line pushes the rest of the region down
+	write(s, "\x1b[2;1H\x1b[L")
+	want = "a\n\nc\nd"
+	if got := s.String(); got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestScreenAltScreen(t *testing.T) {
+	s := NewScreen(10, 2)
+	write(s, "$ vi")
+	write(s, "\x1b[?1049h\x1b[Hediting")
+	if got := s.String(); got != "editing" {
+		t.Errorf("Expected the alternate screen, got %q", got)
+	}
+	write(s, "\x1b[?1049l")
+	if got := s.String(); got != "$ vi" {
+		t.Errorf("Expected the main screen back, got %q", got)
+	}
+	if x, y := s.Cursor(); x != 4 || y != 0 {
+		t.Errorf("Expected the cursor restored, got %d,%d", x, y)
+	}
+}
+
+func TestScreenStyles(t *testing.T) {
+	s := NewScreen(6, 1)
+	// The sequence is split between writes, as it may be when read from a terminal
+	write(s, "\x1b[1;3")
+	write(s, "1mab\x1b[0m\x1b[38;5;200mc\x1b[38;2;1;2;3md")
+
+	view := s.View(false)
+	for _, want := range []string{"\x1b[0;1;31mab", "\x1b[0;38;5;200mc", "\x1b[0;38;2;1;2;3md"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected %q in %q", want, view)
+		}
+	}
+	if !strings.HasSuffix(view, "d\x1b[0m  ") {
+		t.Errorf("Expected the styles reset after the styled text, got %q", view)
+	}
+
+	// The cursor is drawn reversed
+	if view := s.View(true); !strings.Contains(view, "\x1b[0;7m ") {
+		t.Errorf("Expected the cursor in %q", view)
+	}
+	write(s, "\x1b[?25l")
+	if view := s.View(true); strings.Contains(view, "\x1b[0;7m") {
+		t.Errorf("Expected a hidden cursor in %q", view)
+	}
+}
+
+func TestScreenResize(t *testing.T) {
+	s := NewScreen(4, 3)
+	write(s, "a\r\nb\r\nc")
+
+	// Shrinking keeps the line with the cursor
+	s.Resize(2, 2)
+	if got := s.String(); got != "b\nc" {
+		t.Errorf("Expected the bottom lines, got %q", got)
+	}
+	if x, y := s.Cursor(); x != 1 || y != 1 {
+		t.Errorf("Expected the cursor on the last line, got %d,%d", x, y)
+	}
+}
+
+func TestScreenReplies(t *testing.T) {
+	s := NewScreen(10, 5)
+	write(s, "\x1b[3;4H\x1b[6n")
+	if got := string(s.Replies()); got != "\x1b[3;4R" {
+		t.Errorf("Expected a cursor position report, got %q", got)
+	}
+	if got := s.Replies(); len(got) != 0 {
+		t.Errorf("Expected replies to be cleared, got %q", got)
+	}
+}
+
+func TestScreenTitle(t *testing.T) {
+	s := NewScreen(10, 1)
+	write(s, "\x1b]0;root@app: /\a")
+	if got := s.Title(); got != "root@app: /" {
+		t.Errorf("Expected the title, got %q", got)
+	}
+}
+
+func TestKeyBytes(t *testing.T) {
+	tests := []struct {
+		name      string
+		msg       tea.KeyMsg
+		appCursor bool
+		paste     bool
+		want      string
+	}{
+		{"runes", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("ls")}, false, false, "ls"},
+		{"enter", tea.KeyMsg{Type: tea.KeyEnter}, false, false, "\r"},
+		{"ctrl+c", tea.KeyMsg{Type: tea.KeyCtrlC}, false, false, "\x03"},
+		{"backspace", tea.KeyMsg{Type: tea.KeyBackspace}, false, false, "\x7f"},
+		{"space", tea.KeyMsg{Type: tea.KeySpace}, false, false, " "},
+		{"up", tea.KeyMsg{Type: tea.KeyUp}, false, false, "\x1b[A"},
+		{"up in application mode", tea.KeyMsg{Type: tea.KeyUp}, true, false, "\x1bOA"},
+		{"page down in application mode", tea.KeyMsg{Type: tea.KeyPgDown}, true, false, "\x1b[6~"},
+		{"alt+b", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("b"), Alt: true}, false, false, "\x1bb"},
+		{"paste", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("echo"), Paste: true}, false, false, "echo"},
+		{"bracketed paste", tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("echo"), Paste: true}, false, true, "\x1b[200~echo\x1b[201~"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(KeyBytes(tt.msg, tt.appCursor, tt.paste)); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
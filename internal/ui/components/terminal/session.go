@@ -0,0 +1,197 @@
+package terminal
+
+import (
+	"os"
+	"os/exec"
+	"syscall"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const (
+	// outputBuffer is how many reads of output a session buffers ahead of the UI
+	outputBuffer = 64
+	// readSize is the most output read from the terminal at once
+	readSize = 32 * 1024
+	// maxOutputMsg is the most output coalesced into one OutputMsg
+	maxOutputMsg = 256 * 1024
+)
+
+// OutputMsg carries output of the program of a session
+type OutputMsg struct {
+	ID   int
+	Data []byte
+}
+
+// ExitedMsg reports that the program of a session exited, with the error it exited with
+type ExitedMsg struct {
+	ID  int
+	Err error
+}
+
+// Session is a program running in a pseudo-terminal whose output is emulated
+// by a Screen. Output is delivered as OutputMsg by the command returned by
+// Read, which has to be issued again after each message.
+type Session struct {
+	id     int
+	name   string
+	screen *Screen
+	pty    *pty
+	cmd    *exec.Cmd
+
+	output  chan []byte
+	waitErr error // set before output is closed
+	closed  chan struct{}
+
+	exited  bool
+	exitErr error
+}
+
+// Start runs a command in a new terminal of the given size. The command must
+// not have been started and its standard streams are replaced by the terminal.
+func Start(id int, name string, cmd *exec.Cmd, cols, rows int) (*Session, error) {
+	cols, rows = max(cols, 1), max(rows, 1)
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, "TERM=xterm-256color")
+
+	p, err := startPty(cmd, cols, rows)
+	if err != nil {
+		return nil, err
+	}
+	s := &Session{
+		id:     id,
+		name:   name,
+		screen: NewScreen(cols, rows),
+		pty:    p,
+		cmd:    cmd,
+		output: make(chan []byte, outputBuffer),
+		closed: make(chan struct{}),
+	}
+	go s.readLoop()
+	return s, nil
+}
+
+// readLoop forwards the output of the terminal until the program exits or the session is closed
+func (s *Session) readLoop() {
+	buf := make([]byte, readSize)
+	for {
+		n, err := s.pty.Read(buf)
+		if n > 0 {
+			select {
+			case s.output <- append([]byte(nil), buf[:n]...):
+			case <-s.closed:
+				err = os.ErrClosed
+			}
+		}
+		if err != nil {
+			break
+		}
+	}
+	s.waitErr = s.cmd.Wait()
+	close(s.output)
+}
+
+// ID returns the identifier the messages of the session carry
+func (s *Session) ID() int {
+	return s.id
+}
+
+// Name returns the name the session was started with
+func (s *Session) Name() string {
+	return s.name
+}
+
+// Screen returns the emulated screen of the session
+func (s *Session) Screen() *Screen {
+	return s.screen
+}
+
+// Exited reports whether the program has exited
+func (s *Session) Exited() bool {
+	return s.exited
+}
+
+// Err returns the error the program exited with, nil while it runs or when it succeeded
+func (s *Session) Err() error {
+	return s.exitErr
+}
+
+// Read returns a command waiting for the next output of the program, or for it to exit.
+// Output that is already waiting is delivered at once, so a burst is rendered once.
+func (s *Session) Read() tea.Cmd {
+	return func() tea.Msg {
+		data, ok := <-s.output
+		if !ok {
+			return ExitedMsg{ID: s.id, Err: s.waitErr}
+		}
+		for len(data) < maxOutputMsg {
+			select {
+			case more, ok := <-s.output:
+				if !ok {
+					// The next Read reports the exit
+					return OutputMsg{ID: s.id, Data: data}
+				}
+				data = append(data, more...)
+			default:
+				return OutputMsg{ID: s.id, Data: data}
+			}
+		}
+		return OutputMsg{ID: s.id, Data: data}
+	}
+}
+
+// Output feeds output of the program to the screen, answering its terminal queries
+func (s *Session) Output(data []byte) {
+	_, _ = s.screen.Write(data)
+	if replies := s.screen.Replies(); len(replies) > 0 && !s.exited {
+		_, _ = s.pty.Write(replies)
+	}
+}
+
+// SetExited records that the program exited
+func (s *Session) SetExited(err error) {
+	s.exited = true
+	s.exitErr = err
+}
+
+// SendKey sends a key press to the program
+func (s *Session) SendKey(msg tea.KeyMsg) error {
+	if s.exited {
+		return nil
+	}
+	b := KeyBytes(msg, s.screen.AppCursorKeys(), s.screen.BracketedPaste())
+	if len(b) == 0 {
+		return nil
+	}
+	_, err := s.pty.Write(b)
+	return err
+}
+
+// Resize changes the size of the terminal when it differs
+func (s *Session) Resize(cols, rows int) error {
+	cols, rows = max(cols, 1), max(rows, 1)
+	if c, r := s.screen.Size(); c == cols && r == rows {
+		return nil
+	}
+	s.screen.Resize(cols, rows)
+	if s.exited {
+		return nil
+	}
+	return s.pty.Resize(cols, rows)
+}
+
+// Close hangs up the terminal, which ends the program
+func (s *Session) Close() error {
+	select {
+	case <-s.closed:
+		return nil
+	default:
+		close(s.closed)
+	}
+	if !s.exited && s.cmd.Process != nil {
+		_ = s.cmd.Process.Signal(syscall.SIGHUP)
+	}
+	return s.pty.Close()
+}
@@ -0,0 +1,87 @@
+package terminal
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// startSession starts a shell script in a session, skipping the test where
+// terminals are not supported
+func startSession(t *testing.T, script string) *Session {
+	t.Helper()
+	s, err := Start(1, "test", exec.Command("sh", "-c", script), 20, 5)
+	if errors.Is(err, ErrUnsupported) {
+		t.Skip(err)
+	}
+	if err != nil {
+		t.Fatalf("Failed to start the session: %v", err)
+	}
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// readUntilExit feeds the output of a session to its screen until the program exits
+func readUntilExit(t *testing.T, s *Session) ExitedMsg {
+	t.Helper()
+	for {
+		switch msg := s.Read()().(type) {
+		case OutputMsg:
+			s.Output(msg.Data)
+		case ExitedMsg:
+			s.SetExited(msg.Err)
+			return msg
+		}
+	}
+}
+
+func TestSessionRunsInTerminal(t *testing.T) {
+	// The program sees a terminal of the session's size
+	s := startSession(t, `test -t 0 && stty size; exit 3`)
+
+	msg := readUntilExit(t, s)
+	if got := s.Screen().String(); got != "5 20" {
+		t.Errorf("Expected the terminal size, got %q", got)
+	}
+	var exitErr *exec.ExitError
+	if !errors.As(msg.Err, &exitErr) || exitErr.ExitCode() != 3 {
+		t.Errorf("Expected exit code 3, got %v", msg.Err)
+	}
+	if !s.Exited() || s.Err() == nil {
+		t.Errorf("Expected the session to record the exit")
+	}
+}
+
+func TestSessionSendsKeys(t *testing.T) {
+	s := startSession(t, `read line; echo "got $line"`)
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("hi")},
+		{Type: tea.KeyEnter},
+	} {
+		if err := s.SendKey(msg); err != nil {
+			t.Fatalf("Failed to send a key: %v", err)
+		}
+	}
+
+	if msg := readUntilExit(t, s); msg.Err != nil {
+		t.Errorf("Expected the program to succeed, got %v", msg.Err)
+	}
+	// The terminal echoes the input before the program's output
+	if got := s.Screen().String(); !strings.HasSuffix(got, "hi\ngot hi") {
+		t.Errorf("Expected the echoed input and the output, got %q", got)
+	}
+}
+
+func TestSessionClose(t *testing.T) {
+	s := startSession(t, `sleep 60`)
+	if err := s.Close(); err != nil {
+		t.Fatalf("Failed to close the session: %v", err)
+	}
+	if msg := readUntilExit(t, s); msg.Err == nil {
+		t.Errorf("Expected the program to be hung up")
+	}
+}
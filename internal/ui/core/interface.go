@@ -1,7 +1,14 @@
 package core
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
 	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/providers"
+	"github.com/IAL32/az-tui/internal/ui/components/logexport"
+	"github.com/IAL32/az-tui/internal/ui/components/terminal"
 	"github.com/IAL32/az-tui/internal/ui/layouts"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
@@ -13,6 +20,14 @@ type CoreInterface interface {
 	GetCurrentMode() Mode
 	GetNavigationState() NavigationState
 	GoBack() tea.Cmd
+	NavigateToSubscriptions() tea.Cmd
+	NavigateToEnvironments(rg models.ResourceGroup) tea.Cmd
+	NavigateToApps(rg models.ResourceGroup) tea.Cmd
+	NavigateToJobs(rg models.ResourceGroup) tea.Cmd
+	NavigateToOperations() tea.Cmd
+	NavigateToLogQuery() tea.Cmd
+	NavigateToExec() tea.Cmd
+	NavigateToPortForwards() tea.Cmd
 
 	// State management
 	GetStatusLine() string
@@ -44,6 +59,9 @@ type CoreInterface interface {
 	GetCurrentApp() models.ContainerApp
 	GetCurrentRevision() models.Revision
 	GetCurrentContainer() models.Container
+	ExecSessionCount() int
+	PortForwardCount() int
+	IsProductionResourceGroup(name string) bool
 
 	// Message handling
 	HandleMessage(msg tea.Msg) tea.Cmd
@@ -55,22 +73,78 @@ type CoreInterface interface {
 	GetBreadcrumb() string
 }
 
+// jobExecutionsRefreshInterval is how often executions are reloaded while any is running
+const jobExecutionsRefreshInterval = 5 * time.Second
+
+// rolloutRefreshInterval is how often the revision created by an image update
+// is reloaded until it is healthy or failed, giving up after rolloutTimeout
+const (
+	rolloutRefreshInterval = 2 * time.Second
+	rolloutTimeout         = 10 * time.Minute
+)
+
 // Ensure CoreModel implements CoreInterface
 var _ CoreInterface = (*CoreModel)(nil)
 
 // HandleMessage handles various message types and delegates to appropriate handlers
 func (cm *CoreModel) HandleMessage(msg tea.Msg) tea.Cmd {
 	switch msg := msg.(type) {
+	case LoadedSubscriptionsMsg:
+		return cm.handleLoadedSubscriptions(msg)
 	case LoadedResourceGroupsMsg:
 		return cm.handleLoadedResourceGroups(msg)
+	case LoadedEnvironmentsMsg:
+		return cm.handleLoadedEnvironments(msg)
 	case LoadedAppsMsg:
 		return cm.handleLoadedApps(msg)
 	case LoadedRevisionsMsg:
 		return cm.handleLoadedRevisions(msg)
+	case LoadedReplicasMsg:
+		return cm.handleLoadedReplicas(msg)
+	case LoadedSecretsMsg:
+		return cm.handleLoadedSecrets(msg)
+	case SecretValueMsg:
+		return cm.handleSecretValue(msg)
+	case LoadedScaleMsg:
+		return cm.handleLoadedScale(msg)
 	case LoadedContainersMsg:
 		return cm.handleLoadedContainers(msg)
-	case RevisionRestartedMsg:
-		return cm.handleRevisionRestarted(msg)
+	case LoadedTrafficMsg:
+		return cm.handleLoadedTraffic(msg)
+	case LoadedAppDetailsMsg:
+		return cm.handleLoadedAppDetails(msg)
+	case LoadedMetricsMsg:
+		return cm.handleLoadedMetrics(msg)
+	case LogQueryResultMsg:
+		return cm.handleLogQueryResult(msg)
+	case ProbeResultMsg:
+		return cm.handleProbeResult(msg)
+	case LogStreamStartedMsg:
+		return cm.handleLogStreamStarted(msg)
+	case LogLinesMsg:
+		return cm.handleLogLines(msg)
+	case LogStreamEndedMsg:
+		return cm.handleLogStreamEnded(msg)
+	case LogRateTickMsg:
+		return cm.handleLogRateTick(msg)
+	case logexport.SavedMsg:
+		return cm.handleLogsSaved(msg)
+	case terminal.OutputMsg:
+		return cm.handleExecOutput(msg)
+	case terminal.ExitedMsg:
+		return cm.handleExecExited(msg)
+	case PortForwardTickMsg:
+		return cm.handlePortForwardTick(msg)
+	case LoadedJobsMsg:
+		return cm.handleLoadedJobs(msg)
+	case LoadedJobExecutionsMsg:
+		return cm.handleLoadedJobExecutions(msg)
+	case RevisionRolloutMsg:
+		return cm.handleRevisionRollout(msg)
+	case RefreshJobExecutionsMsg:
+		return cm.handleRefreshJobExecutions(msg)
+	case providers.OperationResultMsg:
+		return cm.handleOperationResult(msg)
 	case LeaveEnvVarsMsg:
 		return cm.handleLeaveEnvVars(msg)
 	default:
@@ -80,6 +154,21 @@ func (cm *CoreModel) HandleMessage(msg tea.Msg) tea.Cmd {
 
 // Message handlers
 
+func (cm *CoreModel) handleLoadedSubscriptions(msg LoadedSubscriptionsMsg) tea.Cmd {
+	page := cm.pageManager.GetSubscriptionsPage()
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetData(msg.Subscriptions)
+	}
+
+	return nil
+}
+
 func (cm *CoreModel) handleLoadedResourceGroups(msg LoadedResourceGroupsMsg) tea.Cmd {
 	page := cm.pageManager.GetResourceGroupsPage()
 	page.SetLoading(false)
@@ -95,6 +184,21 @@ func (cm *CoreModel) handleLoadedResourceGroups(msg LoadedResourceGroupsMsg) tea
 	return nil
 }
 
+func (cm *CoreModel) handleLoadedEnvironments(msg LoadedEnvironmentsMsg) tea.Cmd {
+	page := cm.pageManager.GetEnvironmentsPage()
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetData(msg.Environments)
+	}
+
+	return nil
+}
+
 func (cm *CoreModel) handleLoadedApps(msg LoadedAppsMsg) tea.Cmd {
 	page := cm.pageManager.GetAppsPage()
 	page.SetLoading(false)
@@ -105,6 +209,7 @@ func (cm *CoreModel) handleLoadedApps(msg LoadedAppsMsg) tea.Cmd {
 	} else {
 		page.SetError(nil)
 		page.SetData(msg.Apps)
+		return cm.LoadAppSparklines(msg.Apps)
 	}
 
 	return nil
@@ -119,12 +224,83 @@ func (cm *CoreModel) handleLoadedRevisions(msg LoadedRevisionsMsg) tea.Cmd {
 		page.ClearData()
 	} else {
 		page.SetError(nil)
-		page.SetData(msg.Revisions)
+		page.SetRevisions(msg.Revisions)
+		return cm.LoadRevisionSparklines(cm.GetCurrentApp(), msg.Revisions)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleLoadedReplicas(msg LoadedReplicasMsg) tea.Cmd {
+	page := cm.pageManager.GetReplicasPage()
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetData(msg.Replicas)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleLoadedSecrets(msg LoadedSecretsMsg) tea.Cmd {
+	// Ignore results for an app whose secrets are no longer shown
+	page := cm.pageManager.GetSecretsPage()
+	if msg.AppID != page.GetAppID() {
+		return nil
+	}
+
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetSecrets(msg.Secrets)
 	}
 
 	return nil
 }
 
+func (cm *CoreModel) handleLoadedScale(msg LoadedScaleMsg) tea.Cmd {
+	// Ignore results for an app whose scale is no longer shown
+	page := cm.pageManager.GetScalePage()
+	if msg.AppID != page.GetAppID() {
+		return nil
+	}
+
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetScale(msg.Scale)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleSecretValue(msg SecretValueMsg) tea.Cmd {
+	page := cm.pageManager.GetSecretsPage()
+	if msg.AppID != page.GetAppID() {
+		return nil
+	}
+
+	if msg.Error != nil {
+		page.SetStatusMessage(fmt.Sprintf("Failed to show %s: %v", msg.Secret.Name, msg.Error))
+		return nil
+	}
+	page.SetSecretValue(msg.Secret.Name, msg.Secret.Value)
+
+	return nil
+}
+
 func (cm *CoreModel) handleLoadedContainers(msg LoadedContainersMsg) tea.Cmd {
 	page := cm.pageManager.GetContainersPage()
 	page.SetLoading(false)
@@ -136,23 +312,240 @@ func (cm *CoreModel) handleLoadedContainers(msg LoadedContainersMsg) tea.Cmd {
 		page.SetError(nil)
 		// Cache containers
 		cm.SetContainersCache(msg.AppID, msg.RevName, msg.Containers)
-		page.SetData(msg.Containers)
+		page.SetContainers(msg.Containers)
 	}
 
 	return nil
 }
 
-func (cm *CoreModel) handleRevisionRestarted(msg RevisionRestartedMsg) tea.Cmd {
+func (cm *CoreModel) handleLoadedTraffic(msg LoadedTrafficMsg) tea.Cmd {
+	// Ignore results for an app that is no longer being edited
+	page := cm.pageManager.GetTrafficPage()
+	if msg.AppID != page.GetAppID() {
+		return nil
+	}
+
+	page.SetLoading(false)
+
 	if msg.Error != nil {
-		cm.SetStatusLine("Restart failed: " + msg.Error.Error())
+		page.SetError(msg.Error)
+		page.ClearData()
 	} else {
-		cm.SetStatusLine("Revision restart triggered.")
-		// Reload revisions to reflect status changes after restart
-		if app := cm.GetCurrentApp(); app.Name != "" {
-			navState := cm.GetNavigationState()
-			appID := cm.formatAppID(app)
-			if appID == msg.AppID && navState.CurrentRevName == msg.RevName {
-				return cm.LoadRevisions(app)
+		page.SetError(nil)
+		page.SetData(msg.Weights)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleLoadedAppDetails(msg LoadedAppDetailsMsg) tea.Cmd {
+	// Ignore results for an app that is no longer being viewed
+	page := cm.pageManager.GetAppDetailsPage()
+	if msg.AppID != page.GetAppID() {
+		return nil
+	}
+
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else if err := page.SetContent(msg.Details); err != nil {
+		page.SetError(fmt.Errorf("failed to parse app details: %w", err))
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleLoadedMetrics(msg LoadedMetricsMsg) tea.Cmd {
+	// Sparklines are best effort, a metric that failed to load is shown as
+	// missing and loaded again with the list
+	if msg.Window.Name == models.MetricsWindows[0].Name {
+		key := sparklineKey{appID: msg.AppID, revName: msg.RevName, window: msg.Window.Name}
+		delete(cm.sparklineLoads, key)
+		if msg.Error == nil {
+			cm.sparklines[key] = sparklineMetrics{metrics: msg.Metrics, loadedAt: time.Now()}
+			cm.setSparkline(key, msg.Metrics)
+		}
+	}
+
+	// Ignore results for metrics that are no longer being viewed
+	page := cm.pageManager.GetMetricsPage()
+	if msg.AppID != page.GetAppID() || msg.RevName != page.GetRevisionName() || msg.Window.Name != page.GetWindow().Name {
+		return nil
+	}
+
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetData(msg.Metrics)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleLogQueryResult(msg LogQueryResultMsg) tea.Cmd {
+	// Ignore results of queries that are no longer shown
+	page := cm.pageManager.GetLogQueryPage()
+	if msg.AppID != cm.formatAppID(page.GetScope().App) || msg.Query != page.GetQuery() || msg.Range.Name != page.GetTimeRange().Name {
+		return nil
+	}
+
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetResult(msg.Result)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleProbeResult(msg ProbeResultMsg) tea.Cmd {
+	// The history is kept while browsing, so probes answered elsewhere are added too
+	cm.pageManager.GetProbePage().AddProbe(msg.Probe)
+	return nil
+}
+
+func (cm *CoreModel) handleLogStreamStarted(msg LogStreamStartedMsg) tea.Cmd {
+	// Close streams started for a source that is no longer being viewed
+	if msg.StreamID != cm.logStreamID {
+		if msg.Stream != nil {
+			msg.Stream.Close()
+		}
+		return nil
+	}
+
+	page := cm.pageManager.GetLogsPage()
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		// When tailing several apps, the other apps keep streaming
+		if page.IsAggregated() {
+			return cm.handleLogStreamEnded(LogStreamEndedMsg{StreamID: msg.StreamID, Index: msg.Index, Error: msg.Error})
+		}
+		// Stop the other stream of combined logs as well
+		cm.stopLogStream()
+		page.SetError(msg.Error)
+		return nil
+	}
+
+	cm.logStreams[msg.Index] = msg.Stream
+	return CreateReadLogStreamCmd(msg.Stream, msg.StreamID, msg.Index)
+}
+
+func (cm *CoreModel) handleLogLines(msg LogLinesMsg) tea.Cmd {
+	stream, ok := cm.logStreams[msg.Index]
+	if msg.StreamID != cm.logStreamID || !ok {
+		return nil
+	}
+
+	cm.pageManager.GetLogsPage().AppendStreamLines(msg.Index, msg.Lines)
+	return CreateReadLogStreamCmd(stream, msg.StreamID, msg.Index)
+}
+
+func (cm *CoreModel) handleLogStreamEnded(msg LogStreamEndedMsg) tea.Cmd {
+	if msg.StreamID != cm.logStreamID {
+		return nil
+	}
+
+	page := cm.pageManager.GetLogsPage()
+	delete(cm.logStreams, msg.Index)
+	cm.logStreamsOpen--
+	if msg.Error != nil {
+		cm.logStreamErr = fmt.Errorf("%s logs: %w", page.StreamName(msg.Index), msg.Error)
+	}
+	// Logs made up of several streams end once every stream has ended
+	if cm.logStreamsOpen <= 0 {
+		page.SetEnded(cm.logStreamErr)
+	}
+	return nil
+}
+
+func (cm *CoreModel) handleLogRateTick(msg LogRateTickMsg) tea.Cmd {
+	// Stop ticking once the streams were replaced or have ended
+	if msg.StreamID != cm.logStreamID || cm.logStreamsOpen <= 0 {
+		return nil
+	}
+	return CreateLogRateTickCmd(msg.StreamID)
+}
+
+func (cm *CoreModel) handleLogsSaved(msg logexport.SavedMsg) tea.Cmd {
+	// Confirm in the status bar of the logs page, where saving was asked for
+	cm.pageManager.GetLogsPage().SetSaved(msg)
+	return nil
+}
+
+func (cm *CoreModel) handleExecOutput(msg terminal.OutputMsg) tea.Cmd {
+	// Sessions keep reading while their page is not shown
+	return cm.pageManager.GetExecPage().HandleOutput(msg)
+}
+
+func (cm *CoreModel) handleExecExited(msg terminal.ExitedMsg) tea.Cmd {
+	return cm.pageManager.GetExecPage().SetExited(msg)
+}
+
+func (cm *CoreModel) handlePortForwardTick(msg PortForwardTickMsg) tea.Cmd {
+	cm.refreshPortForwards()
+	// Stop ticking once every forward is stopped
+	if len(cm.portForwards) == 0 {
+		cm.portForwardTicking = false
+		return nil
+	}
+	return CreatePortForwardTickCmd()
+}
+
+func (cm *CoreModel) handleLoadedJobs(msg LoadedJobsMsg) tea.Cmd {
+	page := cm.pageManager.GetJobsPage()
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetData(msg.Jobs)
+	}
+
+	return nil
+}
+
+func (cm *CoreModel) handleLoadedJobExecutions(msg LoadedJobExecutionsMsg) tea.Cmd {
+	// Ignore results for a job that is no longer being viewed
+	if msg.JobID != cm.GetNavigationState().CurrentJobID {
+		return nil
+	}
+
+	page := cm.pageManager.GetJobExecutionsPage()
+	page.SetLoading(false)
+
+	if msg.Error != nil {
+		page.SetError(msg.Error)
+		page.ClearData()
+	} else {
+		page.SetError(nil)
+		page.SetData(msg.Executions)
+	}
+
+	// Keep refreshing while any execution is still running
+	if msg.Error == nil && !cm.jobExecutionsRefreshPending[msg.JobID] {
+		for _, execution := range msg.Executions {
+			if execution.Status == "Running" {
+				if cm.jobExecutionsRefreshPending == nil {
+					cm.jobExecutionsRefreshPending = make(map[string]bool)
+				}
+				cm.jobExecutionsRefreshPending[msg.JobID] = true
+				return CreateRefreshJobExecutionsCmd(msg.JobID, jobExecutionsRefreshInterval)
 			}
 		}
 	}
@@ -160,6 +553,248 @@ func (cm *CoreModel) handleRevisionRestarted(msg RevisionRestartedMsg) tea.Cmd {
 	return nil
 }
 
+func (cm *CoreModel) handleRefreshJobExecutions(msg RefreshJobExecutionsMsg) tea.Cmd {
+	delete(cm.jobExecutionsRefreshPending, msg.JobID)
+
+	// Stop refreshing once the user has left the job's executions
+	if cm.GetCurrentMode() != ModeJobExecutions || msg.JobID != cm.GetNavigationState().CurrentJobID {
+		return nil
+	}
+
+	return cm.LoadJobExecutions(cm.GetCurrentJob())
+}
+
+func (cm *CoreModel) handleRevisionRollout(msg RevisionRolloutMsg) tea.Cmd {
+	page := cm.pageManager.GetContainersPage()
+
+	// Stop watching once another image update is rolling out
+	rollout := page.GetRollout()
+	if rollout.AppID != msg.AppID || rollout.Revision.Name != msg.RevisionName || rollout.Error != nil {
+		return nil
+	}
+
+	switch {
+	case msg.Found:
+		page.SetRolloutRevision(msg.Revision)
+		if msg.Revision.RolloutDone() {
+			// Reloads of a finished rollout only refresh its traffic
+			if !rollout.Revision.RolloutDone() {
+				cm.SetStatusLine(describeRollout(msg.Revision))
+			}
+			return nil
+		}
+	case msg.Error == nil:
+		// The revision may not be listed right after the update
+	default:
+		// Keep watching through transient errors, showing the last one
+		cm.SetStatusLine(fmt.Sprintf("Failed to load revision %s: %v", msg.RevisionName, msg.Error))
+	}
+
+	if time.Since(msg.Started) >= rolloutTimeout {
+		page.SetRolloutError(fmt.Errorf("stopped watching after %s", rolloutTimeout))
+		return nil
+	}
+	return CreateWatchRevisionCmd(cm.dataProvider, cm.subscription(), msg.AppID, msg.RevisionName, msg.Started, rolloutRefreshInterval)
+}
+
+// watchRollout starts showing and watching the revision created by an image update
+func (cm *CoreModel) watchRollout(msg providers.OperationResultMsg) tea.Cmd {
+	cm.pageManager.GetContainersPage().StartRollout(msg.AppID, msg.Target, msg.Result)
+	return CreateWatchRevisionCmd(cm.dataProvider, cm.subscription(), msg.AppID, msg.Result, time.Now(), rolloutRefreshInterval)
+}
+
+// describeRollout returns a one-line summary of a finished rollout for the status bar
+func describeRollout(revision models.Revision) string {
+	if revision.IsHealthy() {
+		return fmt.Sprintf("Revision %s is healthy - press T to shift all traffic to it.", revision.Name)
+	}
+	return fmt.Sprintf("Revision %s failed to roll out (provisioning %s, health %s).",
+		revision.Name, revision.ProvisioningState, revision.HealthState)
+}
+
+func (cm *CoreModel) handleOperationResult(msg providers.OperationResultMsg) tea.Cmd {
+	status := describeOperation(msg)
+	cm.SetStatusLine(status)
+
+	// Record the result in the operation log
+	resource := msg.AppID
+	if resource == "" {
+		resource = msg.JobID
+	}
+	cm.stateManager.AddOperation(models.Operation{
+		Time:      time.Now(),
+		Name:      msg.Operation,
+		Resource:  resource,
+		Target:    msg.Target,
+		Succeeded: msg.Err == nil,
+		Message:   status,
+	})
+	cm.pageManager.GetOperationsPage().SetData(cm.stateManager.GetOperations())
+
+	if msg.Operation == providers.OperationUpdateEnv && msg.Err == nil {
+		if cmd := cm.showCreatedRevision(msg); cmd != nil {
+			return cmd
+		}
+	}
+	if msg.Operation == providers.OperationSetRevisionMode && msg.Err == nil {
+		cm.showRevisionMode(msg)
+	}
+	if msg.Operation == providers.OperationUpdateImage && msg.Err == nil && msg.Result != "" {
+		return tea.Batch(cm.watchRollout(msg), cm.reloadAfterOperation(msg))
+	}
+	if rollout := cm.pageManager.GetContainersPage().GetRollout(); msg.Operation == providers.OperationSetTraffic &&
+		msg.Err == nil && rollout.Active() && rollout.AppID == msg.AppID {
+		// Show the traffic the rollout now receives
+		watch := CreateWatchRevisionCmd(cm.dataProvider, cm.subscription(), msg.AppID, rollout.Revision.Name, time.Now(), 0)
+		return tea.Batch(watch, cm.reloadAfterOperation(msg))
+	}
+
+	return cm.reloadAfterOperation(msg)
+}
+
+// showCreatedRevision goes back from the env vars the operation changed to the
+// revisions of the app, highlighting the revision it created when az reported it
+func (cm *CoreModel) showCreatedRevision(msg providers.OperationResultMsg) tea.Cmd {
+	navState := cm.GetNavigationState()
+	if cm.GetCurrentMode() != ModeEnvVars || msg.AppID != navState.CurrentAppID {
+		return nil
+	}
+	if !cm.navigationManager.GoBackTo(ModeRevisions) {
+		return nil
+	}
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	page := cm.pageManager.GetRevisionsPage()
+	page.SelectRevision(msg.Result)
+	return cm.LoadRevisions(cm.GetCurrentApp())
+}
+
+// showRevisionMode updates the current app with the revision mode an operation set
+func (cm *CoreModel) showRevisionMode(msg providers.OperationResultMsg) {
+	app, ok := cm.stateManager.GetCurrentApp()
+	if !ok || cm.formatAppID(app) != msg.AppID {
+		return
+	}
+	app.RevisionMode = msg.Result
+	cm.stateManager.SetCurrentApp(app)
+	cm.pageManager.GetRevisionsPage().SetRevisionMode(msg.Result)
+}
+
+// reloadAfterOperation reloads the current page if it shows the app or job changed by an operation
+func (cm *CoreModel) reloadAfterOperation(msg providers.OperationResultMsg) tea.Cmd {
+	navState := cm.GetNavigationState()
+
+	switch cm.GetCurrentMode() {
+	case ModeApps:
+		if msg.AppID != "" {
+			return cm.LoadApps(navState.CurrentRG)
+		}
+	case ModeRevisions:
+		if msg.AppID != "" && msg.AppID == navState.CurrentAppID {
+			return cm.LoadRevisions(cm.GetCurrentApp())
+		}
+	case ModeReplicas:
+		// Restarting a revision replaces its replicas
+		if msg.AppID != "" && msg.AppID == navState.CurrentAppID {
+			return cm.LoadReplicas(cm.GetCurrentApp(), navState.CurrentRevName)
+		}
+	case ModeTraffic:
+		if msg.AppID != "" && msg.AppID == navState.CurrentAppID {
+			return cm.LoadTraffic(cm.GetCurrentApp())
+		}
+	case ModeAppDetails:
+		if msg.AppID != "" && msg.AppID == navState.CurrentAppID {
+			return cm.LoadAppDetails(cm.GetCurrentApp())
+		}
+	case ModeSecrets:
+		if msg.AppID != "" && msg.AppID == navState.CurrentAppID {
+			return cm.LoadSecrets(cm.GetCurrentApp())
+		}
+	case ModeScale:
+		if msg.AppID != "" && msg.AppID == navState.CurrentAppID {
+			return cm.LoadScale(cm.GetCurrentApp())
+		}
+	case ModeJobs:
+		if msg.JobID != "" {
+			return cm.LoadJobs(navState.CurrentRG)
+		}
+	case ModeJobExecutions:
+		if msg.JobID != "" && msg.JobID == navState.CurrentJobID {
+			return cm.LoadJobExecutions(cm.GetCurrentJob())
+		}
+	}
+
+	return nil
+}
+
+// describeOperation returns a one-line summary of an operation result for the status bar
+func describeOperation(msg providers.OperationResultMsg) string {
+	if msg.Err != nil {
+		return fmt.Sprintf("Failed to %s %s: %s", msg.Operation, msg.Target, operationError(msg))
+	}
+
+	switch msg.Operation {
+	case providers.OperationRestartRevision:
+		return fmt.Sprintf("Restarted revision %s.", msg.Target)
+	case providers.OperationActivateRevision:
+		return fmt.Sprintf("Activated revision %s.", msg.Target)
+	case providers.OperationDeactivateRevision:
+		return fmt.Sprintf("Deactivated revision %s.", msg.Target)
+	case providers.OperationAddLabel:
+		return fmt.Sprintf("Added label %s to revision %s.", msg.Target, msg.Result)
+	case providers.OperationRemoveLabel:
+		return fmt.Sprintf("Removed label %s.", msg.Target)
+	case providers.OperationSetRevisionMode:
+		return fmt.Sprintf("Switched %s to %s revision mode.", msg.Target, strings.ToLower(msg.Result))
+	case providers.OperationSetTraffic:
+		return fmt.Sprintf("Updated traffic split of %s.", msg.Target)
+	case providers.OperationUpdateEnv:
+		return fmt.Sprintf("Updated env vars of %s, %s.", msg.Target, createdRevision(msg.Result))
+	case providers.OperationUpdateImage:
+		if msg.Result == "" {
+			return fmt.Sprintf("Updated image of %s, but az did not report the revision created, so its rollout cannot be followed.", msg.Target)
+		}
+		return fmt.Sprintf("Updated image of %s, created revision %s.", msg.Target, msg.Result)
+	case providers.OperationUpdateScale:
+		return fmt.Sprintf("Updated scale of %s, %s.", msg.Target, createdRevision(msg.Result))
+	case providers.OperationStartJob:
+		return fmt.Sprintf("Started execution %s.", msg.Result)
+	case providers.OperationStopExecution:
+		return fmt.Sprintf("Stopped execution %s.", msg.Target)
+	case providers.OperationRerunExecution:
+		return fmt.Sprintf("Re-run of %s started as execution %s.", msg.Target, msg.Result)
+	default:
+		return fmt.Sprintf("Completed %s %s.", msg.Operation, msg.Target)
+	}
+}
+
+// createdRevision describes the revision an update created, which az may not report
+func createdRevision(revision string) string {
+	if revision == "" {
+		return "but az did not report the revision created"
+	}
+	return "created revision " + revision
+}
+
+// operationError returns the most useful description of why an operation failed.
+// The az CLI exits with a bare status code, so its "ERROR:" output is preferred.
+func operationError(msg providers.OperationResultMsg) string {
+	var last string
+	for _, line := range strings.Split(msg.Out, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "ERROR:") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "ERROR:"))
+		}
+		if line != "" {
+			last = line
+		}
+	}
+	if last != "" {
+		return last
+	}
+	return msg.Err.Error()
+}
+
 func (cm *CoreModel) handleLeaveEnvVars(msg LeaveEnvVarsMsg) tea.Cmd {
 	return cm.GoBack()
 }
@@ -169,6 +804,8 @@ func (cm *CoreModel) handleLeaveEnvVars(msg LeaveEnvVarsMsg) tea.Cmd {
 // NavigateToModeByName navigates to a mode by its string name
 func (cm *CoreModel) NavigateToModeByName(modeName string) tea.Cmd {
 	switch modeName {
+	case "subscriptions":
+		return cm.NavigateToSubscriptions()
 	case "resource-groups":
 		return cm.NavigateToResourceGroups()
 	default:
@@ -194,16 +831,40 @@ func (cm *CoreModel) GetModeString() string {
 // IsLoading returns whether the current page is loading
 func (cm *CoreModel) IsLoading() bool {
 	switch cm.GetCurrentMode() {
+	case ModeSubscriptions:
+		return cm.pageManager.GetSubscriptionsPage().IsLoading()
 	case ModeResourceGroups:
 		return cm.pageManager.GetResourceGroupsPage().IsLoading()
+	case ModeEnvironments:
+		return cm.pageManager.GetEnvironmentsPage().IsLoading()
 	case ModeApps:
 		return cm.pageManager.GetAppsPage().IsLoading()
 	case ModeRevisions:
 		return cm.pageManager.GetRevisionsPage().IsLoading()
+	case ModeReplicas:
+		return cm.pageManager.GetReplicasPage().IsLoading()
 	case ModeContainers:
 		return cm.pageManager.GetContainersPage().IsLoading()
+	case ModeTraffic:
+		return cm.pageManager.GetTrafficPage().IsLoading()
 	case ModeEnvVars:
 		return cm.pageManager.GetEnvVarsPage().IsLoading()
+	case ModeJobs:
+		return cm.pageManager.GetJobsPage().IsLoading()
+	case ModeJobExecutions:
+		return cm.pageManager.GetJobExecutionsPage().IsLoading()
+	case ModeAppDetails:
+		return cm.pageManager.GetAppDetailsPage().IsLoading()
+	case ModeSecrets:
+		return cm.pageManager.GetSecretsPage().IsLoading()
+	case ModeScale:
+		return cm.pageManager.GetScalePage().IsLoading()
+	case ModeMetrics:
+		return cm.pageManager.GetMetricsPage().IsLoading()
+	case ModeLogs:
+		return cm.pageManager.GetLogsPage().IsLoading()
+	case ModeLogQuery:
+		return cm.pageManager.GetLogQueryPage().IsLoading()
 	default:
 		return false
 	}
@@ -212,16 +873,40 @@ func (cm *CoreModel) IsLoading() bool {
 // GetError returns the current page's error
 func (cm *CoreModel) GetError() error {
 	switch cm.GetCurrentMode() {
+	case ModeSubscriptions:
+		return cm.pageManager.GetSubscriptionsPage().GetError()
 	case ModeResourceGroups:
 		return cm.pageManager.GetResourceGroupsPage().GetError()
+	case ModeEnvironments:
+		return cm.pageManager.GetEnvironmentsPage().GetError()
 	case ModeApps:
 		return cm.pageManager.GetAppsPage().GetError()
 	case ModeRevisions:
 		return cm.pageManager.GetRevisionsPage().GetError()
+	case ModeReplicas:
+		return cm.pageManager.GetReplicasPage().GetError()
 	case ModeContainers:
 		return cm.pageManager.GetContainersPage().GetError()
+	case ModeTraffic:
+		return cm.pageManager.GetTrafficPage().GetError()
 	case ModeEnvVars:
 		return cm.pageManager.GetEnvVarsPage().GetError()
+	case ModeJobs:
+		return cm.pageManager.GetJobsPage().GetError()
+	case ModeJobExecutions:
+		return cm.pageManager.GetJobExecutionsPage().GetError()
+	case ModeAppDetails:
+		return cm.pageManager.GetAppDetailsPage().GetError()
+	case ModeSecrets:
+		return cm.pageManager.GetSecretsPage().GetError()
+	case ModeScale:
+		return cm.pageManager.GetScalePage().GetError()
+	case ModeMetrics:
+		return cm.pageManager.GetMetricsPage().GetError()
+	case ModeLogs:
+		return cm.pageManager.GetLogsPage().GetError()
+	case ModeLogQuery:
+		return cm.pageManager.GetLogQueryPage().GetError()
 	default:
 		return nil
 	}
@@ -230,8 +915,13 @@ func (cm *CoreModel) GetError() error {
 // RefreshCurrentPage refreshes the current page's data
 func (cm *CoreModel) RefreshCurrentPage() tea.Cmd {
 	switch cm.GetCurrentMode() {
+	case ModeSubscriptions:
+		return cm.LoadSubscriptions()
 	case ModeResourceGroups:
 		return cm.LoadResourceGroups()
+	case ModeEnvironments:
+		navState := cm.GetNavigationState()
+		return cm.LoadEnvironments(navState.CurrentRG)
 	case ModeApps:
 		navState := cm.GetNavigationState()
 		return cm.LoadApps(navState.CurrentRG)
@@ -239,11 +929,50 @@ func (cm *CoreModel) RefreshCurrentPage() tea.Cmd {
 		if app := cm.GetCurrentApp(); app.Name != "" {
 			return cm.LoadRevisions(app)
 		}
+	case ModeReplicas:
+		if app := cm.GetCurrentApp(); app.Name != "" {
+			navState := cm.GetNavigationState()
+			return cm.LoadReplicas(app, navState.CurrentRevName)
+		}
 	case ModeContainers:
 		if app := cm.GetCurrentApp(); app.Name != "" {
 			navState := cm.GetNavigationState()
 			return cm.LoadContainers(app, navState.CurrentRevName)
 		}
+	case ModeTraffic:
+		if app := cm.GetCurrentApp(); app.Name != "" {
+			return cm.LoadTraffic(app)
+		}
+	case ModeJobs:
+		navState := cm.GetNavigationState()
+		return cm.LoadJobs(navState.CurrentRG)
+	case ModeJobExecutions:
+		if job := cm.GetCurrentJob(); job.Name != "" {
+			return cm.LoadJobExecutions(job)
+		}
+	case ModeAppDetails:
+		if app := cm.GetCurrentApp(); app.Name != "" {
+			return cm.LoadAppDetails(app)
+		}
+	case ModeSecrets:
+		if app := cm.GetCurrentApp(); app.Name != "" {
+			return cm.LoadSecrets(app)
+		}
+	case ModeScale:
+		if app := cm.GetCurrentApp(); app.Name != "" {
+			return cm.LoadScale(app)
+		}
+	case ModeMetrics:
+		if app := cm.GetCurrentApp(); app.Name != "" {
+			page := cm.pageManager.GetMetricsPage()
+			page.SetLoading(true)
+			return cm.LoadMetrics(app, page.GetRevisionName(), page.GetWindow())
+		}
+	case ModeLogs:
+		return cm.ReconnectLogs()
+	case ModeLogQuery:
+		page := cm.pageManager.GetLogQueryPage()
+		return cm.RunLogQuery(page.GetQuery(), page.GetTimeRange())
 	}
 	return nil
 }
@@ -2,6 +2,8 @@ package core
 
 import (
 	"context"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/IAL32/az-tui/internal/models"
@@ -37,12 +39,24 @@ type LoadContainersMsg struct {
 
 // Data loaded messages
 
+// LoadedSubscriptionsMsg represents loaded subscriptions data
+type LoadedSubscriptionsMsg struct {
+	Subscriptions []models.Subscription
+	Error         error
+}
+
 // LoadedResourceGroupsMsg represents loaded resource groups data
 type LoadedResourceGroupsMsg struct {
 	ResourceGroups []models.ResourceGroup
 	Error          error
 }
 
+// LoadedEnvironmentsMsg represents loaded managed environments data
+type LoadedEnvironmentsMsg struct {
+	Environments []models.ManagedEnvironment
+	Error        error
+}
+
 // LoadedAppsMsg represents loaded apps data
 type LoadedAppsMsg struct {
 	Apps  []models.ContainerApp
@@ -55,6 +69,42 @@ type LoadedRevisionsMsg struct {
 	Error     error
 }
 
+// LoadedTrafficMsg represents the loaded traffic split of an app
+type LoadedTrafficMsg struct {
+	AppID   string
+	Weights []models.TrafficWeight
+	Error   error
+}
+
+// LoadedSecretsMsg represents the loaded secrets of an app
+type LoadedSecretsMsg struct {
+	AppID   string
+	Secrets []models.Secret
+	Error   error
+}
+
+// LoadedScaleMsg represents the loaded replica bounds and scale rules of an app
+type LoadedScaleMsg struct {
+	AppID string
+	Scale models.Scale
+	Error error
+}
+
+// SecretValueMsg represents the value of a secret shown on demand
+type SecretValueMsg struct {
+	AppID  string
+	Secret models.Secret
+	Error  error
+}
+
+// LoadedReplicasMsg represents the loaded replicas of a revision
+type LoadedReplicasMsg struct {
+	AppID    string
+	RevName  string
+	Replicas []models.Replica
+	Error    error
+}
+
 // LoadedContainersMsg represents loaded containers data
 type LoadedContainersMsg struct {
 	AppID      string
@@ -63,55 +113,454 @@ type LoadedContainersMsg struct {
 	Error      error
 }
 
-// RevisionRestartedMsg represents a revision restart result
-type RevisionRestartedMsg struct {
+// LoadedAppDetailsMsg represents the loaded JSON document of an app
+type LoadedAppDetailsMsg struct {
+	AppID   string
+	Details string
+	Error   error
+}
+
+// LoadedMetricsMsg represents the loaded metrics of an app, or of one of its
+// revisions when RevName is set
+type LoadedMetricsMsg struct {
 	AppID   string
 	RevName string
+	Window  models.MetricsWindow
+	Metrics models.MetricSet
 	Error   error
 }
 
+// LogQueryResultMsg represents the rows a Log Analytics query returned, or the
+// error it failed with
+type LogQueryResultMsg struct {
+	AppID  string
+	Query  string
+	Range  models.LogQueryRange
+	Result models.LogQueryResult
+	Error  error
+}
+
+// LogStreamStartedMsg reports a log stream that was started for the logs page,
+// or the error starting it failed with. StreamID tells streams started for
+// earlier sources apart, and Index the streams making up the logs, such as the
+// console and system streams of combined logs or the streams of several apps.
+type LogStreamStartedMsg struct {
+	StreamID int
+	Index    int
+	Stream   providers.LogStream
+	Error    error
+}
+
+// LogLinesMsg carries the lines read from a log stream
+type LogLinesMsg struct {
+	StreamID int
+	Index    int
+	Lines    []string
+}
+
+// LogStreamEndedMsg reports that a log stream ended, with the error it failed with if any
+type LogStreamEndedMsg struct {
+	StreamID int
+	Index    int
+	Error    error
+}
+
+// LogRateTickMsg requests the logs page to be rendered again, so the rate of
+// lines it shows stays current while the streams started with StreamID are open
+type LogRateTickMsg struct {
+	StreamID int
+}
+
+// ProbeResultMsg represents a probe sent from the probe page, answered or failed
+type ProbeResultMsg struct {
+	Probe models.Probe
+}
+
+// PortForwardTickMsg requests the port forwards to be reported again, so the
+// traffic shown stays current while any is open
+type PortForwardTickMsg struct{}
+
+// LoadedJobsMsg represents loaded container app jobs data
+type LoadedJobsMsg struct {
+	Jobs  []models.Job
+	Error error
+}
+
+// LoadedJobExecutionsMsg represents loaded job executions data
+type LoadedJobExecutionsMsg struct {
+	JobID      string
+	Executions []models.JobExecution
+	Error      error
+}
+
+// RefreshJobExecutionsMsg requests a background refresh of a job's executions
+type RefreshJobExecutionsMsg struct {
+	JobID string
+}
+
+// RevisionRolloutMsg reports the state of a revision being rolled out. Found
+// is false while the revision is not listed yet.
+type RevisionRolloutMsg struct {
+	AppID        string
+	RevisionName string
+	Revision     models.Revision
+	Found        bool
+	Started      time.Time // when the rollout started being watched
+	Error        error
+}
+
 // LeaveEnvVarsMsg represents leaving environment variables mode
 type LeaveEnvVarsMsg struct{}
 
 // Command creators that return tea.Cmd
 
-// CreateLoadResourceGroupsCmd creates a command to load resource groups
-func CreateLoadResourceGroupsCmd(provider providers.DataProvider) tea.Cmd {
+// newLoadContext returns the context data is loaded with, scoped to the subscription
+// of the navigation state. An empty subscription uses the az CLI default.
+func newLoadContext(subscription string) (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	return providers.WithSubscription(ctx, subscription), cancel
+}
+
+// CreateLoadSubscriptionsCmd creates a command to load subscriptions
+func CreateLoadSubscriptionsCmd(provider providers.DataProvider) tea.Cmd {
 	return func() tea.Msg {
 		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 		defer cancel()
+		subscriptions, err := provider.ListSubscriptions(ctx)
+		return LoadedSubscriptionsMsg{Subscriptions: subscriptions, Error: err}
+	}
+}
+
+// CreateLoadResourceGroupsCmd creates a command to load resource groups
+func CreateLoadResourceGroupsCmd(provider providers.DataProvider, subscription string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
 		resourceGroups, err := provider.ListResourceGroups(ctx)
 		return LoadedResourceGroupsMsg{ResourceGroups: resourceGroups, Error: err}
 	}
 }
 
 // CreateLoadAppsCmd creates a command to load apps
-func CreateLoadAppsCmd(provider providers.DataProvider, resourceGroup string) tea.Cmd {
+func CreateLoadAppsCmd(provider providers.DataProvider, subscription, resourceGroup string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := newLoadContext(subscription)
 		defer cancel()
 		apps, err := provider.ListContainerApps(ctx, resourceGroup)
 		return LoadedAppsMsg{Apps: apps, Error: err}
 	}
 }
 
+// CreateLoadEnvironmentsCmd creates a command to load managed environments
+func CreateLoadEnvironmentsCmd(provider providers.DataProvider, subscription, resourceGroup string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		environments, err := provider.ListManagedEnvironments(ctx, resourceGroup)
+		return LoadedEnvironmentsMsg{Environments: environments, Error: err}
+	}
+}
+
+// CreateLoadEnvironmentAppsCmd creates a command to load the apps of a managed environment
+func CreateLoadEnvironmentAppsCmd(provider providers.DataProvider, subscription, environmentID string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		apps, err := provider.ListEnvironmentApps(ctx, environmentID)
+		return LoadedAppsMsg{Apps: apps, Error: err}
+	}
+}
+
 // CreateLoadRevisionsCmd creates a command to load revisions
-func CreateLoadRevisionsCmd(provider providers.DataProvider, app models.ContainerApp) tea.Cmd {
+func CreateLoadRevisionsCmd(provider providers.DataProvider, subscription string, app models.ContainerApp) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		revisions, err := provider.ListRevisions(ctx, app.Name, app.ResourceGroup)
+		if err != nil {
+			return LoadedRevisionsMsg{Error: err}
+		}
+
+		// Labels live in the traffic split, which apps without ingress lack
+		if weights, err := provider.ListTrafficWeights(ctx, app.Name, app.ResourceGroup); err == nil {
+			labelRevisions(revisions, weights)
+		}
+		return LoadedRevisionsMsg{Revisions: revisions}
+	}
+}
+
+// labelRevisions sets the labels the traffic split gives each revision
+func labelRevisions(revisions []models.Revision, weights []models.TrafficWeight) {
+	for i := range revisions {
+		revisions[i].Labels = nil
+		for _, weight := range weights {
+			if weight.Label != "" && weight.RevisionName == revisions[i].Name {
+				revisions[i].Labels = append(revisions[i].Labels, weight.Label)
+			}
+		}
+	}
+}
+
+// CreateLoadTrafficCmd creates a command to load the traffic split of an app.
+// Active revisions without traffic are included with a weight of zero so that
+// traffic can be shifted to them.
+func CreateLoadTrafficCmd(provider providers.DataProvider, subscription string, app models.ContainerApp) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
 		defer cancel()
+		appID := app.ResourceGroup + "/" + app.Name
+		weights, err := provider.ListTrafficWeights(ctx, app.Name, app.ResourceGroup)
+		if err != nil {
+			return LoadedTrafficMsg{AppID: appID, Error: err}
+		}
 		revisions, err := provider.ListRevisions(ctx, app.Name, app.ResourceGroup)
-		return LoadedRevisionsMsg{Revisions: revisions, Error: err}
+		if err != nil {
+			return LoadedTrafficMsg{AppID: appID, Error: err}
+		}
+		return LoadedTrafficMsg{AppID: appID, Weights: withActiveRevisions(weights, revisions)}
+	}
+}
+
+// withActiveRevisions appends the active revisions missing from a traffic split with a weight of zero
+func withActiveRevisions(weights []models.TrafficWeight, revisions []models.Revision) []models.TrafficWeight {
+	inSplit := make(map[string]bool)
+	for _, weight := range weights {
+		inSplit[weight.RevisionName] = true
+	}
+	for _, rev := range revisions {
+		if rev.Active && !inSplit[rev.Name] {
+			weights = append(weights, models.TrafficWeight{RevisionName: rev.Name})
+		}
+	}
+	return weights
+}
+
+// CreateLoadSecretsCmd creates a command to load the secrets of an app, without their values
+func CreateLoadSecretsCmd(provider providers.DataProvider, subscription string, app models.ContainerApp) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		secrets, err := provider.ListSecrets(ctx, app)
+		appID := app.ResourceGroup + "/" + app.Name
+		return LoadedSecretsMsg{AppID: appID, Secrets: secrets, Error: err}
+	}
+}
+
+// CreateLoadScaleCmd creates a command to load the replica bounds and scale rules of an app
+func CreateLoadScaleCmd(provider providers.DataProvider, subscription string, app models.ContainerApp) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		scale, err := provider.GetScale(ctx, app)
+		appID := app.ResourceGroup + "/" + app.Name
+		return LoadedScaleMsg{AppID: appID, Scale: scale, Error: err}
+	}
+}
+
+// CreateShowSecretCmd creates a command to show the value of a secret of an app
+func CreateShowSecretCmd(provider providers.DataProvider, subscription string, app models.ContainerApp, secretName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		secret, err := provider.ShowSecret(ctx, app, secretName)
+		if secret.Name == "" {
+			secret.Name = secretName
+		}
+		appID := app.ResourceGroup + "/" + app.Name
+		return SecretValueMsg{AppID: appID, Secret: secret, Error: err}
+	}
+}
+
+// CreateLoadReplicasCmd creates a command to load the replicas of a revision
+func CreateLoadReplicasCmd(provider providers.DataProvider, subscription string, app models.ContainerApp, revName string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		replicas, err := provider.ListReplicas(ctx, app, revName)
+		appID := app.ResourceGroup + "/" + app.Name
+		return LoadedReplicasMsg{AppID: appID, RevName: revName, Replicas: replicas, Error: err}
 	}
 }
 
 // CreateLoadContainersCmd creates a command to load containers
-func CreateLoadContainersCmd(provider providers.DataProvider, app models.ContainerApp, revName string) tea.Cmd {
+func CreateLoadContainersCmd(provider providers.DataProvider, subscription string, app models.ContainerApp, revName string) tea.Cmd {
 	return func() tea.Msg {
-		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		ctx, cancel := newLoadContext(subscription)
 		defer cancel()
 		containers, err := provider.ListContainers(ctx, app, revName)
 		appID := app.ResourceGroup + "/" + app.Name
 		return LoadedContainersMsg{AppID: appID, RevName: revName, Containers: containers, Error: err}
 	}
 }
+
+// CreateLoadMetricsCmd creates a command to load the metrics of an app, or of
+// one of its revisions when revName is set
+func CreateLoadMetricsCmd(provider providers.MetricsProvider, subscription string, app models.ContainerApp, revName string, window models.MetricsWindow) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		metrics, err := provider.GetAppMetrics(ctx, app, revName, window)
+		appID := app.ResourceGroup + "/" + app.Name
+		return LoadedMetricsMsg{AppID: appID, RevName: revName, Window: window, Metrics: metrics, Error: err}
+	}
+}
+
+// logQueryTimeout is how long a Log Analytics query may run, longer than other
+// loads since queries over days of logs take a while
+const logQueryTimeout = 2 * time.Minute
+
+// CreateRunLogQueryCmd creates a command to run a query against the Log
+// Analytics workspace of the managed environment of an app
+func CreateRunLogQueryCmd(runner providers.LogQueryRunner, subscription string, app models.ContainerApp, query string, timeRange models.LogQueryRange) tea.Cmd {
+	return func() tea.Msg {
+		appID := app.ResourceGroup + "/" + app.Name
+		msg := LogQueryResultMsg{AppID: appID, Query: query, Range: timeRange}
+		if app.EnvironmentID == "" {
+			msg.Error = fmt.Errorf("app %s has no managed environment", app.Name)
+			return msg
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), logQueryTimeout)
+		defer cancel()
+		ctx = providers.WithSubscription(ctx, subscription)
+		msg.Result, msg.Error = runner.RunLogQuery(ctx, app.EnvironmentID, query, timeRange.Duration)
+		return msg
+	}
+}
+
+// probeTimeout is how long a probe may take, from sending the request until
+// its body was read
+const probeTimeout = time.Minute
+
+// CreateSendProbeCmd creates a command to send the request of a probe and
+// record its response
+func CreateSendProbeCmd(prober providers.Prober, probe models.Probe) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := context.WithTimeout(context.Background(), probeTimeout)
+		defer cancel()
+		probe.Response, probe.Err = prober.Probe(ctx, probe.Request)
+		return ProbeResultMsg{Probe: probe}
+	}
+}
+
+// maxLogLinesPerMsg is the most lines a LogLinesMsg carries, so that bursts of
+// lines are rendered at once without holding up the UI
+const maxLogLinesPerMsg = 500
+
+// CreateStartLogStreamCmd creates a command to start streaming the console or
+// system logs of a source, the stream at index among those making up the logs
+func CreateStartLogStreamCmd(provider providers.CommandProvider, streamID, index int, source models.LogSource) tea.Cmd {
+	return func() tea.Msg {
+		stream, err := provider.StreamLogs(source)
+		return LogStreamStartedMsg{StreamID: streamID, Index: index, Stream: stream, Error: err}
+	}
+}
+
+// CreateReadLogStreamCmd creates a command that waits for the next lines of a
+// log stream, along with the lines already received after them
+func CreateReadLogStreamCmd(stream providers.LogStream, streamID, index int) tea.Cmd {
+	return func() tea.Msg {
+		line, ok := <-stream.Lines()
+		if !ok {
+			return LogStreamEndedMsg{StreamID: streamID, Index: index, Error: stream.Err()}
+		}
+
+		lines := []string{line}
+		for len(lines) < maxLogLinesPerMsg {
+			select {
+			case line, ok := <-stream.Lines():
+				if !ok {
+					// The end is reported by the next read
+					return LogLinesMsg{StreamID: streamID, Index: index, Lines: lines}
+				}
+				lines = append(lines, line)
+			default:
+				return LogLinesMsg{StreamID: streamID, Index: index, Lines: lines}
+			}
+		}
+		return LogLinesMsg{StreamID: streamID, Index: index, Lines: lines}
+	}
+}
+
+// CreateLoadAppDetailsCmd creates a command to load the details of an app
+func CreateLoadAppDetailsCmd(provider providers.DataProvider, subscription string, app models.ContainerApp) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		details, err := provider.GetAppDetails(ctx, app.Name, app.ResourceGroup)
+		appID := app.ResourceGroup + "/" + app.Name
+		return LoadedAppDetailsMsg{AppID: appID, Details: details, Error: err}
+	}
+}
+
+// CreateLoadJobsCmd creates a command to load container app jobs
+func CreateLoadJobsCmd(provider providers.DataProvider, subscription, resourceGroup string) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		jobs, err := provider.ListJobs(ctx, resourceGroup)
+		return LoadedJobsMsg{Jobs: jobs, Error: err}
+	}
+}
+
+// CreateLoadJobExecutionsCmd creates a command to load job executions
+func CreateLoadJobExecutionsCmd(provider providers.DataProvider, subscription string, job models.Job) tea.Cmd {
+	return func() tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		executions, err := provider.ListJobExecutions(ctx, job.Name, job.ResourceGroup)
+		jobID := job.ResourceGroup + "/" + job.Name
+		return LoadedJobExecutionsMsg{JobID: jobID, Executions: executions, Error: err}
+	}
+}
+
+// logRateInterval is how often the rate of log lines is updated
+const logRateInterval = time.Second
+
+// CreateLogRateTickCmd creates a command that requests the logs page to be
+// rendered again after logRateInterval
+func CreateLogRateTickCmd(streamID int) tea.Cmd {
+	return tea.Tick(logRateInterval, func(time.Time) tea.Msg {
+		return LogRateTickMsg{StreamID: streamID}
+	})
+}
+
+// portForwardInterval is how often the traffic of port forwards is updated
+const portForwardInterval = time.Second
+
+// CreatePortForwardTickCmd creates a command that requests the port forwards
+// to be reported again after portForwardInterval
+func CreatePortForwardTickCmd() tea.Cmd {
+	return tea.Tick(portForwardInterval, func(time.Time) tea.Msg {
+		return PortForwardTickMsg{}
+	})
+}
+
+// CreateRefreshJobExecutionsCmd creates a command that requests a job executions refresh after a delay
+func CreateRefreshJobExecutionsCmd(jobID string, delay time.Duration) tea.Cmd {
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		return RefreshJobExecutionsMsg{JobID: jobID}
+	})
+}
+
+// CreateWatchRevisionCmd creates a command that reloads a revision of an app
+// after a delay, to follow its rollout
+func CreateWatchRevisionCmd(provider providers.DataProvider, subscription, appID, revisionName string, started time.Time, delay time.Duration) tea.Cmd {
+	resourceGroup, appName, _ := strings.Cut(appID, "/")
+	return tea.Tick(delay, func(time.Time) tea.Msg {
+		ctx, cancel := newLoadContext(subscription)
+		defer cancel()
+		msg := RevisionRolloutMsg{AppID: appID, RevisionName: revisionName, Started: started}
+		revisions, err := provider.ListRevisions(ctx, appName, resourceGroup)
+		if err != nil {
+			msg.Error = err
+			return msg
+		}
+		for _, rev := range revisions {
+			if rev.Name == revisionName {
+				msg.Revision, msg.Found = rev, true
+			}
+		}
+		return msg
+	})
+}
@@ -3,10 +3,16 @@ package core
 import (
 	"fmt"
 	"os"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/IAL32/az-tui/internal/models"
 	"github.com/IAL32/az-tui/internal/providers"
+	"github.com/IAL32/az-tui/internal/ui/components/logview"
+	"github.com/IAL32/az-tui/internal/ui/components/terminal"
 	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages/execsessions"
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
 )
@@ -24,16 +30,50 @@ type CoreModel struct {
 	// Providers
 	dataProvider    providers.DataProvider
 	commandProvider providers.CommandProvider
+	metricsProvider providers.MetricsProvider
+	logQueryRunner  providers.LogQueryRunner
+	prober          providers.Prober
 
 	// Context list for mode switching
 	contextList list.Model
 
+	// Log streams of the logs page by index, two for combined logs and more
+	// when tailing several apps, the ID telling them apart from streams started
+	// earlier, how many have not ended yet and the error the last one to fail
+	// ended with
+	logStreams     map[int]providers.LogStream
+	logStreamID    int
+	logStreamsOpen int
+	logStreamErr   error
+
+	// ID of the last exec session started
+	execSessions int
+
+	// Loopback ports forwarded to apps, in the order they were started
+	portForwards []portForward
+	// ID of the last port forward started
+	portForwardID int
+	// Whether the traffic of the port forwards is being updated
+	portForwardTicking bool
+
+	// ID of the last probe sent
+	probeID int
+
+	// Sparkline metrics loaded for the apps and revisions pages, the sparkline
+	// loads running or queued, and the slots limiting how many run at once
+	sparklines     map[sparklineKey]sparklineMetrics
+	sparklineLoads map[sparklineKey]bool
+	sparklineSlots chan struct{}
+
+	// Jobs whose executions refresh is already scheduled, by job ID
+	jobExecutionsRefreshPending map[string]bool
+
 	// Terminal dimensions
 	termW, termH int
 }
 
 // NewCoreModel creates a new core model
-func NewCoreModel(dataProvider providers.DataProvider, commandProvider providers.CommandProvider, termW, termH int) *CoreModel {
+func NewCoreModel(dataProvider providers.DataProvider, commandProvider providers.CommandProvider, metricsProvider providers.MetricsProvider, logQueryRunner providers.LogQueryRunner, prober providers.Prober, termW, termH int) *CoreModel {
 	// Create managers
 	navigationManager := NewNavigationManager()
 	stateManager := NewStateManager()
@@ -48,24 +88,95 @@ func NewCoreModel(dataProvider providers.DataProvider, commandProvider providers
 		layoutSystem:      layoutSystem,
 		dataProvider:      dataProvider,
 		commandProvider:   commandProvider,
+		metricsProvider:   metricsProvider,
+		logQueryRunner:    logQueryRunner,
+		prober:            prober,
+		sparklines:        make(map[sparklineKey]sparklineMetrics),
+		sparklineLoads:    make(map[sparklineKey]bool),
+		sparklineSlots:    make(chan struct{}, maxSparklineLoads),
 		termW:             termW,
 		termH:             termH,
 	}
 
+	// Stop streaming logs whenever the logs page is left
+	navigationManager.SetModeChangeFunc(coreModel.handleModeChange)
+
 	// Setup page navigation and actions
 	pageManager.SetupPageNavigation(coreModel)
 	pageManager.SetupPageActions(coreModel)
 
-	// Initialize with environment variable if available
+	// Initialize with environment variables if available
+	if subscription := os.Getenv("ACA_SUBSCRIPTION"); subscription != "" {
+		navigationManager.SetCurrentSubscription(subscription, subscription)
+		coreModel.applySubscription()
+	}
 	if rg := os.Getenv("ACA_RG"); rg != "" {
 		navigationManager.SetCurrentRG(rg)
 	}
+	if spec := os.Getenv("ACA_LOG_FIELDS"); spec != "" {
+		fields, err := logview.ParseFieldConfig(spec)
+		if err != nil {
+			coreModel.SetStatusLine(fmt.Sprintf("Ignoring ACA_LOG_FIELDS: %v", err))
+		} else {
+			pageManager.GetLogsPage().SetFieldConfig(fields)
+		}
+	}
+	if spec := os.Getenv("ACA_EXEC_SHELLS"); spec != "" {
+		shells, err := execsessions.ParseShellConfig(spec)
+		if err != nil {
+			coreModel.SetStatusLine(fmt.Sprintf("Ignoring ACA_EXEC_SHELLS: %v", err))
+		} else {
+			pageManager.GetExecPage().SetShellConfig(shells)
+		}
+	}
 
 	return coreModel
 }
 
 // Navigation methods
 
+// NavigateToSubscriptions navigates to subscriptions mode
+func (cm *CoreModel) NavigateToSubscriptions() tea.Cmd {
+	cm.navigationManager.NavigateToSubscriptions()
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the subscriptions page
+	page := cm.pageManager.GetSubscriptionsPage()
+	page.SetCurrentSubscription(cm.subscription())
+	page.SetLoading(true)
+	page.SetError(nil)
+
+	return cm.LoadSubscriptions()
+}
+
+// NavigateToSubscription switches to a subscription and navigates to its resource groups.
+// Only the commands of az-tui are scoped to it, the az CLI default is left unchanged.
+func (cm *CoreModel) NavigateToSubscription(sub models.Subscription) tea.Cmd {
+	cm.navigationManager.NavigateToSubscription(sub)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+	cm.applySubscription()
+
+	// Resource group names are only unique within a subscription
+	cm.stateManager.ClearContainerCache()
+
+	// Set up the resource groups page
+	page := cm.pageManager.GetResourceGroupsPage()
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	cm.SetStatusLine(fmt.Sprintf("Switched to subscription %s", sub.Name))
+	return cm.LoadResourceGroups()
+}
+
+// applySubscription scopes commands and the resource groups page to the subscription of the navigation state
+func (cm *CoreModel) applySubscription() {
+	navState := cm.navigationManager.GetNavigationState()
+	cm.commandProvider.SetSubscription(navState.CurrentSubscription)
+	clear(cm.sparklines)
+	cm.pageManager.GetResourceGroupsPage().SetSubscriptionContext(navState.CurrentSubscriptionName)
+}
+
 // NavigateToResourceGroups navigates to resource groups mode
 func (cm *CoreModel) NavigateToResourceGroups() tea.Cmd {
 	cm.navigationManager.NavigateToResourceGroups()
@@ -80,6 +191,37 @@ func (cm *CoreModel) NavigateToResourceGroups() tea.Cmd {
 	return cm.LoadResourceGroups()
 }
 
+// NavigateToEnvironments navigates to managed environments mode with resource group context
+func (cm *CoreModel) NavigateToEnvironments(rg models.ResourceGroup) tea.Cmd {
+	cm.navigationManager.NavigateToEnvironments(rg)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the environments page
+	page := cm.pageManager.GetEnvironmentsPage()
+	page.SetResourceGroupContext(rg.Name)
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadEnvironments(rg.Name)
+}
+
+// NavigateToEnvironmentApps navigates to apps mode with the apps of a managed environment
+func (cm *CoreModel) NavigateToEnvironmentApps(env models.ManagedEnvironment) tea.Cmd {
+	cm.navigationManager.NavigateToEnvironmentApps(env)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the apps page
+	page := cm.pageManager.GetAppsPage()
+	page.SetResourceGroupContext(cm.navigationManager.GetNavigationState().CurrentRG)
+	page.SetEnvironmentContext(env.Name)
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadApps(cm.navigationManager.GetNavigationState().CurrentRG)
+}
+
 // NavigateToApps navigates to apps mode with resource group context
 func (cm *CoreModel) NavigateToApps(rg models.ResourceGroup) tea.Cmd {
 	cm.navigationManager.NavigateToApps(rg)
@@ -88,6 +230,7 @@ func (cm *CoreModel) NavigateToApps(rg models.ResourceGroup) tea.Cmd {
 	// Set up the apps page
 	page := cm.pageManager.GetAppsPage()
 	page.SetResourceGroupContext(rg.Name)
+	page.SetEnvironmentContext("")
 	page.SetLoading(true)
 	page.SetError(nil)
 	page.ClearData()
@@ -105,6 +248,7 @@ func (cm *CoreModel) NavigateToRevisions(app models.ContainerApp) tea.Cmd {
 	page := cm.pageManager.GetRevisionsPage()
 	appID := cm.formatAppID(app)
 	page.SetAppContext(app.Name, appID)
+	page.SetRevisionMode(app.RevisionMode)
 	page.SetLoading(true)
 	page.SetError(nil)
 	page.ClearData()
@@ -112,6 +256,23 @@ func (cm *CoreModel) NavigateToRevisions(app models.ContainerApp) tea.Cmd {
 	return cm.LoadRevisions(app)
 }
 
+// NavigateToReplicas navigates to replicas mode with revision context
+func (cm *CoreModel) NavigateToReplicas(rev models.Revision) tea.Cmd {
+	cm.navigationManager.NavigateToReplicas(rev)
+	cm.stateManager.SetCurrentRevision(rev)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the replicas page
+	page := cm.pageManager.GetReplicasPage()
+	app := cm.GetCurrentApp()
+	page.SetRevisionContext(app.Name, cm.formatAppID(app), rev.Name)
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadReplicas(app, rev.Name)
+}
+
 // NavigateToContainers navigates to containers mode with revision context
 func (cm *CoreModel) NavigateToContainers(rev models.Revision) tea.Cmd {
 	cm.navigationManager.NavigateToContainers(rev)
@@ -130,6 +291,25 @@ func (cm *CoreModel) NavigateToContainers(rev models.Revision) tea.Cmd {
 	return cm.LoadContainers(app, rev.Name)
 }
 
+// NavigateToReplicaContainers navigates to containers mode with replica context
+func (cm *CoreModel) NavigateToReplicaContainers(replica models.Replica) tea.Cmd {
+	cm.navigationManager.NavigateToReplicaContainers(replica)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the containers page
+	page := cm.pageManager.GetContainersPage()
+	app := cm.GetCurrentApp()
+	revName := cm.navigationManager.GetNavigationState().CurrentRevName
+	page.SetRevisionContext(app.Name, cm.formatAppID(app), revName)
+	page.SetReplicaContext(replica.Name)
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	// Every replica runs the containers of its revision
+	return cm.LoadContainers(app, revName)
+}
+
 // NavigateToEnvVars navigates to environment variables mode with container context
 func (cm *CoreModel) NavigateToEnvVars(container models.Container) tea.Cmd {
 	cm.navigationManager.NavigateToEnvVars(container)
@@ -138,12 +318,329 @@ func (cm *CoreModel) NavigateToEnvVars(container models.Container) tea.Cmd {
 
 	// Set up the env vars page
 	page := cm.pageManager.GetEnvVarsPage()
+	app := cm.GetCurrentApp()
 	containers := cm.pageManager.GetContainersPage().GetData()
+	page.SetAppContext(app.Name, cm.formatAppID(app))
 	page.SetContainerContext(container.Name, containers)
 
 	return nil
 }
 
+// NavigateToAppDetails navigates to app details mode with app context
+func (cm *CoreModel) NavigateToAppDetails(app models.ContainerApp) tea.Cmd {
+	cm.navigationManager.NavigateToAppDetails(app)
+	cm.stateManager.SetCurrentApp(app)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the app details page
+	page := cm.pageManager.GetAppDetailsPage()
+	page.SetAppContext(app.Name, cm.formatAppID(app))
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadAppDetails(app)
+}
+
+// NavigateToSecrets navigates to the secrets of an app
+func (cm *CoreModel) NavigateToSecrets(app models.ContainerApp) tea.Cmd {
+	cm.navigationManager.NavigateToSecrets(app)
+	cm.stateManager.SetCurrentApp(app)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the secrets page
+	page := cm.pageManager.GetSecretsPage()
+	page.SetAppContext(app.Name, cm.formatAppID(app))
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadSecrets(app)
+}
+
+// NavigateToScale navigates to the scale rules of an app
+func (cm *CoreModel) NavigateToScale(app models.ContainerApp) tea.Cmd {
+	cm.navigationManager.NavigateToScale(app)
+	cm.stateManager.SetCurrentApp(app)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the scale page
+	page := cm.pageManager.GetScalePage()
+	page.SetAppContext(app.Name, cm.formatAppID(app))
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadScale(app)
+}
+
+// ShowEnvVarSecret navigates to the secrets of the current app, highlighting
+// the secret an environment variable references
+func (cm *CoreModel) ShowEnvVarSecret(secretName string) tea.Cmd {
+	cmd := cm.NavigateToSecrets(cm.GetCurrentApp())
+	cm.pageManager.GetSecretsPage().SelectSecret(secretName)
+	return cmd
+}
+
+// NavigateToTraffic navigates to the traffic split editor of the current app
+func (cm *CoreModel) NavigateToTraffic() tea.Cmd {
+	cm.navigationManager.NavigateToTraffic()
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the traffic page
+	page := cm.pageManager.GetTrafficPage()
+	app := cm.GetCurrentApp()
+	page.SetAppContext(app.Name, cm.formatAppID(app))
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadTraffic(app)
+}
+
+// NavigateToMetrics navigates to the metrics of an app, or of one of its
+// revisions when revName is set
+func (cm *CoreModel) NavigateToMetrics(app models.ContainerApp, revName string) tea.Cmd {
+	cm.navigationManager.NavigateToMetrics(app, revName)
+	cm.stateManager.SetCurrentApp(app)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the metrics page, keeping the time window selected last
+	page := cm.pageManager.GetMetricsPage()
+	page.SetAppContext(app.Name, cm.formatAppID(app), revName)
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadMetrics(app, revName, page.GetWindow())
+}
+
+// SetMetricsWindow reloads the metrics page over another time window
+func (cm *CoreModel) SetMetricsWindow(window models.MetricsWindow) tea.Cmd {
+	page := cm.pageManager.GetMetricsPage()
+	page.SetWindow(window)
+	page.SetLoading(true)
+	page.SetError(nil)
+
+	return cm.LoadMetrics(cm.GetCurrentApp(), page.GetRevisionName(), window)
+}
+
+// ProbeApp navigates to the HTTP probe of the ingress of an app
+func (cm *CoreModel) ProbeApp(app models.ContainerApp) tea.Cmd {
+	return cm.openProbe(app, "", app.IngressFQDN)
+}
+
+// ProbeRevision navigates to the HTTP probe of the ingress of a revision, which
+// reaches it whatever traffic it gets
+func (cm *CoreModel) ProbeRevision(app models.ContainerApp, rev models.Revision) tea.Cmd {
+	return cm.openProbe(app, rev.Name, rev.FQDN)
+}
+
+// openProbe navigates to the HTTP probe of an app or revision served at fqdn
+func (cm *CoreModel) openProbe(app models.ContainerApp, revName, fqdn string) tea.Cmd {
+	if fqdn == "" {
+		target := app.Name
+		if revName != "" {
+			target = revName
+		}
+		cm.SetStatusLine(fmt.Sprintf("Cannot probe %s: no ingress is enabled", target))
+		return nil
+	}
+
+	cm.navigationManager.NavigateToProbe(app, revName)
+	cm.stateManager.SetCurrentApp(app)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the probe page, keeping the request as edited and the history
+	cm.pageManager.GetProbePage().SetTarget(app.Name, revName, fqdn)
+	return nil
+}
+
+// SendProbe sends a request to the app or revision of the probe page
+func (cm *CoreModel) SendProbe(target string, request models.ProbeRequest) tea.Cmd {
+	cm.probeID++
+	return CreateSendProbeCmd(cm.prober, models.Probe{
+		ID:      cm.probeID,
+		Target:  target,
+		SentAt:  time.Now(),
+		Request: request,
+	})
+}
+
+// ShowLogs navigates to the logs page and starts streaming the logs of a
+// source. Without a log type, the type shown last is kept.
+func (cm *CoreModel) ShowLogs(source models.LogSource) tea.Cmd {
+	cm.navigationManager.NavigateToLogs(source)
+	cm.stateManager.SetCurrentApp(source.App)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	page := cm.pageManager.GetLogsPage()
+	if source.Type == "" {
+		source.Type = page.GetSource().Type
+	}
+	page.SetSource(source)
+	return cm.ReconnectLogs()
+}
+
+// ReconnectLogs restarts the log streams of the logs page, dropping the lines received so far
+func (cm *CoreModel) ReconnectLogs() tea.Cmd {
+	cm.stopLogStream()
+
+	page := cm.pageManager.GetLogsPage()
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	var cmds []tea.Cmd
+	for i, source := range page.Streams() {
+		cmds = append(cmds, CreateStartLogStreamCmd(cm.commandProvider, cm.logStreamID, i, source))
+	}
+	cm.logStreamsOpen = len(cmds)
+	cmds = append(cmds, CreateLogRateTickCmd(cm.logStreamID))
+	return tea.Batch(cmds...)
+}
+
+// TailApps navigates to the logs page and tails the logs of the latest
+// revisions of several apps together, keeping the log type shown last
+func (cm *CoreModel) TailApps(apps []models.ContainerApp) tea.Cmd {
+	if len(apps) == 1 {
+		return cm.ShowLogs(models.LogSource{App: apps[0]})
+	}
+
+	cm.navigationManager.NavigateToAppsLogs()
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	page := cm.pageManager.GetLogsPage()
+	logType := page.GetSource().Type
+	sources := make([]models.LogSource, len(apps))
+	for i, app := range apps {
+		sources[i] = models.LogSource{App: app, Revision: app.LatestRevision, Type: logType}
+	}
+	page.SetSources(sources)
+	return cm.ReconnectLogs()
+}
+
+// SetLogType switches the logs page between console, system and combined logs
+func (cm *CoreModel) SetLogType(logType models.LogType) tea.Cmd {
+	page := cm.pageManager.GetLogsPage()
+	sources := slices.Clone(page.GetSources())
+	for i := range sources {
+		sources[i].Type = logType
+	}
+	page.SetSources(sources)
+	return cm.ReconnectLogs()
+}
+
+// NavigateToLogQuery navigates to the log query page, scoped to the app,
+// revision, replica and container of the logs being viewed, and runs the query
+func (cm *CoreModel) NavigateToLogQuery() tea.Cmd {
+	// Queries are scoped to a single app
+	if cm.pageManager.GetLogsPage().IsAggregated() {
+		cm.SetStatusLine("Query history is only available for the logs of a single app")
+		return nil
+	}
+
+	cm.navigationManager.NavigateToLogQuery()
+	navState := cm.navigationManager.GetNavigationState()
+
+	// Set up the log query page, keeping the query and time range unless the scope changed
+	page := cm.pageManager.GetLogQueryPage()
+	page.SetScope(models.LogSource{
+		App:       cm.GetCurrentApp(),
+		Revision:  navState.CurrentRevName,
+		Replica:   navState.CurrentReplicaName,
+		Container: navState.CurrentContainerName,
+		Type:      cm.pageManager.GetLogsPage().GetSource().Type,
+	})
+	page.ClearData()
+
+	return cm.RunLogQuery(page.GetQuery(), page.GetTimeRange())
+}
+
+// RunLogQuery runs a query of the log query page over the logs of a time range
+func (cm *CoreModel) RunLogQuery(query string, timeRange models.LogQueryRange) tea.Cmd {
+	page := cm.pageManager.GetLogQueryPage()
+	page.SetLoading(true)
+	page.SetError(nil)
+
+	return CreateRunLogQueryCmd(cm.logQueryRunner, cm.subscription(), page.GetScope().App, query, timeRange)
+}
+
+// handleModeChange stops the log streams once the logs page is left. Logs are
+// only streamed while the logs page is open, or the log query opened from it.
+func (cm *CoreModel) handleModeChange(event ModeChangeEvent) {
+	if event.NewMode != ModeLogs && event.NewMode != ModeLogQuery && cm.logStreamsOpen > 0 {
+		cm.stopLogStream()
+	}
+}
+
+// stopLogStream closes the log streams of the logs page, if any, and ignores
+// whatever streams started before send from now on
+func (cm *CoreModel) stopLogStream() {
+	for _, stream := range cm.logStreams {
+		stream.Close()
+	}
+	cm.logStreams = make(map[int]providers.LogStream)
+	cm.logStreamsOpen = 0
+	cm.logStreamErr = nil
+	cm.logStreamID++
+}
+
+// NavigateToOperations navigates to the operation log
+func (cm *CoreModel) NavigateToOperations() tea.Cmd {
+	cm.navigationManager.NavigateToOperations()
+
+	// Set up the operations page
+	page := cm.pageManager.GetOperationsPage()
+	page.SetData(cm.stateManager.GetOperations())
+
+	return nil
+}
+
+// NavigateToExec shows the exec sessions that are open
+func (cm *CoreModel) NavigateToExec() tea.Cmd {
+	cm.navigationManager.NavigateToExec()
+	return nil
+}
+
+// NavigateToPortForwards shows the port forwards that are open
+func (cm *CoreModel) NavigateToPortForwards() tea.Cmd {
+	cm.navigationManager.NavigateToPortForwards()
+	cm.refreshPortForwards()
+	return nil
+}
+
+// NavigateToJobs navigates to jobs mode with resource group context
+func (cm *CoreModel) NavigateToJobs(rg models.ResourceGroup) tea.Cmd {
+	cm.navigationManager.NavigateToJobs(rg)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the jobs page
+	page := cm.pageManager.GetJobsPage()
+	page.SetResourceGroupContext(rg.Name)
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadJobs(rg.Name)
+}
+
+// NavigateToJobExecutions navigates to job executions mode with job context
+func (cm *CoreModel) NavigateToJobExecutions(job models.Job) tea.Cmd {
+	cm.navigationManager.NavigateToJobExecutions(job)
+	cm.stateManager.SetCurrentJob(job)
+	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+
+	// Set up the job executions page
+	page := cm.pageManager.GetJobExecutionsPage()
+	page.SetJobContext(job.Name, cm.formatJobID(job))
+	page.SetLoading(true)
+	page.SetError(nil)
+	page.ClearData()
+
+	return cm.LoadJobExecutions(job)
+}
+
 // GoBack navigates back to the previous mode
 func (cm *CoreModel) GoBack() tea.Cmd {
 	if !cm.navigationManager.GoBack() {
@@ -151,23 +648,70 @@ func (cm *CoreModel) GoBack() tea.Cmd {
 	}
 
 	cm.stateManager.ValidateState(cm.navigationManager.GetNavigationState())
+	cm.applySubscription()
 
 	// Refresh the current page if needed
 	switch cm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		cm.pageManager.GetSubscriptionsPage().SetCurrentSubscription(cm.subscription())
+		return cm.LoadSubscriptions()
 	case ModeResourceGroups:
 		return cm.LoadResourceGroups()
+	case ModeEnvironments:
+		navState := cm.navigationManager.GetNavigationState()
+		return cm.LoadEnvironments(navState.CurrentRG)
 	case ModeApps:
 		navState := cm.navigationManager.GetNavigationState()
+		cm.pageManager.GetAppsPage().SetEnvironmentContext(navState.CurrentEnvironment)
 		return cm.LoadApps(navState.CurrentRG)
 	case ModeRevisions:
 		if app, ok := cm.stateManager.GetCurrentApp(); ok {
 			return cm.LoadRevisions(app)
 		}
+	case ModeReplicas:
+		if app, ok := cm.stateManager.GetCurrentApp(); ok {
+			navState := cm.navigationManager.GetNavigationState()
+			return cm.LoadReplicas(app, navState.CurrentRevName)
+		}
 	case ModeContainers:
 		if app, ok := cm.stateManager.GetCurrentApp(); ok {
 			navState := cm.navigationManager.GetNavigationState()
+			cm.pageManager.GetContainersPage().SetReplicaContext(navState.CurrentReplicaName)
 			return cm.LoadContainers(app, navState.CurrentRevName)
 		}
+	case ModeTraffic:
+		if app, ok := cm.stateManager.GetCurrentApp(); ok {
+			return cm.LoadTraffic(app)
+		}
+	case ModeJobs:
+		navState := cm.navigationManager.GetNavigationState()
+		return cm.LoadJobs(navState.CurrentRG)
+	case ModeJobExecutions:
+		if job, ok := cm.stateManager.GetCurrentJob(); ok {
+			return cm.LoadJobExecutions(job)
+		}
+	case ModeAppDetails:
+		if app, ok := cm.stateManager.GetCurrentApp(); ok {
+			return cm.LoadAppDetails(app)
+		}
+	case ModeSecrets:
+		if app, ok := cm.stateManager.GetCurrentApp(); ok {
+			return cm.LoadSecrets(app)
+		}
+	case ModeScale:
+		if app, ok := cm.stateManager.GetCurrentApp(); ok {
+			return cm.LoadScale(app)
+		}
+	case ModeMetrics:
+		if app, ok := cm.stateManager.GetCurrentApp(); ok {
+			page := cm.pageManager.GetMetricsPage()
+			return cm.LoadMetrics(app, page.GetRevisionName(), page.GetWindow())
+		}
+	case ModeLogs:
+		// The streams were stopped unless coming back from the log query page
+		if cm.logStreamsOpen == 0 {
+			return cm.ReconnectLogs()
+		}
 	}
 
 	return nil
@@ -175,68 +719,476 @@ func (cm *CoreModel) GoBack() tea.Cmd {
 
 // Data loading methods
 
+// subscription returns the subscription every az command runs against, empty for the az CLI default
+func (cm *CoreModel) subscription() string {
+	return cm.navigationManager.GetNavigationState().CurrentSubscription
+}
+
+// LoadSubscriptions loads the subscriptions of the signed-in account
+func (cm *CoreModel) LoadSubscriptions() tea.Cmd {
+	return CreateLoadSubscriptionsCmd(cm.dataProvider)
+}
+
 // LoadResourceGroups loads resource groups data
 func (cm *CoreModel) LoadResourceGroups() tea.Cmd {
-	return CreateLoadResourceGroupsCmd(cm.dataProvider)
+	return CreateLoadResourceGroupsCmd(cm.dataProvider, cm.subscription())
 }
 
-// LoadApps loads apps data for a resource group
+// LoadEnvironments loads managed environments data for a resource group
+func (cm *CoreModel) LoadEnvironments(resourceGroup string) tea.Cmd {
+	return CreateLoadEnvironmentsCmd(cm.dataProvider, cm.subscription(), resourceGroup)
+}
+
+// LoadApps loads apps data for a resource group, or for the current managed environment
+// when one is selected since its apps may live in other resource groups
 func (cm *CoreModel) LoadApps(resourceGroup string) tea.Cmd {
-	return CreateLoadAppsCmd(cm.dataProvider, resourceGroup)
+	if environmentID := cm.navigationManager.GetNavigationState().CurrentEnvironmentID; environmentID != "" {
+		return CreateLoadEnvironmentAppsCmd(cm.dataProvider, cm.subscription(), environmentID)
+	}
+	return CreateLoadAppsCmd(cm.dataProvider, cm.subscription(), resourceGroup)
 }
 
 // LoadRevisions loads revisions data for an app
 func (cm *CoreModel) LoadRevisions(app models.ContainerApp) tea.Cmd {
-	return CreateLoadRevisionsCmd(cm.dataProvider, app)
+	return CreateLoadRevisionsCmd(cm.dataProvider, cm.subscription(), app)
+}
+
+// LoadTraffic loads the traffic split of an app
+func (cm *CoreModel) LoadTraffic(app models.ContainerApp) tea.Cmd {
+	return CreateLoadTrafficCmd(cm.dataProvider, cm.subscription(), app)
+}
+
+// LoadSecrets loads the secrets of an app
+func (cm *CoreModel) LoadSecrets(app models.ContainerApp) tea.Cmd {
+	return CreateLoadSecretsCmd(cm.dataProvider, cm.subscription(), app)
+}
+
+// LoadScale loads the replica bounds and scale rules of an app
+func (cm *CoreModel) LoadScale(app models.ContainerApp) tea.Cmd {
+	return CreateLoadScaleCmd(cm.dataProvider, cm.subscription(), app)
+}
+
+// RevealSecret shows the value of a secret of the current app
+func (cm *CoreModel) RevealSecret(secret models.Secret) tea.Cmd {
+	return CreateShowSecretCmd(cm.dataProvider, cm.subscription(), cm.GetCurrentApp(), secret.Name)
+}
+
+// LoadReplicas loads the replicas of a revision
+func (cm *CoreModel) LoadReplicas(app models.ContainerApp, revName string) tea.Cmd {
+	return CreateLoadReplicasCmd(cm.dataProvider, cm.subscription(), app, revName)
 }
 
 // LoadContainers loads containers data for a revision
 func (cm *CoreModel) LoadContainers(app models.ContainerApp, revName string) tea.Cmd {
-	return CreateLoadContainersCmd(cm.dataProvider, app, revName)
+	return CreateLoadContainersCmd(cm.dataProvider, cm.subscription(), app, revName)
+}
+
+// LoadAppDetails loads the JSON document of an app
+func (cm *CoreModel) LoadAppDetails(app models.ContainerApp) tea.Cmd {
+	return CreateLoadAppDetailsCmd(cm.dataProvider, cm.subscription(), app)
+}
+
+// LoadMetrics loads the metrics of an app, or of one of its revisions when revName is set
+func (cm *CoreModel) LoadMetrics(app models.ContainerApp, revName string, window models.MetricsWindow) tea.Cmd {
+	return CreateLoadMetricsCmd(cm.metricsProvider, cm.subscription(), app, revName, window)
+}
+
+// maxSparklineLoads is how many sparkline loads run at once, each being an
+// az monitor process taking seconds
+const maxSparklineLoads = 4
+
+// sparklineKey identifies the sparkline metrics of an app, or of one of its
+// revisions when revName is set, over a time window
+type sparklineKey struct {
+	appID, revName, window string
+}
+
+// sparklineMetrics are sparkline metrics and when they were loaded
+type sparklineMetrics struct {
+	metrics  models.MetricSet
+	loadedAt time.Time
+}
+
+// LoadAppSparklines loads the metrics shown inline for apps, over the shortest time window
+func (cm *CoreModel) LoadAppSparklines(apps []models.ContainerApp) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, app := range apps {
+		cmds = append(cmds, cm.loadSparkline(app, ""))
+	}
+	return tea.Batch(cmds...)
+}
+
+// LoadRevisionSparklines loads the metrics shown inline for the revisions of an app,
+// over the shortest time window
+func (cm *CoreModel) LoadRevisionSparklines(app models.ContainerApp, revisions []models.Revision) tea.Cmd {
+	var cmds []tea.Cmd
+	for _, rev := range revisions {
+		cmds = append(cmds, cm.loadSparkline(app, rev.Name))
+	}
+	return tea.Batch(cmds...)
+}
+
+// loadSparkline loads the sparkline metrics of an app or revision. Metrics
+// loaded less than a point interval ago are shown again rather than loaded,
+// and loads already running are not repeated, so that reloading a list does
+// not start an az process per row every time.
+func (cm *CoreModel) loadSparkline(app models.ContainerApp, revName string) tea.Cmd {
+	window := models.MetricsWindows[0]
+	key := sparklineKey{appID: cm.formatAppID(app), revName: revName, window: window.Name}
+	if cached, ok := cm.sparklines[key]; ok && time.Since(cached.loadedAt) < window.Interval {
+		cm.setSparkline(key, cached.metrics)
+		return nil
+	}
+	if cm.sparklineLoads[key] {
+		return nil
+	}
+	cm.sparklineLoads[key] = true
+
+	load := cm.LoadMetrics(app, revName, window)
+	slots := cm.sparklineSlots
+	return func() tea.Msg {
+		slots <- struct{}{}
+		defer func() { <-slots }()
+		return load()
+	}
+}
+
+// setSparkline shows sparkline metrics on the apps page, or on the revisions
+// page when they are of a revision of the current app
+func (cm *CoreModel) setSparkline(key sparklineKey, metrics models.MetricSet) {
+	if key.revName == "" {
+		cm.pageManager.GetAppsPage().SetMetrics(key.appID, metrics)
+	} else if key.appID == cm.GetNavigationState().CurrentAppID {
+		cm.pageManager.GetRevisionsPage().SetMetrics(key.revName, metrics)
+	}
+}
+
+// LoadJobs loads container app jobs data for a resource group
+func (cm *CoreModel) LoadJobs(resourceGroup string) tea.Cmd {
+	return CreateLoadJobsCmd(cm.dataProvider, cm.subscription(), resourceGroup)
+}
+
+// LoadJobExecutions loads executions data for a job
+func (cm *CoreModel) LoadJobExecutions(job models.Job) tea.Cmd {
+	return CreateLoadJobExecutionsCmd(cm.dataProvider, cm.subscription(), job)
 }
 
 // Action methods
 
 // ShowAppLogs shows logs for an app
 func (cm *CoreModel) ShowAppLogs(app models.ContainerApp) tea.Cmd {
-	return cm.commandProvider.ShowAppLogs(app)
+	return cm.ShowLogs(models.LogSource{App: app})
 }
 
-// ExecIntoApp executes into an app
+// ExecIntoApp opens an exec session in the latest revision of an app
 func (cm *CoreModel) ExecIntoApp(app models.ContainerApp) tea.Cmd {
-	return cm.commandProvider.ExecIntoApp(app)
+	return cm.OpenExec(models.ExecTarget{App: app})
 }
 
 // RestartRevision restarts a revision
 func (cm *CoreModel) RestartRevision(rev models.Revision) tea.Cmd {
 	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Restarting revision %s...", rev.Name))
 	return cm.commandProvider.RestartRevision(app, rev.Name)
 }
 
+// ActivateRevision activates an inactive revision of the current app
+func (cm *CoreModel) ActivateRevision(rev models.Revision) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Activating revision %s...", rev.Name))
+	return cm.commandProvider.ActivateRevision(app, rev.Name)
+}
+
+// DeactivateRevision deactivates an active revision of the current app
+func (cm *CoreModel) DeactivateRevision(rev models.Revision) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Deactivating revision %s...", rev.Name))
+	return cm.commandProvider.DeactivateRevision(app, rev.Name)
+}
+
+// AddRevisionLabel labels a revision of the current app
+func (cm *CoreModel) AddRevisionLabel(rev models.Revision, label string) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Adding label %s to revision %s...", label, rev.Name))
+	return cm.commandProvider.AddRevisionLabel(app, rev.Name, label)
+}
+
+// RemoveRevisionLabel removes a label from the traffic split of the current app
+func (cm *CoreModel) RemoveRevisionLabel(label string) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Removing label %s from %s...", label, app.Name))
+	return cm.commandProvider.RemoveRevisionLabel(app, label)
+}
+
+// SetRevisionMode switches the current app to single or multiple revision mode
+func (cm *CoreModel) SetRevisionMode(mode string) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Switching %s to %s revision mode...", app.Name, strings.ToLower(mode)))
+	return cm.commandProvider.SetRevisionMode(app, mode)
+}
+
+// SetTraffic applies a new traffic split to the current app
+func (cm *CoreModel) SetTraffic(weights []models.TrafficWeight) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Updating traffic split of %s...", app.Name))
+	return cm.commandProvider.SetTraffic(app, weights)
+}
+
+// UpdateContainerEnv applies env var changes to a container of the current app
+func (cm *CoreModel) UpdateContainerEnv(update models.EnvUpdate) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Updating env vars of %s in %s...", update.Container, app.Name))
+	return cm.commandProvider.UpdateContainerEnv(app, update)
+}
+
+// UpdateContainerImage replaces the image of a container of the current app
+func (cm *CoreModel) UpdateContainerImage(container, image string) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Updating image of %s in %s to %s...", container, app.Name, image))
+	return cm.commandProvider.UpdateContainerImage(app, container, image)
+}
+
+// ShiftTrafficToRevision routes all traffic of the current app to a revision
+func (cm *CoreModel) ShiftTrafficToRevision(revisionName string) tea.Cmd {
+	return cm.SetTraffic([]models.TrafficWeight{{RevisionName: revisionName, Weight: 100}})
+}
+
+// UpdateScale replaces the replica bounds and scale rules of the current app
+func (cm *CoreModel) UpdateScale(scale models.Scale) tea.Cmd {
+	app := cm.GetCurrentApp()
+	cm.SetStatusLine(fmt.Sprintf("Updating scale of %s...", app.Name))
+	return cm.commandProvider.UpdateScale(app, scale)
+}
+
+// StartJob starts a new execution of a job
+func (cm *CoreModel) StartJob(job models.Job) tea.Cmd {
+	cm.SetStatusLine(fmt.Sprintf("Starting job %s...", job.Name))
+	return cm.commandProvider.StartJob(job)
+}
+
+// StopJobExecution stops a running execution of the current job
+func (cm *CoreModel) StopJobExecution(execution models.JobExecution) tea.Cmd {
+	if execution.Status != "Running" {
+		cm.SetStatusLine(fmt.Sprintf("Execution %s is not running", execution.Name))
+		return nil
+	}
+	cm.SetStatusLine(fmt.Sprintf("Stopping execution %s...", execution.Name))
+	return cm.commandProvider.StopJobExecution(cm.GetCurrentJob(), execution.Name)
+}
+
+// RerunJobExecution re-runs a finished execution of the current job
+func (cm *CoreModel) RerunJobExecution(execution models.JobExecution) tea.Cmd {
+	if execution.Status == "Running" {
+		cm.SetStatusLine(fmt.Sprintf("Execution %s is still running", execution.Name))
+		return nil
+	}
+	cm.SetStatusLine(fmt.Sprintf("Re-running execution %s...", execution.Name))
+	return cm.commandProvider.RerunJobExecution(cm.GetCurrentJob(), execution.Name)
+}
+
 // ShowRevisionLogs shows logs for a revision
 func (cm *CoreModel) ShowRevisionLogs(rev models.Revision) tea.Cmd {
-	app := cm.GetCurrentApp()
-	return cm.commandProvider.ShowRevisionLogs(app, rev.Name)
+	return cm.ShowLogs(models.LogSource{App: cm.GetCurrentApp(), Revision: rev.Name})
 }
 
-// ExecIntoRevision executes into a revision
+// ExecIntoRevision opens an exec session in a revision
 func (cm *CoreModel) ExecIntoRevision(rev models.Revision) tea.Cmd {
-	app := cm.GetCurrentApp()
-	return cm.commandProvider.ExecIntoRevision(app, rev.Name)
+	return cm.OpenExec(models.ExecTarget{App: cm.GetCurrentApp(), Revision: rev.Name})
+}
+
+// ShowReplicaLogs shows logs for a replica of the current revision
+func (cm *CoreModel) ShowReplicaLogs(replica models.Replica) tea.Cmd {
+	navState := cm.navigationManager.GetNavigationState()
+	return cm.ShowLogs(models.LogSource{
+		App:      cm.GetCurrentApp(),
+		Revision: navState.CurrentRevName,
+		Replica:  replica.Name,
+	})
+}
+
+// ExecIntoReplica opens an exec session in a replica of the current revision
+func (cm *CoreModel) ExecIntoReplica(replica models.Replica) tea.Cmd {
+	navState := cm.navigationManager.GetNavigationState()
+	return cm.OpenExec(models.ExecTarget{
+		App:      cm.GetCurrentApp(),
+		Revision: navState.CurrentRevName,
+		Replica:  replica.Name,
+	})
 }
 
-// ShowContainerLogs shows logs for a container
+// ShowContainerLogs shows logs for a container, in the current replica if one is selected
 func (cm *CoreModel) ShowContainerLogs(container models.Container) tea.Cmd {
-	app := cm.GetCurrentApp()
 	navState := cm.navigationManager.GetNavigationState()
-	return cm.commandProvider.ShowContainerLogs(app, navState.CurrentRevName, container.Name)
+	return cm.ShowLogs(models.LogSource{
+		App:       cm.GetCurrentApp(),
+		Revision:  navState.CurrentRevName,
+		Replica:   navState.CurrentReplicaName,
+		Container: container.Name,
+	})
 }
 
-// ExecIntoContainer executes into a container
+// ExecIntoContainer opens an exec session in a container, in the current replica if one is selected
 func (cm *CoreModel) ExecIntoContainer(container models.Container) tea.Cmd {
-	app := cm.GetCurrentApp()
 	navState := cm.navigationManager.GetNavigationState()
-	return cm.commandProvider.ExecIntoContainer(app, navState.CurrentRevName, container.Name)
+	return cm.OpenExec(models.ExecTarget{
+		App:       cm.GetCurrentApp(),
+		Revision:  navState.CurrentRevName,
+		Replica:   navState.CurrentReplicaName,
+		Container: container.Name,
+		Image:     container.Image,
+	})
+}
+
+// OpenExec shows the exec sessions, asking for the program to run in a target.
+// The image of the target, which chooses the shells offered, is looked up in
+// the containers already loaded when it is not known.
+func (cm *CoreModel) OpenExec(target models.ExecTarget) tea.Cmd {
+	if target.Image == "" {
+		target.Image = cm.cachedImage(target)
+	}
+	if cm.GetCurrentMode() != ModeExec {
+		cm.navigationManager.NavigateToExec()
+	}
+	return cm.pageManager.GetExecPage().PromptCommand(target)
+}
+
+// cachedImage returns the image of the container an exec session runs in, the
+// first container when none is set as az does, from the containers loaded
+func (cm *CoreModel) cachedImage(target models.ExecTarget) string {
+	revision := target.Revision
+	if revision == "" {
+		revision = target.App.LatestRevision
+	}
+	containers, ok := cm.stateManager.GetContainersForRevision(cm.formatAppID(target.App), revision)
+	if !ok || len(containers) == 0 {
+		return ""
+	}
+	if target.Container == "" {
+		return containers[0].Image
+	}
+	for _, container := range containers {
+		if container.Name == target.Container {
+			return container.Image
+		}
+	}
+	return ""
+}
+
+// StartExec starts an exec session running a command in a target, in a
+// terminal the size of the exec sessions page
+func (cm *CoreModel) StartExec(target models.ExecTarget, command string) tea.Cmd {
+	page := cm.pageManager.GetExecPage()
+	cols, rows := page.TerminalSize()
+	cm.execSessions++
+	name := fmt.Sprintf("%s %s", target, command)
+	session, err := terminal.Start(cm.execSessions, name, cm.commandProvider.ExecCommand(target, command), cols, rows)
+	if err != nil {
+		page.SetStartError(target, command, err)
+		return nil
+	}
+	page.AddSession(session, target, command)
+	return session.Read()
+}
+
+// portForward is a tunnel started for an app
+type portForward struct {
+	id      int
+	app     models.ContainerApp
+	tunnel  providers.Tunnel
+	started time.Time
+}
+
+// StartPortForward forwards a loopback port to the ingress of an app and shows
+// the port forwards. The target port of the app is used when it is free,
+// otherwise any free port.
+func (cm *CoreModel) StartPortForward(app models.ContainerApp) tea.Cmd {
+	page := cm.pageManager.GetPortForwardsPage()
+	appID := cm.formatAppID(app)
+	for _, forward := range cm.portForwards {
+		if cm.formatAppID(forward.app) == appID && forward.tunnel.Err() == nil {
+			page.SetStatusMessage(fmt.Sprintf("%s is already forwarded to http://%s", app.Name, forward.tunnel.LocalAddr()))
+			cm.showPortForwards()
+			return nil
+		}
+	}
+
+	tunnel, err := cm.commandProvider.PortForward(app, app.TargetPort)
+	if err != nil && app.TargetPort != 0 {
+		tunnel, err = cm.commandProvider.PortForward(app, 0)
+	}
+	if err != nil {
+		cm.SetStatusLine(fmt.Sprintf("Failed to forward a port to %s: %v", app.Name, err))
+		return nil
+	}
+
+	cm.portForwardID++
+	cm.portForwards = append(cm.portForwards, portForward{
+		id:      cm.portForwardID,
+		app:     app,
+		tunnel:  tunnel,
+		started: time.Now(),
+	})
+	page.SetStatusMessage(fmt.Sprintf("Forwarding http://%s to %s", tunnel.LocalAddr(), tunnel.Remote()))
+
+	cm.showPortForwards()
+	if !cm.portForwardTicking {
+		cm.portForwardTicking = true
+		return CreatePortForwardTickCmd()
+	}
+	return nil
+}
+
+// showPortForwards navigates to the port forwards unless they are shown already
+func (cm *CoreModel) showPortForwards() {
+	if cm.GetCurrentMode() != ModePortForwards {
+		cm.navigationManager.NavigateToPortForwards()
+	}
+	cm.refreshPortForwards()
+}
+
+// StopPortForward stops forwarding a port
+func (cm *CoreModel) StopPortForward(forward models.PortForward) tea.Cmd {
+	page := cm.pageManager.GetPortForwardsPage()
+	for i, f := range cm.portForwards {
+		if f.id != forward.ID {
+			continue
+		}
+		if err := f.tunnel.Close(); err != nil {
+			page.SetStatusMessage(fmt.Sprintf("Failed to stop forwarding to %s: %v", f.app.Name, err))
+		} else {
+			page.SetStatusMessage(fmt.Sprintf("Stopped forwarding http://%s to %s", forward.LocalAddr, f.app.Name))
+		}
+		cm.portForwards = append(cm.portForwards[:i], cm.portForwards[i+1:]...)
+		break
+	}
+	cm.refreshPortForwards()
+	return nil
+}
+
+// PortForwardCount returns how many ports are forwarded
+func (cm *CoreModel) PortForwardCount() int {
+	return len(cm.portForwards)
+}
+
+// refreshPortForwards shows the current traffic of the port forwards
+func (cm *CoreModel) refreshPortForwards() {
+	forwards := make([]models.PortForward, len(cm.portForwards))
+	for i, f := range cm.portForwards {
+		stats := f.tunnel.Stats()
+		forwards[i] = models.PortForward{
+			ID:            f.id,
+			App:           f.app,
+			LocalAddr:     f.tunnel.LocalAddr(),
+			Remote:        f.tunnel.Remote(),
+			StartedAt:     f.started,
+			Connections:   stats.Connections,
+			Active:        stats.Active,
+			BytesSent:     stats.BytesSent,
+			BytesReceived: stats.BytesReceived,
+			Err:           f.tunnel.Err(),
+		}
+	}
+	cm.pageManager.GetPortForwardsPage().SetForwards(forwards)
 }
 
 // State access methods
@@ -287,9 +1239,39 @@ func (cm *CoreModel) GetCurrentContainer() models.Container {
 	return models.Container{}
 }
 
-// SetStatusLine sets the global status line
+// ExecSessionCount returns how many exec sessions are open
+func (cm *CoreModel) ExecSessionCount() int {
+	return cm.pageManager.GetExecPage().Sessions()
+}
+
+// GetCurrentJob returns the current job
+func (cm *CoreModel) GetCurrentJob() models.Job {
+	if job, ok := cm.stateManager.GetCurrentJob(); ok {
+		return job
+	}
+	// Fallback: try to get from jobs page
+	if job, ok := cm.pageManager.GetJobsPage().GetSelectedItem(); ok {
+		return job
+	}
+	return models.Job{}
+}
+
+// IsProductionResourceGroup reports whether the named resource group is tagged as
+// production. A resource group whose tags are not loaded, as when starting in it
+// with ACA_RG before the resource groups are listed, is treated as production.
+func (cm *CoreModel) IsProductionResourceGroup(name string) bool {
+	for _, rg := range cm.pageManager.GetResourceGroupsPage().GetData() {
+		if strings.EqualFold(rg.Name, name) {
+			return rg.IsProduction()
+		}
+	}
+	return true
+}
+
+// SetStatusLine sets the global status line, shown in the status bar of every page
 func (cm *CoreModel) SetStatusLine(status string) {
 	cm.stateManager.SetStatusLine(status)
+	cm.layoutSystem.SetStatusMessage(status)
 }
 
 // GetStatusLine returns the global status line
@@ -372,6 +1354,11 @@ func (cm *CoreModel) formatAppID(app models.ContainerApp) string {
 	return fmt.Sprintf("%s/%s", app.ResourceGroup, app.Name)
 }
 
+// formatJobID formats a job into an ID string
+func (cm *CoreModel) formatJobID(job models.Job) string {
+	return fmt.Sprintf("%s/%s", job.ResourceGroup, job.Name)
+}
+
 // Cache management
 
 // SetContainersCache caches containers for a revision
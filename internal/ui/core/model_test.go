@@ -0,0 +1,117 @@
+package core
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/mock"
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/providers"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// closeCountingProvider counts how many of the log streams it started were closed
+type closeCountingProvider struct {
+	*providers.MockCommandProvider
+	closed atomic.Int32
+}
+
+func (p *closeCountingProvider) StreamLogs(source models.LogSource) (providers.LogStream, error) {
+	stream, err := p.MockCommandProvider.StreamLogs(source)
+	if err != nil {
+		return nil, err
+	}
+	return &closeCountingStream{LogStream: stream, closed: &p.closed}, nil
+}
+
+type closeCountingStream struct {
+	providers.LogStream
+	closed *atomic.Int32
+}
+
+func (s *closeCountingStream) Close() error {
+	s.closed.Add(1)
+	return s.LogStream.Close()
+}
+
+// createTestModel creates a core model backed by the mock providers
+func createTestModel(t *testing.T) (*CoreModel, *closeCountingProvider) {
+	t.Helper()
+	data, err := mock.NewProvider()
+	if err != nil {
+		t.Fatalf("Failed to create the mock provider: %v", err)
+	}
+	commands := &closeCountingProvider{MockCommandProvider: providers.NewMockCommandProvider(data)}
+	return NewCoreModel(data, commands, data, data, providers.NewMockProber(), 200, 40), commands
+}
+
+// startLogStreams runs the commands starting the log streams of the logs page,
+// handing the streams started to the model
+func startLogStreams(t *testing.T, cm *CoreModel, cmd tea.Cmd) {
+	t.Helper()
+	batch, ok := cmd().(tea.BatchMsg)
+	if !ok {
+		t.Fatal("Expected a batch of commands starting the streams")
+	}
+
+	// The batch also holds the log rate tick, which is not waited for
+	started := make(chan LogStreamStartedMsg, len(batch))
+	for _, c := range batch {
+		go func() {
+			if msg, ok := c().(LogStreamStartedMsg); ok {
+				started <- msg
+			}
+		}()
+	}
+	for range cm.logStreamsOpen {
+		select {
+		case msg := <-started:
+			if msg.Error != nil {
+				t.Fatalf("Failed to start the log stream: %v", msg.Error)
+			}
+			cm.HandleMessage(msg)
+		case <-time.After(2 * time.Second):
+			t.Fatal("Timed out starting the log streams")
+		}
+	}
+}
+
+// Test that leaving the logs page through the context list stops the log
+// streams, and that they are started again when coming back
+func TestLogStreamsStopWhenLeavingLogs(t *testing.T) {
+	cm, commands := createTestModel(t)
+	app := models.ContainerApp{Name: "web-frontend-prod", ResourceGroup: "rg-prod"}
+
+	startLogStreams(t, cm, cm.ShowLogs(models.LogSource{App: app, Type: models.LogTypeCombined}))
+	if len(cm.logStreams) != 2 {
+		t.Fatalf("Expected the console and system streams, got %d", len(cm.logStreams))
+	}
+
+	// The log query opened from the logs keeps streaming
+	cm.NavigateToLogQuery()
+	if commands.closed.Load() != 0 {
+		t.Fatal("Expected the streams to keep running for the log query")
+	}
+
+	// ":" → operations
+	cm.NavigateToOperations()
+	if cm.GetCurrentMode() != ModeOperations {
+		t.Fatalf("Expected the operations mode, got %v", cm.GetCurrentMode())
+	}
+	if commands.closed.Load() != 2 || len(cm.logStreams) != 0 {
+		t.Fatalf("Expected both streams to be closed, %d were", commands.closed.Load())
+	}
+
+	// Back to the log query, then to the logs, which stream again
+	cm.GoBack()
+	cmd := cm.GoBack()
+	if cm.GetCurrentMode() != ModeLogs || cmd == nil {
+		t.Fatalf("Expected the logs to reconnect, got mode %v", cm.GetCurrentMode())
+	}
+	startLogStreams(t, cm, cmd)
+	if len(cm.logStreams) != 2 {
+		t.Errorf("Expected the streams to be started again, got %d", len(cm.logStreams))
+	}
+	cm.stopLogStream()
+}
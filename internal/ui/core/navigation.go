@@ -9,6 +9,9 @@ type NavigationManager struct {
 	state       NavigationState
 	currentMode Mode
 	history     []NavigationStep
+
+	// Called whenever the current mode changes
+	onModeChange func(ModeChangeEvent)
 }
 
 // NavigationStep represents a step in navigation history
@@ -26,6 +29,20 @@ func NewNavigationManager() *NavigationManager {
 	}
 }
 
+// SetModeChangeFunc sets the function called whenever the current mode changes
+func (nm *NavigationManager) SetModeChangeFunc(fn func(ModeChangeEvent)) {
+	nm.onModeChange = fn
+}
+
+// setMode changes the current mode, reporting the change
+func (nm *NavigationManager) setMode(mode Mode) {
+	oldMode := nm.currentMode
+	nm.currentMode = mode
+	if nm.onModeChange != nil && oldMode != mode {
+		nm.onModeChange(nm.CreateModeChangeEvent(oldMode, mode))
+	}
+}
+
 // GetCurrentMode returns the current mode
 func (nm *NavigationManager) GetCurrentMode() Mode {
 	return nm.currentMode
@@ -36,6 +53,12 @@ func (nm *NavigationManager) GetNavigationState() NavigationState {
 	return nm.state
 }
 
+// SetCurrentSubscription sets the subscription every az command runs against
+func (nm *NavigationManager) SetCurrentSubscription(subscriptionID, name string) {
+	nm.state.CurrentSubscription = subscriptionID
+	nm.state.CurrentSubscriptionName = name
+}
+
 // SetCurrentRG sets the current resource group
 func (nm *NavigationManager) SetCurrentRG(rg string) {
 	nm.state.CurrentRG = rg
@@ -51,11 +74,21 @@ func (nm *NavigationManager) SetCurrentRevName(revName string) {
 	nm.state.CurrentRevName = revName
 }
 
+// SetCurrentReplicaName sets the current replica name
+func (nm *NavigationManager) SetCurrentReplicaName(replicaName string) {
+	nm.state.CurrentReplicaName = replicaName
+}
+
 // SetCurrentContainerName sets the current container name
 func (nm *NavigationManager) SetCurrentContainerName(containerName string) {
 	nm.state.CurrentContainerName = containerName
 }
 
+// SetCurrentJobID sets the current job ID
+func (nm *NavigationManager) SetCurrentJobID(jobID string) {
+	nm.state.CurrentJobID = jobID
+}
+
 // NavigateToMode navigates to a specific mode and updates state accordingly
 func (nm *NavigationManager) NavigateToMode(mode Mode) {
 	// Save current step to history
@@ -65,7 +98,20 @@ func (nm *NavigationManager) NavigateToMode(mode Mode) {
 	nm.state.ResetFrom(mode)
 
 	// Update current mode
-	nm.currentMode = mode
+	nm.setMode(mode)
+}
+
+// NavigateToSubscriptions navigates to subscriptions mode, keeping the current subscription
+func (nm *NavigationManager) NavigateToSubscriptions() {
+	nm.NavigateToMode(ModeSubscriptions)
+}
+
+// NavigateToSubscription navigates to the resource groups of a subscription
+func (nm *NavigationManager) NavigateToSubscription(sub models.Subscription) {
+	nm.pushToHistory()
+	nm.setMode(ModeResourceGroups)
+	nm.SetCurrentSubscription(sub.ID, sub.Name)
+	nm.state.ResetFrom(ModeResourceGroups)
 }
 
 // NavigateToResourceGroups navigates to resource groups mode
@@ -73,10 +119,27 @@ func (nm *NavigationManager) NavigateToResourceGroups() {
 	nm.NavigateToMode(ModeResourceGroups)
 }
 
+// NavigateToEnvironments navigates to managed environments mode with resource group context
+func (nm *NavigationManager) NavigateToEnvironments(rg models.ResourceGroup) {
+	nm.pushToHistory()
+	nm.setMode(ModeEnvironments)
+	nm.state.CurrentRG = rg.Name
+	nm.state.ResetFrom(ModeEnvironments)
+}
+
+// NavigateToEnvironmentApps navigates to apps mode filtered by a managed environment
+func (nm *NavigationManager) NavigateToEnvironmentApps(env models.ManagedEnvironment) {
+	nm.pushToHistory()
+	nm.setMode(ModeApps)
+	nm.state.ResetFrom(ModeApps)
+	nm.state.CurrentEnvironmentID = env.ID
+	nm.state.CurrentEnvironment = env.Name
+}
+
 // NavigateToApps navigates to apps mode with resource group context
 func (nm *NavigationManager) NavigateToApps(rg models.ResourceGroup) {
 	nm.pushToHistory()
-	nm.currentMode = ModeApps
+	nm.setMode(ModeApps)
 	nm.state.CurrentRG = rg.Name
 	nm.state.ResetFrom(ModeApps)
 }
@@ -84,26 +147,153 @@ func (nm *NavigationManager) NavigateToApps(rg models.ResourceGroup) {
 // NavigateToRevisions navigates to revisions mode with app context
 func (nm *NavigationManager) NavigateToRevisions(app models.ContainerApp) {
 	nm.pushToHistory()
-	nm.currentMode = ModeRevisions
+	nm.setMode(ModeRevisions)
 	nm.state.CurrentAppID = nm.formatAppID(app)
 	nm.state.ResetFrom(ModeRevisions)
 }
 
+// NavigateToReplicas navigates to replicas mode with revision context
+func (nm *NavigationManager) NavigateToReplicas(rev models.Revision) {
+	nm.pushToHistory()
+	nm.setMode(ModeReplicas)
+	nm.state.CurrentRevName = rev.Name
+	nm.state.ResetFrom(ModeReplicas)
+}
+
 // NavigateToContainers navigates to containers mode with revision context
 func (nm *NavigationManager) NavigateToContainers(rev models.Revision) {
 	nm.pushToHistory()
-	nm.currentMode = ModeContainers
+	nm.setMode(ModeContainers)
 	nm.state.CurrentRevName = rev.Name
+	nm.state.ResetFrom(ModeReplicas)
+}
+
+// NavigateToReplicaContainers navigates to containers mode with replica context
+func (nm *NavigationManager) NavigateToReplicaContainers(replica models.Replica) {
+	nm.pushToHistory()
+	nm.setMode(ModeContainers)
+	nm.state.CurrentReplicaName = replica.Name
 	nm.state.ResetFrom(ModeContainers)
 }
 
 // NavigateToEnvVars navigates to environment variables mode with container context
 func (nm *NavigationManager) NavigateToEnvVars(container models.Container) {
 	nm.pushToHistory()
-	nm.currentMode = ModeEnvVars
+	nm.setMode(ModeEnvVars)
 	nm.state.CurrentContainerName = container.Name
 }
 
+// NavigateToAppDetails navigates to app details mode with app context
+func (nm *NavigationManager) NavigateToAppDetails(app models.ContainerApp) {
+	nm.pushToHistory()
+	nm.setMode(ModeAppDetails)
+	nm.state.CurrentAppID = nm.formatAppID(app)
+}
+
+// NavigateToSecrets navigates to the secrets of an app
+func (nm *NavigationManager) NavigateToSecrets(app models.ContainerApp) {
+	nm.pushToHistory()
+	nm.setMode(ModeSecrets)
+	nm.state.CurrentAppID = nm.formatAppID(app)
+	nm.state.ResetFrom(ModeRevisions)
+}
+
+// NavigateToScale navigates to the scale rules of an app
+func (nm *NavigationManager) NavigateToScale(app models.ContainerApp) {
+	nm.pushToHistory()
+	nm.setMode(ModeScale)
+	nm.state.CurrentAppID = nm.formatAppID(app)
+	nm.state.ResetFrom(ModeRevisions)
+}
+
+// NavigateToTraffic navigates to the traffic split editor of the current app
+func (nm *NavigationManager) NavigateToTraffic() {
+	nm.pushToHistory()
+	nm.setMode(ModeTraffic)
+	nm.state.ResetFrom(ModeRevisions)
+}
+
+// NavigateToMetrics navigates to the metrics of an app, or of one of its
+// revisions when revName is set
+func (nm *NavigationManager) NavigateToMetrics(app models.ContainerApp, revName string) {
+	nm.pushToHistory()
+	nm.setMode(ModeMetrics)
+	nm.state.CurrentAppID = nm.formatAppID(app)
+	nm.state.CurrentRevName = revName
+	nm.state.ResetFrom(ModeReplicas)
+}
+
+// NavigateToProbe navigates to the HTTP probe of an app, or of one of its
+// revisions when revName is set
+func (nm *NavigationManager) NavigateToProbe(app models.ContainerApp, revName string) {
+	nm.pushToHistory()
+	nm.setMode(ModeProbe)
+	nm.state.CurrentAppID = nm.formatAppID(app)
+	nm.state.CurrentRevName = revName
+	nm.state.ResetFrom(ModeReplicas)
+}
+
+// NavigateToLogs navigates to the logs of an app, revision, replica or container
+func (nm *NavigationManager) NavigateToLogs(source models.LogSource) {
+	nm.pushToHistory()
+	nm.setMode(ModeLogs)
+	nm.state.CurrentAppID = nm.formatAppID(source.App)
+	nm.state.CurrentRevName = source.Revision
+	nm.state.CurrentReplicaName = source.Replica
+	nm.state.CurrentContainerName = source.Container
+}
+
+// NavigateToAppsLogs navigates to the logs of several apps tailed together,
+// which belong to no single app
+func (nm *NavigationManager) NavigateToAppsLogs() {
+	nm.pushToHistory()
+	nm.setMode(ModeLogs)
+	nm.state.CurrentAppID = ""
+	nm.state.CurrentRevName = ""
+	nm.state.CurrentReplicaName = ""
+	nm.state.CurrentContainerName = ""
+}
+
+// NavigateToLogQuery navigates to the log query page, keeping the app, revision,
+// replica and container of the logs being viewed
+func (nm *NavigationManager) NavigateToLogQuery() {
+	nm.pushToHistory()
+	nm.setMode(ModeLogQuery)
+}
+
+// NavigateToOperations navigates to the operation log, keeping the current context
+func (nm *NavigationManager) NavigateToOperations() {
+	nm.pushToHistory()
+	nm.setMode(ModeOperations)
+}
+
+// NavigateToExec navigates to the exec sessions, keeping the current context
+func (nm *NavigationManager) NavigateToExec() {
+	nm.pushToHistory()
+	nm.setMode(ModeExec)
+}
+
+// NavigateToPortForwards navigates to the port forwards, keeping the current context
+func (nm *NavigationManager) NavigateToPortForwards() {
+	nm.pushToHistory()
+	nm.setMode(ModePortForwards)
+}
+
+// NavigateToJobs navigates to jobs mode with resource group context
+func (nm *NavigationManager) NavigateToJobs(rg models.ResourceGroup) {
+	nm.pushToHistory()
+	nm.setMode(ModeJobs)
+	nm.state.CurrentRG = rg.Name
+	nm.state.ResetFrom(ModeJobs)
+}
+
+// NavigateToJobExecutions navigates to job executions mode with job context
+func (nm *NavigationManager) NavigateToJobExecutions(job models.Job) {
+	nm.pushToHistory()
+	nm.setMode(ModeJobExecutions)
+	nm.state.CurrentJobID = nm.formatJobID(job)
+}
+
 // GoBack navigates back to the previous mode
 func (nm *NavigationManager) GoBack() bool {
 	if len(nm.history) == 0 {
@@ -115,12 +305,28 @@ func (nm *NavigationManager) GoBack() bool {
 	nm.history = nm.history[:len(nm.history)-1]
 
 	// Restore the previous state
-	nm.currentMode = lastStep.Mode
+	nm.setMode(lastStep.Mode)
 	nm.state = lastStep.State
 
 	return true
 }
 
+// GoBackTo navigates back to the latest step of history in a mode, dropping
+// the steps after it. Nothing changes when the mode is not in the history.
+func (nm *NavigationManager) GoBackTo(mode Mode) bool {
+	for i := len(nm.history) - 1; i >= 0; i-- {
+		if nm.history[i].Mode != mode {
+			continue
+		}
+		step := nm.history[i]
+		nm.history = nm.history[:i]
+		nm.setMode(step.Mode)
+		nm.state = step.State
+		return true
+	}
+	return false
+}
+
 // CanGoBack returns true if there's navigation history to go back to
 func (nm *NavigationManager) CanGoBack() bool {
 	return len(nm.history) > 0
@@ -134,14 +340,47 @@ func (nm *NavigationManager) GetBreadcrumb() string {
 // GetParentMode returns the parent mode for the current mode
 func (nm *NavigationManager) GetParentMode() (Mode, bool) {
 	switch nm.currentMode {
+	case ModeResourceGroups:
+		return ModeSubscriptions, true
+	case ModeEnvironments:
+		return ModeResourceGroups, true
 	case ModeApps:
+		if nm.state.CurrentEnvironmentID != "" {
+			return ModeEnvironments, true
+		}
 		return ModeResourceGroups, true
 	case ModeRevisions:
 		return ModeApps, true
 	case ModeContainers:
+		if nm.state.CurrentReplicaName != "" {
+			return ModeReplicas, true
+		}
+		return ModeRevisions, true
+	case ModeReplicas, ModeTraffic:
 		return ModeRevisions, true
 	case ModeEnvVars:
 		return ModeContainers, true
+	case ModeJobs:
+		return ModeResourceGroups, true
+	case ModeJobExecutions:
+		return ModeJobs, true
+	case ModeAppDetails, ModeSecrets, ModeScale:
+		return ModeApps, true
+	case ModeMetrics, ModeProbe:
+		if nm.state.CurrentRevName != "" {
+			return ModeRevisions, true
+		}
+		return ModeApps, true
+	case ModeLogQuery:
+		return ModeLogs, true
+	case ModeLogs:
+		if nm.state.CurrentReplicaName != "" {
+			return ModeReplicas, true
+		}
+		if nm.state.CurrentRevName != "" {
+			return ModeRevisions, true
+		}
+		return ModeApps, true
 	default:
 		return ModeResourceGroups, false
 	}
@@ -150,16 +389,28 @@ func (nm *NavigationManager) GetParentMode() (Mode, bool) {
 // ValidateNavigation validates if navigation to a mode is possible
 func (nm *NavigationManager) ValidateNavigation(mode Mode) bool {
 	switch mode {
+	case ModeSubscriptions:
+		return true // Always can go to subscriptions
 	case ModeResourceGroups:
 		return true // Always can go to resource groups
+	case ModeEnvironments:
+		return nm.state.CurrentRG != "" // Need resource group
 	case ModeApps:
 		return nm.state.CurrentRG != "" // Need resource group
 	case ModeRevisions:
 		return nm.state.CurrentRG != "" && nm.state.CurrentAppID != "" // Need RG and app
-	case ModeContainers:
+	case ModeReplicas, ModeContainers:
 		return nm.state.CurrentRG != "" && nm.state.CurrentAppID != "" && nm.state.CurrentRevName != "" // Need RG, app, and revision
 	case ModeEnvVars:
 		return nm.state.CurrentRG != "" && nm.state.CurrentAppID != "" && nm.state.CurrentRevName != "" && nm.state.CurrentContainerName != "" // Need all
+	case ModeJobs:
+		return nm.state.CurrentRG != "" // Need resource group
+	case ModeJobExecutions:
+		return nm.state.CurrentRG != "" && nm.state.CurrentJobID != "" // Need RG and job
+	case ModeAppDetails, ModeSecrets, ModeScale, ModeTraffic, ModeMetrics, ModeProbe, ModeLogs, ModeLogQuery:
+		return nm.state.CurrentRG != "" && nm.state.CurrentAppID != "" // Need RG and app
+	case ModeOperations, ModeExec, ModePortForwards:
+		return true // Available from anywhere
 	default:
 		return false
 	}
@@ -168,14 +419,50 @@ func (nm *NavigationManager) ValidateNavigation(mode Mode) bool {
 // GetNavigationFlow returns the expected navigation flow for the current state
 func (nm *NavigationManager) GetNavigationFlow() []Mode {
 	flow := []Mode{ModeResourceGroups}
+	if nm.currentMode == ModeSubscriptions {
+		return []Mode{ModeSubscriptions}
+	}
+	if nm.state.CurrentSubscription != "" {
+		flow = []Mode{ModeSubscriptions, ModeResourceGroups}
+	}
 
+	if nm.state.CurrentJobID != "" {
+		return append(flow, ModeJobs, ModeJobExecutions)
+	}
+
+	if nm.currentMode == ModeLogQuery && nm.state.CurrentRevName == "" {
+		return append(flow, ModeApps, ModeLogs, ModeLogQuery)
+	}
+	if nm.currentMode == ModeAppDetails || nm.currentMode == ModeSecrets || nm.currentMode == ModeScale || ((nm.currentMode == ModeMetrics || nm.currentMode == ModeProbe || nm.currentMode == ModeLogs) && nm.state.CurrentRevName == "") {
+		return append(flow, ModeApps, nm.currentMode)
+	}
+
+	if nm.currentMode == ModeEnvironments {
+		return append(flow, ModeEnvironments)
+	}
+
+	if nm.state.CurrentEnvironmentID != "" {
+		flow = append(flow, ModeEnvironments)
+	}
 	if nm.state.CurrentRG != "" {
 		flow = append(flow, ModeApps)
 	}
 	if nm.state.CurrentAppID != "" {
 		flow = append(flow, ModeRevisions)
 	}
-	if nm.state.CurrentRevName != "" {
+	if nm.currentMode == ModeTraffic || nm.currentMode == ModeMetrics || nm.currentMode == ModeProbe {
+		return append(flow, nm.currentMode)
+	}
+	if nm.currentMode == ModeReplicas || nm.state.CurrentReplicaName != "" {
+		flow = append(flow, ModeReplicas)
+	}
+	if nm.currentMode == ModeLogs {
+		return append(flow, ModeLogs)
+	}
+	if nm.currentMode == ModeLogQuery {
+		return append(flow, ModeLogs, ModeLogQuery)
+	}
+	if nm.currentMode != ModeReplicas && nm.state.CurrentRevName != "" {
 		flow = append(flow, ModeContainers)
 	}
 	if nm.state.CurrentContainerName != "" {
@@ -188,7 +475,7 @@ func (nm *NavigationManager) GetNavigationFlow() []Mode {
 // Reset resets the navigation manager to initial state
 func (nm *NavigationManager) Reset() {
 	nm.state.Reset()
-	nm.currentMode = ModeResourceGroups
+	nm.setMode(ModeResourceGroups)
 	nm.history = nm.history[:0] // Clear history
 }
 
@@ -212,6 +499,11 @@ func (nm *NavigationManager) formatAppID(app models.ContainerApp) string {
 	return app.ResourceGroup + "/" + app.Name
 }
 
+// formatJobID formats a job into an ID string
+func (nm *NavigationManager) formatJobID(job models.Job) string {
+	return job.ResourceGroup + "/" + job.Name
+}
+
 // CreateNavigationEvent creates a navigation event
 func (nm *NavigationManager) CreateNavigationEvent(fromMode, toMode Mode, data interface{}) NavigationEvent {
 	return NavigationEvent{
@@ -3,22 +3,54 @@ package core
 import (
 	"github.com/IAL32/az-tui/internal/models"
 	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages/appdetails"
 	"github.com/IAL32/az-tui/internal/ui/pages/apps"
 	"github.com/IAL32/az-tui/internal/ui/pages/containers"
+	"github.com/IAL32/az-tui/internal/ui/pages/environments"
 	"github.com/IAL32/az-tui/internal/ui/pages/envvars"
+	"github.com/IAL32/az-tui/internal/ui/pages/execsessions"
+	"github.com/IAL32/az-tui/internal/ui/pages/jobexecutions"
+	"github.com/IAL32/az-tui/internal/ui/pages/jobs"
+	"github.com/IAL32/az-tui/internal/ui/pages/logquery"
+	"github.com/IAL32/az-tui/internal/ui/pages/logs"
+	"github.com/IAL32/az-tui/internal/ui/pages/metrics"
+	"github.com/IAL32/az-tui/internal/ui/pages/operations"
+	"github.com/IAL32/az-tui/internal/ui/pages/portforwards"
+	"github.com/IAL32/az-tui/internal/ui/pages/probe"
+	"github.com/IAL32/az-tui/internal/ui/pages/replicas"
 	"github.com/IAL32/az-tui/internal/ui/pages/resourcegroups"
 	"github.com/IAL32/az-tui/internal/ui/pages/revisions"
+	"github.com/IAL32/az-tui/internal/ui/pages/scale"
+	"github.com/IAL32/az-tui/internal/ui/pages/secrets"
+	"github.com/IAL32/az-tui/internal/ui/pages/subscriptions"
+	"github.com/IAL32/az-tui/internal/ui/pages/traffic"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 // PageManager manages all page instances and their lifecycle
 type PageManager struct {
 	// Page instances
+	subscriptionsPage  *subscriptions.SubscriptionsPage
 	resourceGroupsPage *resourcegroups.ResourceGroupsPage
+	environmentsPage   *environments.EnvironmentsPage
 	appsPage           *apps.AppsPage
 	revisionsPage      *revisions.RevisionsPage
+	replicasPage       *replicas.ReplicasPage
 	containersPage     *containers.ContainersPage
+	trafficPage        *traffic.TrafficPage
 	envVarsPage        *envvars.EnvVarsPage
+	jobsPage           *jobs.JobsPage
+	jobExecutionsPage  *jobexecutions.JobExecutionsPage
+	appDetailsPage     *appdetails.AppDetailsPage
+	metricsPage        *metrics.MetricsPage
+	logsPage           *logs.LogsPage
+	logQueryPage       *logquery.LogQueryPage
+	operationsPage     *operations.OperationsPage
+	execPage           *execsessions.ExecPage
+	portForwardsPage   *portforwards.PortForwardsPage
+	probePage          *probe.ProbePage
+	secretsPage        *secrets.SecretsPage
+	scalePage          *scale.ScalePage
 
 	// Layout system
 	layoutSystem *layouts.LayoutSystem
@@ -43,30 +75,159 @@ func NewPageManager(layoutSystem *layouts.LayoutSystem, navigationManager *Navig
 // initializePages creates and configures all page instances
 func (pm *PageManager) initializePages() {
 	// Create page instances
+	pm.subscriptionsPage = subscriptions.NewSubscriptionsPage(pm.layoutSystem)
 	pm.resourceGroupsPage = resourcegroups.NewResourceGroupsPage(pm.layoutSystem)
+	pm.environmentsPage = environments.NewEnvironmentsPage(pm.layoutSystem)
 	pm.appsPage = apps.NewAppsPage(pm.layoutSystem)
 	pm.revisionsPage = revisions.NewRevisionsPage(pm.layoutSystem)
+	pm.replicasPage = replicas.NewReplicasPage(pm.layoutSystem)
 	pm.containersPage = containers.NewContainersPage(pm.layoutSystem)
+	pm.trafficPage = traffic.NewTrafficPage(pm.layoutSystem)
 	pm.envVarsPage = envvars.NewEnvVarsPage(pm.layoutSystem)
+	pm.jobsPage = jobs.NewJobsPage(pm.layoutSystem)
+	pm.jobExecutionsPage = jobexecutions.NewJobExecutionsPage(pm.layoutSystem)
+	pm.appDetailsPage = appdetails.NewAppDetailsPage(pm.layoutSystem)
+	pm.metricsPage = metrics.NewMetricsPage(pm.layoutSystem)
+	pm.logsPage = logs.NewLogsPage(pm.layoutSystem)
+	pm.logQueryPage = logquery.NewLogQueryPage(pm.layoutSystem)
+	pm.operationsPage = operations.NewOperationsPage(pm.layoutSystem)
+	pm.execPage = execsessions.NewExecPage(pm.layoutSystem)
+	pm.portForwardsPage = portforwards.NewPortForwardsPage(pm.layoutSystem)
+	pm.probePage = probe.NewProbePage(pm.layoutSystem)
+	pm.secretsPage = secrets.NewSecretsPage(pm.layoutSystem)
+	pm.scalePage = scale.NewScalePage(pm.layoutSystem)
 }
 
 // SetupPageNavigation configures navigation functions between pages
 func (pm *PageManager) SetupPageNavigation(coreModel *CoreModel) {
+	// Subscriptions -> ResourceGroups navigation
+	pm.subscriptionsPage.SetNavigateToResourceGroupsFunc(func(sub models.Subscription) tea.Cmd {
+		return coreModel.NavigateToSubscription(sub)
+	})
+
+	// ResourceGroups -> Subscriptions back navigation
+	pm.resourceGroupsPage.SetBackToSubscriptionsFunc(func() tea.Cmd {
+		return coreModel.NavigateToSubscriptions()
+	})
+
 	// ResourceGroups -> Apps navigation
 	pm.resourceGroupsPage.SetNavigateToAppsFunc(func(rg models.ResourceGroup) tea.Cmd {
 		return coreModel.NavigateToApps(rg)
 	})
 
+	// ResourceGroups -> Environments navigation
+	pm.resourceGroupsPage.SetNavigateToEnvironmentsFunc(func(rg models.ResourceGroup) tea.Cmd {
+		return coreModel.NavigateToEnvironments(rg)
+	})
+
+	// Environments -> Apps navigation
+	pm.environmentsPage.SetNavigateToAppsFunc(func(env models.ManagedEnvironment) tea.Cmd {
+		return coreModel.NavigateToEnvironmentApps(env)
+	})
+
+	// Environments -> ResourceGroups back navigation
+	pm.environmentsPage.SetBackToResourceGroupsFunc(func() tea.Cmd {
+		return coreModel.NavigateToResourceGroups()
+	})
+
 	// Apps -> Revisions navigation
 	pm.appsPage.SetNavigateToRevisionsFunc(func(app models.ContainerApp) tea.Cmd {
 		return coreModel.NavigateToRevisions(app)
 	})
 
-	// Apps -> ResourceGroups back navigation
+	// Apps -> ResourceGroups back navigation, or back to environments when filtered by one
 	pm.appsPage.SetBackToResourceGroupsFunc(func() tea.Cmd {
+		if coreModel.GetNavigationState().CurrentEnvironmentID != "" {
+			return coreModel.GoBack()
+		}
 		return coreModel.NavigateToResourceGroups()
 	})
 
+	// Apps -> AppDetails navigation
+	pm.appsPage.SetShowDetailsFunc(func(app models.ContainerApp) tea.Cmd {
+		return coreModel.NavigateToAppDetails(app)
+	})
+
+	// AppDetails -> Apps back navigation
+	pm.appDetailsPage.SetBackToAppsFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Apps -> Metrics navigation
+	pm.appsPage.SetShowMetricsFunc(func(app models.ContainerApp) tea.Cmd {
+		return coreModel.NavigateToMetrics(app, "")
+	})
+
+	// Revisions -> Metrics navigation
+	pm.revisionsPage.SetShowMetricsFunc(func(rev models.Revision) tea.Cmd {
+		return coreModel.NavigateToMetrics(coreModel.GetCurrentApp(), rev.Name)
+	})
+
+	// Apps -> Probe navigation
+	pm.appsPage.SetProbeFunc(func(app models.ContainerApp) tea.Cmd {
+		return coreModel.ProbeApp(app)
+	})
+
+	// Revisions -> Probe navigation
+	pm.revisionsPage.SetProbeFunc(func(rev models.Revision) tea.Cmd {
+		return coreModel.ProbeRevision(coreModel.GetCurrentApp(), rev)
+	})
+
+	// Apps -> Secrets navigation
+	pm.appsPage.SetShowSecretsFunc(func(app models.ContainerApp) tea.Cmd {
+		return coreModel.NavigateToSecrets(app)
+	})
+
+	// EnvVars -> Secrets navigation, to the secret a variable references
+	pm.envVarsPage.SetShowSecretFunc(func(secretName string) tea.Cmd {
+		return coreModel.ShowEnvVarSecret(secretName)
+	})
+
+	// Secrets -> Apps or EnvVars back navigation
+	pm.secretsPage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Apps -> Scale navigation
+	pm.appsPage.SetShowScaleFunc(func(app models.ContainerApp) tea.Cmd {
+		return coreModel.NavigateToScale(app)
+	})
+
+	// Scale -> Apps back navigation
+	pm.scalePage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Probe -> Apps or Revisions back navigation
+	pm.probePage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Metrics -> Apps or Revisions back navigation
+	pm.metricsPage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Logs -> back navigation, to wherever the logs were opened from
+	pm.logsPage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Logs -> LogQuery navigation
+	pm.logsPage.SetShowHistoryFunc(func() tea.Cmd {
+		return coreModel.NavigateToLogQuery()
+	})
+
+	// LogQuery -> Logs back navigation
+	pm.logQueryPage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Revisions -> Replicas navigation
+	pm.revisionsPage.SetNavigateToReplicasFunc(func(rev models.Revision) tea.Cmd {
+		return coreModel.NavigateToReplicas(rev)
+	})
+
 	// Revisions -> Containers navigation
 	pm.revisionsPage.SetNavigateToContainersFunc(func(rev models.Revision) tea.Cmd {
 		return coreModel.NavigateToContainers(rev)
@@ -77,12 +238,32 @@ func (pm *PageManager) SetupPageNavigation(coreModel *CoreModel) {
 		return coreModel.GoBack()
 	})
 
+	// Revisions -> Traffic navigation
+	pm.revisionsPage.SetEditTrafficFunc(func() tea.Cmd {
+		return coreModel.NavigateToTraffic()
+	})
+
+	// Traffic -> Revisions back navigation
+	pm.trafficPage.SetBackToRevisionsFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Replicas -> Containers navigation
+	pm.replicasPage.SetNavigateToContainersFunc(func(replica models.Replica) tea.Cmd {
+		return coreModel.NavigateToReplicaContainers(replica)
+	})
+
+	// Replicas -> Revisions back navigation
+	pm.replicasPage.SetBackToRevisionsFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
 	// Containers -> EnvVars navigation
 	pm.containersPage.SetNavigateToEnvVarsFunc(func(container models.Container) tea.Cmd {
 		return coreModel.NavigateToEnvVars(container)
 	})
 
-	// Containers -> Revisions back navigation
+	// Containers -> Replicas or Revisions back navigation
 	pm.containersPage.SetBackToRevisionsFunc(func() tea.Cmd {
 		return coreModel.GoBack()
 	})
@@ -91,22 +272,117 @@ func (pm *PageManager) SetupPageNavigation(coreModel *CoreModel) {
 	pm.envVarsPage.SetBackFunc(func() tea.Cmd {
 		return coreModel.GoBack()
 	})
+
+	// Jobs -> JobExecutions navigation
+	pm.jobsPage.SetNavigateToExecutionsFunc(func(job models.Job) tea.Cmd {
+		return coreModel.NavigateToJobExecutions(job)
+	})
+
+	// Jobs -> ResourceGroups back navigation
+	pm.jobsPage.SetBackToResourceGroupsFunc(func() tea.Cmd {
+		return coreModel.NavigateToResourceGroups()
+	})
+
+	// JobExecutions -> Jobs back navigation
+	pm.jobExecutionsPage.SetBackToJobsFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Operations -> previous page back navigation
+	pm.operationsPage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Exec sessions -> previous page back navigation, the sessions keep running
+	pm.execPage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
+
+	// Port forwards -> previous page back navigation, the forwards keep running
+	pm.portForwardsPage.SetBackFunc(func() tea.Cmd {
+		return coreModel.GoBack()
+	})
 }
 
 // SetupPageActions configures action functions for pages
 func (pm *PageManager) SetupPageActions(coreModel *CoreModel) {
+	// Subscriptions page actions
+	pm.subscriptionsPage.SetRefreshFunc(func() tea.Cmd {
+		return coreModel.LoadSubscriptions()
+	})
+
+	// Environments page actions
+	pm.environmentsPage.SetRefreshFunc(func() tea.Cmd {
+		return coreModel.LoadEnvironments(coreModel.GetNavigationState().CurrentRG)
+	})
+
 	// Apps page actions
 	pm.appsPage.SetShowLogsFunc(func(app models.ContainerApp) tea.Cmd {
 		return coreModel.ShowAppLogs(app)
 	})
+	pm.appsPage.SetTailLogsFunc(func(apps []models.ContainerApp) tea.Cmd {
+		return coreModel.TailApps(apps)
+	})
 	pm.appsPage.SetExecIntoAppFunc(func(app models.ContainerApp) tea.Cmd {
 		return coreModel.ExecIntoApp(app)
 	})
+	pm.appsPage.SetPortForwardFunc(func(app models.ContainerApp) tea.Cmd {
+		return coreModel.StartPortForward(app)
+	})
+
+	// App details page actions
+	pm.appDetailsPage.SetRefreshFunc(func() tea.Cmd {
+		return coreModel.RefreshCurrentPage()
+	})
+
+	// Secrets page actions
+	pm.secretsPage.SetRevealFunc(func(secret models.Secret) tea.Cmd {
+		return coreModel.RevealSecret(secret)
+	})
+	pm.secretsPage.SetRefreshFunc(func() tea.Cmd {
+		return coreModel.RefreshCurrentPage()
+	})
+
+	// Metrics page actions
+	pm.metricsPage.SetWindowChangedFunc(func(window models.MetricsWindow) tea.Cmd {
+		return coreModel.SetMetricsWindow(window)
+	})
+	pm.metricsPage.SetRefreshFunc(func() tea.Cmd {
+		return coreModel.RefreshCurrentPage()
+	})
+
+	// Logs page actions
+	pm.logsPage.SetReconnectFunc(func() tea.Cmd {
+		return coreModel.ReconnectLogs()
+	})
+	pm.logsPage.SetLogTypeFunc(func(logType models.LogType) tea.Cmd {
+		return coreModel.SetLogType(logType)
+	})
+
+	// Log query page actions
+	pm.logQueryPage.SetRunQueryFunc(func(query string, timeRange models.LogQueryRange) tea.Cmd {
+		return coreModel.RunLogQuery(query, timeRange)
+	})
 
 	// Revisions page actions
 	pm.revisionsPage.SetRestartRevisionFunc(func(rev models.Revision) tea.Cmd {
 		return coreModel.RestartRevision(rev)
 	})
+	pm.revisionsPage.SetActivateFunc(func(rev models.Revision) tea.Cmd {
+		return coreModel.ActivateRevision(rev)
+	})
+	pm.revisionsPage.SetDeactivateFunc(func(rev models.Revision) tea.Cmd {
+		return coreModel.DeactivateRevision(rev)
+	})
+	pm.revisionsPage.SetAddLabelFunc(func(rev models.Revision, label string) tea.Cmd {
+		return coreModel.AddRevisionLabel(rev, label)
+	})
+	pm.revisionsPage.SetRemoveLabelFunc(func(label string) tea.Cmd {
+		return coreModel.RemoveRevisionLabel(label)
+	})
+	pm.revisionsPage.SetRevisionModeFunc(func(mode string) tea.Cmd {
+		return coreModel.SetRevisionMode(mode)
+	})
 	pm.revisionsPage.SetShowLogsFunc(func(rev models.Revision) tea.Cmd {
 		return coreModel.ShowRevisionLogs(rev)
 	})
@@ -114,6 +390,35 @@ func (pm *PageManager) SetupPageActions(coreModel *CoreModel) {
 		return coreModel.ExecIntoRevision(rev)
 	})
 
+	// Traffic page actions
+	pm.trafficPage.SetApplyFunc(func(weights []models.TrafficWeight) tea.Cmd {
+		return coreModel.SetTraffic(weights)
+	})
+	pm.trafficPage.SetRefreshFunc(func() tea.Cmd {
+		return coreModel.LoadTraffic(coreModel.GetCurrentApp())
+	})
+
+	// EnvVars page actions
+	pm.envVarsPage.SetApplyFunc(func(update models.EnvUpdate) tea.Cmd {
+		return coreModel.UpdateContainerEnv(update)
+	})
+
+	// Scale page actions
+	pm.scalePage.SetApplyFunc(func(s models.Scale) tea.Cmd {
+		return coreModel.UpdateScale(s)
+	})
+	pm.scalePage.SetRefreshFunc(func() tea.Cmd {
+		return coreModel.RefreshCurrentPage()
+	})
+
+	// Replicas page actions
+	pm.replicasPage.SetShowLogsFunc(func(replica models.Replica) tea.Cmd {
+		return coreModel.ShowReplicaLogs(replica)
+	})
+	pm.replicasPage.SetExecIntoReplicaFunc(func(replica models.Replica) tea.Cmd {
+		return coreModel.ExecIntoReplica(replica)
+	})
+
 	// Containers page actions
 	pm.containersPage.SetShowLogsFunc(func(container models.Container) tea.Cmd {
 		return coreModel.ShowContainerLogs(container)
@@ -121,31 +426,114 @@ func (pm *PageManager) SetupPageActions(coreModel *CoreModel) {
 	pm.containersPage.SetExecIntoContainerFunc(func(container models.Container) tea.Cmd {
 		return coreModel.ExecIntoContainer(container)
 	})
+	pm.containersPage.SetUpdateImageFunc(func(container, image string) tea.Cmd {
+		return coreModel.UpdateContainerImage(container, image)
+	})
+	pm.containersPage.SetShiftTrafficFunc(func(revisionName string) tea.Cmd {
+		return coreModel.ShiftTrafficToRevision(revisionName)
+	})
+
+	// Exec sessions page actions
+	pm.execPage.SetStartFunc(func(target models.ExecTarget, command string) tea.Cmd {
+		return coreModel.StartExec(target, command)
+	})
+
+	// Jobs page actions
+	pm.jobsPage.SetStartJobFunc(func(job models.Job) tea.Cmd {
+		return coreModel.StartJob(job)
+	})
+
+	// Port forwards page actions
+	pm.portForwardsPage.SetStopFunc(func(forward models.PortForward) tea.Cmd {
+		return coreModel.StopPortForward(forward)
+	})
+	pm.portForwardsPage.SetRefreshFunc(func() tea.Cmd {
+		coreModel.refreshPortForwards()
+		return nil
+	})
+
+	// Probe page actions
+	pm.probePage.SetSendFunc(func(target string, request models.ProbeRequest) tea.Cmd {
+		return coreModel.SendProbe(target, request)
+	})
+
+	// Job executions page actions
+	pm.jobExecutionsPage.SetStartJobFunc(func() tea.Cmd {
+		return coreModel.StartJob(coreModel.GetCurrentJob())
+	})
+	pm.jobExecutionsPage.SetStopExecutionFunc(func(execution models.JobExecution) tea.Cmd {
+		return coreModel.StopJobExecution(execution)
+	})
+	pm.jobExecutionsPage.SetRerunExecutionFunc(func(execution models.JobExecution) tea.Cmd {
+		return coreModel.RerunJobExecution(execution)
+	})
 }
 
 // GetCurrentPage returns the page instance for the current mode
 func (pm *PageManager) GetCurrentPage() interface{} {
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		return pm.subscriptionsPage
 	case ModeResourceGroups:
 		return pm.resourceGroupsPage
+	case ModeEnvironments:
+		return pm.environmentsPage
 	case ModeApps:
 		return pm.appsPage
 	case ModeRevisions:
 		return pm.revisionsPage
+	case ModeReplicas:
+		return pm.replicasPage
 	case ModeContainers:
 		return pm.containersPage
+	case ModeTraffic:
+		return pm.trafficPage
 	case ModeEnvVars:
 		return pm.envVarsPage
+	case ModeJobs:
+		return pm.jobsPage
+	case ModeJobExecutions:
+		return pm.jobExecutionsPage
+	case ModeAppDetails:
+		return pm.appDetailsPage
+	case ModeMetrics:
+		return pm.metricsPage
+	case ModeLogs:
+		return pm.logsPage
+	case ModeLogQuery:
+		return pm.logQueryPage
+	case ModeOperations:
+		return pm.operationsPage
+	case ModeExec:
+		return pm.execPage
+	case ModePortForwards:
+		return pm.portForwardsPage
+	case ModeProbe:
+		return pm.probePage
+	case ModeSecrets:
+		return pm.secretsPage
+	case ModeScale:
+		return pm.scalePage
 	default:
 		return pm.resourceGroupsPage
 	}
 }
 
+// GetSubscriptionsPage returns the subscriptions page
+func (pm *PageManager) GetSubscriptionsPage() *subscriptions.SubscriptionsPage {
+	return pm.subscriptionsPage
+}
+
 // GetResourceGroupsPage returns the resource groups page
 func (pm *PageManager) GetResourceGroupsPage() *resourcegroups.ResourceGroupsPage {
 	return pm.resourceGroupsPage
 }
 
+// GetEnvironmentsPage returns the managed environments page
+func (pm *PageManager) GetEnvironmentsPage() *environments.EnvironmentsPage {
+	return pm.environmentsPage
+}
+
 // GetAppsPage returns the apps page
 func (pm *PageManager) GetAppsPage() *apps.AppsPage {
 	return pm.appsPage
@@ -156,29 +544,131 @@ func (pm *PageManager) GetRevisionsPage() *revisions.RevisionsPage {
 	return pm.revisionsPage
 }
 
+// GetReplicasPage returns the replicas page
+func (pm *PageManager) GetReplicasPage() *replicas.ReplicasPage {
+	return pm.replicasPage
+}
+
 // GetContainersPage returns the containers page
 func (pm *PageManager) GetContainersPage() *containers.ContainersPage {
 	return pm.containersPage
 }
 
+// GetTrafficPage returns the traffic split editor page
+func (pm *PageManager) GetTrafficPage() *traffic.TrafficPage {
+	return pm.trafficPage
+}
+
 // GetEnvVarsPage returns the environment variables page
 func (pm *PageManager) GetEnvVarsPage() *envvars.EnvVarsPage {
 	return pm.envVarsPage
 }
 
+// GetJobsPage returns the container app jobs page
+func (pm *PageManager) GetJobsPage() *jobs.JobsPage {
+	return pm.jobsPage
+}
+
+// GetJobExecutionsPage returns the job executions page
+func (pm *PageManager) GetJobExecutionsPage() *jobexecutions.JobExecutionsPage {
+	return pm.jobExecutionsPage
+}
+
+// GetAppDetailsPage returns the app details page
+func (pm *PageManager) GetAppDetailsPage() *appdetails.AppDetailsPage {
+	return pm.appDetailsPage
+}
+
+// GetMetricsPage returns the metrics page
+func (pm *PageManager) GetMetricsPage() *metrics.MetricsPage {
+	return pm.metricsPage
+}
+
+// GetLogsPage returns the logs page
+func (pm *PageManager) GetLogsPage() *logs.LogsPage {
+	return pm.logsPage
+}
+
+// GetLogQueryPage returns the log query page
+func (pm *PageManager) GetLogQueryPage() *logquery.LogQueryPage {
+	return pm.logQueryPage
+}
+
+// GetOperationsPage returns the operation log page
+func (pm *PageManager) GetOperationsPage() *operations.OperationsPage {
+	return pm.operationsPage
+}
+
+// GetExecPage returns the exec sessions page
+func (pm *PageManager) GetExecPage() *execsessions.ExecPage {
+	return pm.execPage
+}
+
+// GetPortForwardsPage returns the port forwards page
+func (pm *PageManager) GetPortForwardsPage() *portforwards.PortForwardsPage {
+	return pm.portForwardsPage
+}
+
+// GetProbePage returns the HTTP probe page
+func (pm *PageManager) GetProbePage() *probe.ProbePage {
+	return pm.probePage
+}
+
+// GetSecretsPage returns the secrets page
+func (pm *PageManager) GetSecretsPage() *secrets.SecretsPage {
+	return pm.secretsPage
+}
+
+// GetScalePage returns the scale page
+func (pm *PageManager) GetScalePage() *scale.ScalePage {
+	return pm.scalePage
+}
+
 // HandleKeyMsg delegates key handling to the current page
 func (pm *PageManager) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		return pm.subscriptionsPage.HandleKeyMsg(msg)
 	case ModeResourceGroups:
 		return pm.resourceGroupsPage.HandleKeyMsg(msg)
+	case ModeEnvironments:
+		return pm.environmentsPage.HandleKeyMsg(msg)
 	case ModeApps:
 		return pm.appsPage.HandleKeyMsg(msg)
 	case ModeRevisions:
 		return pm.revisionsPage.HandleKeyMsg(msg)
+	case ModeReplicas:
+		return pm.replicasPage.HandleKeyMsg(msg)
 	case ModeContainers:
 		return pm.containersPage.HandleKeyMsg(msg)
+	case ModeTraffic:
+		return pm.trafficPage.HandleKeyMsg(msg)
 	case ModeEnvVars:
 		return pm.envVarsPage.HandleKeyMsg(msg)
+	case ModeJobs:
+		return pm.jobsPage.HandleKeyMsg(msg)
+	case ModeJobExecutions:
+		return pm.jobExecutionsPage.HandleKeyMsg(msg)
+	case ModeAppDetails:
+		return pm.appDetailsPage.HandleKeyMsg(msg)
+	case ModeMetrics:
+		return pm.metricsPage.HandleKeyMsg(msg)
+	case ModeLogs:
+		return pm.logsPage.HandleKeyMsg(msg)
+	case ModeLogQuery:
+		return pm.logQueryPage.HandleKeyMsg(msg)
+	case ModeOperations:
+		return pm.operationsPage.HandleKeyMsg(msg)
+	case ModeExec:
+		return pm.execPage.HandleKeyMsg(msg)
+	case ModePortForwards:
+		return pm.portForwardsPage.HandleKeyMsg(msg)
+	case ModeProbe:
+		return pm.probePage.HandleKeyMsg(msg)
+	case ModeSecrets:
+		return pm.secretsPage.HandleKeyMsg(msg)
+	case ModeScale:
+		return pm.scalePage.HandleKeyMsg(msg)
 	default:
 		return nil, false
 	}
@@ -187,32 +677,119 @@ func (pm *PageManager) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 // UpdateTable updates the table for the current page
 func (pm *PageManager) UpdateTable(msg tea.KeyMsg) tea.Cmd {
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		table := pm.subscriptionsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.subscriptionsPage.SetTable(table)
+		return cmd
 	case ModeResourceGroups:
 		table := pm.resourceGroupsPage.GetTable()
 		table, cmd := table.Update(msg)
 		pm.resourceGroupsPage.SetTable(table)
 		return cmd
+	case ModeEnvironments:
+		table := pm.environmentsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.environmentsPage.SetTable(table)
+		return cmd
 	case ModeApps:
 		table := pm.appsPage.GetTable()
 		table, cmd := table.Update(msg)
 		pm.appsPage.SetTable(table)
 		return cmd
 	case ModeRevisions:
+		if pm.revisionsPage.IsEditing() {
+			// The label form handles its own keys
+			return nil
+		}
 		table := pm.revisionsPage.GetTable()
 		table, cmd := table.Update(msg)
 		pm.revisionsPage.SetTable(table)
 		return cmd
+	case ModeReplicas:
+		table := pm.replicasPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.replicasPage.SetTable(table)
+		return cmd
 	case ModeContainers:
+		if pm.containersPage.IsEditing() {
+			// The image form handles its own keys
+			return nil
+		}
 		table := pm.containersPage.GetTable()
 		table, cmd := table.Update(msg)
 		pm.containersPage.SetTable(table)
 		return cmd
+	case ModeTraffic:
+		table := pm.trafficPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.trafficPage.SetTable(table)
+		return cmd
 	case ModeEnvVars:
-		// Handle table updates for envvars page like other pages
+		if pm.envVarsPage.IsEditing() {
+			// The variable form handles its own keys
+			return nil
+		}
 		table := pm.envVarsPage.GetTable()
 		table, cmd := table.Update(msg)
 		pm.envVarsPage.SetTable(table)
 		return cmd
+	case ModeJobs:
+		table := pm.jobsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.jobsPage.SetTable(table)
+		return cmd
+	case ModeJobExecutions:
+		table := pm.jobExecutionsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.jobExecutionsPage.SetTable(table)
+		return cmd
+	case ModeMetrics:
+		table := pm.metricsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.metricsPage.SetTable(table)
+		return cmd
+	case ModeLogQuery:
+		table := pm.logQueryPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.logQueryPage.SetTable(table)
+		return cmd
+	case ModeOperations:
+		table := pm.operationsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.operationsPage.SetTable(table)
+		return cmd
+	case ModePortForwards:
+		table := pm.portForwardsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.portForwardsPage.SetTable(table)
+		return cmd
+	case ModeSecrets:
+		table := pm.secretsPage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.secretsPage.SetTable(table)
+		return cmd
+	case ModeScale:
+		if pm.scalePage.IsEditing() {
+			// The scale form handles its own keys
+			return nil
+		}
+		table := pm.scalePage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.scalePage.SetTable(table)
+		return cmd
+	case ModeProbe:
+		if pm.probePage.IsEditing() {
+			// The request form handles its own keys
+			return nil
+		}
+		table := pm.probePage.GetTable()
+		table, cmd := table.Update(msg)
+		pm.probePage.SetTable(table)
+		return cmd
+	case ModeAppDetails, ModeLogs:
+		// The document and log viewers handle their own navigation keys
+		return nil
 	default:
 		return nil
 	}
@@ -221,16 +798,48 @@ func (pm *PageManager) UpdateTable(msg tea.KeyMsg) tea.Cmd {
 // View renders the current page
 func (pm *PageManager) View() string {
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		return pm.subscriptionsPage.View()
 	case ModeResourceGroups:
 		return pm.resourceGroupsPage.View()
+	case ModeEnvironments:
+		return pm.environmentsPage.View()
 	case ModeApps:
 		return pm.appsPage.View()
 	case ModeRevisions:
 		return pm.revisionsPage.View()
+	case ModeReplicas:
+		return pm.replicasPage.View()
 	case ModeContainers:
 		return pm.containersPage.View()
+	case ModeTraffic:
+		return pm.trafficPage.View()
 	case ModeEnvVars:
 		return pm.envVarsPage.View()
+	case ModeJobs:
+		return pm.jobsPage.View()
+	case ModeJobExecutions:
+		return pm.jobExecutionsPage.View()
+	case ModeAppDetails:
+		return pm.appDetailsPage.View()
+	case ModeMetrics:
+		return pm.metricsPage.View()
+	case ModeLogs:
+		return pm.logsPage.View()
+	case ModeLogQuery:
+		return pm.logQueryPage.View()
+	case ModeOperations:
+		return pm.operationsPage.View()
+	case ModeExec:
+		return pm.execPage.View()
+	case ModePortForwards:
+		return pm.portForwardsPage.View()
+	case ModeProbe:
+		return pm.probePage.View()
+	case ModeSecrets:
+		return pm.secretsPage.View()
+	case ModeScale:
+		return pm.scalePage.View()
 	default:
 		return pm.resourceGroupsPage.View()
 	}
@@ -242,16 +851,48 @@ func (pm *PageManager) ViewWithHelpContext(helpContext layouts.HelpContext) stri
 	helpContext.Mode = pm.navigationManager.GetCurrentMode()
 
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		return pm.subscriptionsPage.ViewWithHelpContext(helpContext)
 	case ModeResourceGroups:
 		return pm.resourceGroupsPage.ViewWithHelpContext(helpContext)
+	case ModeEnvironments:
+		return pm.environmentsPage.ViewWithHelpContext(helpContext)
 	case ModeApps:
 		return pm.appsPage.ViewWithHelpContext(helpContext)
 	case ModeRevisions:
 		return pm.revisionsPage.ViewWithHelpContext(helpContext)
+	case ModeReplicas:
+		return pm.replicasPage.ViewWithHelpContext(helpContext)
 	case ModeContainers:
 		return pm.containersPage.ViewWithHelpContext(helpContext)
+	case ModeTraffic:
+		return pm.trafficPage.ViewWithHelpContext(helpContext)
 	case ModeEnvVars:
 		return pm.envVarsPage.ViewWithHelpContext(helpContext)
+	case ModeJobs:
+		return pm.jobsPage.ViewWithHelpContext(helpContext)
+	case ModeJobExecutions:
+		return pm.jobExecutionsPage.ViewWithHelpContext(helpContext)
+	case ModeAppDetails:
+		return pm.appDetailsPage.ViewWithHelpContext(helpContext)
+	case ModeMetrics:
+		return pm.metricsPage.ViewWithHelpContext(helpContext)
+	case ModeLogs:
+		return pm.logsPage.ViewWithHelpContext(helpContext)
+	case ModeLogQuery:
+		return pm.logQueryPage.ViewWithHelpContext(helpContext)
+	case ModeOperations:
+		return pm.operationsPage.ViewWithHelpContext(helpContext)
+	case ModeExec:
+		return pm.execPage.ViewWithHelpContext(helpContext)
+	case ModePortForwards:
+		return pm.portForwardsPage.ViewWithHelpContext(helpContext)
+	case ModeProbe:
+		return pm.probePage.ViewWithHelpContext(helpContext)
+	case ModeSecrets:
+		return pm.secretsPage.ViewWithHelpContext(helpContext)
+	case ModeScale:
+		return pm.scalePage.ViewWithHelpContext(helpContext)
 	default:
 		return pm.resourceGroupsPage.ViewWithHelpContext(helpContext)
 	}
@@ -260,58 +901,177 @@ func (pm *PageManager) ViewWithHelpContext(helpContext layouts.HelpContext) stri
 // SetLoading sets loading state for the current page
 func (pm *PageManager) SetLoading(loading bool) {
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		pm.subscriptionsPage.SetLoading(loading)
 	case ModeResourceGroups:
 		pm.resourceGroupsPage.SetLoading(loading)
+	case ModeEnvironments:
+		pm.environmentsPage.SetLoading(loading)
 	case ModeApps:
 		pm.appsPage.SetLoading(loading)
 	case ModeRevisions:
 		pm.revisionsPage.SetLoading(loading)
+	case ModeReplicas:
+		pm.replicasPage.SetLoading(loading)
 	case ModeContainers:
 		pm.containersPage.SetLoading(loading)
+	case ModeTraffic:
+		pm.trafficPage.SetLoading(loading)
 	case ModeEnvVars:
 		pm.envVarsPage.SetLoading(loading)
+	case ModeJobs:
+		pm.jobsPage.SetLoading(loading)
+	case ModeJobExecutions:
+		pm.jobExecutionsPage.SetLoading(loading)
+	case ModeAppDetails:
+		pm.appDetailsPage.SetLoading(loading)
+	case ModeMetrics:
+		pm.metricsPage.SetLoading(loading)
+	case ModeLogs:
+		pm.logsPage.SetLoading(loading)
+	case ModeLogQuery:
+		pm.logQueryPage.SetLoading(loading)
+	case ModeOperations:
+		pm.operationsPage.SetLoading(loading)
+	case ModeExec:
+		pm.execPage.SetLoading(loading)
+	case ModePortForwards:
+		pm.portForwardsPage.SetLoading(loading)
+	case ModeProbe:
+		pm.probePage.SetLoading(loading)
+	case ModeSecrets:
+		pm.secretsPage.SetLoading(loading)
+	case ModeScale:
+		pm.scalePage.SetLoading(loading)
 	}
 }
 
 // SetError sets error state for the current page
 func (pm *PageManager) SetError(err error) {
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		pm.subscriptionsPage.SetError(err)
 	case ModeResourceGroups:
 		pm.resourceGroupsPage.SetError(err)
+	case ModeEnvironments:
+		pm.environmentsPage.SetError(err)
 	case ModeApps:
 		pm.appsPage.SetError(err)
 	case ModeRevisions:
 		pm.revisionsPage.SetError(err)
+	case ModeReplicas:
+		pm.replicasPage.SetError(err)
 	case ModeContainers:
 		pm.containersPage.SetError(err)
+	case ModeTraffic:
+		pm.trafficPage.SetError(err)
 	case ModeEnvVars:
 		pm.envVarsPage.SetError(err)
+	case ModeJobs:
+		pm.jobsPage.SetError(err)
+	case ModeJobExecutions:
+		pm.jobExecutionsPage.SetError(err)
+	case ModeAppDetails:
+		pm.appDetailsPage.SetError(err)
+	case ModeMetrics:
+		pm.metricsPage.SetError(err)
+	case ModeLogs:
+		pm.logsPage.SetError(err)
+	case ModeLogQuery:
+		pm.logQueryPage.SetError(err)
+	case ModeOperations:
+		pm.operationsPage.SetError(err)
+	case ModeExec:
+		pm.execPage.SetError(err)
+	case ModePortForwards:
+		pm.portForwardsPage.SetError(err)
+	case ModeProbe:
+		pm.probePage.SetError(err)
+	case ModeSecrets:
+		pm.secretsPage.SetError(err)
+	case ModeScale:
+		pm.scalePage.SetError(err)
 	}
 }
 
 // ClearData clears data for the current page
 func (pm *PageManager) ClearData() {
 	switch pm.navigationManager.GetCurrentMode() {
+	case ModeSubscriptions:
+		pm.subscriptionsPage.ClearData()
 	case ModeResourceGroups:
 		pm.resourceGroupsPage.ClearData()
+	case ModeEnvironments:
+		pm.environmentsPage.ClearData()
 	case ModeApps:
 		pm.appsPage.ClearData()
 	case ModeRevisions:
 		pm.revisionsPage.ClearData()
+	case ModeReplicas:
+		pm.replicasPage.ClearData()
 	case ModeContainers:
 		pm.containersPage.ClearData()
+	case ModeTraffic:
+		pm.trafficPage.ClearData()
 	case ModeEnvVars:
 		pm.envVarsPage.ClearData()
+	case ModeJobs:
+		pm.jobsPage.ClearData()
+	case ModeJobExecutions:
+		pm.jobExecutionsPage.ClearData()
+	case ModeAppDetails:
+		pm.appDetailsPage.ClearData()
+	case ModeMetrics:
+		pm.metricsPage.ClearData()
+	case ModeLogs:
+		pm.logsPage.ClearData()
+	case ModeLogQuery:
+		pm.logQueryPage.ClearData()
+	case ModeOperations:
+		pm.operationsPage.ClearData()
+	case ModeExec:
+		pm.execPage.ClearData()
+	case ModePortForwards:
+		pm.portForwardsPage.ClearData()
+	case ModeProbe:
+		pm.probePage.ClearData()
+	case ModeSecrets:
+		pm.secretsPage.ClearData()
+	case ModeScale:
+		pm.scalePage.ClearData()
 	}
 }
 
 // IsAnyFilterActive checks if any page has an active filter
 func (pm *PageManager) IsAnyFilterActive() bool {
-	return pm.resourceGroupsPage.GetFilterInput().Focused() ||
+	return pm.subscriptionsPage.GetFilterInput().Focused() ||
+		pm.resourceGroupsPage.GetFilterInput().Focused() ||
+		pm.environmentsPage.GetFilterInput().Focused() ||
 		pm.appsPage.GetFilterInput().Focused() ||
 		pm.revisionsPage.GetFilterInput().Focused() ||
+		pm.revisionsPage.IsEditing() ||
+		pm.replicasPage.GetFilterInput().Focused() ||
 		pm.containersPage.GetFilterInput().Focused() ||
-		pm.envVarsPage.GetFilterInput().Focused()
+		pm.containersPage.IsEditing() ||
+		pm.trafficPage.GetFilterInput().Focused() ||
+		pm.envVarsPage.GetFilterInput().Focused() ||
+		pm.envVarsPage.IsEditing() ||
+		pm.jobsPage.GetFilterInput().Focused() ||
+		pm.jobExecutionsPage.GetFilterInput().Focused() ||
+		pm.appDetailsPage.IsSearching() ||
+		pm.metricsPage.GetFilterInput().Focused() ||
+		pm.logsPage.IsSearching() ||
+		pm.logQueryPage.GetFilterInput().Focused() ||
+		pm.logQueryPage.IsEditing() ||
+		pm.operationsPage.GetFilterInput().Focused() ||
+		pm.portForwardsPage.GetFilterInput().Focused() ||
+		pm.probePage.GetFilterInput().Focused() ||
+		pm.probePage.IsEditing() ||
+		pm.secretsPage.GetFilterInput().Focused() ||
+		pm.scalePage.GetFilterInput().Focused() ||
+		pm.scalePage.IsEditing() ||
+		// Sessions keep running in the background, their keys only matter when shown
+		(pm.navigationManager.GetCurrentMode() == ModeExec && pm.execPage.IsSearching())
 }
 
 // UpdateLayoutSystem updates the layout system for all pages
@@ -13,10 +13,14 @@ type StateManager struct {
 	currentApp       *models.ContainerApp
 	currentRevision  *models.Revision
 	currentContainer *models.Container
+	currentJob       *models.Job
 
 	// Global status
 	statusLine string
 
+	// Results of operations that changed Azure resources, oldest first
+	operations []models.Operation
+
 	// Context management
 	showContextList bool
 }
@@ -105,6 +109,24 @@ func (sm *StateManager) ClearCurrentContainer() {
 	sm.currentContainer = nil
 }
 
+// SetCurrentJob sets the current job
+func (sm *StateManager) SetCurrentJob(job models.Job) {
+	sm.currentJob = &job
+}
+
+// GetCurrentJob returns the current job
+func (sm *StateManager) GetCurrentJob() (models.Job, bool) {
+	if sm.currentJob == nil {
+		return models.Job{}, false
+	}
+	return *sm.currentJob, true
+}
+
+// ClearCurrentJob clears the current job
+func (sm *StateManager) ClearCurrentJob() {
+	sm.currentJob = nil
+}
+
 // Status management
 
 // SetStatusLine sets the global status line
@@ -122,6 +144,26 @@ func (sm *StateManager) ClearStatusLine() {
 	sm.statusLine = ""
 }
 
+// Operation log management
+
+// maxOperations is the number of operations kept in the operation log
+const maxOperations = 200
+
+// AddOperation appends an operation to the operation log
+func (sm *StateManager) AddOperation(op models.Operation) {
+	sm.operations = append(sm.operations, op)
+	if len(sm.operations) > maxOperations {
+		sm.operations = sm.operations[len(sm.operations)-maxOperations:]
+	}
+}
+
+// GetOperations returns a copy of the operation log, oldest first
+func (sm *StateManager) GetOperations() []models.Operation {
+	operations := make([]models.Operation, len(sm.operations))
+	copy(operations, sm.operations)
+	return operations
+}
+
 // Context list management
 
 // SetShowContextList sets whether the context list should be shown
@@ -152,6 +194,11 @@ func (sm *StateManager) ValidateState(navState NavigationState) {
 	if navState.CurrentContainerName == "" {
 		sm.ClearCurrentContainer()
 	}
+
+	// Clear current job if we're not in job-related modes
+	if navState.CurrentJobID == "" {
+		sm.ClearCurrentJob()
+	}
 }
 
 // Reset resets all state to initial values
@@ -160,6 +207,7 @@ func (sm *StateManager) Reset() {
 	sm.ClearCurrentApp()
 	sm.ClearCurrentRevision()
 	sm.ClearCurrentContainer()
+	sm.ClearCurrentJob()
 	sm.ClearStatusLine()
 	sm.showContextList = false
 }
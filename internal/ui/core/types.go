@@ -15,35 +15,79 @@ const (
 	ModeRevisions      = layouts.ModeRevisions
 	ModeContainers     = layouts.ModeContainers
 	ModeEnvVars        = layouts.ModeEnvVars
+	ModeJobs           = layouts.ModeJobs
+	ModeJobExecutions  = layouts.ModeJobExecutions
+	ModeAppDetails     = layouts.ModeAppDetails
+	ModeOperations     = layouts.ModeOperations
+	ModeSubscriptions  = layouts.ModeSubscriptions
+	ModeEnvironments   = layouts.ModeEnvironments
+	ModeTraffic        = layouts.ModeTraffic
+	ModeReplicas       = layouts.ModeReplicas
+	ModeMetrics        = layouts.ModeMetrics
+	ModeLogs           = layouts.ModeLogs
+	ModeLogQuery       = layouts.ModeLogQuery
+	ModeExec           = layouts.ModeExec
+	ModePortForwards   = layouts.ModePortForwards
+	ModeProbe          = layouts.ModeProbe
+	ModeSecrets        = layouts.ModeSecrets
+	ModeScale          = layouts.ModeScale
 )
 
 // NavigationState holds the current navigation context
 type NavigationState struct {
-	CurrentRG            string // Current resource group
-	CurrentAppID         string // When viewing revisions
-	CurrentRevName       string // When viewing containers
-	CurrentContainerName string // When viewing environment variables
+	CurrentSubscription     string // Subscription ID every az command runs against, empty for the az CLI default
+	CurrentSubscriptionName string // Display name of the current subscription
+	CurrentRG               string // Current resource group
+	CurrentEnvironmentID    string // When viewing the apps of a managed environment
+	CurrentEnvironment      string // Display name of the current managed environment
+	CurrentAppID            string // When viewing revisions
+	CurrentRevName          string // When viewing replicas or containers
+	CurrentReplicaName      string // When viewing the containers of a replica
+	CurrentContainerName    string // When viewing environment variables or the logs of a container
+	CurrentJobID            string // When viewing job executions
 }
 
 // Reset clears all navigation state
 func (ns *NavigationState) Reset() {
+	ns.CurrentSubscription = ""
+	ns.CurrentSubscriptionName = ""
 	ns.CurrentRG = ""
+	ns.CurrentEnvironmentID = ""
+	ns.CurrentEnvironment = ""
 	ns.CurrentAppID = ""
 	ns.CurrentRevName = ""
+	ns.CurrentReplicaName = ""
 	ns.CurrentContainerName = ""
+	ns.CurrentJobID = ""
 }
 
 // ResetFrom resets navigation state from a specific level
 func (ns *NavigationState) ResetFrom(mode Mode) {
 	switch mode {
-	case ModeResourceGroups:
-		ns.Reset()
-	case ModeApps:
+	case ModeSubscriptions, ModeResourceGroups:
+		// Keep the subscription, it scopes everything below it
+		ns.CurrentRG = ""
+		ns.CurrentEnvironmentID = ""
+		ns.CurrentEnvironment = ""
 		ns.CurrentAppID = ""
 		ns.CurrentRevName = ""
+		ns.CurrentReplicaName = ""
 		ns.CurrentContainerName = ""
+		ns.CurrentJobID = ""
+	case ModeEnvironments, ModeApps, ModeJobs:
+		ns.CurrentEnvironmentID = ""
+		ns.CurrentEnvironment = ""
+		ns.CurrentAppID = ""
+		ns.CurrentRevName = ""
+		ns.CurrentReplicaName = ""
+		ns.CurrentContainerName = ""
+		ns.CurrentJobID = ""
 	case ModeRevisions:
 		ns.CurrentRevName = ""
+		ns.CurrentReplicaName = ""
+		ns.CurrentContainerName = ""
+	case ModeReplicas:
+		ns.CurrentReplicaName = ""
 		ns.CurrentContainerName = ""
 	case ModeContainers:
 		ns.CurrentContainerName = ""
@@ -54,15 +98,27 @@ func (ns *NavigationState) ResetFrom(mode Mode) {
 func (ns *NavigationState) GetBreadcrumb() string {
 	var parts []string
 
+	if ns.CurrentSubscriptionName != "" {
+		parts = append(parts, ns.CurrentSubscriptionName)
+	}
 	if ns.CurrentRG != "" {
 		parts = append(parts, ns.CurrentRG)
 	}
+	if ns.CurrentEnvironment != "" {
+		parts = append(parts, ns.CurrentEnvironment)
+	}
 	if ns.CurrentAppID != "" {
 		parts = append(parts, ns.CurrentAppID)
 	}
+	if ns.CurrentJobID != "" {
+		parts = append(parts, ns.CurrentJobID)
+	}
 	if ns.CurrentRevName != "" {
 		parts = append(parts, ns.CurrentRevName)
 	}
+	if ns.CurrentReplicaName != "" {
+		parts = append(parts, ns.CurrentReplicaName)
+	}
 	if ns.CurrentContainerName != "" {
 		parts = append(parts, ns.CurrentContainerName)
 	}
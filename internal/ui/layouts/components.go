@@ -10,6 +10,9 @@ import (
 type DefaultStatusBarFactory struct {
 	theme *ThemeManager
 	termW int
+
+	// Global message shown when the page has nothing more specific to report
+	message string
 }
 
 // NewDefaultStatusBarFactory creates a new default status bar factory
@@ -37,6 +40,38 @@ func (f *DefaultStatusBarFactory) CreateStatusBar(context StatusContext) string
 		modeIndicator = f.theme.GetStyle("modeContainers").Render("🔧 ENV VARS")
 	case ModeResourceGroups:
 		modeIndicator = f.theme.GetStyle("modeApps").Render("📁 RESOURCE GROUPS")
+	case ModeJobs:
+		modeIndicator = f.theme.GetStyle("modeApps").Render("⚡ JOBS")
+	case ModeJobExecutions:
+		modeIndicator = f.theme.GetStyle("modeRevisions").Render("▶ EXECUTIONS")
+	case ModeAppDetails:
+		modeIndicator = f.theme.GetStyle("modeApps").Render("📄 DETAILS")
+	case ModeOperations:
+		modeIndicator = f.theme.GetStyle("modeContainers").Render("📜 OPERATIONS")
+	case ModeSubscriptions:
+		modeIndicator = f.theme.GetStyle("modeApps").Render("🔑 SUBSCRIPTIONS")
+	case ModeEnvironments:
+		modeIndicator = f.theme.GetStyle("modeApps").Render("🌐 ENVIRONMENTS")
+	case ModeTraffic:
+		modeIndicator = f.theme.GetStyle("modeRevisions").Render("🔀 TRAFFIC")
+	case ModeReplicas:
+		modeIndicator = f.theme.GetStyle("modeContainers").Render("🧩 REPLICAS")
+	case ModeMetrics:
+		modeIndicator = f.theme.GetStyle("modeRevisions").Render("📈 METRICS")
+	case ModeLogs:
+		modeIndicator = f.theme.GetStyle("modeContainers").Render("📃 LOGS")
+	case ModeLogQuery:
+		modeIndicator = f.theme.GetStyle("modeRevisions").Render("🔎 LOG QUERY")
+	case ModeExec:
+		modeIndicator = f.theme.GetStyle("modeContainers").Render("💻 EXEC")
+	case ModePortForwards:
+		modeIndicator = f.theme.GetStyle("modeContainers").Render("🔌 FORWARDS")
+	case ModeProbe:
+		modeIndicator = f.theme.GetStyle("modeRevisions").Render("📡 PROBE")
+	case ModeSecrets:
+		modeIndicator = f.theme.GetStyle("modeApps").Render("🔐 SECRETS")
+	case ModeScale:
+		modeIndicator = f.theme.GetStyle("modeApps").Render("📈 SCALE")
 	default:
 		modeIndicator = f.theme.GetStyle("modeApps").Render("📦 APPS")
 	}
@@ -68,7 +103,7 @@ func (f *DefaultStatusBarFactory) CreateStatusBar(context StatusContext) string
 	// Context info indicators
 	var contextIndicators []string
 	// Define consistent key order to ensure deterministic display
-	keyOrder := []string{"app", "revision", "container", "resource_group"}
+	keyOrder := []string{"app", "revisions", "job", "revision", "replica", "container", "command", "logs", "template", "range", "window", "stream", "filter", "marked", "environment", "resource_group", "subscription"}
 	for _, name := range keyOrder {
 		if value, exists := context.ContextInfo[name]; exists {
 			indicator := f.theme.GetStyle("context").Render(fmt.Sprintf("%s: %s", name, value))
@@ -93,7 +128,7 @@ func (f *DefaultStatusBarFactory) CreateStatusBar(context StatusContext) string
 		} else if context.Loading {
 			statusMessage = "Loading..."
 		} else {
-			statusMessage = ""
+			statusMessage = f.message
 		}
 	}
 
@@ -119,6 +154,11 @@ func (f *DefaultStatusBarFactory) SetTerminalWidth(width int) {
 	f.termW = width
 }
 
+// SetMessage sets the global status message
+func (f *DefaultStatusBarFactory) SetMessage(message string) {
+	f.message = message
+}
+
 // DefaultHelpBarFactory provides the default help bar implementation
 type DefaultHelpBarFactory struct {
 	theme *ThemeManager
@@ -139,15 +179,47 @@ func (f *DefaultHelpBarFactory) CreateHelpBar(context HelpContext) string {
 	// Add mode-specific help
 	switch context.Mode {
 	case ModeApps:
-		helpItems = append(helpItems, "enter: view revisions", "l: logs", "s/e: exec", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+		helpItems = append(helpItems, "enter: view revisions", "d: details", "m: metrics", "l: logs", "space: mark", "L: tail marked", "s/e: exec", "p: port-forward", "h: http probe", "S: secrets", "a: scale", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
 	case ModeRevisions:
-		helpItems = append(helpItems, "enter: view containers", "R: restart", "l: logs", "s: exec", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+		helpItems = append(helpItems, "enter: view replicas", "c: containers", "R: restart", "a: activate", "d: deactivate", "+/-: labels", "M: revision mode", "t: traffic", "m: metrics", "l: logs", "s: exec", "h: http probe", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+	case ModeReplicas:
+		helpItems = append(helpItems, "enter: view containers", "l: logs", "s: shell", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+	case ModeMetrics:
+		helpItems = append(helpItems, "1-4: time window", "w: next window", "r: refresh", "esc: back", "?: help", "q: quit")
+	case ModeLogs:
+		helpItems = append(helpItems, "p: pause", "f: follow", "w: wrap", "c: columns", "/: search", "F: field filter", "n/N: next/prev match", "t: log type", "h: history", "s: save", "1-9: mute app", "0: unmute all", "r: reconnect", "esc: back", "?: help", "q: quit")
+	case ModeLogQuery:
+		helpItems = append(helpItems, "e: edit query", "t: template", "w: time range", "r: run", "/: filter", "shift+←/→: scroll", "esc: back", "?: help", "q: quit")
+	case ModeExec:
+		helpItems = append(helpItems, "ctrl+] n/p: next/prev tab", "ctrl+] 1-9: go to tab", "ctrl+] c: new session", "ctrl+] x: close", "ctrl+] ctrl+]: send ctrl+]", "ctrl+] esc: back")
+	case ModePortForwards:
+		helpItems = append(helpItems, "x: stop", "r: refresh", "/: filter", "shift+←/→: scroll", "esc: back", "?: help", "q: quit")
+	case ModeProbe:
+		helpItems = append(helpItems, "e: edit request", "enter: send", "m: method", "u: reuse request", "ctrl+d/u: scroll response", "/: filter", "esc: back", "?: help", "q: quit")
+	case ModeSecrets:
+		helpItems = append(helpItems, "v: reveal/hide value", "r: refresh", "/: filter", "shift+←/→: scroll", "esc: back", "?: help", "q: quit")
+	case ModeScale:
+		helpItems = append(helpItems, "m: min/max replicas", "e: edit rule", "u: undo", "A: apply", "r: refresh", "/: filter", "shift+←/→: scroll", "esc: back", "?: help", "q: quit")
 	case ModeContainers:
-		helpItems = append(helpItems, "v: env vars", "s: shell", "l: logs", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+		helpItems = append(helpItems, "v: env vars", "s: shell", "l: logs", "i: update image", "T: shift traffic", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
 	case ModeEnvVars:
-		helpItems = append(helpItems, "/: filter", "shift+←/→: scroll", "esc: back", "?: help", "q: quit")
+		helpItems = append(helpItems, "a: add", "e: edit", "d: delete/restore", "u: undo", "A: apply", "enter: go to secret", "/: filter", "shift+←/→: scroll", "esc: back", "?: help", "q: quit")
 	case ModeResourceGroups:
+		helpItems = append(helpItems, "enter: select", "e: environments", "r: refresh", "/: filter", "esc: subscriptions", "?: help", "q: quit")
+	case ModeSubscriptions:
 		helpItems = append(helpItems, "enter: select", "r: refresh", "/: filter", "?: help", "q: quit")
+	case ModeEnvironments:
+		helpItems = append(helpItems, "enter: view apps", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+	case ModeTraffic:
+		helpItems = append(helpItems, "+/-: adjust weight", "u: undo", "enter: apply", "r: refresh", "esc: back", "?: help", "q: quit")
+	case ModeJobs:
+		helpItems = append(helpItems, "enter: view executions", "S: start", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+	case ModeJobExecutions:
+		helpItems = append(helpItems, "S: start", "X: stop", "R: re-run", "r: refresh", "/: filter", "esc: back", "?: help", "q: quit")
+	case ModeOperations:
+		helpItems = append(helpItems, "/: filter", "shift+←/→: scroll", "esc: back", "?: help", "q: quit")
+	case ModeAppDetails:
+		helpItems = append(helpItems, "enter: fold", "z/Z: fold/unfold all", "t: json/yaml", "/: search", "n/N: next/prev match", "c: copy JSONPath", "r: refresh", "esc: back", "?: help", "q: quit")
 	default:
 		helpItems = append(helpItems, "?: help", "q: quit")
 	}
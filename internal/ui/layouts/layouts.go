@@ -76,6 +76,14 @@ func (ls *LayoutSystem) SetDimensions(termW, termH int) {
 
 // Layout creation methods
 
+// SetStatusMessage sets the message shown in the status bar of every page that
+// does not report a more specific status, such as the result of the last operation
+func (ls *LayoutSystem) SetStatusMessage(message string) {
+	if factory, ok := ls.statusFactory.(*DefaultStatusBarFactory); ok {
+		factory.SetMessage(message)
+	}
+}
+
 // CreateLayout creates a basic layout with content and bars
 func (ls *LayoutSystem) CreateLayout(content string, options LayoutOptions) string {
 	return ls.manager.CreateLayout(content, options)
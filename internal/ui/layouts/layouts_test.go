@@ -115,6 +115,10 @@ func TestLayoutSystem_ModalLayout(t *testing.T) {
 	if !strings.Contains(result, content) {
 		t.Error("Result does not contain modal content")
 	}
+
+	if !strings.Contains(result, "╭") {
+		t.Error("Modal content should be rendered in a bordered box")
+	}
 }
 
 func TestLayoutSystem_DimensionUpdates(t *testing.T) {
@@ -129,6 +133,25 @@ func TestLayoutSystem_DimensionUpdates(t *testing.T) {
 	}
 }
 
+func TestLayoutSystem_StatusMessage(t *testing.T) {
+	ls := NewLayoutSystem(120, 24)
+	ls.SetStatusMessage("Restarted revision my-app--rev1.")
+
+	helpContext := HelpContext{Mode: ModeRevisions}
+
+	// The global message is shown when the page has nothing more specific to report
+	result := ls.CreateTableLayout("content", StatusContext{Mode: ModeRevisions}, helpContext)
+	if !strings.Contains(result, "Restarted revision my-app--rev1.") {
+		t.Error("Result does not contain the global status message")
+	}
+
+	// A page-specific message takes precedence
+	result = ls.CreateTableLayout("content", StatusContext{Mode: ModeRevisions, StatusMessage: "Copied $.name"}, helpContext)
+	if strings.Contains(result, "Restarted revision") || !strings.Contains(result, "Copied $.name") {
+		t.Error("Page status message should replace the global status message")
+	}
+}
+
 func TestThemeManager_DefaultTheme(t *testing.T) {
 	theme := DefaultTheme()
 
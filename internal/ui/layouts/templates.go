@@ -115,6 +115,13 @@ func (tm *TemplateManager) RenderTemplate(templateName, content string, options
 		content = template.ContentFunc(content, state)
 	}
 
+	// Show the styled content in the modal overlay
+	if mergedOptions.Modal != nil && mergedOptions.Modal.Visible {
+		modal := *mergedOptions.Modal
+		modal.Content = content
+		mergedOptions.Modal = &modal
+	}
+
 	return tm.manager.CreateLayout(content, mergedOptions)
 }
 
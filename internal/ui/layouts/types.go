@@ -13,6 +13,22 @@ const (
 	ModeRevisions
 	ModeContainers
 	ModeEnvVars
+	ModeJobs
+	ModeJobExecutions
+	ModeAppDetails
+	ModeOperations
+	ModeSubscriptions
+	ModeEnvironments
+	ModeTraffic
+	ModeReplicas
+	ModeMetrics
+	ModeLogs
+	ModeLogQuery
+	ModeExec
+	ModePortForwards
+	ModeProbe
+	ModeSecrets
+	ModeScale
 )
 
 // String returns the string representation of the mode
@@ -28,6 +44,38 @@ func (m Mode) String() string {
 		return "Containers"
 	case ModeEnvVars:
 		return "Environment Variables"
+	case ModeJobs:
+		return "Container App Jobs"
+	case ModeJobExecutions:
+		return "Job Executions"
+	case ModeAppDetails:
+		return "App Details"
+	case ModeOperations:
+		return "Operation Log"
+	case ModeSubscriptions:
+		return "Subscriptions"
+	case ModeEnvironments:
+		return "Environments"
+	case ModeTraffic:
+		return "Traffic Split"
+	case ModeReplicas:
+		return "Replicas"
+	case ModeMetrics:
+		return "Metrics"
+	case ModeLogs:
+		return "Logs"
+	case ModeLogQuery:
+		return "Log Query"
+	case ModeExec:
+		return "Exec Sessions"
+	case ModePortForwards:
+		return "Port Forwards"
+	case ModeProbe:
+		return "HTTP Probe"
+	case ModeSecrets:
+		return "Secrets"
+	case ModeScale:
+		return "Scale"
 	default:
 		return "Unknown"
 	}
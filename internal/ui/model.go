@@ -6,14 +6,17 @@ import (
 	"strings"
 
 	"github.com/IAL32/az-tui/internal/mock"
+	"github.com/IAL32/az-tui/internal/models"
 	"github.com/IAL32/az-tui/internal/providers"
 	"github.com/IAL32/az-tui/internal/ui/core"
 	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
 
 	"github.com/charmbracelet/bubbles/help"
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -62,6 +65,8 @@ func (d contextDelegate) Render(w io.Writer, m list.Model, index int, listItem l
 type ConfirmDialog struct {
 	Visible bool
 	Text    string
+	Confirm string                         // text the user has to type to confirm, empty for a y/n prompt
+	Input   textinput.Model                // input for the text to type
 	OnYes   func(m model) (model, tea.Cmd) // executed if user presses yes
 	OnNo    func(m model) (model, tea.Cmd) // executed if user presses no/cancel
 }
@@ -152,14 +157,17 @@ func InitialModel(useMockMode bool) model {
 		dataProvider = providers.NewAzureProvider()
 	}
 
-	// Create command provider
-	commandProvider := createCommandProvider(useMockMode)
+	// Create command, metrics, log query and probe providers
+	commandProvider := createCommandProvider(dataProvider)
+	metricsProvider := createMetricsProvider(dataProvider)
+	logQueryRunner := createLogQueryRunner(dataProvider)
+	prober := createProber(dataProvider)
 
 	// Initialize terminal dimensions
 	termW, termH := 80, 24
 
 	// Create core model
-	coreModel := core.NewCoreModel(dataProvider, commandProvider, termW, termH)
+	coreModel := core.NewCoreModel(dataProvider, commandProvider, metricsProvider, logQueryRunner, prober, termW, termH)
 
 	// Create main model
 	m := model{
@@ -287,6 +295,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleWindowSize(msg)
 	case tea.KeyMsg:
 		return m.handleKeyMsg(msg)
+	case pages.ConfirmRequestMsg:
+		return m.handleConfirmRequest(msg)
 	default:
 		// Handle spinner updates and delegate to core
 		var spinnerCmd tea.Cmd
@@ -322,6 +332,11 @@ func (m model) View() string {
 		helpContext.BubbleTeaHelp = m.help.View(keyMap)
 	}
 
+	// Show the confirmation dialog on top of everything else
+	if m.confirm.Visible {
+		return m.viewConfirmDialog()
+	}
+
 	// Show context list when active
 	if m.core.IsShowingContextList() {
 		return m.viewContextList()
@@ -354,6 +369,9 @@ func (m model) handleWindowSize(msg tea.WindowSizeMsg) (tea.Model, tea.Cmd) {
 func (m model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	// Handle confirmation dialog
 	if m.confirm.Visible {
+		if m.confirm.Confirm != "" {
+			return m.handleTypedConfirmKey(msg)
+		}
 		switch msg.String() {
 		case "y", "enter":
 			m.confirm.Visible = false
@@ -376,10 +394,11 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		return m.handleContextListKey(msg)
 	}
 
-	// Intercept ":" to show context list
-	if msg.String() == ":" {
-		// Don't show if in filter mode or confirm dialog
-		if m.core.IsAnyFilterActive() || m.confirm.Visible {
+	// Intercept ":" to show context list, unless it is typed into a filter or
+	// another input of the page
+	if msg.String() == ":" && !m.core.IsAnyFilterActive() {
+		// Don't show over the confirm dialog
+		if m.confirm.Visible {
 			return m, nil
 		}
 		m.core.SetShowContextList(true)
@@ -413,11 +432,102 @@ func (m model) handleKeyMsg(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// handleConfirmRequest shows the confirmation dialog for a destructive action.
+// Actions on resources in production resource groups require typing the resource name.
+func (m model) handleConfirmRequest(msg pages.ConfirmRequestMsg) (tea.Model, tea.Cmd) {
+	m.confirm = ConfirmDialog{
+		Visible: true,
+		Text:    msg.Text,
+		OnYes: func(m model) (model, tea.Cmd) {
+			if msg.OnConfirm == nil {
+				return m, nil
+			}
+			return m, msg.OnConfirm()
+		},
+		OnNo: func(m model) (model, tea.Cmd) {
+			m.core.SetStatusLine("Cancelled.")
+			return m, nil
+		},
+	}
+
+	if msg.Resource != "" && m.core.IsProductionResourceGroup(msg.ResourceGroup) {
+		input := textinput.New()
+		input.Prompt = "> "
+		input.Placeholder = msg.Resource
+		input.Focus()
+		m.confirm.Confirm = msg.Resource
+		m.confirm.Input = input
+	}
+
+	return m, nil
+}
+
+// handleTypedConfirmKey handles keys while the user types the name of the resource to confirm
+func (m model) handleTypedConfirmKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "enter":
+		if m.confirm.Input.Value() != m.confirm.Confirm {
+			return m, nil // keep the dialog open until the name matches
+		}
+		m.confirm.Visible = false
+		if m.confirm.OnYes != nil {
+			return m.confirm.OnYes(m)
+		}
+		return m, nil
+	case "esc":
+		m.confirm.Visible = false
+		if m.confirm.OnNo != nil {
+			return m.confirm.OnNo(m)
+		}
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.confirm.Input, cmd = m.confirm.Input.Update(msg)
+	return m, cmd
+}
+
 // Context list management
 
 func (m model) createContextList() list.Model {
 	items := m.createContextItems()
 
+	// Subscriptions can be switched from every context
+	if m.core.GetCurrentMode() != core.ModeSubscriptions {
+		items = append(items, simpleContextItem{
+			id:      "subscriptions",
+			display: "🔑 Subscriptions",
+			enabled: true,
+		})
+	}
+
+	// The operation log can be opened from every context
+	if m.core.GetCurrentMode() != core.ModeOperations {
+		items = append(items, simpleContextItem{
+			id:      "operations",
+			display: "📜 Operation Log",
+			enabled: true,
+		})
+	}
+
+	// Exec sessions stay open while browsing, so they can be returned to
+	if m.core.GetCurrentMode() != core.ModeExec && m.core.ExecSessionCount() > 0 {
+		items = append(items, simpleContextItem{
+			id:      "exec",
+			display: "💻 Exec Sessions",
+			enabled: true,
+		})
+	}
+
+	// Port forwards keep running while browsing, so they can be returned to
+	if m.core.GetCurrentMode() != core.ModePortForwards && m.core.PortForwardCount() > 0 {
+		items = append(items, simpleContextItem{
+			id:      "port-forwards",
+			display: "🔌 Port Forwards",
+			enabled: true,
+		})
+	}
+
 	// Use our custom single-line delegate
 	delegate := contextDelegate{}
 
@@ -442,9 +552,18 @@ func (m model) createContextList() list.Model {
 
 func (m model) createContextItems() []list.Item {
 	switch m.core.GetCurrentMode() {
+	case core.ModeSubscriptions:
+		// From subscriptions, a subscription must be selected before going further
+		return []list.Item{}
+
 	case core.ModeResourceGroups:
-		// From resource groups, can go to container apps or jobs (no resource group selected)
+		// From resource groups, can go to environments, container apps or jobs (no resource group selected)
 		return []list.Item{
+			simpleContextItem{
+				id:      "environments",
+				display: "🌐 Environments",
+				enabled: true,
+			},
 			simpleContextItem{
 				id:      "apps",
 				display: "📦 Container Apps",
@@ -453,13 +572,18 @@ func (m model) createContextItems() []list.Item {
 			simpleContextItem{
 				id:      "jobs",
 				display: "⚡ Container App Jobs",
-				enabled: false, // To be implemented
+				enabled: true,
 			},
 		}
 
-	case core.ModeApps:
-		// From apps, can switch between apps and jobs (preserve resource group selection)
+	case core.ModeEnvironments, core.ModeApps, core.ModeJobs:
+		// From environments, apps or jobs, can switch between them (preserve resource group selection)
 		return []list.Item{
+			simpleContextItem{
+				id:      "environments",
+				display: "🌐 Environments",
+				enabled: true,
+			},
 			simpleContextItem{
 				id:      "apps",
 				display: "📦 Container Apps",
@@ -468,7 +592,7 @@ func (m model) createContextItems() []list.Item {
 			simpleContextItem{
 				id:      "jobs",
 				display: "⚡ Container App Jobs",
-				enabled: false, // To be implemented
+				enabled: true,
 			},
 		}
 
@@ -482,6 +606,16 @@ func (m model) createContextItems() []list.Item {
 			},
 		}
 
+	case core.ModeReplicas:
+		// From replicas, can only go to replicas (preserve resource group, app, and revision selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "replicas",
+				display: "🧩 Replicas",
+				enabled: true,
+			},
+		}
+
 	case core.ModeContainers:
 		// From containers, can only go to containers (preserve resource group, app, and revision selection)
 		return []list.Item{
@@ -492,6 +626,16 @@ func (m model) createContextItems() []list.Item {
 			},
 		}
 
+	case core.ModeTraffic:
+		// From the traffic split editor, can only go to the editor (preserve resource group and app selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "traffic",
+				display: "🔀 Traffic Split",
+				enabled: true,
+			},
+		}
+
 	case core.ModeEnvVars:
 		// From env vars, can only go to env vars (preserve all selections)
 		return []list.Item{
@@ -502,6 +646,121 @@ func (m model) createContextItems() []list.Item {
 			},
 		}
 
+	case core.ModeJobExecutions:
+		// From job executions, can only go to job executions (preserve resource group and job selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "job-executions",
+				display: "▶ Job Executions",
+				enabled: true,
+			},
+		}
+
+	case core.ModeOperations:
+		// From the operation log, can only go to the operation log (esc returns to the previous view)
+		return []list.Item{
+			simpleContextItem{
+				id:      "operations",
+				display: "📜 Operation Log",
+				enabled: true,
+			},
+		}
+
+	case core.ModeExec:
+		// From exec sessions, can only go to exec sessions (esc returns to the previous view)
+		return []list.Item{
+			simpleContextItem{
+				id:      "exec",
+				display: "💻 Exec Sessions",
+				enabled: true,
+			},
+		}
+
+	case core.ModePortForwards:
+		// From port forwards, can only go to port forwards (esc returns to the previous view)
+		return []list.Item{
+			simpleContextItem{
+				id:      "port-forwards",
+				display: "🔌 Port Forwards",
+				enabled: true,
+			},
+		}
+
+	case core.ModeAppDetails:
+		// From app details, can only go to app details (preserve resource group and app selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "app-details",
+				display: "📄 App Details",
+				enabled: true,
+			},
+		}
+
+	case core.ModeSecrets:
+		// From secrets, can only go to secrets (preserve resource group and app selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "secrets",
+				display: "🔐 Secrets",
+				enabled: true,
+			},
+		}
+
+	case core.ModeScale:
+		// From scale, can only go to scale (preserve resource group and app selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "scale",
+				display: "📈 Scale",
+				enabled: true,
+			},
+		}
+
+	case core.ModeMetrics:
+		// From metrics, can only go to metrics (preserve resource group, app, and revision selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "metrics",
+				display: "📈 Metrics",
+				enabled: true,
+			},
+		}
+
+	case core.ModeProbe:
+		// From the probe, can only go to the probe (preserve resource group, app, and revision selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "probe",
+				display: "📡 HTTP Probe",
+				enabled: true,
+			},
+		}
+
+	case core.ModeLogs:
+		// From logs, can go to logs or query their history (preserve resource group, app, and revision selection)
+		return []list.Item{
+			simpleContextItem{
+				id:      "logs",
+				display: "📃 Logs",
+				enabled: true,
+			},
+			simpleContextItem{
+				id:      "log-query",
+				display: "🔎 Log Query",
+				enabled: true,
+			},
+		}
+
+	case core.ModeLogQuery:
+		// From the log query, can only go to the log query (esc returns to the logs)
+		return []list.Item{
+			simpleContextItem{
+				id:      "log-query",
+				display: "🔎 Log Query",
+				enabled: true,
+			},
+		}
+
 	default:
 		// Fallback - show top-level contexts
 		return []list.Item{
@@ -513,7 +772,7 @@ func (m model) createContextItems() []list.Item {
 			simpleContextItem{
 				id:      "jobs",
 				display: "⚡ Container App Jobs",
-				enabled: false,
+				enabled: true,
 			},
 		}
 	}
@@ -548,33 +807,135 @@ func (m model) handleContextListKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
 		switch selectedItem.id {
 		case "apps":
 			// Navigate to container apps
-			if m.core.GetCurrentMode() == core.ModeResourceGroups {
+			switch m.core.GetCurrentMode() {
+			case core.ModeResourceGroups:
 				// From resource groups to apps - need to select a resource group first
 				m.core.SetStatusLine("Please select a resource group first")
 				// Keep list open
 				return m, nil
-			} else {
+			case core.ModeJobs, core.ModeEnvironments:
+				// From jobs or environments mode - switch to apps (preserve resource group selection)
+				rg := models.ResourceGroup{Name: m.core.GetNavigationState().CurrentRG}
+				cmd = m.core.NavigateToApps(rg)
+				m.core.SetStatusLine("Container Apps")
+			default:
 				// From apps mode - stay in apps (preserve resource group selection)
 				m.core.SetStatusLine("Container Apps")
 			}
 
 		case "jobs":
-			// Context switching to jobs (not implemented yet)
-			m.core.SetStatusLine(selectedItem.display + " is not yet implemented")
-			// Keep list open
-			return m, nil
+			// Navigate to container app jobs
+			switch m.core.GetCurrentMode() {
+			case core.ModeResourceGroups:
+				// From resource groups to jobs - need to select a resource group first
+				m.core.SetStatusLine("Please select a resource group first")
+				// Keep list open
+				return m, nil
+			case core.ModeApps, core.ModeEnvironments:
+				// From apps or environments mode - switch to jobs (preserve resource group selection)
+				rg := models.ResourceGroup{Name: m.core.GetNavigationState().CurrentRG}
+				cmd = m.core.NavigateToJobs(rg)
+				m.core.SetStatusLine("Container App Jobs")
+			default:
+				// From jobs mode - stay in jobs (preserve resource group selection)
+				m.core.SetStatusLine("Container App Jobs")
+			}
+
+		case "environments":
+			// Navigate to managed environments
+			switch m.core.GetCurrentMode() {
+			case core.ModeResourceGroups:
+				// From resource groups to environments - need to select a resource group first
+				m.core.SetStatusLine("Please select a resource group first")
+				// Keep list open
+				return m, nil
+			case core.ModeApps, core.ModeJobs:
+				// From apps or jobs mode - switch to environments (preserve resource group selection)
+				rg := models.ResourceGroup{Name: m.core.GetNavigationState().CurrentRG}
+				cmd = m.core.NavigateToEnvironments(rg)
+				m.core.SetStatusLine("Environments")
+			default:
+				// From environments mode - stay in environments (preserve resource group selection)
+				m.core.SetStatusLine("Environments")
+			}
 
 		case "revisions":
 			// Stay in revisions mode (preserve resource group and app selection)
 			m.core.SetStatusLine("App Revisions")
 
+		case "replicas":
+			// Stay in replicas mode (preserve resource group, app, and revision selection)
+			m.core.SetStatusLine("Replicas")
+
 		case "containers":
 			// Stay in containers mode (preserve resource group, app, and revision selection)
 			m.core.SetStatusLine("Containers")
 
+		case "traffic":
+			// Stay in the traffic split editor (preserve resource group and app selection)
+			m.core.SetStatusLine("Traffic Split")
+
 		case "env-vars":
 			// Stay in env vars mode (preserve all selections)
 			m.core.SetStatusLine("Environment Variables")
+
+		case "job-executions":
+			// Stay in job executions mode (preserve resource group and job selection)
+			m.core.SetStatusLine("Job Executions")
+
+		case "app-details":
+			// Stay in app details mode (preserve resource group and app selection)
+			m.core.SetStatusLine("App Details")
+
+		case "secrets":
+			// Stay in secrets mode (preserve resource group and app selection)
+			m.core.SetStatusLine("Secrets")
+
+		case "scale":
+			// Stay in scale mode (preserve resource group and app selection)
+			m.core.SetStatusLine("Scale")
+
+		case "metrics":
+			// Stay in metrics mode (preserve resource group, app, and revision selection)
+			m.core.SetStatusLine("Metrics")
+
+		case "probe":
+			// Stay in probe mode (preserve resource group, app, and revision selection)
+			m.core.SetStatusLine("HTTP Probe")
+
+		case "logs":
+			// Stay in logs mode (preserve resource group, app, and revision selection)
+			m.core.SetStatusLine("Logs")
+
+		case "log-query":
+			// Query the history of the logs being viewed (preserve all selections)
+			if m.core.GetCurrentMode() != core.ModeLogQuery {
+				cmd = m.core.NavigateToLogQuery()
+			}
+
+		case "subscriptions":
+			// Switch subscriptions, resource groups are reloaded once one is selected
+			if m.core.GetCurrentMode() != core.ModeSubscriptions {
+				cmd = m.core.NavigateToSubscriptions()
+			}
+
+		case "operations":
+			// Open the operation log on top of the current view (preserve all selections)
+			if m.core.GetCurrentMode() != core.ModeOperations {
+				cmd = m.core.NavigateToOperations()
+			}
+
+		case "exec":
+			// Return to the exec sessions on top of the current view (preserve all selections)
+			if m.core.GetCurrentMode() != core.ModeExec {
+				cmd = m.core.NavigateToExec()
+			}
+
+		case "port-forwards":
+			// Return to the port forwards on top of the current view (preserve all selections)
+			if m.core.GetCurrentMode() != core.ModePortForwards {
+				cmd = m.core.NavigateToPortForwards()
+			}
 		}
 
 		m.core.SetShowContextList(false)
@@ -625,6 +986,33 @@ func (m model) viewContextList() string {
 	return layoutSystem.CreateContextLayout(listView, statusContext, helpContext)
 }
 
+// viewConfirmDialog renders the confirmation dialog
+func (m model) viewConfirmDialog() string {
+	content := m.confirm.Text + "\n\n[y] Yes  [n] No"
+	if m.confirm.Confirm != "" {
+		content = lipgloss.JoinVertical(
+			lipgloss.Left,
+			m.confirm.Text,
+			"",
+			fmt.Sprintf("This resource group is tagged as production. Type %s to confirm:", m.confirm.Confirm),
+			m.confirm.Input.View(),
+			"",
+			"[enter] Confirm  [esc] Cancel",
+		)
+	}
+
+	// Render the dialog as a modal using the layout system
+	layoutSystem := m.core.GetLayoutSystem()
+	statusContext := layouts.StatusContext{
+		Mode: m.core.GetCurrentMode(),
+	}
+	helpContext := layouts.HelpContext{
+		Mode: m.core.GetCurrentMode(),
+	}
+
+	return layoutSystem.CreateModalLayout(content, statusContext, helpContext)
+}
+
 // getContextKeyMap returns a key map based on the current page context
 func (m model) getContextKeyMap() help.KeyMap {
 	// Get the current page's help keys
@@ -671,10 +1059,38 @@ func (ckm *contextKeyMap) FullHelp() [][]key.Binding {
 
 // Helper methods
 
-// createCommandProvider creates the appropriate command provider based on mock mode
-func createCommandProvider(useMockMode bool) providers.CommandProvider {
-	if useMockMode {
-		return providers.NewMockCommandProvider()
+// createCommandProvider creates the command provider matching the data provider,
+// so that mock operations act on the same mock data that is displayed
+func createCommandProvider(dataProvider providers.DataProvider) providers.CommandProvider {
+	if mockProvider, ok := dataProvider.(*mock.Provider); ok {
+		return providers.NewMockCommandProvider(mockProvider)
 	}
 	return providers.NewAzureCommandProvider()
 }
+
+// createMetricsProvider creates the metrics provider matching the data provider,
+// so that mock metrics follow the mock apps and revisions
+func createMetricsProvider(dataProvider providers.DataProvider) providers.MetricsProvider {
+	if mockProvider, ok := dataProvider.(*mock.Provider); ok {
+		return mockProvider
+	}
+	return providers.NewAzureProvider()
+}
+
+// createLogQueryRunner creates the log query runner matching the data provider,
+// so that mock query results follow the mock apps
+func createLogQueryRunner(dataProvider providers.DataProvider) providers.LogQueryRunner {
+	if mockProvider, ok := dataProvider.(*mock.Provider); ok {
+		return mockProvider
+	}
+	return providers.NewAzureProvider()
+}
+
+// createProber creates the prober matching the data provider, so that mock
+// apps answer probes without network access
+func createProber(dataProvider providers.DataProvider) providers.Prober {
+	if _, ok := dataProvider.(*mock.Provider); ok {
+		return providers.NewMockProber()
+	}
+	return providers.NewHTTPProber(nil)
+}
@@ -0,0 +1,164 @@
+package appdetails
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// AppDetailsPage represents the app details page using the new page interface system.
+// It displays the full `az containerapp show` document of a single app as JSON or YAML.
+type AppDetailsPage struct {
+	*pages.DocumentPage
+
+	// Navigation context
+	appName string
+	appID   string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys AppDetailsKeyMap
+
+	// Action functions
+	refreshFunc func() tea.Cmd
+}
+
+// AppDetailsKeyMap defines the key bindings for the app details page that are
+// not provided by the document page
+type AppDetailsKeyMap struct {
+	Refresh key.Binding
+	Help    key.Binding
+}
+
+// NewAppDetailsPage creates a new app details page
+func NewAppDetailsPage(layoutSystem *layouts.LayoutSystem) *AppDetailsPage {
+	return &AppDetailsPage{
+		DocumentPage: pages.NewDocumentPage("Search app details..."),
+		layoutSystem: layoutSystem,
+		keys:         defaultAppDetailsKeyMap(),
+	}
+}
+
+// defaultAppDetailsKeyMap returns the default key bindings for app details
+func defaultAppDetailsKeyMap() AppDetailsKeyMap {
+	return AppDetailsKeyMap{
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetAppContext sets the app context for the app details page
+func (p *AppDetailsPage) SetAppContext(appName, appID string) {
+	p.appName = appName
+	p.appID = appID
+}
+
+// GetAppID returns the ID of the app whose details are displayed
+func (p *AppDetailsPage) GetAppID() string {
+	return p.appID
+}
+
+// SetRefreshFunc sets the function to call for reloading the app details
+func (p *AppDetailsPage) SetRefreshFunc(fn func() tea.Cmd) {
+	p.refreshFunc = fn
+}
+
+// SetBackToAppsFunc sets the function to call when going back to apps
+func (p *AppDetailsPage) SetBackToAppsFunc(fn func() tea.Cmd) {
+	p.SetBackFunc(fn)
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the app details page
+func (p *AppDetailsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if !p.IsSearching() && key.Matches(msg, p.keys.Refresh) {
+		if p.refreshFunc != nil {
+			return p.refreshFunc(), true
+		}
+		return nil, true
+	}
+
+	return p.DocumentPage.HandleKeyMsg(msg)
+}
+
+// GetHelpKeys returns the help keys for the app details page
+func (p *AppDetailsPage) GetHelpKeys() []key.Binding {
+	return append(p.DocumentPage.GetHelpKeys(), p.keys.Refresh, p.keys.Help)
+}
+
+// View rendering methods
+
+// View renders the app details page
+func (p *AppDetailsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeAppDetails,
+	})
+}
+
+// ViewWithHelpContext renders the app details page with help context
+func (p *AppDetailsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeAppDetails
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading app details...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeAppDetails,
+				ContextInfo: map[string]string{"app": p.appName},
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeAppDetails,
+				Error:       err,
+				ContextInfo: map[string]string{"app": p.appName},
+			},
+			helpContext,
+		)
+	}
+
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeAppDetails,
+		ContextInfo:   map[string]string{"app": p.appName},
+		FilterActive:  p.IsSearching(),
+		StatusMessage: p.GetStatusMessage(),
+	}
+	if viewer := p.GetViewer(); viewer != nil {
+		statusContext.Counters = map[string]int{"line": viewer.LineCount()}
+	}
+
+	// Size the document to the space left by the status and help bars
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: statusContext,
+		HelpContext:   helpContext,
+	})
+
+	return p.layoutSystem.CreateTableLayout(
+		p.RenderDocument(contentWidth, contentHeight),
+		statusContext,
+		helpContext,
+	)
+}
@@ -0,0 +1,130 @@
+package appdetails
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+const testDetails = `{
+  "name": "web-frontend",
+  "properties": {
+    "template": {
+      "containers": [
+        {"name": "web", "image": "nginx:latest"}
+      ]
+    }
+  }
+}`
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// Test that the content is parsed and rendered
+func TestAppDetailsPageContent(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewAppDetailsPage(layoutSystem)
+	page.SetAppContext("web-frontend", "rg/web-frontend")
+
+	if err := page.SetContent("not json"); err == nil {
+		t.Error("Expected error for invalid JSON")
+	}
+	if page.HasContent() {
+		t.Error("Page should have no content after a parse error")
+	}
+
+	if err := page.SetContent(testDetails); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+
+	view := page.View()
+	if !strings.Contains(view, "nginx:latest") {
+		t.Error("View should contain the document")
+	}
+
+	// Switching to YAML is kept across reloads
+	page.HandleKeyMsg(runeKey("t"))
+	if err := page.SetContent(testDetails); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+	if lines := page.GetViewer().Lines(); lines[0] != "name: web-frontend" {
+		t.Errorf("Expected YAML rendering after reload, got %q", lines[0])
+	}
+}
+
+// Test searching and copying the JSONPath under the cursor
+func TestAppDetailsPageSearchAndCopy(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewAppDetailsPage(layoutSystem)
+	if err := page.SetContent(testDetails); err != nil {
+		t.Fatalf("SetContent failed: %v", err)
+	}
+
+	var copied string
+	page.SetCopyFunc(func(text string) error {
+		copied = text
+		return nil
+	})
+
+	// Type a search query
+	page.HandleKeyMsg(runeKey("/"))
+	if !page.IsSearching() {
+		t.Fatal("Expected search input to be focused after '/'")
+	}
+	for _, r := range "image" {
+		page.HandleKeyMsg(runeKey(string(r)))
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if page.IsSearching() {
+		t.Error("Search input should be closed after enter")
+	}
+
+	page.HandleKeyMsg(runeKey("c"))
+	if copied != "$.properties.template.containers[0].image" {
+		t.Errorf("Expected JSONPath of the match to be copied, got %q", copied)
+	}
+	if !strings.Contains(page.GetStatusMessage(), copied) {
+		t.Errorf("Expected status message to mention the path, got %q", page.GetStatusMessage())
+	}
+
+	// n wraps to the same single match
+	page.HandleKeyMsg(runeKey("n"))
+	page.HandleKeyMsg(runeKey("c"))
+	if copied != "$.properties.template.containers[0].image" {
+		t.Errorf("Expected next match to wrap, got %q", copied)
+	}
+
+	// Copy failures are reported instead of silently ignored
+	page.SetCopyFunc(func(string) error { return errors.New("no clipboard") })
+	page.HandleKeyMsg(runeKey("c"))
+	if !strings.Contains(page.GetStatusMessage(), "no clipboard") {
+		t.Errorf("Expected copy error in status message, got %q", page.GetStatusMessage())
+	}
+}
+
+// Test refresh and back navigation
+func TestAppDetailsPageNavigation(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewAppDetailsPage(layoutSystem)
+
+	refreshed, back := false, false
+	page.SetRefreshFunc(func() tea.Cmd {
+		refreshed = true
+		return nil
+	})
+	page.SetBackToAppsFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+
+	if _, handled := page.HandleKeyMsg(runeKey("r")); !handled || !refreshed {
+		t.Error("Refresh key 'r' should call the refresh function")
+	}
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
@@ -21,6 +21,13 @@ type AppsPage struct {
 
 	// Navigation context
 	resourceGroupName string
+	environmentName   string
+
+	// Inline metrics of the apps, by app ID
+	metrics map[string]models.MetricSet
+
+	// Apps marked for tailing their logs together, by app ID
+	marked map[string]bool
 
 	// Layout system
 	layoutSystem *layouts.LayoutSystem
@@ -31,6 +38,13 @@ type AppsPage struct {
 	// Action functions
 	showLogsFunc    func(models.ContainerApp) tea.Cmd
 	execIntoAppFunc func(models.ContainerApp) tea.Cmd
+	portForwardFunc func(models.ContainerApp) tea.Cmd
+	probeFunc       func(models.ContainerApp) tea.Cmd
+	showSecretsFunc func(models.ContainerApp) tea.Cmd
+	showScaleFunc   func(models.ContainerApp) tea.Cmd
+	showDetailsFunc func(models.ContainerApp) tea.Cmd
+	showMetricsFunc func(models.ContainerApp) tea.Cmd
+	tailLogsFunc    func([]models.ContainerApp) tea.Cmd
 
 	// Navigation functions
 	navigateToRevisionsFunc  func(models.ContainerApp) tea.Cmd
@@ -42,6 +56,14 @@ type AppsKeyMap struct {
 	Enter       key.Binding
 	Logs        key.Binding
 	Exec        key.Binding
+	PortForward key.Binding
+	Probe       key.Binding
+	Secrets     key.Binding
+	Scale       key.Binding
+	Details     key.Binding
+	Metrics     key.Binding
+	Mark        key.Binding
+	TailMarked  key.Binding
 	Refresh     key.Binding
 	Filter      key.Binding
 	ScrollLeft  key.Binding
@@ -61,6 +83,8 @@ func NewAppsPage(layoutSystem *layouts.LayoutSystem) *AppsPage {
 		ActionablePage: basePage,
 		layoutSystem:   layoutSystem,
 		keys:           defaultAppsKeyMap(),
+		metrics:        make(map[string]models.MetricSet),
+		marked:         make(map[string]bool),
 	}
 
 	// Set the table creation function
@@ -90,6 +114,38 @@ func defaultAppsKeyMap() AppsKeyMap {
 			key.WithKeys("s", "e"),
 			key.WithHelp("s/e", "exec"),
 		),
+		PortForward: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "port-forward"),
+		),
+		Probe: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "http probe"),
+		),
+		Secrets: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "secrets"),
+		),
+		Scale: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "scale"),
+		),
+		Details: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "details"),
+		),
+		Metrics: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "metrics"),
+		),
+		Mark: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "mark"),
+		),
+		TailMarked: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "tail marked"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -123,11 +179,32 @@ func defaultAppsKeyMap() AppsKeyMap {
 
 // Configuration methods
 
-// SetResourceGroupContext sets the resource group context for the apps page
+// SetResourceGroupContext sets the resource group context for the apps page,
+// unmarking the apps of another resource group
 func (p *AppsPage) SetResourceGroupContext(resourceGroupName string) {
+	if resourceGroupName != p.resourceGroupName {
+		p.marked = make(map[string]bool)
+	}
 	p.resourceGroupName = resourceGroupName
 }
 
+// SetEnvironmentContext sets the managed environment the apps are filtered by, empty for all apps of the resource group
+func (p *AppsPage) SetEnvironmentContext(environmentName string) {
+	p.environmentName = environmentName
+}
+
+// contextInfo returns the navigation context shown in the status bar
+func (p *AppsPage) contextInfo() map[string]string {
+	info := map[string]string{"resource_group": p.resourceGroupName}
+	if p.environmentName != "" {
+		info["environment"] = p.environmentName
+	}
+	if marked := len(p.GetMarkedApps()); marked > 0 {
+		info["marked"] = fmt.Sprintf("%d apps", marked)
+	}
+	return info
+}
+
 // SetShowLogsFunc sets the function to call for showing logs
 func (p *AppsPage) SetShowLogsFunc(fn func(models.ContainerApp) tea.Cmd) {
 	p.showLogsFunc = fn
@@ -138,6 +215,103 @@ func (p *AppsPage) SetExecIntoAppFunc(fn func(models.ContainerApp) tea.Cmd) {
 	p.execIntoAppFunc = fn
 }
 
+// SetPortForwardFunc sets the function to call for forwarding a local port to an app
+func (p *AppsPage) SetPortForwardFunc(fn func(models.ContainerApp) tea.Cmd) {
+	p.portForwardFunc = fn
+}
+
+// SetProbeFunc sets the function to call for sending HTTP requests to an app
+func (p *AppsPage) SetProbeFunc(fn func(models.ContainerApp) tea.Cmd) {
+	p.probeFunc = fn
+}
+
+// SetShowSecretsFunc sets the function to call for showing the secrets of an app
+func (p *AppsPage) SetShowSecretsFunc(fn func(models.ContainerApp) tea.Cmd) {
+	p.showSecretsFunc = fn
+}
+
+// SetShowScaleFunc sets the function to call for showing the scale rules of an app
+func (p *AppsPage) SetShowScaleFunc(fn func(models.ContainerApp) tea.Cmd) {
+	p.showScaleFunc = fn
+}
+
+// SetShowDetailsFunc sets the function to call for showing app details
+func (p *AppsPage) SetShowDetailsFunc(fn func(models.ContainerApp) tea.Cmd) {
+	p.showDetailsFunc = fn
+}
+
+// SetShowMetricsFunc sets the function to call for showing app metrics
+func (p *AppsPage) SetShowMetricsFunc(fn func(models.ContainerApp) tea.Cmd) {
+	p.showMetricsFunc = fn
+}
+
+// SetTailLogsFunc sets the function to call for tailing the logs of several apps together
+func (p *AppsPage) SetTailLogsFunc(fn func([]models.ContainerApp) tea.Cmd) {
+	p.tailLogsFunc = fn
+}
+
+// SetMetrics sets the metrics shown inline for an app, keeping the highlighted row
+func (p *AppsPage) SetMetrics(appID string, metrics models.MetricSet) {
+	p.metrics[appID] = metrics
+	p.refreshTable(0)
+}
+
+// GetMarkedApps returns the apps marked for tailing their logs together, in
+// the order they are listed
+func (p *AppsPage) GetMarkedApps() []models.ContainerApp {
+	var marked []models.ContainerApp
+	for _, app := range p.GetData() {
+		if p.marked[appID(app)] {
+			marked = append(marked, app)
+		}
+	}
+	return marked
+}
+
+// toggleMarked marks or unmarks the selected app and moves to the next one
+func (p *AppsPage) toggleMarked() {
+	app, ok := p.GetSelectedItem()
+	if !ok {
+		return
+	}
+	id := appID(app)
+	if p.marked[id] {
+		delete(p.marked, id)
+	} else {
+		p.marked[id] = true
+	}
+	p.refreshTable(1)
+}
+
+// tailMarked tails the logs of the marked apps, or of the selected app if none is marked
+func (p *AppsPage) tailMarked() tea.Cmd {
+	apps := p.GetMarkedApps()
+	if len(apps) == 0 {
+		app, ok := p.GetSelectedItem()
+		if !ok {
+			return nil
+		}
+		apps = []models.ContainerApp{app}
+	}
+	if p.tailLogsFunc != nil {
+		return p.tailLogsFunc(apps)
+	}
+	return nil
+}
+
+// refreshTable rebuilds the table, moving the highlighted row by offset rows
+func (p *AppsPage) refreshTable(offset int) {
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.UpdateTableWithData()
+	p.SetTable(p.GetTable().WithHighlightedRow(row + offset))
+}
+
+// appID identifies an app across resource groups
+func appID(app models.ContainerApp) string {
+	return app.ResourceGroup + "/" + app.Name
+}
+
 // SetNavigateToRevisionsFunc sets the function to call when navigating to revisions
 func (p *AppsPage) SetNavigateToRevisionsFunc(fn func(models.ContainerApp) tea.Cmd) {
 	p.navigateToRevisionsFunc = fn
@@ -168,6 +342,54 @@ func (p *AppsPage) setupActions() {
 		}
 		return nil
 	})
+
+	// Add port forward action
+	p.AddAction("port-forward", p.keys.PortForward, func(app models.ContainerApp) tea.Cmd {
+		if p.portForwardFunc != nil {
+			return p.portForwardFunc(app)
+		}
+		return nil
+	})
+
+	// Add HTTP probe action
+	p.AddAction("probe", p.keys.Probe, func(app models.ContainerApp) tea.Cmd {
+		if p.probeFunc != nil {
+			return p.probeFunc(app)
+		}
+		return nil
+	})
+
+	// Add secrets action
+	p.AddAction("secrets", p.keys.Secrets, func(app models.ContainerApp) tea.Cmd {
+		if p.showSecretsFunc != nil {
+			return p.showSecretsFunc(app)
+		}
+		return nil
+	})
+
+	// Add scale action
+	p.AddAction("scale", p.keys.Scale, func(app models.ContainerApp) tea.Cmd {
+		if p.showScaleFunc != nil {
+			return p.showScaleFunc(app)
+		}
+		return nil
+	})
+
+	// Add details action
+	p.AddAction("details", p.keys.Details, func(app models.ContainerApp) tea.Cmd {
+		if p.showDetailsFunc != nil {
+			return p.showDetailsFunc(app)
+		}
+		return nil
+	})
+
+	// Add metrics action
+	p.AddAction("metrics", p.keys.Metrics, func(app models.ContainerApp) tea.Cmd {
+		if p.showMetricsFunc != nil {
+			return p.showMetricsFunc(app)
+		}
+		return nil
+	})
 }
 
 // Table creation methods
@@ -179,6 +401,9 @@ func (p *AppsPage) createAppsTable(data []models.ContainerApp) table.Model {
 		AddColumn("name", "Name", 15, true).                 // Dynamic width, min 15
 		AddColumn("location", "Location", 15, true).         // Fixed width
 		AddColumn("status", "Status", 12, true).             // Fixed width
+		AddColumn("cpu", "CPU 1h", 12, false).               // Fixed width (sparkline)
+		AddColumn("memory", "Memory 1h", 12, false).         // Fixed width (sparkline)
+		AddColumn("requests", "Requests 1h", 12, false).     // Fixed width (sparkline)
 		AddColumn("replicas", "Replicas", 10, false).        // Fixed width
 		AddColumn("resources", "Resources", 12, false).      // Fixed width
 		AddColumn("ingress", "Ingress", 18, false).          // Fixed width
@@ -189,7 +414,7 @@ func (p *AppsPage) createAppsTable(data []models.ContainerApp) table.Model {
 
 	// Update dynamic column widths based on actual content
 	for _, app := range data {
-		builder.UpdateWidthFromString("name", app.Name)
+		builder.UpdateWidthFromString("name", p.appName(app))
 	}
 
 	// Build columns with calculated widths
@@ -249,10 +474,15 @@ func (p *AppsPage) createAppsTable(data []models.ContainerApp) table.Model {
 				workload = "Consumption"
 			}
 
+			metrics := p.metrics[appID(app)]
+
 			rows[i] = table.NewRow(table.RowData{
-				"name":      app.Name,
+				"name":      p.appName(app),
 				"location":  app.Location,
 				"status":    table.NewStyledCell(status, lipgloss.NewStyle().Foreground(pages.GetStatusColor(status))),
+				"cpu":       pages.MetricSparkline(metrics, models.MetricCPU),
+				"memory":    pages.MetricSparkline(metrics, models.MetricMemory),
+				"requests":  pages.MetricSparkline(metrics, models.MetricRequests),
 				"replicas":  replicas,
 				"resources": resources,
 				"ingress":   ingress,
@@ -261,6 +491,7 @@ func (p *AppsPage) createAppsTable(data []models.ContainerApp) table.Model {
 				"revision":  app.LatestRevision,
 				"fqdn":      fqdn,
 			})
+			rows[i].Data[pages.RowIndexKey] = i
 		}
 	}
 
@@ -280,6 +511,14 @@ func (p *AppsPage) createAppsTable(data []models.ContainerApp) table.Model {
 	return tablebuilder.CreateUnifiedTable(config)
 }
 
+// appName returns the name shown for an app, flagged when it is marked
+func (p *AppsPage) appName(app models.ContainerApp) string {
+	if p.marked[appID(app)] {
+		return "● " + app.Name
+	}
+	return app.Name
+}
+
 // Navigation methods
 
 // handleNavigation handles navigation to the selected app's revisions
@@ -311,6 +550,14 @@ func (p *AppsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 		return nil, false
 	}
 
+	switch {
+	case key.Matches(msg, p.keys.Mark):
+		p.toggleMarked()
+		return nil, true
+	case key.Matches(msg, p.keys.TailMarked):
+		return p.tailMarked(), true
+	}
+
 	return nil, false
 }
 
@@ -318,6 +565,8 @@ func (p *AppsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 func (p *AppsPage) GetHelpKeys() []key.Binding {
 	baseKeys := []key.Binding{
 		p.keys.Enter,
+		p.keys.Mark,
+		p.keys.TailMarked,
 		p.keys.Refresh,
 		p.keys.Filter,
 		p.keys.ScrollLeft,
@@ -353,7 +602,7 @@ func (p *AppsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
 			"Loading container apps...",
 			layouts.StatusContext{
 				Mode:        layouts.ModeApps,
-				ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+				ContextInfo: p.contextInfo(),
 			},
 			helpContext,
 		)
@@ -367,7 +616,7 @@ func (p *AppsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
 			layouts.StatusContext{
 				Mode:        layouts.ModeApps,
 				Error:       err,
-				ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+				ContextInfo: p.contextInfo(),
 			},
 			helpContext,
 		)
@@ -379,7 +628,7 @@ func (p *AppsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
 		tableView,
 		layouts.StatusContext{
 			Mode:        layouts.ModeApps,
-			ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+			ContextInfo: p.contextInfo(),
 			Counters:    map[string]int{"count": len(p.GetData())},
 		},
 		helpContext,
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/IAL32/az-tui/internal/models"
 	"github.com/IAL32/az-tui/internal/ui/layouts"
@@ -129,6 +130,86 @@ func TestAppsPageActions(t *testing.T) {
 	_ = execCalled
 }
 
+// Test inline metric sparklines and the metrics action
+func TestAppsPageMetrics(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(200, 24)
+	page := NewAppsPage(layoutSystem)
+	page.SetData(createTestContainerApps())
+
+	// Highlight the second app, it must stay highlighted when metrics arrive
+	table := page.GetTable()
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	page.SetTable(table)
+
+	start := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	series := models.MetricSeries{Name: models.MetricCPU}
+	for i := range 4 {
+		series.Points = append(series.Points, models.MetricPoint{Time: start.Add(time.Duration(i) * 5 * time.Minute), Value: float64(i)})
+	}
+	page.SetMetrics("rg-production-eastus/api-service-prod", models.MetricSet{series})
+
+	view := page.View()
+	if !strings.Contains(view, "▁▃▅█") {
+		t.Error("View should contain the CPU sparkline of the app")
+	}
+
+	var selected string
+	page.SetShowMetricsFunc(func(app models.ContainerApp) tea.Cmd {
+		selected = app.Name
+		return nil
+	})
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")}); !handled {
+		t.Error("Metrics key 'm' should be handled")
+	}
+	if selected != "api-service-prod" {
+		t.Errorf("Expected metrics of api-service-prod, got %q", selected)
+	}
+}
+
+// Test marking apps and tailing their logs together
+func TestAppsPageTailMarked(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(200, 24)
+	page := NewAppsPage(layoutSystem)
+	page.SetResourceGroupContext("rg-production-eastus")
+	page.SetData(createTestContainerApps())
+
+	var tailed []string
+	page.SetTailLogsFunc(func(apps []models.ContainerApp) tea.Cmd {
+		tailed = nil
+		for _, app := range apps {
+			tailed = append(tailed, app.Name)
+		}
+		return nil
+	})
+
+	// Without marks the selected app is tailed
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if strings.Join(tailed, ",") != "web-app-prod" {
+		t.Errorf("Expected the selected app to be tailed, got %v", tailed)
+	}
+
+	// Marking moves to the next app, so both apps are marked in turn
+	for range 2 {
+		if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune(" ")}); !handled {
+			t.Error("Mark key 'space' should be handled")
+		}
+	}
+	view := page.View()
+	if !strings.Contains(view, "● web-app-prod") || !strings.Contains(view, "marked: 2 apps") {
+		t.Error("View should flag the marked apps")
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("L")})
+	if strings.Join(tailed, ",") != "web-app-prod,api-service-prod" {
+		t.Errorf("Expected the marked apps to be tailed in order, got %v", tailed)
+	}
+
+	// Marks are dropped with the resource group
+	page.SetResourceGroupContext("rg-staging")
+	if len(page.GetMarkedApps()) != 0 {
+		t.Error("Changing the resource group should unmark the apps")
+	}
+}
+
 // Test navigation handling
 func TestAppsPageNavigation(t *testing.T) {
 	layoutSystem := layouts.NewLayoutSystem(80, 24)
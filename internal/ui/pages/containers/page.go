@@ -2,9 +2,11 @@ package containers
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
@@ -15,8 +17,14 @@ import (
 	"github.com/IAL32/az-tui/internal/ui/pages"
 )
 
+// headerHeight is the number of lines above the table while the image form or
+// a rollout is shown: the form or rollout, a line of detail and a blank line
+const headerHeight = 3
+
 // ContainersPage represents the containers page using the new page interface system.
-// It displays containers in an actionable table format with logs, exec, and envvars actions.
+// It displays containers in an actionable table format with logs, exec, envvars
+// and image update actions. After an image update, the rollout of the revision
+// it created is shown above the table until it is healthy or failed.
 type ContainersPage struct {
 	*pages.ActionablePage[models.Container]
 
@@ -24,6 +32,17 @@ type ContainersPage struct {
 	appName      string
 	appID        string
 	revisionName string
+	replicaName  string // empty when the containers are not scoped to a replica
+
+	// Image form, completing the tags of the images seen by repository
+	imageInput    textinput.Model
+	editing       bool
+	editContainer string
+	seenImages    map[string][]string
+	statusMessage string
+
+	// Rollout of the revision created by the last image update
+	rollout Rollout
 
 	// Layout system
 	layoutSystem *layouts.LayoutSystem
@@ -34,17 +53,35 @@ type ContainersPage struct {
 	// Action functions
 	showLogsFunc          func(models.Container) tea.Cmd
 	execIntoContainerFunc func(models.Container) tea.Cmd
+	updateImageFunc       func(container, image string) tea.Cmd
+	shiftTrafficFunc      func(revisionName string) tea.Cmd
 
 	// Navigation functions
 	navigateToEnvVarsFunc func(models.Container) tea.Cmd
 	backToRevisionsFunc   func() tea.Cmd
 }
 
+// Rollout is the revision created by an image update, as last watched
+type Rollout struct {
+	AppID     string
+	Container string
+	Image     string
+	Revision  models.Revision
+	Error     error
+}
+
+// Active reports whether a rollout is being shown
+func (r Rollout) Active() bool {
+	return r.Revision.Name != ""
+}
+
 // ContainersKeyMap defines the key bindings for the containers page
 type ContainersKeyMap struct {
 	Logs        key.Binding
 	Exec        key.Binding
 	EnvVars     key.Binding
+	Image       key.Binding
+	Traffic     key.Binding
 	Refresh     key.Binding
 	Filter      key.Binding
 	ScrollLeft  key.Binding
@@ -64,7 +101,12 @@ func NewContainersPage(layoutSystem *layouts.LayoutSystem) *ContainersPage {
 		ActionablePage: basePage,
 		layoutSystem:   layoutSystem,
 		keys:           defaultContainersKeyMap(),
+		seenImages:     make(map[string][]string),
 	}
+	page.imageInput = textinput.New()
+	page.imageInput.Prompt = ""
+	page.imageInput.Placeholder = "registry/repository:tag"
+	page.imageInput.ShowSuggestions = true
 
 	// Set the table creation function
 	page.SetCreateTableFunc(page.createContainersTable)
@@ -93,6 +135,14 @@ func defaultContainersKeyMap() ContainersKeyMap {
 			key.WithKeys("v"),
 			key.WithHelp("v", "env vars"),
 		),
+		Image: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "update image"),
+		),
+		Traffic: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "shift traffic to rollout"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -126,11 +176,28 @@ func defaultContainersKeyMap() ContainersKeyMap {
 
 // Configuration methods
 
-// SetRevisionContext sets the revision context for the containers page
+// SetRevisionContext sets the revision context for the containers page. The
+// rollout of an image update is kept while the containers of its app are shown.
 func (p *ContainersPage) SetRevisionContext(appName, appID, revisionName string) {
+	if appID != p.rollout.AppID {
+		p.rollout = Rollout{}
+	}
 	p.appName = appName
 	p.appID = appID
 	p.revisionName = revisionName
+	p.replicaName = ""
+	p.statusMessage = ""
+	p.blur()
+}
+
+// SetReplicaContext scopes the containers page to a replica of the revision
+func (p *ContainersPage) SetReplicaContext(replicaName string) {
+	p.replicaName = replicaName
+}
+
+// GetReplicaName returns the replica the containers are scoped to, if any
+func (p *ContainersPage) GetReplicaName() string {
+	return p.replicaName
 }
 
 // SetShowLogsFunc sets the function to call for showing logs
@@ -148,6 +215,16 @@ func (p *ContainersPage) SetNavigateToEnvVarsFunc(fn func(models.Container) tea.
 	p.navigateToEnvVarsFunc = fn
 }
 
+// SetUpdateImageFunc sets the function to call for updating the image of a container
+func (p *ContainersPage) SetUpdateImageFunc(fn func(container, image string) tea.Cmd) {
+	p.updateImageFunc = fn
+}
+
+// SetShiftTrafficFunc sets the function to call for shifting all traffic to a revision
+func (p *ContainersPage) SetShiftTrafficFunc(fn func(revisionName string) tea.Cmd) {
+	p.shiftTrafficFunc = fn
+}
+
 // SetBackToRevisionsFunc sets the function to call when going back to revisions
 func (p *ContainersPage) SetBackToRevisionsFunc(fn func() tea.Cmd) {
 	p.backToRevisionsFunc = fn
@@ -181,6 +258,184 @@ func (p *ContainersPage) setupActions() {
 		}
 		return nil
 	})
+
+	// Add image update action
+	p.AddAction("image", p.keys.Image, p.startEditingImage)
+}
+
+// Data methods
+
+// SetContainers sets the containers shown, remembering their images to
+// complete tags with
+func (p *ContainersPage) SetContainers(containers []models.Container) {
+	for _, container := range containers {
+		p.recordImage(container.Image)
+	}
+	p.SetData(containers)
+}
+
+// recordImage remembers an image as seen for its repository
+func (p *ContainersPage) recordImage(image string) {
+	repository, tag := models.SplitImage(image)
+	if tag == "" || slices.Contains(p.seenImages[repository], image) {
+		return
+	}
+	p.seenImages[repository] = append(p.seenImages[repository], image)
+	slices.Sort(p.seenImages[repository])
+}
+
+// SeenImages returns the images seen for the repository of an image
+func (p *ContainersPage) SeenImages(image string) []string {
+	repository, _ := models.SplitImage(image)
+	return slices.Clone(p.seenImages[repository])
+}
+
+// Image form methods
+
+// IsEditing returns true while the image of a container is being edited
+func (p *ContainersPage) IsEditing() bool {
+	return p.editing
+}
+
+// startEditingImage opens the image form for a container, pre-filled with its
+// image and completing the tags seen for its repository
+func (p *ContainersPage) startEditingImage(container models.Container) tea.Cmd {
+	p.editContainer = container.Name
+	p.statusMessage = ""
+	p.imageInput.SetSuggestions(p.SeenImages(container.Image))
+	p.imageInput.SetValue(container.Image)
+	p.imageInput.CursorEnd()
+	p.editing = true
+	p.resize()
+	return p.imageInput.Focus()
+}
+
+// submitImage asks for confirmation of the image update
+func (p *ContainersPage) submitImage() tea.Cmd {
+	image := strings.TrimSpace(p.imageInput.Value())
+	if image == "" {
+		p.statusMessage = "An image is required"
+		return nil
+	}
+	container, ok := p.FindItemByPredicate(func(c models.Container) bool {
+		return c.Name == p.editContainer
+	})
+	if ok && container.Image == image {
+		p.statusMessage = "The container already runs " + image
+		return nil
+	}
+	p.blur()
+	p.statusMessage = ""
+
+	name, appID := p.editContainer, p.appID
+	resourceGroup, _, _ := strings.Cut(p.appID, "/")
+	request := pages.ConfirmRequestMsg{
+		Text: fmt.Sprintf("Update the image of %s in %s? This creates a new revision.\n\n  ~ %s → %s",
+			name, p.appName, container.Image, image),
+		Resource:      p.appName,
+		ResourceGroup: resourceGroup,
+		OnConfirm: func() tea.Cmd {
+			p.recordImage(image)
+			// The rollout is shown once the update reports its revision
+			p.rollout = Rollout{AppID: appID, Container: name, Image: image}
+			if p.updateImageFunc != nil {
+				return p.updateImageFunc(name, image)
+			}
+			return nil
+		},
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
+// blur closes the image form
+func (p *ContainersPage) blur() {
+	if p.editing {
+		p.editing = false
+		p.resize()
+	}
+	p.imageInput.Blur()
+}
+
+// Rollout methods
+
+// StartRollout starts showing the rollout of the revision an image update
+// created, with the image of the update confirmed last
+func (p *ContainersPage) StartRollout(appID, container, revisionName string) {
+	image := ""
+	if p.rollout.AppID == appID && p.rollout.Container == container {
+		image = p.rollout.Image
+	}
+	p.rollout = Rollout{
+		AppID:     appID,
+		Container: container,
+		Image:     image,
+		Revision:  models.Revision{Name: revisionName},
+	}
+	p.resize()
+}
+
+// GetRollout returns the rollout shown
+func (p *ContainersPage) GetRollout() Rollout {
+	return p.rollout
+}
+
+// SetRolloutRevision updates the state of the revision rolling out
+func (p *ContainersPage) SetRolloutRevision(revision models.Revision) {
+	if revision.Name != p.rollout.Revision.Name {
+		return
+	}
+	p.rollout.Revision = revision
+	p.statusMessage = ""
+}
+
+// SetRolloutError stops the rollout from being watched, reporting why
+func (p *ContainersPage) SetRolloutError(err error) {
+	p.rollout.Error = err
+}
+
+// shiftTraffic asks for confirmation of shifting all traffic to the revision
+// rolled out, once it is healthy
+func (p *ContainersPage) shiftTraffic() tea.Cmd {
+	if !p.rollout.Active() || p.rollout.AppID != p.appID {
+		p.statusMessage = "No rollout to shift traffic to"
+		return nil
+	}
+	revision := p.rollout.Revision
+	if !revision.IsHealthy() {
+		p.statusMessage = fmt.Sprintf("Revision %s is not healthy yet", revision.Name)
+		return nil
+	}
+
+	resourceGroup, _, _ := strings.Cut(p.appID, "/")
+	request := pages.ConfirmRequestMsg{
+		Text:          fmt.Sprintf("Shift all traffic of %s to revision %s?", p.appName, revision.Name),
+		Resource:      p.appName,
+		ResourceGroup: resourceGroup,
+		OnConfirm: func() tea.Cmd {
+			if p.shiftTrafficFunc != nil {
+				return p.shiftTrafficFunc(revision.Name)
+			}
+			return nil
+		},
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
+// resize rebuilds the table when the header above it appears or disappears
+func (p *ContainersPage) resize() {
+	table := p.GetTable()
+	row := table.GetHighlightedRowIndex()
+	p.SetData(p.GetData())
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+}
+
+// showsHeader reports whether the image form or a rollout is shown above the table
+func (p *ContainersPage) showsHeader() bool {
+	return p.editing || (p.rollout.Active() && p.rollout.AppID == p.appID)
 }
 
 // Table creation methods
@@ -256,11 +511,15 @@ func (p *ContainersPage) createContainersTable(data []models.Container) table.Mo
 				"volumes":   volumes,
 				"status":    table.NewStyledCell("Running", lipgloss.NewStyle().Foreground(pages.GetStatusColor("Running"))),
 			})
+			rows[i].Data[pages.RowIndexKey] = i
 		}
 	}
 
-	// Get content dimensions
+	// Get content dimensions, leaving room for the image form or rollout
 	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+	if p.showsHeader() {
+		contentHeight = max(5, contentHeight-headerHeight)
+	}
 
 	// Create the table using the unified table builder with theme styling
 	config := tablebuilder.UnifiedTableConfig{
@@ -279,6 +538,10 @@ func (p *ContainersPage) createContainersTable(data []models.Container) table.Mo
 
 // HandleKeyMsg handles key messages for the containers page
 func (p *ContainersPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.editing {
+		return p.handleFormInput(msg)
+	}
+
 	// First, try base actionable page key handling
 	if cmd, handled := p.ActionablePage.HandleKeyMsg(msg); handled {
 		return cmd, handled
@@ -286,6 +549,8 @@ func (p *ContainersPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 
 	// Handle containers-specific keys
 	switch msg.String() {
+	case "T":
+		return p.shiftTraffic(), true
 	case "esc":
 		if p.backToRevisionsFunc != nil {
 			return p.backToRevisionsFunc(), true
@@ -299,9 +564,29 @@ func (p *ContainersPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 	return nil, false
 }
 
+// handleFormInput handles keys while the image form is open. Tab completes
+// the tag shown, ctrl+n and ctrl+p cycle through the tags seen.
+func (p *ContainersPage) handleFormInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		return p.submitImage(), true
+	case "esc":
+		p.blur()
+		p.statusMessage = ""
+		return nil, true
+	case "ctrl+c":
+		return tea.Quit, true
+	default:
+		var cmd tea.Cmd
+		p.imageInput, cmd = p.imageInput.Update(msg)
+		return cmd, true
+	}
+}
+
 // GetHelpKeys returns the help keys for the containers page
 func (p *ContainersPage) GetHelpKeys() []key.Binding {
 	baseKeys := []key.Binding{
+		p.keys.Traffic,
 		p.keys.Refresh,
 		p.keys.Filter,
 		p.keys.ScrollLeft,
@@ -311,7 +596,7 @@ func (p *ContainersPage) GetHelpKeys() []key.Binding {
 		p.keys.Quit,
 	}
 
-	// Add action keys (includes logs, exec, and envvars)
+	// Add action keys (includes logs, exec, envvars and image)
 	actionKeys := p.GetActionKeys()
 	return append(baseKeys, actionKeys...)
 }
@@ -336,11 +621,8 @@ func (p *ContainersPage) ViewWithHelpContext(helpContext layouts.HelpContext) st
 		return p.layoutSystem.CreateLoadingLayout(
 			"Loading containers...",
 			layouts.StatusContext{
-				Mode: layouts.ModeContainers,
-				ContextInfo: map[string]string{
-					"app":      p.appName,
-					"revision": p.revisionName,
-				},
+				Mode:        layouts.ModeContainers,
+				ContextInfo: p.contextInfo(),
 			},
 			helpContext,
 		)
@@ -352,31 +634,115 @@ func (p *ContainersPage) ViewWithHelpContext(helpContext layouts.HelpContext) st
 			err.Error(),
 			"Press 'r' to retry or 'esc' to go back",
 			layouts.StatusContext{
-				Mode:  layouts.ModeContainers,
-				Error: err,
-				ContextInfo: map[string]string{
-					"app":      p.appName,
-					"revision": p.revisionName,
-				},
+				Mode:        layouts.ModeContainers,
+				Error:       err,
+				ContextInfo: p.contextInfo(),
 			},
 			helpContext,
 		)
 	}
 
-	// Render the table view
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeContainers,
+		ContextInfo:   p.contextInfo(),
+		Counters:      map[string]int{"count": len(p.GetData())},
+		StatusMessage: p.currentStatusMessage(),
+	}
+
+	// Render the image form or the rollout above the table
 	tableView := p.GetTable().View()
-	return p.layoutSystem.CreateTableLayout(
-		tableView,
-		layouts.StatusContext{
-			Mode: layouts.ModeContainers,
-			ContextInfo: map[string]string{
-				"app":      p.appName,
-				"revision": p.revisionName,
-			},
-			Counters: map[string]int{"count": len(p.GetData())},
-		},
-		helpContext,
-	)
+	if p.showsHeader() {
+		contentWidth, _ := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+			StatusContext: statusContext,
+			HelpContext:   helpContext,
+		})
+		header := p.renderRollout(contentWidth)
+		if p.editing {
+			header = p.renderForm(contentWidth)
+		}
+		tableView = lipgloss.JoinVertical(lipgloss.Left, header, tableView)
+	}
+	return p.layoutSystem.CreateTableLayout(tableView, statusContext, helpContext)
+}
+
+// currentStatusMessage reports the form keys while editing and what to do
+// about a finished rollout otherwise
+func (p *ContainersPage) currentStatusMessage() string {
+	if p.statusMessage != "" {
+		return p.statusMessage
+	}
+	if p.editing {
+		return "Editing image of " + p.editContainer + ", tab: complete tag, ctrl+n/ctrl+p: next/previous tag, enter: update, esc: cancel"
+	}
+	if p.rollout.AppID == p.appID && p.rollout.Revision.IsHealthy() && p.rollout.Revision.Traffic < 100 {
+		return fmt.Sprintf("Revision %s is healthy - press T to shift all traffic to it", p.rollout.Revision.Name)
+	}
+	return ""
+}
+
+// renderForm renders the image input with the tags seen for its repository
+func (p *ContainersPage) renderForm(width int) string {
+	label := p.layoutSystem.GetStyle("accent").Render("Image ")
+	p.imageInput.Width = max(1, width-lipgloss.Width(label)-1)
+
+	tags := make([]string, 0, len(p.imageInput.AvailableSuggestions()))
+	for _, image := range p.imageInput.AvailableSuggestions() {
+		_, tag := models.SplitImage(image)
+		tags = append(tags, tag)
+	}
+	seen := "Seen tags  -"
+	if len(tags) > 0 {
+		seen = "Seen tags  " + strings.Join(tags, ", ")
+	}
+
+	lines := []string{label + p.imageInput.View(), seen, ""}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// renderRollout renders the state of the revision rolling out
+func (p *ContainersPage) renderRollout(width int) string {
+	accent := p.layoutSystem.GetStyle("accent")
+	revision := p.rollout.Revision
+	state := func(value string) string {
+		if value == "" {
+			value = "-"
+		}
+		return lipgloss.NewStyle().Foreground(pages.GetStatusColor(value)).Render(value)
+	}
+
+	image := p.rollout.Image
+	if image == "" {
+		image = "new image"
+	}
+	title := fmt.Sprintf("Rollout  %s → %s  revision %s",
+		p.rollout.Container, accent.Render(image), revision.Name)
+	detail := fmt.Sprintf("Provisioning %s  Health %s  Running %s  Replicas %d",
+		state(revision.ProvisioningState), state(revision.HealthState), state(revision.RunningState), revision.Replicas)
+	switch {
+	case p.rollout.Error != nil:
+		detail += "  " + state("error") + ": " + p.rollout.Error.Error()
+	case revision.ProvisioningState == models.RevisionFailed:
+		detail += "  rollout failed"
+	case revision.IsHealthy():
+		detail += fmt.Sprintf("  Traffic %d%%", revision.Traffic)
+	case revision.ProvisioningState == "":
+		detail = "Waiting for the revision..."
+	}
+
+	lines := []string{title, detail, ""}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
 }
 
 // Helper functions
+
+// contextInfo returns the status bar context of the containers page
+func (p *ContainersPage) contextInfo() map[string]string {
+	info := map[string]string{
+		"app":      p.appName,
+		"revision": p.revisionName,
+	}
+	if p.replicaName != "" {
+		info["replica"] = p.replicaName
+	}
+	return info
+}
@@ -0,0 +1,144 @@
+package containers
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// createTestPage creates a page showing the containers of a revision
+func createTestPage() *ContainersPage {
+	page := NewContainersPage(layouts.NewLayoutSystem(200, 24))
+	page.SetRevisionContext("web", "rg-test/web", "web--v1")
+	page.SetContainers([]models.Container{
+		{Name: "app", Image: "myregistry.azurecr.io/web:v1.0"},
+		{Name: "proxy", Image: "envoyproxy/envoy:v1.28"},
+	})
+	return page
+}
+
+func typeText(page *ContainersPage, text string) {
+	for _, r := range text {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func pressKey(page *ContainersPage, k string) tea.Cmd {
+	cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return cmd
+}
+
+// Test that tags seen in the containers listed are completed for the same repository
+func TestContainersPageImageCompletion(t *testing.T) {
+	page := createTestPage()
+	page.SetRevisionContext("web", "rg-test/web", "web--v2")
+	page.SetContainers([]models.Container{{Name: "app", Image: "myregistry.azurecr.io/web:v2.0"}})
+
+	seen := page.SeenImages("myregistry.azurecr.io/web:latest")
+	if len(seen) != 2 || seen[0] != "myregistry.azurecr.io/web:v1.0" || seen[1] != "myregistry.azurecr.io/web:v2.0" {
+		t.Fatalf("Expected the images seen for the repository, got %v", seen)
+	}
+
+	pressKey(page, "i")
+	if !page.IsEditing() || page.imageInput.Value() != "myregistry.azurecr.io/web:v2.0" {
+		t.Fatalf("Expected i to open the form with the image, got %q", page.imageInput.Value())
+	}
+
+	// Completing the tag after removing it
+	for range len("2.0") {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	typeText(page, "1")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyTab})
+	if page.imageInput.Value() != "myregistry.azurecr.io/web:v1.0" {
+		t.Errorf("Expected tab to complete the tag, got %q", page.imageInput.Value())
+	}
+	if !strings.Contains(page.View(), "Seen tags  v1.0, v2.0") {
+		t.Error("Expected the view to list the tags seen")
+	}
+
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	if page.IsEditing() {
+		t.Error("Expected esc to close the form")
+	}
+}
+
+// Test updating an image, then following its rollout until traffic can be shifted
+func TestContainersPageUpdateImageAndRollout(t *testing.T) {
+	page := createTestPage()
+
+	var updated []string
+	page.SetUpdateImageFunc(func(container, image string) tea.Cmd {
+		updated = append(updated, container+"="+image)
+		return nil
+	})
+	var shifted []string
+	page.SetShiftTrafficFunc(func(revisionName string) tea.Cmd {
+		shifted = append(shifted, revisionName)
+		return nil
+	})
+
+	// An unchanged image is not submitted
+	pressKey(page, "i")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if !page.IsEditing() || page.statusMessage == "" {
+		t.Error("Expected an unchanged image to be rejected")
+	}
+
+	page.imageInput.SetValue("myregistry.azurecr.io/web:v1.1")
+	cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if page.IsEditing() || cmd == nil {
+		t.Fatalf("Expected enter to request confirmation, got %q", page.statusMessage)
+	}
+	request := cmd().(pages.ConfirmRequestMsg)
+	if !strings.Contains(request.Text, "myregistry.azurecr.io/web:v1.0 → myregistry.azurecr.io/web:v1.1") {
+		t.Errorf("Expected the confirmation to show the image change, got %q", request.Text)
+	}
+	if pressKey(page, "T") != nil {
+		t.Error("Expected no traffic shift without a rollout")
+	}
+	request.OnConfirm()
+	if len(updated) != 1 || updated[0] != "app=myregistry.azurecr.io/web:v1.1" {
+		t.Fatalf("Expected the image to be updated once, got %v", updated)
+	}
+
+	// The revision provisions, and traffic can only be shifted once it is healthy
+	page.StartRollout("rg-test/web", "app", "web--v3")
+	page.SetRolloutRevision(models.Revision{Name: "web--v3", ProvisioningState: models.RevisionProvisioning, HealthState: models.RevisionHealthNone})
+	view := page.View()
+	for _, want := range []string{"web--v3", "myregistry.azurecr.io/web:v1.1", "Provisioning"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the rollout to show %q", want)
+		}
+	}
+	if pressKey(page, "T") != nil {
+		t.Error("Expected no traffic shift to a provisioning revision")
+	}
+
+	page.SetRolloutRevision(models.Revision{Name: "web--v3", ProvisioningState: models.RevisionProvisioned, HealthState: models.RevisionHealthy, Replicas: 1})
+	if !strings.Contains(page.View(), "press T to shift all traffic") {
+		t.Error("Expected the healthy revision to offer a traffic shift")
+	}
+	cmd = pressKey(page, "T")
+	if cmd == nil {
+		t.Fatal("Expected T to request confirmation")
+	}
+	cmd().(pages.ConfirmRequestMsg).OnConfirm()
+	if len(shifted) != 1 || shifted[0] != "web--v3" {
+		t.Errorf("Expected traffic to be shifted to web--v3, got %v", shifted)
+	}
+
+	// The rollout is kept for other revisions of the app only
+	page.SetRevisionContext("web", "rg-test/web", "web--v3")
+	if !page.GetRollout().Active() {
+		t.Error("Expected the rollout to be kept for the same app")
+	}
+	page.SetRevisionContext("api", "rg-test/api", "api--v1")
+	if page.GetRollout().Active() {
+		t.Error("Expected the rollout to be dropped for another app")
+	}
+}
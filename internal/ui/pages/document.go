@@ -0,0 +1,314 @@
+package pages
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/IAL32/az-tui/internal/ui/components/document"
+)
+
+// DocumentPage is a page for displaying a single structured document, such as
+// the JSON returned by `az ... show`, instead of a table. It supports
+// collapsing objects and arrays, switching between JSON and YAML, searching
+// keys and values, and copying the JSONPath of the node under the cursor.
+type DocumentPage struct {
+	*BasePage
+
+	// Document state
+	viewer *document.Viewer
+
+	// Search input shown while typing a query
+	searchInput textinput.Model
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Navigation and clipboard functions
+	backFunc func() tea.Cmd
+	copyFunc func(string) error
+}
+
+// DocumentKeys are the key bindings handled by every document page
+var DocumentKeys = struct {
+	Up       key.Binding
+	Down     key.Binding
+	Top      key.Binding
+	Bottom   key.Binding
+	PageUp   key.Binding
+	PageDown key.Binding
+	Toggle   key.Binding
+	Collapse key.Binding
+	Expand   key.Binding
+	Format   key.Binding
+	Search   key.Binding
+	Next     key.Binding
+	Prev     key.Binding
+	CopyPath key.Binding
+}{
+	Up:       key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+	Down:     key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+	Top:      key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g", "top")),
+	Bottom:   key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G", "bottom")),
+	PageUp:   key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", "page up")),
+	PageDown: key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdn", "page down")),
+	Toggle:   key.NewBinding(key.WithKeys("enter", " "), key.WithHelp("enter", "fold/unfold")),
+	Collapse: key.NewBinding(key.WithKeys("z"), key.WithHelp("z", "fold all")),
+	Expand:   key.NewBinding(key.WithKeys("Z"), key.WithHelp("Z", "unfold all")),
+	Format:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "json/yaml")),
+	Search:   key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+	Next:     key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+	Prev:     key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "prev match")),
+	CopyPath: key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "copy JSONPath")),
+}
+
+// NewDocumentPage creates a new DocumentPage
+func NewDocumentPage(searchPlaceholder string) *DocumentPage {
+	searchInput := textinput.New()
+	searchInput.Placeholder = searchPlaceholder
+	searchInput.Prompt = "/"
+
+	return &DocumentPage{
+		BasePage:    NewBasePage(searchPlaceholder),
+		searchInput: searchInput,
+		copyFunc:    copyToClipboard,
+	}
+}
+
+// Configuration methods
+
+// SetBackFunc sets the function to call when leaving the page
+func (dp *DocumentPage) SetBackFunc(fn func() tea.Cmd) {
+	dp.backFunc = fn
+}
+
+// SetCopyFunc sets the function used to copy text to the clipboard
+func (dp *DocumentPage) SetCopyFunc(fn func(string) error) {
+	dp.copyFunc = fn
+}
+
+// Data management methods
+
+// SetContent parses and displays a JSON document, keeping the current format
+func (dp *DocumentPage) SetContent(raw string) error {
+	root, err := document.Parse(raw)
+	if err != nil {
+		return err
+	}
+
+	format := document.FormatJSON
+	if dp.viewer != nil {
+		format = dp.viewer.Format()
+	}
+
+	dp.viewer = document.NewViewer(root)
+	dp.viewer.SetFormat(format)
+	dp.statusMessage = ""
+	return nil
+}
+
+// HasContent returns true if a document is loaded
+func (dp *DocumentPage) HasContent() bool {
+	return dp.viewer != nil
+}
+
+// GetViewer returns the document viewer, or nil if no document is loaded
+func (dp *DocumentPage) GetViewer() *document.Viewer {
+	return dp.viewer
+}
+
+// GetStatusMessage returns feedback from the last document action
+func (dp *DocumentPage) GetStatusMessage() string {
+	return dp.statusMessage
+}
+
+// ClearData removes the displayed document
+func (dp *DocumentPage) ClearData() {
+	dp.BasePage.ClearData()
+	dp.viewer = nil
+	dp.statusMessage = ""
+	dp.searchInput.SetValue("")
+	dp.searchInput.Blur()
+}
+
+// IsSearching returns true while a search query is being typed
+func (dp *DocumentPage) IsSearching() bool {
+	return dp.searchInput.Focused()
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the document page
+func (dp *DocumentPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if dp.searchInput.Focused() {
+		return dp.handleSearchInput(msg)
+	}
+
+	switch msg.String() {
+	case "esc":
+		if dp.backFunc != nil {
+			return dp.backFunc(), true
+		}
+		return nil, true
+	case "ctrl+c", "q":
+		return tea.Quit, true
+	}
+
+	if dp.viewer == nil {
+		return nil, false
+	}
+
+	switch {
+	case key.Matches(msg, DocumentKeys.Up):
+		dp.viewer.MoveCursor(-1)
+	case key.Matches(msg, DocumentKeys.Down):
+		dp.viewer.MoveCursor(1)
+	case key.Matches(msg, DocumentKeys.Top):
+		dp.viewer.GotoTop()
+	case key.Matches(msg, DocumentKeys.Bottom):
+		dp.viewer.GotoBottom()
+	case key.Matches(msg, DocumentKeys.PageUp):
+		dp.viewer.PageUp()
+	case key.Matches(msg, DocumentKeys.PageDown):
+		dp.viewer.PageDown()
+	case key.Matches(msg, DocumentKeys.Toggle):
+		dp.viewer.ToggleCollapse()
+	case key.Matches(msg, DocumentKeys.Collapse):
+		dp.viewer.CollapseAll()
+	case key.Matches(msg, DocumentKeys.Expand):
+		dp.viewer.ExpandAll()
+	case key.Matches(msg, DocumentKeys.Format):
+		dp.viewer.ToggleFormat()
+		dp.statusMessage = "Showing " + dp.viewer.Format().String()
+	case key.Matches(msg, DocumentKeys.Search):
+		dp.searchInput.SetValue("")
+		dp.searchInput.Focus()
+		return textinput.Blink, true
+	case key.Matches(msg, DocumentKeys.Next):
+		dp.moveToMatch(dp.viewer.NextMatch)
+	case key.Matches(msg, DocumentKeys.Prev):
+		dp.moveToMatch(dp.viewer.PrevMatch)
+	case key.Matches(msg, DocumentKeys.CopyPath):
+		dp.copyCursorPath()
+	default:
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// handleSearchInput handles key input while the search query is being typed
+func (dp *DocumentPage) handleSearchInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		dp.searchInput.Blur()
+		query := dp.searchInput.Value()
+		if dp.viewer == nil || query == "" {
+			return nil, true
+		}
+		if count := dp.viewer.Search(query); count == 0 {
+			dp.statusMessage = fmt.Sprintf("No matches for %q", query)
+		} else {
+			dp.setMatchStatus()
+		}
+		return nil, true
+	case "esc":
+		dp.searchInput.SetValue("")
+		dp.searchInput.Blur()
+		return nil, true
+	default:
+		var cmd tea.Cmd
+		dp.searchInput, cmd = dp.searchInput.Update(msg)
+		return cmd, true
+	}
+}
+
+// moveToMatch moves to another search match and reports the position
+func (dp *DocumentPage) moveToMatch(move func() bool) {
+	if !move() {
+		if dp.viewer.Query() == "" {
+			dp.statusMessage = "No active search, press / to search"
+		} else {
+			dp.statusMessage = fmt.Sprintf("No matches for %q", dp.viewer.Query())
+		}
+		return
+	}
+	dp.setMatchStatus()
+}
+
+// setMatchStatus reports the current search match position
+func (dp *DocumentPage) setMatchStatus() {
+	current, total := dp.viewer.MatchPosition()
+	dp.statusMessage = fmt.Sprintf("Match %d/%d for %q", current, total, dp.viewer.Query())
+}
+
+// copyCursorPath copies the JSONPath of the node under the cursor
+func (dp *DocumentPage) copyCursorPath() {
+	path := dp.viewer.CursorNode().Path()
+	if dp.copyFunc == nil {
+		dp.statusMessage = path
+		return
+	}
+	if err := dp.copyFunc(path); err != nil {
+		dp.statusMessage = fmt.Sprintf("Copy failed (%v): %s", err, path)
+		return
+	}
+	dp.statusMessage = "Copied " + path
+}
+
+// GetHelpKeys returns the help keys for the document page
+func (dp *DocumentPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		DocumentKeys.Up,
+		DocumentKeys.Down,
+		DocumentKeys.Top,
+		DocumentKeys.Bottom,
+		DocumentKeys.PageUp,
+		DocumentKeys.PageDown,
+		DocumentKeys.Toggle,
+		DocumentKeys.Collapse,
+		DocumentKeys.Expand,
+		DocumentKeys.Format,
+		DocumentKeys.Search,
+		DocumentKeys.Next,
+		DocumentKeys.Prev,
+		DocumentKeys.CopyPath,
+		BackKey,
+		QuitKey,
+	}
+}
+
+// View rendering methods
+
+// RenderDocument renders the document, and the search prompt while searching,
+// within the given dimensions
+func (dp *DocumentPage) RenderDocument(width, height int) string {
+	if dp.viewer == nil {
+		return ""
+	}
+
+	if !dp.searchInput.Focused() {
+		dp.viewer.SetSize(width, height)
+		return dp.viewer.View()
+	}
+
+	dp.viewer.SetSize(width, height-1)
+	dp.searchInput.Width = max(1, width-2)
+	return lipgloss.JoinVertical(lipgloss.Left, dp.viewer.View(), dp.searchInput.View())
+}
+
+// copyToClipboard writes text to the system clipboard, falling back to the
+// OSC 52 escape sequence when no clipboard utility is available (e.g. over SSH)
+func copyToClipboard(text string) error {
+	if err := clipboard.WriteAll(text); err == nil {
+		return nil
+	}
+	_, err := osc52.New(text).WriteTo(os.Stderr)
+	return err
+}
@@ -0,0 +1,318 @@
+package environments
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// EnvironmentsPage represents the managed environments page using the new page interface system.
+// It displays the Container Apps environments of a resource group in a navigable table format.
+type EnvironmentsPage struct {
+	*pages.NavigablePage[models.ManagedEnvironment]
+
+	// Navigation context
+	resourceGroupName string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys EnvironmentsKeyMap
+
+	// Navigation function
+	navigateToAppsFunc func(models.ManagedEnvironment) tea.Cmd
+}
+
+// EnvironmentsKeyMap defines the key bindings for the managed environments page
+type EnvironmentsKeyMap struct {
+	Enter       key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewEnvironmentsPage creates a new managed environments page
+func NewEnvironmentsPage(layoutSystem *layouts.LayoutSystem) *EnvironmentsPage {
+	// Create the base navigable page
+	basePage := pages.NewNavigablePage[models.ManagedEnvironment]("Filter environments...")
+
+	// Create the environments page
+	page := &EnvironmentsPage{
+		NavigablePage: basePage,
+		layoutSystem:  layoutSystem,
+		keys:          defaultEnvironmentsKeyMap(),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createEnvironmentsTable)
+
+	// Enable navigation
+	page.SetNavigationFunc(page.handleNavigation)
+
+	// Set help keys for the base page
+	page.SetHelpKeys(page.GetHelpKeys())
+
+	return page
+}
+
+// defaultEnvironmentsKeyMap returns the default key bindings for managed environments
+func defaultEnvironmentsKeyMap() EnvironmentsKeyMap {
+	return EnvironmentsKeyMap{
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "view apps"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetResourceGroupContext sets the resource group context for the environments page
+func (p *EnvironmentsPage) SetResourceGroupContext(resourceGroupName string) {
+	p.resourceGroupName = resourceGroupName
+}
+
+// SetNavigateToAppsFunc sets the function to call when navigating to the apps of an environment
+func (p *EnvironmentsPage) SetNavigateToAppsFunc(fn func(models.ManagedEnvironment) tea.Cmd) {
+	p.navigateToAppsFunc = fn
+}
+
+// SetBackToResourceGroupsFunc sets the function to call when going back to resource groups
+func (p *EnvironmentsPage) SetBackToResourceGroupsFunc(fn func() tea.Cmd) {
+	p.SetBackFunc(fn)
+}
+
+// Table creation methods
+
+// createEnvironmentsTable creates a table for displaying managed environments
+func (p *EnvironmentsPage) createEnvironmentsTable(data []models.ManagedEnvironment) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("name", "Name", 15, true).                  // Dynamic width, min 15
+		AddColumn("location", "Location", 15, true).          // Fixed width
+		AddColumn("status", "Status", 12, true).              // Fixed width
+		AddColumn("profiles", "Workload Profiles", 20, true). // Dynamic width, min 20
+		AddColumn("vnet", "VNet", 10, false).                 // Fixed width
+		AddColumn("staticIp", "Static IP", 16, false).        // Fixed width
+		AddColumn("apps", "Apps", 6, false).                  // Fixed width
+		AddColumn("domain", "Default Domain", 50, false)      // Fixed width (longest content)
+
+	// Update dynamic column widths based on actual content
+	for _, env := range data {
+		builder.UpdateWidthFromString("name", env.Name)
+		builder.UpdateWidthFromString("profiles", formatWorkloadProfiles(env.WorkloadProfiles))
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, env := range data {
+			status := env.ProvisioningState
+			if status == "" {
+				status = "Unknown"
+			}
+
+			staticIP := env.StaticIP
+			if staticIP == "" {
+				staticIP = "-"
+			}
+
+			domain := env.DefaultDomain
+			if domain == "" {
+				domain = "-"
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"name":     env.Name,
+				"location": env.Location,
+				"status":   table.NewStyledCell(status, lipgloss.NewStyle().Foreground(pages.GetStatusColor(status))),
+				"profiles": formatWorkloadProfiles(env.WorkloadProfiles),
+				"vnet":     formatVNet(env),
+				"staticIp": staticIP,
+				"apps":     fmt.Sprintf("%d", env.AppCount),
+				"domain":   domain,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config).SortByAsc("name")
+}
+
+// Navigation methods
+
+// handleNavigation handles navigation to the apps of the selected environment
+func (p *EnvironmentsPage) handleNavigation(env models.ManagedEnvironment) tea.Cmd {
+	if p.navigateToAppsFunc != nil {
+		return p.navigateToAppsFunc(env)
+	}
+	return nil
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the environments page
+func (p *EnvironmentsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// First, try base navigable page key handling
+	if cmd, handled := p.NavigablePage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	// Handle environments-specific keys
+	switch msg.String() {
+	case "r":
+		// Refresh
+		return p.Refresh(), true
+	}
+
+	// Don't handle any other keys - let them bubble up
+	return nil, false
+}
+
+// GetHelpKeys returns the help keys for the environments page
+func (p *EnvironmentsPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Enter,
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the environments page
+func (p *EnvironmentsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeEnvironments,
+	})
+}
+
+// ViewWithHelpContext renders the environments page with help context
+func (p *EnvironmentsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeEnvironments
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading environments...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeEnvironments,
+				ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeEnvironments,
+				Error:       err,
+				ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:        layouts.ModeEnvironments,
+			ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+			Counters:    map[string]int{"count": len(p.GetData())},
+		},
+		helpContext,
+	)
+}
+
+// Helper functions for environment formatting
+
+// formatWorkloadProfiles formats the workload profile names of an environment
+func formatWorkloadProfiles(profiles []string) string {
+	if len(profiles) == 0 {
+		return "Consumption only"
+	}
+	return strings.Join(profiles, ", ")
+}
+
+// formatVNet describes the virtual network integration of an environment
+func formatVNet(env models.ManagedEnvironment) string {
+	switch {
+	case !env.HasVNetIntegration():
+		return "No"
+	case env.Internal:
+		return "Internal"
+	default:
+		return "External"
+	}
+}
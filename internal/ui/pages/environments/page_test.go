@@ -0,0 +1,101 @@
+package environments
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestEnvironments() []models.ManagedEnvironment {
+	return []models.ManagedEnvironment{
+		{
+			ID:                "/subscriptions/1/resourceGroups/rg-prod/providers/Microsoft.App/managedEnvironments/env-a",
+			Name:              "env-a",
+			ResourceGroup:     "rg-prod",
+			Location:          "East US",
+			ProvisioningState: "Succeeded",
+			WorkloadProfiles:  []string{"Consumption", "D4-general"},
+			SubnetID:          "/subscriptions/1/resourceGroups/rg-prod/providers/Microsoft.Network/virtualNetworks/vnet/subnets/aca",
+			Internal:          true,
+			StaticIP:          "10.0.16.4",
+			DefaultDomain:     "proudocean-12345.eastus.azurecontainerapps.io",
+			AppCount:          3,
+		},
+		{
+			ID:                "/subscriptions/1/resourceGroups/rg-prod/providers/Microsoft.App/managedEnvironments/env-b",
+			Name:              "env-b",
+			ResourceGroup:     "rg-prod",
+			Location:          "East US",
+			ProvisioningState: "Succeeded",
+		},
+	}
+}
+
+// Test environment formatting helpers
+func TestEnvironmentFormatting(t *testing.T) {
+	envs := createTestEnvironments()
+
+	if got := formatWorkloadProfiles(envs[0].WorkloadProfiles); got != "Consumption, D4-general" {
+		t.Errorf("Expected joined workload profiles, got %q", got)
+	}
+	if got := formatWorkloadProfiles(envs[1].WorkloadProfiles); got != "Consumption only" {
+		t.Errorf("Expected consumption only environment, got %q", got)
+	}
+
+	if got := formatVNet(envs[0]); got != "Internal" {
+		t.Errorf("Expected internal VNet, got %q", got)
+	}
+	if got := formatVNet(envs[1]); got != "No" {
+		t.Errorf("Expected no VNet integration, got %q", got)
+	}
+
+	layoutSystem := layouts.NewLayoutSystem(200, 24)
+	page := NewEnvironmentsPage(layoutSystem)
+	page.SetResourceGroupContext("rg-prod")
+	page.SetData(envs)
+	if view := page.View(); !strings.Contains(view, "10.0.16.4") {
+		t.Error("View should contain the static IP")
+	}
+}
+
+// Test navigation handling
+func TestEnvironmentsPageNavigation(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewEnvironmentsPage(layoutSystem)
+	page.SetData(createTestEnvironments())
+
+	var selected models.ManagedEnvironment
+	page.SetNavigateToAppsFunc(func(env models.ManagedEnvironment) tea.Cmd {
+		selected = env
+		return nil
+	})
+
+	// Move the cursor to the second row before pressing Enter
+	table := page.GetTable()
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	page.SetTable(table)
+
+	cmd, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if !handled {
+		t.Error("Enter key should be handled")
+	}
+	if cmd != nil {
+		cmd()
+	}
+	if selected.Name != "env-b" {
+		t.Errorf("Expected navigation to env-b, got %q", selected.Name)
+	}
+
+	back := false
+	page.SetBackToResourceGroupsFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
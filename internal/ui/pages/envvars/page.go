@@ -1,8 +1,16 @@
 package envvars
 
 import (
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
 
 	"github.com/IAL32/az-tui/internal/models"
@@ -11,27 +19,88 @@ import (
 	"github.com/IAL32/az-tui/internal/ui/pages"
 )
 
+// formHeight is the number of lines of the variable form, with the blank line below it
+const formHeight = 3
+
+// namePattern matches the names accepted for environment variables
+var namePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.-]*$`)
+
+// Fields of the variable form, in the order tab moves through them
+const (
+	fieldName = iota
+	fieldValue
+	fieldCount
+)
+
+// Change is the change staged for an environment variable
+type Change int
+
+const (
+	Unchanged Change = iota
+	Added
+	Changed
+	Removed
+)
+
+// EnvVar is an environment variable of the container with the change staged
+// for it. Values referencing a secret start with models.SecretRefPrefix.
+type EnvVar struct {
+	Name     string
+	Value    string // value once the change is applied
+	Original string // value as loaded, empty for added variables
+	Change   Change
+}
+
 // EnvVarsPage represents the environment variables page using the new page interface system.
-// It displays environment variables for a specific container in a read-only table format.
+// It displays the environment variables of a container, showing the secret each
+// secret-backed variable references instead of its value. Variables are added,
+// edited and removed in an inline form and the staged changes are applied at once,
+// which creates a new revision of the app.
 type EnvVarsPage struct {
-	*pages.ReadOnlyPage[map[string]string]
+	*pages.ReadOnlyPage[EnvVar]
 
 	// Navigation context
+	appName       string
+	appID         string
 	containerName string
 	containers    []models.Container
 
+	// Variables as loaded, by name, and the changes staged on top of them
+	original map[string]string
+	staged   map[string]string
+	removed  map[string]bool
+
+	// Variable form; editName is the variable edited, empty when adding one
+	inputs   [fieldCount]textinput.Model
+	editing  bool
+	field    int
+	editName string
+
+	// Feedback shown in the status bar
+	statusMessage string
+
 	// Layout system
 	layoutSystem *layouts.LayoutSystem
 
 	// Key bindings
 	keys EnvVarsKeyMap
 
-	// Back navigation function
-	backFunc func() tea.Cmd
+	// Action function
+	applyFunc func(models.EnvUpdate) tea.Cmd
+
+	// Navigation functions
+	showSecretFunc func(secretName string) tea.Cmd
+	backFunc       func() tea.Cmd
 }
 
 // EnvVarsKeyMap defines the key bindings for the environment variables page
 type EnvVarsKeyMap struct {
+	Add         key.Binding
+	Edit        key.Binding
+	Delete      key.Binding
+	Undo        key.Binding
+	Apply       key.Binding
+	Secret      key.Binding
 	Filter      key.Binding
 	ScrollLeft  key.Binding
 	ScrollRight key.Binding
@@ -43,7 +112,7 @@ type EnvVarsKeyMap struct {
 // NewEnvVarsPage creates a new environment variables page
 func NewEnvVarsPage(layoutSystem *layouts.LayoutSystem) *EnvVarsPage {
 	// Create the base read-only page
-	basePage := pages.NewReadOnlyPage[map[string]string]("Filter environment variables...")
+	basePage := pages.NewReadOnlyPage[EnvVar]("Filter environment variables...")
 
 	// Create the envvars page
 	page := &EnvVarsPage{
@@ -51,6 +120,13 @@ func NewEnvVarsPage(layoutSystem *layouts.LayoutSystem) *EnvVarsPage {
 		layoutSystem: layoutSystem,
 		keys:         defaultEnvVarsKeyMap(),
 	}
+	for i, placeholder := range []string{"NAME", "value, or secretref:secret-name"} {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		page.inputs[i] = input
+	}
+	page.discardChanges()
 
 	// Set the table creation function
 	page.SetCreateTableFunc(page.createEnvVarsTable)
@@ -61,6 +137,30 @@ func NewEnvVarsPage(layoutSystem *layouts.LayoutSystem) *EnvVarsPage {
 // defaultEnvVarsKeyMap returns the default key bindings for environment variables
 func defaultEnvVarsKeyMap() EnvVarsKeyMap {
 	return EnvVarsKeyMap{
+		Add: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit"),
+		),
+		Delete: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "delete/restore"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo changes"),
+		),
+		Apply: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "apply changes"),
+		),
+		Secret: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "go to secret"),
+		),
 		Filter: key.NewBinding(
 			key.WithKeys("/"),
 			key.WithHelp("/", "filter"),
@@ -90,11 +190,31 @@ func defaultEnvVarsKeyMap() EnvVarsKeyMap {
 
 // Configuration methods
 
-// SetContainerContext sets the container context for the environment variables page
+// SetAppContext sets the app whose container is shown, to which changes are applied
+func (p *EnvVarsPage) SetAppContext(appName, appID string) {
+	p.appName = appName
+	p.appID = appID
+}
+
+// SetContainerContext sets the container context for the environment variables
+// page, discarding any unapplied changes
 func (p *EnvVarsPage) SetContainerContext(containerName string, containers []models.Container) {
 	p.containerName = containerName
 	p.containers = containers
+	p.discardChanges()
+	p.blur()
 	p.loadEnvVarsData()
+	p.SetTable(p.GetTable().WithHighlightedRow(0))
+}
+
+// SetApplyFunc sets the function to call for applying the staged changes
+func (p *EnvVarsPage) SetApplyFunc(fn func(models.EnvUpdate) tea.Cmd) {
+	p.applyFunc = fn
+}
+
+// SetShowSecretFunc sets the function to call for showing the secret a variable references
+func (p *EnvVarsPage) SetShowSecretFunc(fn func(secretName string) tea.Cmd) {
+	p.showSecretFunc = fn
 }
 
 // SetBackFunc sets the function to call when navigating back
@@ -106,58 +226,267 @@ func (p *EnvVarsPage) SetBackFunc(fn func() tea.Cmd) {
 
 // loadEnvVarsData loads environment variables data from the current container
 func (p *EnvVarsPage) loadEnvVarsData() {
-	if p.containerName == "" {
-		p.SetData([]map[string]string{})
-		return
-	}
+	p.original = make(map[string]string)
 
 	// Find the current container and get its environment variables
 	for _, ctr := range p.containers {
-		if ctr.Name == p.containerName {
-			if len(ctr.Env) > 0 {
-				// Convert the environment variables map to a slice of maps for the table
-				envVars := []map[string]string{ctr.Env}
-				p.SetData(envVars)
-			} else {
-				// Set empty data if no environment variables
-				p.SetData([]map[string]string{})
+		if ctr.Name == p.containerName && p.containerName != "" {
+			for name, value := range ctr.Env {
+				if secretName, ok := ctr.SecretRef(name); ok {
+					value = models.SecretRefPrefix + secretName
+				}
+				p.original[name] = value
 			}
-			return
+			break
+		}
+	}
+
+	p.rebuild()
+}
+
+// rebuild sets the variables as loaded with the staged changes, sorted by
+// name, keeping the highlighted row
+func (p *EnvVarsPage) rebuild() {
+	names := slices.Collect(maps.Keys(p.original))
+	for name := range p.staged {
+		if _, ok := p.original[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	envVars := make([]EnvVar, 0, len(names))
+	for _, name := range names {
+		original, loaded := p.original[name]
+		envVar := EnvVar{Name: name, Value: original, Original: original}
+		switch value, staged := p.staged[name]; {
+		case p.removed[name]:
+			envVar.Change = Removed
+		case staged && !loaded:
+			envVar.Value, envVar.Change = value, Added
+		case staged:
+			envVar.Value, envVar.Change = value, Changed
 		}
+		envVars = append(envVars, envVar)
+	}
+
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.SetData(envVars)
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+}
+
+// Staging methods
+
+// HasChanges reports whether any change is staged
+func (p *EnvVarsPage) HasChanges() bool {
+	return len(p.staged) > 0 || len(p.removed) > 0
+}
+
+// PendingUpdate returns the staged changes as an update of the container
+func (p *EnvVarsPage) PendingUpdate() models.EnvUpdate {
+	update := models.EnvUpdate{Container: p.containerName}
+	if len(p.staged) > 0 {
+		update.Set = maps.Clone(p.staged)
+	}
+	for _, name := range slices.Sorted(maps.Keys(p.removed)) {
+		update.Remove = append(update.Remove, name)
 	}
+	return update
+}
+
+// stage sets the value of a variable, dropping the change when it restores
+// the value as loaded
+func (p *EnvVarsPage) stage(name, value string) {
+	delete(p.removed, name)
+	if original, ok := p.original[name]; ok && original == value {
+		delete(p.staged, name)
+	} else {
+		p.staged[name] = value
+	}
+	p.rebuild()
+}
+
+// toggleRemoved removes the highlighted variable, or restores it when it is
+// already removed. Added variables are dropped.
+func (p *EnvVarsPage) toggleRemoved() {
+	envVar, ok := p.GetSelectedItem()
+	if !ok {
+		return
+	}
+	switch envVar.Change {
+	case Added:
+		delete(p.staged, envVar.Name)
+	case Removed:
+		delete(p.removed, envVar.Name)
+	default:
+		delete(p.staged, envVar.Name)
+		p.removed[envVar.Name] = true
+	}
+	p.rebuild()
+}
 
-	// Container not found, set empty data
-	p.SetData([]map[string]string{})
+// discardChanges drops the staged changes
+func (p *EnvVarsPage) discardChanges() {
+	p.staged = make(map[string]string)
+	p.removed = make(map[string]bool)
+	p.statusMessage = ""
+}
+
+// undo restores the variables as loaded
+func (p *EnvVarsPage) undo() {
+	p.discardChanges()
+	p.rebuild()
+}
+
+// apply asks for confirmation of the staged changes, showing their diff
+func (p *EnvVarsPage) apply() tea.Cmd {
+	if !p.HasChanges() {
+		p.statusMessage = "No changes to apply"
+		return nil
+	}
+
+	var diff []string
+	for _, envVar := range p.GetData() {
+		switch envVar.Change {
+		case Added:
+			diff = append(diff, fmt.Sprintf("  + %s = %s", envVar.Name, formatValue(envVar.Value)))
+		case Changed:
+			diff = append(diff, fmt.Sprintf("  ~ %s = %s → %s", envVar.Name, formatValue(envVar.Original), formatValue(envVar.Value)))
+		case Removed:
+			diff = append(diff, fmt.Sprintf("  - %s", envVar.Name))
+		}
+	}
+
+	update := p.PendingUpdate()
+	resourceGroup, _, _ := strings.Cut(p.appID, "/")
+	request := pages.ConfirmRequestMsg{
+		Text: fmt.Sprintf("Apply these changes to container %s of %s? This creates a new revision.\n\n%s",
+			p.containerName, p.appName, strings.Join(diff, "\n")),
+		Resource:      p.appName,
+		ResourceGroup: resourceGroup,
+		OnConfirm: func() tea.Cmd {
+			if p.applyFunc != nil {
+				return p.applyFunc(update)
+			}
+			return nil
+		},
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
+// Form methods
+
+// IsEditing returns true while a variable is being edited
+func (p *EnvVarsPage) IsEditing() bool {
+	return p.editing
+}
+
+// startAdding opens the form for a new variable
+func (p *EnvVarsPage) startAdding() tea.Cmd {
+	p.editName = ""
+	p.inputs[fieldName].SetValue("")
+	p.inputs[fieldValue].SetValue("")
+	return p.focus(fieldName)
+}
+
+// startEditing opens the form for the highlighted variable
+func (p *EnvVarsPage) startEditing() tea.Cmd {
+	envVar, ok := p.GetSelectedItem()
+	if !ok {
+		return nil
+	}
+	p.editName = envVar.Name
+	p.inputs[fieldName].SetValue(envVar.Name)
+	p.inputs[fieldValue].SetValue(envVar.Value)
+	return p.focus(fieldValue)
+}
+
+// submit stages the variable in the form
+func (p *EnvVarsPage) submit() {
+	name := strings.TrimSpace(p.inputs[fieldName].Value())
+	value := p.inputs[fieldValue].Value()
+	if p.editName != "" {
+		name = p.editName
+	}
+
+	switch _, exists := p.original[name]; {
+	case !namePattern.MatchString(name):
+		p.statusMessage = fmt.Sprintf("Invalid name %q: use letters, digits, '_', '.' and '-'", name)
+		return
+	case p.editName == "" && exists && !p.removed[name]:
+		p.statusMessage = fmt.Sprintf("%s already exists, press 'e' to edit it", name)
+		return
+	case value == models.SecretRefPrefix:
+		p.statusMessage = "Missing secret name after " + models.SecretRefPrefix
+		return
+	}
+	if _, staged := p.staged[name]; p.editName == "" && staged {
+		p.statusMessage = fmt.Sprintf("%s already exists, press 'e' to edit it", name)
+		return
+	}
+
+	p.blur()
+	p.stage(name, value)
+	p.statusMessage = ""
+}
+
+// focus starts editing a field of the form. The name of an existing variable
+// cannot be changed.
+func (p *EnvVarsPage) focus(field int) tea.Cmd {
+	if p.editName != "" {
+		field = fieldValue
+	}
+	p.blur()
+	p.editing = true
+	p.field = field
+	p.inputs[field].CursorEnd()
+	return p.inputs[field].Focus()
+}
+
+// blur stops editing the form
+func (p *EnvVarsPage) blur() {
+	p.editing = false
+	for i := range p.inputs {
+		p.inputs[i].Blur()
+	}
 }
 
 // Table creation methods
 
 // createEnvVarsTable creates a table for displaying environment variables
-func (p *EnvVarsPage) createEnvVarsTable(data []map[string]string) table.Model {
+func (p *EnvVarsPage) createEnvVarsTable(data []EnvVar) table.Model {
 	// Create dynamic column builder
 	builder := tablebuilder.NewDynamicColumnBuilder().
-		AddColumn("name", "Name", 15, true).  // Min width 15, with filter
-		AddColumn("value", "Value", 20, true) // Min width 20, with filter
+		AddColumn("change", " ", 1, false).    // Fixed width
+		AddColumn("name", "Name", 15, true).   // Min width 15, with filter
+		AddColumn("value", "Value", 20, true). // Min width 20, with filter
+		AddColumn("before", "Before", 10, true)
 
 	var rows []table.Row
 
-	// Process environment variables data
-	if len(data) > 0 && len(data[0]) > 0 {
-		envVars := data[0] // Get the first (and only) map of environment variables
-
-		// Update column widths based on actual content
-		for name, value := range envVars {
-			builder.UpdateWidthFromString("name", name)
+	// Create rows from environment variables, updating column widths based on actual content
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, envVar := range data {
+			value := formatValue(envVar.Value)
+			before := "-"
+			if envVar.Change == Changed {
+				before = formatValue(envVar.Original)
+			}
+			builder.UpdateWidthFromString("name", envVar.Name)
 			builder.UpdateWidthFromString("value", value)
-		}
-
-		// Create rows from environment variables
-		rows = make([]table.Row, 0, len(envVars))
-		for name, value := range envVars {
-			rows = append(rows, table.NewRow(table.RowData{
-				"name":  name,
-				"value": value,
-			}))
+			builder.UpdateWidthFromString("before", before)
+
+			rows[i] = table.NewRow(table.RowData{
+				"change": changeCell(envVar.Change),
+				"name":   envVar.Name,
+				"value":  value,
+				"before": before,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
 		}
 	}
 
@@ -174,7 +503,7 @@ func (p *EnvVarsPage) createEnvVarsTable(data []map[string]string) table.Model {
 	// Build columns with calculated widths
 	columns := builder.Build()
 
-	// Get content dimensions
+	// Get content dimensions, leaving room for the form
 	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
 
 	// Create the table using the unified table builder with theme styling
@@ -184,24 +513,60 @@ func (p *EnvVarsPage) createEnvVarsTable(data []map[string]string) table.Model {
 		FilterInput: p.GetFilterInput(),
 		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
 		MaxWidth:    contentWidth,
-		MaxHeight:   contentHeight,
+		MaxHeight:   max(5, contentHeight-formHeight),
 	}
 
+	// Rows keep the order of the data, sorted by name
 	return tablebuilder.CreateUnifiedTable(config)
 }
 
+// selectedSecret returns the secret referenced by the highlighted variable
+func (p *EnvVarsPage) selectedSecret() (string, bool) {
+	envVar, ok := p.GetSelectedItem()
+	if !ok {
+		return "", false
+	}
+	return strings.CutPrefix(envVar.Value, models.SecretRefPrefix)
+}
+
 // Event handling methods
 
 // HandleKeyMsg handles key messages for the environment variables page
 func (p *EnvVarsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.editing {
+		return p.handleFormInput(msg)
+	}
+
 	// Handle environment variables specific keys FIRST (before base page)
 	// This prevents ReadOnlyPage from intercepting our navigation keys
-	switch msg.String() {
-	case "esc":
-		if p.backFunc != nil {
-			return p.backFunc(), true
+	if !p.GetFilterInput().Focused() {
+		switch {
+		case key.Matches(msg, p.keys.Back):
+			if p.backFunc != nil {
+				return p.backFunc(), true
+			}
+			return nil, true
+		case key.Matches(msg, p.keys.Secret):
+			if secretName, ok := p.selectedSecret(); ok && p.showSecretFunc != nil {
+				return p.showSecretFunc(secretName), true
+			}
+			return nil, true
+		case key.Matches(msg, p.keys.Add):
+			return p.startAdding(), true
+		case key.Matches(msg, p.keys.Edit):
+			return p.startEditing(), true
+		case key.Matches(msg, p.keys.Delete):
+			p.toggleRemoved()
+			return nil, true
+		case key.Matches(msg, p.keys.Undo):
+			p.undo()
+			return nil, true
+		case key.Matches(msg, p.keys.Apply):
+			return p.apply(), true
 		}
-		return nil, true
+	}
+
+	switch msg.String() {
 	case "?":
 		// Help toggle - let the parent handle this
 		return nil, false
@@ -218,9 +583,38 @@ func (p *EnvVarsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 	return nil, false
 }
 
+// handleFormInput handles keys while a variable is being edited
+func (p *EnvVarsPage) handleFormInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "tab", "down":
+		return p.focus((p.field + 1) % fieldCount), true
+	case "shift+tab", "up":
+		return p.focus((p.field + fieldCount - 1) % fieldCount), true
+	case "enter":
+		p.submit()
+		return nil, true
+	case "esc":
+		p.blur()
+		p.statusMessage = ""
+		return nil, true
+	case "ctrl+c":
+		return tea.Quit, true
+	default:
+		var cmd tea.Cmd
+		p.inputs[p.field], cmd = p.inputs[p.field].Update(msg)
+		return cmd, true
+	}
+}
+
 // GetHelpKeys returns the help keys for the environment variables page
 func (p *EnvVarsPage) GetHelpKeys() []key.Binding {
 	return []key.Binding{
+		p.keys.Add,
+		p.keys.Edit,
+		p.keys.Delete,
+		p.keys.Undo,
+		p.keys.Apply,
+		p.keys.Secret,
 		p.keys.Filter,
 		p.keys.ScrollLeft,
 		p.keys.ScrollRight,
@@ -271,20 +665,72 @@ func (p *EnvVarsPage) ViewWithHelpContext(helpContext layouts.HelpContext) strin
 		)
 	}
 
-	// Render the table view
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeEnvVars,
+		ContextInfo:   map[string]string{"container": p.containerName},
+		Counters:      map[string]int{"count": len(p.GetData())},
+		StatusMessage: p.currentStatusMessage(),
+	}
+	contentWidth, _ := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: statusContext,
+		HelpContext:   helpContext,
+	})
+
+	// Render the form above the table while editing
 	tableView := p.GetTable().View()
-	return p.layoutSystem.CreateTableLayout(
-		tableView,
-		layouts.StatusContext{
-			Mode:        layouts.ModeEnvVars,
-			ContextInfo: map[string]string{"container": p.containerName},
-			Counters:    map[string]int{"count": len(p.GetData())},
-		},
-		helpContext,
-	)
+	if p.editing {
+		tableView = lipgloss.JoinVertical(lipgloss.Left, p.renderForm(contentWidth), tableView)
+	}
+	return p.layoutSystem.CreateTableLayout(tableView, statusContext, helpContext)
+}
+
+// currentStatusMessage reports the form keys while editing and the staged
+// changes otherwise, leaving the status bar to the global message while
+// nothing is staged
+func (p *EnvVarsPage) currentStatusMessage() string {
+	if p.statusMessage != "" {
+		return p.statusMessage
+	}
+	if p.editing {
+		return "Editing variable, tab: next field, enter: stage, esc: cancel"
+	}
+	if !p.HasChanges() {
+		return ""
+	}
+	var added, changed, removed int
+	for _, envVar := range p.GetData() {
+		switch envVar.Change {
+		case Added:
+			added++
+		case Changed:
+			changed++
+		case Removed:
+			removed++
+		}
+	}
+	return fmt.Sprintf("%d added, %d changed, %d removed - press A to apply or u to undo", added, changed, removed)
 }
 
-// Refresh refreshes the environment variables data
+// renderForm renders the name and value of the variable edited
+func (p *EnvVarsPage) renderForm(width int) string {
+	accent := p.layoutSystem.GetStyle("accent")
+	labels := [fieldCount]string{"Name  ", "Value "}
+	lines := make([]string, 0, formHeight)
+	for i, label := range labels {
+		if i == p.field {
+			label = accent.Render(label)
+		}
+		view := p.inputs[i].View()
+		if i == fieldName && p.editName != "" {
+			view = p.editName
+		}
+		p.inputs[i].Width = max(1, width-lipgloss.Width(label)-1)
+		lines = append(lines, label+view)
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(append(lines, ""), "\n"))
+}
+
+// Refresh refreshes the environment variables data, keeping the staged changes
 func (p *EnvVarsPage) Refresh() tea.Cmd {
 	p.SetLoading(true)
 	p.SetError(nil)
@@ -296,6 +742,35 @@ func (p *EnvVarsPage) Refresh() tea.Cmd {
 // Reset resets the page state
 func (p *EnvVarsPage) Reset() {
 	p.ReadOnlyPage.Reset()
+	p.appName = ""
+	p.appID = ""
 	p.containerName = ""
 	p.containers = nil
+	p.original = nil
+	p.discardChanges()
+	p.blur()
+}
+
+// Helper functions for environment variable formatting
+
+// formatValue shows the secret a value references instead of the value
+func formatValue(value string) string {
+	if secretName, ok := strings.CutPrefix(value, models.SecretRefPrefix); ok {
+		return fmt.Sprintf("secret: %s", secretName)
+	}
+	return value
+}
+
+// changeCell marks a staged change with a colored +, ~ or -
+func changeCell(change Change) any {
+	switch change {
+	case Added:
+		return table.NewStyledCell("+", lipgloss.NewStyle().Foreground(pages.GetStatusColor("succeeded")))
+	case Changed:
+		return table.NewStyledCell("~", lipgloss.NewStyle().Foreground(pages.GetStatusColor("pending")))
+	case Removed:
+		return table.NewStyledCell("-", lipgloss.NewStyle().Foreground(pages.GetStatusColor("failed")))
+	default:
+		return ""
+	}
 }
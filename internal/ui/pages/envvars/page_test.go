@@ -0,0 +1,188 @@
+package envvars
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// createTestPage creates a page showing the env vars of a container with a secret-backed variable
+func createTestPage() *EnvVarsPage {
+	page := NewEnvVarsPage(layouts.NewLayoutSystem(200, 24))
+	page.SetContainerContext("web-app", []models.Container{
+		{
+			Name: "web-app",
+			Env: map[string]string{
+				"NODE_ENV":   "production",
+				"JWT_SECRET": "",
+				"LOG_LEVEL":  "info",
+			},
+			EnvSecrets: map[string]string{"JWT_SECRET": "jwt-secret"},
+		},
+	})
+	return page
+}
+
+// Test that secret-backed variables show the secret they reference
+func TestEnvVarsPageSecretRefs(t *testing.T) {
+	page := createTestPage()
+
+	table := page.GetTable()
+	if table.TotalRows() != 3 {
+		t.Fatalf("Expected 3 rows, got %d", table.TotalRows())
+	}
+
+	view := page.View()
+	for _, want := range []string{"secret: jwt-secret", "production"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to contain %q", want)
+		}
+	}
+}
+
+// Test that enter jumps to the secret of the highlighted variable only
+func TestEnvVarsPageShowSecret(t *testing.T) {
+	page := createTestPage()
+
+	var shown []string
+	page.SetShowSecretFunc(func(secretName string) tea.Cmd {
+		shown = append(shown, secretName)
+		return nil
+	})
+
+	// Rows are sorted by name, JWT_SECRET comes first
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(shown) != 1 || shown[0] != "jwt-secret" {
+		t.Fatalf("Expected enter to show jwt-secret, got %v", shown)
+	}
+
+	page.SetTable(page.GetTable().WithHighlightedRow(1))
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(shown) != 1 {
+		t.Errorf("Expected enter on a plain variable to do nothing, got %v", shown)
+	}
+}
+
+func typeText(page *EnvVarsPage, text string) {
+	for _, r := range text {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func pressKey(page *EnvVarsPage, k string) tea.Cmd {
+	cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return cmd
+}
+
+// Test that added, edited and removed variables are staged and applied at once
+func TestEnvVarsPageStageAndApply(t *testing.T) {
+	page := createTestPage()
+	page.SetAppContext("web-frontend", "rg-prod/web-frontend")
+
+	var applied []models.EnvUpdate
+	page.SetApplyFunc(func(update models.EnvUpdate) tea.Cmd {
+		applied = append(applied, update)
+		return nil
+	})
+
+	// Add a variable referencing a secret
+	pressKey(page, "a")
+	if !page.IsEditing() {
+		t.Fatal("Expected 'a' to open the form")
+	}
+	typeText(page, "API_KEY")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(page, "secretref:api-key")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if page.IsEditing() {
+		t.Fatal("Expected enter to stage the variable")
+	}
+
+	// Rows are sorted by name: API_KEY, JWT_SECRET, LOG_LEVEL, NODE_ENV
+	page.SetTable(page.GetTable().WithHighlightedRow(2))
+	pressKey(page, "e")
+	for range len("info") {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyBackspace})
+	}
+	typeText(page, "debug")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+
+	page.SetTable(page.GetTable().WithHighlightedRow(3))
+	pressKey(page, "d")
+
+	data := page.GetData()
+	if len(data) != 4 || data[0].Change != Added || data[2].Change != Changed || data[3].Change != Removed {
+		t.Fatalf("Unexpected staged variables %+v", data)
+	}
+	view := page.View()
+	for _, want := range []string{"secret: api-key", "debug", "1 added, 1 changed, 1 removed"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to contain %q", want)
+		}
+	}
+
+	// Applying asks for confirmation with the diff of the changes
+	cmd := pressKey(page, "A")
+	if cmd == nil {
+		t.Fatal("Expected 'A' to ask for confirmation")
+	}
+	request, ok := cmd().(pages.ConfirmRequestMsg)
+	if !ok {
+		t.Fatal("Expected a confirmation request")
+	}
+	for _, want := range []string{"+ API_KEY = secret: api-key", "~ LOG_LEVEL = info → debug", "- NODE_ENV"} {
+		if !strings.Contains(request.Text, want) {
+			t.Errorf("Expected the confirmation to contain %q, got %q", want, request.Text)
+		}
+	}
+	if request.Resource != "web-frontend" || request.ResourceGroup != "rg-prod" {
+		t.Errorf("Unexpected confirmation resource %s/%s", request.ResourceGroup, request.Resource)
+	}
+
+	request.OnConfirm()
+	if len(applied) != 1 {
+		t.Fatalf("Expected the changes to be applied once, got %d", len(applied))
+	}
+	update := applied[0]
+	if update.Container != "web-app" || update.Set["API_KEY"] != "secretref:api-key" || update.Set["LOG_LEVEL"] != "debug" {
+		t.Errorf("Unexpected update %+v", update)
+	}
+	if len(update.Remove) != 1 || update.Remove[0] != "NODE_ENV" {
+		t.Errorf("Expected NODE_ENV to be removed, got %v", update.Remove)
+	}
+}
+
+// Test that invalid and duplicate variables are not staged, and undo drops the changes
+func TestEnvVarsPageValidationAndUndo(t *testing.T) {
+	page := createTestPage()
+
+	for _, name := range []string{"1BAD", "LOG_LEVEL"} {
+		pressKey(page, "a")
+		typeText(page, name)
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+		if !page.IsEditing() {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	}
+	if page.HasChanges() {
+		t.Fatal("Expected no staged changes")
+	}
+
+	// Deleting twice restores the variable
+	pressKey(page, "d")
+	pressKey(page, "d")
+	if page.HasChanges() {
+		t.Error("Expected 'd' to restore a removed variable")
+	}
+
+	pressKey(page, "d")
+	pressKey(page, "u")
+	if page.HasChanges() || pressKey(page, "A") != nil {
+		t.Error("Expected undo to drop the staged changes")
+	}
+}
@@ -0,0 +1,669 @@
+package execsessions
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/components/terminal"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// tab is an exec session with what it was started with
+type tab struct {
+	session *terminal.Session
+	target  models.ExecTarget
+	command string
+	started time.Time
+
+	// fallbacks are the shells tried next when the command is missing
+	fallbacks []string
+
+	// screenBeforeInput is the screen when the first key was sent to the
+	// program. Output after it can come from commands the user ran.
+	screenBeforeInput string
+	inputSent         bool
+}
+
+// sendKey sends a key to the program of the tab, recording the screen before the first key
+func (t *tab) sendKey(msg tea.KeyMsg) error {
+	if !t.inputSent {
+		t.screenBeforeInput = t.session.Screen().String()
+		t.inputSent = true
+	}
+	return t.session.SendKey(msg)
+}
+
+// outputBeforeInput returns what the program printed before any key was sent to it
+func (t *tab) outputBeforeInput() string {
+	if t.inputSent {
+		return t.screenBeforeInput
+	}
+	return t.session.Screen().String()
+}
+
+// ExecPage shows exec sessions, programs run in containers with `az containerapp exec`,
+// in an embedded terminal. Several sessions can be kept open in tabs.
+//
+// While the program of the current tab runs, every key goes to it except the
+// prefix key: the key after it switches, opens or closes tabs, or goes back
+// to the previous view, leaving the sessions running. Once the program has
+// exited those keys work without the prefix.
+//
+// The prompt offers the shells of the target in the order they are tried:
+// the one last started in its container, those configured for its app or
+// image, then DefaultShells. When the chosen shell is missing, the next one is
+// started in its tab.
+type ExecPage struct {
+	*pages.BasePage
+
+	tabs   []tab
+	active int
+
+	// prefixed is set once the prefix key is pressed, the next key is for the page
+	prefixed bool
+
+	// Command prompt shown while choosing the program of a new session
+	prompting    bool
+	promptTarget models.ExecTarget
+	choices      []string // shells of the target
+	choice       int      // index in choices, len(choices) for a custom command
+	customInput  textinput.Model
+
+	// replacing is the tab a restarted session takes the place of, -1 for a new tab
+	replacing int
+	// fallbacks are the shells left to try after the session being started
+	fallbacks []string
+
+	// Shells configured per app and image, and the last started per container
+	shellConfig ShellConfig
+	shells      map[string]string
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys ExecKeyMap
+
+	// Action functions
+	backFunc  func() tea.Cmd
+	startFunc func(models.ExecTarget, string) tea.Cmd
+}
+
+// ExecKeyMap defines the key bindings for the exec sessions page. Every key
+// but Prefix is used after the prefix, or on its own when no program runs.
+type ExecKeyMap struct {
+	Prefix  key.Binding
+	Next    key.Binding
+	Prev    key.Binding
+	Tab     key.Binding
+	New     key.Binding
+	Close   key.Binding
+	Restart key.Binding
+	Back    key.Binding
+	Help    key.Binding
+	Quit    key.Binding
+}
+
+// NewExecPage creates a new exec sessions page
+func NewExecPage(layoutSystem *layouts.LayoutSystem) *ExecPage {
+	customInput := textinput.New()
+	customInput.Placeholder = "command, e.g. /bin/ash or python"
+	customInput.Prompt = ""
+
+	return &ExecPage{
+		BasePage:     pages.NewBasePage(""),
+		customInput:  customInput,
+		replacing:    -1,
+		shells:       map[string]string{},
+		layoutSystem: layoutSystem,
+		keys:         defaultExecKeyMap(),
+	}
+}
+
+// defaultExecKeyMap returns the default key bindings for exec sessions
+func defaultExecKeyMap() ExecKeyMap {
+	return ExecKeyMap{
+		Prefix:  key.NewBinding(key.WithKeys("ctrl+]"), key.WithHelp("ctrl+]", "terminal prefix")),
+		Next:    key.NewBinding(key.WithKeys("n", "right", "tab"), key.WithHelp("n", "next tab")),
+		Prev:    key.NewBinding(key.WithKeys("p", "left", "shift+tab"), key.WithHelp("p", "prev tab")),
+		Tab:     key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "go to tab")),
+		New:     key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "new session")),
+		Close:   key.NewBinding(key.WithKeys("x"), key.WithHelp("x", "close session")),
+		Restart: key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "restart exited session")),
+		Back:    key.NewBinding(key.WithKeys("esc", "d"), key.WithHelp("esc", "back")),
+		Help:    key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+		Quit:    key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+// Configuration methods
+
+// SetBackFunc sets the function to call when navigating back
+func (p *ExecPage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// SetStartFunc sets the function to call to start a session running a command in a target
+func (p *ExecPage) SetStartFunc(fn func(models.ExecTarget, string) tea.Cmd) {
+	p.startFunc = fn
+}
+
+// SetShellConfig sets the shells tried per app and image
+func (p *ExecPage) SetShellConfig(config ShellConfig) {
+	p.shellConfig = config
+}
+
+// Session management methods
+
+// PromptCommand asks for the program of a new session in a target
+func (p *ExecPage) PromptCommand(target models.ExecTarget) tea.Cmd {
+	p.prompting = true
+	p.promptTarget = target
+	p.choices = p.shellChoices(target)
+	p.prefixed = false
+	p.replacing = -1
+	p.fallbacks = nil
+	p.statusMessage = ""
+	return p.setChoice(0)
+}
+
+// shellChoices returns the shells of a target in the order they are tried
+func (p *ExecPage) shellChoices(target models.ExecTarget) []string {
+	shells := p.shellConfig.Shells(target)
+	if last, ok := p.shells[shellKey(target)]; ok {
+		shells = append([]string{last}, slices.DeleteFunc(shells, func(shell string) bool { return shell == last })...)
+	}
+	return shells
+}
+
+// LastShell returns the shell last started in the container of a target
+func (p *ExecPage) LastShell(target models.ExecTarget) (string, bool) {
+	shell, ok := p.shells[shellKey(target)]
+	return shell, ok
+}
+
+// rememberShell records that the command of a tab started, when it is a shell
+func (p *ExecPage) rememberShell(t *tab) {
+	if slices.Contains(p.shellChoices(t.target), t.command) {
+		p.shells[shellKey(t.target)] = t.command
+	}
+}
+
+// IsPrompting reports whether the command prompt is shown
+func (p *ExecPage) IsPrompting() bool {
+	return p.prompting
+}
+
+// AddSession shows a started session in a new tab, or in place of the
+// exited session it restarts, and makes it the current tab
+func (p *ExecPage) AddSession(session *terminal.Session, target models.ExecTarget, command string) {
+	t := tab{session: session, target: target, command: command, started: time.Now(), fallbacks: p.fallbacks}
+	if p.replacing >= 0 && p.replacing < len(p.tabs) {
+		_ = p.tabs[p.replacing].session.Close()
+		p.tabs[p.replacing] = t
+		p.active = p.replacing
+	} else {
+		p.tabs = append(p.tabs, t)
+		p.active = len(p.tabs) - 1
+	}
+	p.replacing = -1
+	p.fallbacks = nil
+	p.prompting = false
+}
+
+// SetStartError reports that a session could not be started
+func (p *ExecPage) SetStartError(target models.ExecTarget, command string, err error) {
+	p.replacing = -1
+	p.fallbacks = nil
+	p.statusMessage = fmt.Sprintf("Failed to run %s in %s: %v", command, target, err)
+}
+
+// Sessions returns the number of open sessions
+func (p *ExecPage) Sessions() int {
+	return len(p.tabs)
+}
+
+// ActiveSession returns the session of the current tab, nil without sessions
+func (p *ExecPage) ActiveSession() *terminal.Session {
+	if p.active < 0 || p.active >= len(p.tabs) {
+		return nil
+	}
+	return p.tabs[p.active].session
+}
+
+// HandleOutput feeds output to its session, returning the command reading
+// more. Output of closed sessions is dropped.
+func (p *ExecPage) HandleOutput(msg terminal.OutputMsg) tea.Cmd {
+	index := p.find(msg.ID)
+	if index < 0 {
+		return nil
+	}
+	session := p.tabs[index].session
+	session.Output(msg.Data)
+	return session.Read()
+}
+
+// SetExited records that the program of a session exited. A shell that turns
+// out to be missing soon after starting, exiting with status 126 or 127 after
+// saying so before any input was sent, is replaced by the next one to try.
+func (p *ExecPage) SetExited(msg terminal.ExitedMsg) tea.Cmd {
+	index := p.find(msg.ID)
+	if index < 0 {
+		return nil
+	}
+	t := &p.tabs[index]
+	t.session.SetExited(msg.Err)
+
+	if time.Since(t.started) < shellFailureWindow && shellExitedMissing(msg.Err) && shellMissing(t.outputBeforeInput()) {
+		if len(t.fallbacks) > 0 && p.startFunc != nil {
+			next := t.fallbacks[0]
+			p.statusMessage = fmt.Sprintf("%s is missing in %s, trying %s", t.command, t.target, next)
+			p.replacing = index
+			p.fallbacks = t.fallbacks[1:]
+			return p.startFunc(t.target, next)
+		}
+	} else {
+		p.rememberShell(t)
+	}
+	p.statusMessage = fmt.Sprintf("%s exited: %s", t.command, exitStatus(msg.Err))
+	return nil
+}
+
+// find returns the index of the tab of a session, -1 once it is closed
+func (p *ExecPage) find(id int) int {
+	for i := range p.tabs {
+		if p.tabs[i].session.ID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// closeTab closes the session of a tab, going back when it was the last one
+func (p *ExecPage) closeTab(index int) tea.Cmd {
+	if index < 0 || index >= len(p.tabs) {
+		return nil
+	}
+	t := p.tabs[index]
+	_ = t.session.Close()
+	p.tabs = append(p.tabs[:index], p.tabs[index+1:]...)
+	p.active = min(p.active, len(p.tabs)-1)
+	p.statusMessage = fmt.Sprintf("Closed %s in %s", t.command, t.target)
+	if len(p.tabs) == 0 {
+		p.active = 0
+		return p.back()
+	}
+	return nil
+}
+
+// selectTab makes a tab current, wrapping around
+func (p *ExecPage) selectTab(index int) {
+	if len(p.tabs) == 0 {
+		return
+	}
+	p.active = (index + len(p.tabs)) % len(p.tabs)
+}
+
+// back returns to the previous view, leaving the sessions running
+func (p *ExecPage) back() tea.Cmd {
+	p.prefixed = false
+	p.prompting = false
+	if p.backFunc != nil {
+		return p.backFunc()
+	}
+	return nil
+}
+
+// ClearData keeps the sessions, which outlive navigation
+func (p *ExecPage) ClearData() {
+	p.BasePage.ClearData()
+	p.prefixed = false
+}
+
+// IsSearching reports whether keys are taken by the page, so that global keys
+// do not apply: while the command prompt is shown or a program runs
+func (p *ExecPage) IsSearching() bool {
+	if p.prompting {
+		return true
+	}
+	session := p.ActiveSession()
+	return session != nil && !session.Exited()
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the exec sessions page
+func (p *ExecPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.prompting {
+		return p.handlePromptKey(msg)
+	}
+
+	if p.prefixed {
+		p.prefixed = false
+		p.statusMessage = ""
+		return p.handlePageKey(msg)
+	}
+	if key.Matches(msg, p.keys.Prefix) {
+		p.prefixed = true
+		return nil, true
+	}
+
+	if session := p.ActiveSession(); session != nil && !session.Exited() {
+		// A shell still running once failures are ruled out has started
+		t := &p.tabs[p.active]
+		if time.Since(t.started) >= shellFailureWindow {
+			p.rememberShell(t)
+		}
+		if err := t.sendKey(msg); err != nil {
+			p.statusMessage = fmt.Sprintf("Failed to send input: %v", err)
+		}
+		return nil, true
+	}
+
+	// Without a running program, page keys work on their own
+	switch {
+	case key.Matches(msg, p.keys.Quit):
+		return tea.Quit, true
+	case key.Matches(msg, p.keys.Restart):
+		if len(p.tabs) == 0 || p.startFunc == nil {
+			return nil, true
+		}
+		t := p.tabs[p.active]
+		p.replacing = p.active
+		p.fallbacks = nil
+		p.statusMessage = ""
+		return p.startFunc(t.target, t.command), true
+	}
+	return p.handlePageKey(msg)
+}
+
+// handlePageKey handles the keys that manage sessions
+func (p *ExecPage) handlePageKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch {
+	case key.Matches(msg, p.keys.Prefix):
+		// The prefix twice sends it to the program
+		if p.ActiveSession() != nil {
+			_ = p.tabs[p.active].sendKey(msg)
+		}
+	case key.Matches(msg, p.keys.Next):
+		p.selectTab(p.active + 1)
+	case key.Matches(msg, p.keys.Prev):
+		p.selectTab(p.active - 1)
+	case key.Matches(msg, p.keys.Tab):
+		if index := int(msg.Runes[0] - '1'); index < len(p.tabs) {
+			p.active = index
+		}
+	case key.Matches(msg, p.keys.New):
+		if len(p.tabs) > 0 {
+			return p.PromptCommand(p.tabs[p.active].target), true
+		}
+	case key.Matches(msg, p.keys.Close):
+		return p.closeTab(p.active), true
+	case key.Matches(msg, p.keys.Back):
+		return p.back(), true
+	case key.Matches(msg, p.keys.Help):
+		return nil, false
+	}
+	return nil, true
+}
+
+// handlePromptKey handles key input while the program of a new session is chosen
+func (p *ExecPage) handlePromptKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	custom := p.choice == len(p.choices)
+	switch msg.String() {
+	case "esc":
+		p.prompting = false
+		p.customInput.Blur()
+		if len(p.tabs) == 0 {
+			return p.back(), true
+		}
+		return nil, true
+	case "enter":
+		command := p.command()
+		if command == "" {
+			return nil, true
+		}
+		p.customInput.Blur()
+		if p.startFunc == nil {
+			return nil, true
+		}
+		if !custom {
+			// Shells after the chosen one are tried when it is missing
+			p.fallbacks = p.choices[p.choice+1:]
+		}
+		p.statusMessage = ""
+		return p.startFunc(p.promptTarget, command), true
+	case "up", "shift+tab":
+		p.setChoice(p.choice - 1)
+		return nil, true
+	case "down", "tab":
+		return p.setChoice(p.choice + 1), true
+	case "k":
+		if !custom {
+			p.setChoice(p.choice - 1)
+			return nil, true
+		}
+	case "j":
+		if !custom {
+			return p.setChoice(p.choice + 1), true
+		}
+	}
+
+	if !custom {
+		return nil, true
+	}
+	var cmd tea.Cmd
+	p.customInput, cmd = p.customInput.Update(msg)
+	return cmd, true
+}
+
+// setChoice moves the prompt to a command, focusing the input of the custom one
+func (p *ExecPage) setChoice(choice int) tea.Cmd {
+	p.choice = min(max(choice, 0), len(p.choices))
+	if p.choice == len(p.choices) {
+		p.customInput.Focus()
+		return textinput.Blink
+	}
+	p.customInput.Blur()
+	return nil
+}
+
+// command returns the command chosen in the prompt
+func (p *ExecPage) command() string {
+	if p.choice < len(p.choices) {
+		return p.choices[p.choice]
+	}
+	return strings.TrimSpace(p.customInput.Value())
+}
+
+// GetHelpKeys returns the help keys for the exec sessions page
+func (p *ExecPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Prefix,
+		p.keys.Next,
+		p.keys.Prev,
+		p.keys.Tab,
+		p.keys.New,
+		p.keys.Close,
+		p.keys.Restart,
+		p.keys.Back,
+		p.keys.Help,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the exec sessions page
+func (p *ExecPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeExec,
+	})
+}
+
+// ViewWithHelpContext renders the exec sessions page with help context
+func (p *ExecPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeExec
+
+	statusContext := p.statusContext()
+	width, height := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: statusContext,
+		HelpContext:   helpContext,
+	})
+
+	rows := []string{p.renderTabs(width)}
+	if p.prompting {
+		rows = append(rows, p.renderPrompt(width))
+	} else if session := p.ActiveSession(); session != nil {
+		// The terminal follows the space left by the tab, status and help bars
+		_ = session.Resize(width, height-1)
+		rows = append(rows, session.Screen().View(!session.Exited()))
+	}
+
+	return p.layoutSystem.CreateTableLayout(
+		lipgloss.JoinVertical(lipgloss.Left, rows...),
+		statusContext,
+		helpContext,
+	)
+}
+
+// TerminalSize returns the size of the terminal of a new session
+func (p *ExecPage) TerminalSize() (cols, rows int) {
+	width, height := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: p.statusContext(),
+		HelpContext:   layouts.HelpContext{Mode: layouts.ModeExec},
+	})
+	return width, height - 1
+}
+
+// statusContext describes the current session for the status bar
+func (p *ExecPage) statusContext() layouts.StatusContext {
+	info := map[string]string{}
+	target, command := p.promptTarget, p.command()
+	if !p.prompting && len(p.tabs) > 0 {
+		t := p.tabs[p.active]
+		target, command = t.target, t.command
+		if t.session.Exited() {
+			command += " (exited)"
+		}
+	}
+	if target.App.Name != "" {
+		info["app"] = target.App.Name
+		if target.Revision != "" {
+			info["revision"] = target.Revision
+		}
+		if target.Replica != "" {
+			info["replica"] = target.Replica
+		}
+		if target.Container != "" {
+			info["container"] = target.Container
+		}
+		info["command"] = command
+	}
+
+	return layouts.StatusContext{
+		Mode:          layouts.ModeExec,
+		ContextInfo:   info,
+		Counters:      map[string]int{"session": len(p.tabs)},
+		StatusMessage: p.statusMessage,
+	}
+}
+
+var (
+	// tabStyle is the style of the tabs of the sessions
+	tabStyle = lipgloss.NewStyle().Padding(0, 1)
+	// activeTabStyle is the style of the tab of the session shown
+	activeTabStyle = tabStyle.Bold(true).Reverse(true)
+	// exitedTabStyle is the style of the tabs of sessions whose program exited
+	exitedTabStyle = tabStyle.Foreground(lipgloss.Color("#666666"))
+	// prefixStyle is the style of the keys shown after the prefix key
+	prefixStyle = tabStyle.Foreground(lipgloss.Color("205"))
+)
+
+// prefixHint lists the keys available after the prefix key
+const prefixHint = "ctrl+] n/p: tab • 1-9: go to tab • c: new • x: close • esc: back"
+
+// renderTabs renders a tab per session, numbered for the tab keys, followed by
+// the keys available once the prefix key is pressed
+func (p *ExecPage) renderTabs(width int) string {
+	if len(p.tabs) == 0 {
+		return tabStyle.Render("No sessions")
+	}
+	items := make([]string, len(p.tabs), len(p.tabs)+1)
+	for i, t := range p.tabs {
+		label := fmt.Sprintf("%d %s", i+1, tabLabel(t))
+		style := tabStyle
+		switch {
+		case i == p.active && !p.prompting:
+			style = activeTabStyle
+		case t.session.Exited():
+			style = exitedTabStyle
+		}
+		if t.session.Exited() {
+			label += " ✗"
+		}
+		items[i] = style.Render(label)
+	}
+	if p.prefixed {
+		items = append(items, prefixStyle.Render(prefixHint))
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(lipgloss.JoinHorizontal(lipgloss.Top, items...))
+}
+
+// tabLabel names a session by its app, container and the program run
+func tabLabel(t tab) string {
+	name := t.target.App.Name
+	if t.target.Container != "" {
+		name += "/" + t.target.Container
+	}
+	return name + " " + path.Base(strings.Fields(t.command)[0])
+}
+
+// renderPrompt renders the choice of the program of a new session
+func (p *ExecPage) renderPrompt(width int) string {
+	lines := []string{"", fmt.Sprintf("  Run in %s:", p.promptTarget), ""}
+	last, _ := p.LastShell(p.promptTarget)
+	for i, command := range append(slices.Clone(p.choices), "custom: ") {
+		marker := "    "
+		if i == p.choice {
+			marker = "  > "
+		}
+		switch {
+		case i == len(p.choices):
+			p.customInput.Width = max(1, width-len(marker)-len(command)-1)
+			command += p.customInput.View()
+		case command == last:
+			command += " (last used)"
+		}
+		lines = append(lines, marker+command)
+	}
+	lines = append(lines,
+		"",
+		"  When a shell is missing, the shells below it are tried in turn.",
+		"  enter: start • ↑/↓: choose • esc: cancel",
+	)
+	return strings.Join(lines, "\n")
+}
+
+// exitStatus describes how a program exited
+func exitStatus(err error) string {
+	if err == nil {
+		return "exit status 0"
+	}
+	return err.Error()
+}
@@ -0,0 +1,354 @@
+package execsessions
+
+import (
+	"errors"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/components/terminal"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestTarget(container string) models.ExecTarget {
+	return models.ExecTarget{
+		App:       models.ContainerApp{Name: "web-frontend", ResourceGroup: "rg-prod"},
+		Revision:  "web-frontend--abc123",
+		Container: container,
+	}
+}
+
+// startedCall records a call of the start function
+type startedCall struct {
+	target  models.ExecTarget
+	command string
+}
+
+// createTestPage creates a page recording the sessions started and whether it went back
+func createTestPage() (*ExecPage, *[]startedCall, *bool) {
+	page := NewExecPage(layouts.NewLayoutSystem(120, 30))
+	started := &[]startedCall{}
+	back := new(bool)
+	page.SetStartFunc(func(target models.ExecTarget, command string) tea.Cmd {
+		*started = append(*started, startedCall{target, command})
+		return nil
+	})
+	page.SetBackFunc(func() tea.Cmd {
+		*back = true
+		return nil
+	})
+	return page, started, back
+}
+
+// addTestSession adds a session running a program that waits for input
+func addTestSession(t *testing.T, page *ExecPage, id int, target models.ExecTarget) *terminal.Session {
+	t.Helper()
+	return addScriptSession(t, page, id, target, "/bin/sh", "cat")
+}
+
+// addScriptSession adds a session of a command, running a script in its place
+func addScriptSession(t *testing.T, page *ExecPage, id int, target models.ExecTarget, command, script string) *terminal.Session {
+	t.Helper()
+	session, err := terminal.Start(id, target.String(), exec.Command("sh", "-c", script), 80, 24)
+	if errors.Is(err, terminal.ErrUnsupported) {
+		t.Skip("Terminals are not supported on this platform")
+	}
+	if err != nil {
+		t.Fatalf("Failed to start session: %v", err)
+	}
+	t.Cleanup(func() { _ = session.Close() })
+	page.AddSession(session, target, command)
+	return session
+}
+
+// runToExit delivers the output of a session to the page until its program exits
+func runToExit(page *ExecPage, session *terminal.Session) tea.Cmd {
+	for {
+		switch msg := session.Read()().(type) {
+		case terminal.OutputMsg:
+			page.HandleOutput(msg)
+		case terminal.ExitedMsg:
+			return page.SetExited(msg)
+		}
+	}
+}
+
+func keyMsg(k string) tea.KeyMsg {
+	switch k {
+	case "ctrl+]":
+		return tea.KeyMsg{Type: tea.KeyCtrlCloseBracket}
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "esc":
+		return tea.KeyMsg{Type: tea.KeyEsc}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+// Test choosing the program of a new session
+func TestExecPagePrompt(t *testing.T) {
+	page, started, _ := createTestPage()
+	target := createTestTarget("api")
+
+	page.PromptCommand(target)
+	if !page.IsPrompting() || !page.IsSearching() {
+		t.Fatal("Expected the prompt to take the keys")
+	}
+	if view := page.View(); !strings.Contains(view, "/bin/bash") || !strings.Contains(view, "custom:") {
+		t.Errorf("Expected the commands in the prompt, got %q", view)
+	}
+
+	page.HandleKeyMsg(keyMsg("j"))
+	page.HandleKeyMsg(keyMsg("enter"))
+	if len(*started) != 1 || (*started)[0].command != "/bin/bash" || (*started)[0].target != target {
+		t.Fatalf("Expected /bin/bash to be started in the target, got %+v", *started)
+	}
+
+	// Keys type into the custom command, including j and k
+	page.PromptCommand(target)
+	for range DefaultShells {
+		page.HandleKeyMsg(keyMsg("down"))
+	}
+	for _, r := range "python -jk" {
+		page.HandleKeyMsg(keyMsg(string(r)))
+	}
+	page.HandleKeyMsg(keyMsg("enter"))
+	if len(*started) != 2 || (*started)[1].command != "python -jk" {
+		t.Fatalf("Expected the custom command to be started, got %+v", *started)
+	}
+}
+
+// Test that cancelling the prompt goes back when no session is open
+func TestExecPagePromptCancel(t *testing.T) {
+	page, started, back := createTestPage()
+	page.PromptCommand(createTestTarget(""))
+
+	page.HandleKeyMsg(keyMsg("esc"))
+	if page.IsPrompting() || !*back {
+		t.Error("Expected esc to close the prompt and go back")
+	}
+	if len(*started) != 0 {
+		t.Errorf("Expected no session to be started, got %+v", *started)
+	}
+}
+
+// Test switching and closing tabs after the prefix key
+func TestExecPageTabs(t *testing.T) {
+	page, started, back := createTestPage()
+	first := addTestSession(t, page, 1, createTestTarget("api"))
+	second := addTestSession(t, page, 2, createTestTarget("worker"))
+
+	if page.Sessions() != 2 || page.ActiveSession() != second {
+		t.Fatal("Expected the last session to be shown")
+	}
+	if !page.IsSearching() {
+		t.Error("Expected a running session to take the keys")
+	}
+	view := page.View()
+	if !strings.Contains(view, "1 web-frontend/api sh") || !strings.Contains(view, "2 web-frontend/worker sh") {
+		t.Errorf("Expected a tab per session, got %q", view)
+	}
+
+	// Without the prefix keys go to the program
+	if _, handled := page.HandleKeyMsg(keyMsg("p")); !handled || page.ActiveSession() != second {
+		t.Error("Expected p to be sent to the program")
+	}
+
+	page.HandleKeyMsg(keyMsg("ctrl+]"))
+	if view := page.View(); !strings.Contains(view, prefixHint) {
+		t.Error("Expected the prefix keys to be shown")
+	}
+	page.HandleKeyMsg(keyMsg("p"))
+	if page.ActiveSession() != first {
+		t.Error("Expected ctrl+] p to show the previous tab")
+	}
+	page.HandleKeyMsg(keyMsg("ctrl+]"))
+	page.HandleKeyMsg(keyMsg("2"))
+	if page.ActiveSession() != second {
+		t.Error("Expected ctrl+] 2 to show the second tab")
+	}
+
+	// A new session is prompted for in the target of the current tab
+	page.HandleKeyMsg(keyMsg("ctrl+]"))
+	page.HandleKeyMsg(keyMsg("c"))
+	if !page.IsPrompting() || page.promptTarget.Container != "worker" {
+		t.Error("Expected ctrl+] c to prompt for a session in the current container")
+	}
+	page.HandleKeyMsg(keyMsg("esc"))
+	if page.IsPrompting() || *back || len(*started) != 0 {
+		t.Error("Expected esc to return to the open sessions")
+	}
+
+	page.HandleKeyMsg(keyMsg("ctrl+]"))
+	page.HandleKeyMsg(keyMsg("x"))
+	if page.Sessions() != 1 || page.ActiveSession() != first || *back {
+		t.Error("Expected ctrl+] x to close the current tab")
+	}
+	page.HandleKeyMsg(keyMsg("ctrl+]"))
+	page.HandleKeyMsg(keyMsg("x"))
+	if page.Sessions() != 0 || !*back {
+		t.Error("Expected closing the last tab to go back")
+	}
+}
+
+// Test output, exit and restart of a session
+func TestExecPageExitedSession(t *testing.T) {
+	page, started, _ := createTestPage()
+	target := createTestTarget("api")
+	addTestSession(t, page, 7, target)
+
+	if cmd := page.HandleOutput(terminal.OutputMsg{ID: 7, Data: []byte("hello")}); cmd == nil {
+		t.Error("Expected more output to be read")
+	}
+	if cmd := page.HandleOutput(terminal.OutputMsg{ID: 8, Data: []byte("closed")}); cmd != nil {
+		t.Error("Expected output of unknown sessions to be dropped")
+	}
+	if view := page.View(); !strings.Contains(view, "hello") {
+		t.Errorf("Expected the output in the terminal, got %q", view)
+	}
+
+	page.SetExited(terminal.ExitedMsg{ID: 7, Err: errors.New("exit status 1")})
+	if page.IsSearching() {
+		t.Error("Expected an exited session to release the keys")
+	}
+	if !strings.Contains(page.statusMessage, "exit status 1") {
+		t.Errorf("Expected the exit status, got %q", page.statusMessage)
+	}
+
+	page.HandleKeyMsg(keyMsg("enter"))
+	if len(*started) != 1 || (*started)[0].target != target || (*started)[0].command != "/bin/sh" {
+		t.Fatalf("Expected enter to restart the session, got %+v", *started)
+	}
+	if page.replacing != 0 {
+		t.Errorf("Expected the restarted session to replace the tab, got %d", page.replacing)
+	}
+	if cmd, _ := page.HandleKeyMsg(keyMsg("q")); cmd == nil {
+		t.Error("Expected q to quit once the program exited")
+	}
+}
+
+// Test that missing shells are replaced by the next one and the one started is remembered
+func TestExecPageShellFallback(t *testing.T) {
+	page, started, _ := createTestPage()
+	config, err := ParseShellConfig("myregistry.azurecr.io/=/bin/bash|/bin/sh")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	page.SetShellConfig(config)
+	target := createTestTarget("api")
+	target.Image = "myregistry.azurecr.io/api-backend:v1.8"
+
+	page.PromptCommand(target)
+	page.HandleKeyMsg(keyMsg("enter"))
+	if len(*started) != 1 || (*started)[0].command != "/bin/bash" {
+		t.Fatalf("Expected the configured shell to be started, got %+v", *started)
+	}
+	session := addScriptSession(t, page, 1, target, "/bin/bash",
+		`echo 'exec failed: "/bin/bash": stat /bin/bash: no such file or directory'; exit 126`)
+	runToExit(page, session)
+	if len(*started) != 2 || (*started)[1].command != "/bin/sh" || page.replacing != 0 {
+		t.Fatalf("Expected /bin/sh to be tried in the same tab, got %+v", *started)
+	}
+	if !strings.Contains(page.statusMessage, "trying /bin/sh") {
+		t.Errorf("Expected the fallback in the status, got %q", page.statusMessage)
+	}
+
+	session = addScriptSession(t, page, 2, target, "/bin/sh", "echo ok")
+	if page.Sessions() != 1 || page.ActiveSession() != session {
+		t.Fatal("Expected the fallback to replace the tab")
+	}
+	runToExit(page, session)
+	if len(*started) != 2 {
+		t.Errorf("Expected no more fallbacks, got %+v", *started)
+	}
+	if shell, ok := page.LastShell(target); !ok || shell != "/bin/sh" {
+		t.Errorf("Expected /bin/sh to be remembered, got %q", shell)
+	}
+
+	// The remembered shell is offered first in any revision of the container
+	target.Revision = "web-frontend--def456"
+	page.PromptCommand(target)
+	if page.command() != "/bin/sh" {
+		t.Errorf("Expected the remembered shell to be chosen, got %q", page.command())
+	}
+	if view := page.View(); !strings.Contains(view, "/bin/sh (last used)") {
+		t.Errorf("Expected the remembered shell to be marked in %q", view)
+	}
+}
+
+// Test that custom commands and programs exiting later do not fall back
+func TestExecPageNoFallback(t *testing.T) {
+	page, started, _ := createTestPage()
+	target := createTestTarget("api")
+
+	page.PromptCommand(target)
+	for range DefaultShells {
+		page.HandleKeyMsg(keyMsg("down"))
+	}
+	for _, r := range "/bin/zsh" {
+		page.HandleKeyMsg(keyMsg(string(r)))
+	}
+	page.HandleKeyMsg(keyMsg("enter"))
+	session := addScriptSession(t, page, 1, target, "/bin/zsh", "echo 'zsh: not found'; exit 127")
+	runToExit(page, session)
+	if len(*started) != 1 {
+		t.Errorf("Expected a custom command not to fall back, got %+v", *started)
+	}
+	if _, ok := page.LastShell(target); ok {
+		t.Error("Expected a missing command not to be remembered")
+	}
+
+	// A shell exiting once it ran for a while was found
+	page.PromptCommand(target)
+	page.HandleKeyMsg(keyMsg("enter"))
+	session = addScriptSession(t, page, 2, target, "/bin/sh", "echo 'ls: /nope: No such file or directory'")
+	page.tabs[page.active].started = time.Now().Add(-shellFailureWindow)
+	runToExit(page, session)
+	if len(*started) != 2 {
+		t.Errorf("Expected no fallback after the failure window, got %+v", *started)
+	}
+	if shell, ok := page.LastShell(target); !ok || shell != "/bin/sh" {
+		t.Errorf("Expected /bin/sh to be remembered, got %q", shell)
+	}
+}
+
+// Test that a command missing after the user typed it does not make the shell fall back
+func TestExecPageNoFallbackAfterInput(t *testing.T) {
+	page, started, _ := createTestPage()
+	target := createTestTarget("api")
+
+	page.PromptCommand(target)
+	page.HandleKeyMsg(keyMsg("enter"))
+	if len(*started) != 1 || page.fallbacks == nil {
+		t.Fatalf("Expected a shell with fallbacks to be started, got %+v", *started)
+	}
+	session := addScriptSession(t, page, 1, target, (*started)[0].command,
+		`printf 'ready$ '; read line; echo "sh: $line: not found"; exit 127`)
+
+	// Wait for the prompt before typing
+	for !strings.Contains(session.Screen().String(), "ready$") {
+		if msg, ok := session.Read()().(terminal.OutputMsg); ok {
+			page.HandleOutput(msg)
+		}
+	}
+	for _, k := range []string{"f", "o", "o", "enter"} {
+		page.HandleKeyMsg(keyMsg(k))
+	}
+	runToExit(page, session)
+
+	if !strings.Contains(session.Screen().String(), "foo: not found") {
+		t.Fatalf("Expected the command to be missing, got %q", session.Screen().String())
+	}
+	if len(*started) != 1 {
+		t.Errorf("Expected no fallback after input, got %+v", *started)
+	}
+	if shell, ok := page.LastShell(target); !ok || shell != (*started)[0].command {
+		t.Errorf("Expected %s to be remembered, got %q", (*started)[0].command, shell)
+	}
+}
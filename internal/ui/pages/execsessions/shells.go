@@ -0,0 +1,126 @@
+package execsessions
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// DefaultShells are the shells tried in order when none is configured for a target
+var DefaultShells = []string{"/bin/sh", "/bin/bash", "/busybox/sh"}
+
+// shellFailureWindow is how long after starting a session its program exiting
+// can mean the shell is missing from the container. Sessions running longer
+// are considered to have started their shell.
+const shellFailureWindow = 15 * time.Second
+
+// missingShellMessages are printed by the container runtime or a shell when
+// the program to run does not exist, matched case insensitively
+var missingShellMessages = []string{"no such file or directory", "not found", "exec failed"}
+
+// ShellRule lists the shells to try, in order, in the containers of an app or
+// in containers whose image starts with a prefix
+type ShellRule struct {
+	App         string
+	ImagePrefix string
+	Shells      []string
+}
+
+// ShellConfig chooses the shells tried in a target
+type ShellConfig struct {
+	Rules []ShellRule
+}
+
+// ParseShellConfig reads rules from a specification such as
+// "app:api=/bin/bash,gcr.io/distroless/=/busybox/sh|/bin/sh", where a rule is
+// for an app when prefixed by "app:" and for an image prefix otherwise, and
+// alternatives are separated by "|".
+func ParseShellConfig(spec string) (ShellConfig, error) {
+	var config ShellConfig
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		match, shells, ok := strings.Cut(part, "=")
+		match = strings.TrimSpace(match)
+		if !ok || match == "" || strings.TrimSpace(shells) == "" {
+			return config, fmt.Errorf("invalid exec shell rule %q, expected image-prefix=shell or app:name=shell", part)
+		}
+		rule := ShellRule{ImagePrefix: match}
+		if app, ok := strings.CutPrefix(match, "app:"); ok {
+			rule = ShellRule{App: strings.TrimSpace(app)}
+		}
+		for _, shell := range strings.Split(shells, "|") {
+			if shell = strings.TrimSpace(shell); shell != "" {
+				rule.Shells = append(rule.Shells, shell)
+			}
+		}
+		config.Rules = append(config.Rules, rule)
+	}
+	return config, nil
+}
+
+// Shells returns the shells to try in a target: those of the rule for its
+// app, otherwise those of the rule with the longest prefix of its image,
+// followed by the default shells not listed
+func (c ShellConfig) Shells(target models.ExecTarget) []string {
+	var match *ShellRule
+	for i, rule := range c.Rules {
+		switch {
+		case rule.App != "":
+			if strings.EqualFold(rule.App, target.App.Name) {
+				return withDefaults(rule.Shells)
+			}
+		case target.Image != "" && strings.HasPrefix(target.Image, rule.ImagePrefix):
+			if match == nil || len(rule.ImagePrefix) > len(match.ImagePrefix) {
+				match = &c.Rules[i]
+			}
+		}
+	}
+	if match != nil {
+		return withDefaults(match.Shells)
+	}
+	return withDefaults(nil)
+}
+
+// withDefaults appends the default shells missing from a list
+func withDefaults(shells []string) []string {
+	all := slices.Clone(shells)
+	for _, shell := range DefaultShells {
+		if !slices.Contains(all, shell) {
+			all = append(all, shell)
+		}
+	}
+	return all
+}
+
+// shellKey identifies the container a shell is remembered for. Revisions and
+// replicas come and go, so only the app and the container name are kept.
+func shellKey(target models.ExecTarget) string {
+	return target.App.ResourceGroup + "/" + target.App.Name + "/" + target.Container
+}
+
+// shellExitedMissing reports whether a program exited with the status a shell
+// exits with when the program it runs cannot be run (126) or is not found (127)
+func shellExitedMissing(err error) bool {
+	var exitErr *exec.ExitError
+	return errors.As(err, &exitErr) && (exitErr.ExitCode() == 126 || exitErr.ExitCode() == 127)
+}
+
+// shellMissing reports whether the output of a program that exited says the
+// shell it was to run does not exist
+func shellMissing(output string) bool {
+	output = strings.ToLower(output)
+	for _, message := range missingShellMessages {
+		if strings.Contains(output, message) {
+			return true
+		}
+	}
+	return false
+}
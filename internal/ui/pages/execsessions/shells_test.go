@@ -0,0 +1,51 @@
+package execsessions
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+func TestParseShellConfig(t *testing.T) {
+	config, err := ParseShellConfig("app:api=/bin/bash, myregistry.azurecr.io/=/bin/ash|/bin/sh, myregistry.azurecr.io/web-=/busybox/sh")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		target models.ExecTarget
+		want   []string
+	}{
+		{"app", models.ExecTarget{App: models.ContainerApp{Name: "API"}, Image: "myregistry.azurecr.io/web-frontend:v2"}, []string{"/bin/bash", "/bin/sh", "/busybox/sh"}},
+		{"longest image prefix", models.ExecTarget{App: models.ContainerApp{Name: "web"}, Image: "myregistry.azurecr.io/web-frontend:v2"}, []string{"/busybox/sh", "/bin/sh", "/bin/bash"}},
+		{"image prefix", models.ExecTarget{App: models.ContainerApp{Name: "worker"}, Image: "myregistry.azurecr.io/worker:v1"}, []string{"/bin/ash", "/bin/sh", "/bin/bash", "/busybox/sh"}},
+		{"unknown image", models.ExecTarget{App: models.ContainerApp{Name: "worker"}}, DefaultShells},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := config.Shells(tt.target); !slices.Equal(got, tt.want) {
+				t.Errorf("Expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+
+	for _, spec := range []string{"/bin/bash", "app:api=", "=/bin/sh"} {
+		if _, err := ParseShellConfig(spec); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
+
+func TestShellMissing(t *testing.T) {
+	for output, want := range map[string]bool{
+		`OCI runtime exec failed: exec failed: unable to start container process: exec: "/bin/bash": stat /bin/bash: no such file or directory: unknown`: true,
+		"sh: 1: exec: /busybox/sh: not found": true,
+		"root@web-frontend:/app# exit":        false,
+	} {
+		if got := shellMissing(output); got != want {
+			t.Errorf("Expected %v for %q, got %v", want, output, got)
+		}
+	}
+}
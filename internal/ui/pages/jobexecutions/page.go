@@ -0,0 +1,372 @@
+package jobexecutions
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// JobExecutionsPage represents the job executions page using the new page interface system.
+// It displays the executions of a single container app job in an actionable table format.
+type JobExecutionsPage struct {
+	*pages.ActionablePage[models.JobExecution]
+
+	// Navigation context
+	jobName string
+	jobID   string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys JobExecutionsKeyMap
+
+	// Action functions
+	startJobFunc       func() tea.Cmd
+	stopExecutionFunc  func(models.JobExecution) tea.Cmd
+	rerunExecutionFunc func(models.JobExecution) tea.Cmd
+
+	// Navigation functions
+	backToJobsFunc func() tea.Cmd
+
+	// now returns the current time used for running durations; overridable for tests
+	now func() time.Time
+}
+
+// JobExecutionsKeyMap defines the key bindings for the job executions page
+type JobExecutionsKeyMap struct {
+	Start       key.Binding
+	Stop        key.Binding
+	Rerun       key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewJobExecutionsPage creates a new job executions page
+func NewJobExecutionsPage(layoutSystem *layouts.LayoutSystem) *JobExecutionsPage {
+	// Create the base actionable page
+	basePage := pages.NewActionablePage[models.JobExecution]("Filter job executions...")
+
+	// Create the job executions page
+	page := &JobExecutionsPage{
+		ActionablePage: basePage,
+		layoutSystem:   layoutSystem,
+		keys:           defaultJobExecutionsKeyMap(),
+		now:            time.Now,
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createJobExecutionsTable)
+
+	// Set up actions
+	page.setupActions()
+
+	return page
+}
+
+// defaultJobExecutionsKeyMap returns the default key bindings for job executions
+func defaultJobExecutionsKeyMap() JobExecutionsKeyMap {
+	return JobExecutionsKeyMap{
+		Start: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "start execution"),
+		),
+		Stop: key.NewBinding(
+			key.WithKeys("X"),
+			key.WithHelp("X", "stop"),
+		),
+		Rerun: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "re-run"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetJobContext sets the job context for the job executions page
+func (p *JobExecutionsPage) SetJobContext(jobName, jobID string) {
+	p.jobName = jobName
+	p.jobID = jobID
+}
+
+// SetStartJobFunc sets the function to call for starting a new execution of the job
+func (p *JobExecutionsPage) SetStartJobFunc(fn func() tea.Cmd) {
+	p.startJobFunc = fn
+}
+
+// SetStopExecutionFunc sets the function to call for stopping an execution
+func (p *JobExecutionsPage) SetStopExecutionFunc(fn func(models.JobExecution) tea.Cmd) {
+	p.stopExecutionFunc = fn
+}
+
+// SetRerunExecutionFunc sets the function to call for re-running an execution
+func (p *JobExecutionsPage) SetRerunExecutionFunc(fn func(models.JobExecution) tea.Cmd) {
+	p.rerunExecutionFunc = fn
+}
+
+// SetBackToJobsFunc sets the function to call when going back to jobs
+func (p *JobExecutionsPage) SetBackToJobsFunc(fn func() tea.Cmd) {
+	p.backToJobsFunc = fn
+	p.SetBackFunc(fn)
+}
+
+// Action setup
+
+// setupActions configures the available actions for the job executions page.
+// Starting a new execution does not depend on the selected row and is handled in HandleKeyMsg.
+func (p *JobExecutionsPage) setupActions() {
+	// Add stop action, confirmed first since the execution cannot be resumed
+	p.AddDestructiveAction("stop", p.keys.Stop, p.confirmStop, func(execution models.JobExecution) tea.Cmd {
+		if p.stopExecutionFunc != nil {
+			return p.stopExecutionFunc(execution)
+		}
+		return nil
+	})
+
+	// Add re-run action
+	p.AddAction("rerun", p.keys.Rerun, func(execution models.JobExecution) tea.Cmd {
+		if p.rerunExecutionFunc != nil {
+			return p.rerunExecutionFunc(execution)
+		}
+		return nil
+	})
+}
+
+// confirmStop builds the confirmation request for stopping an execution
+func (p *JobExecutionsPage) confirmStop(execution models.JobExecution) pages.ConfirmRequestMsg {
+	resourceGroup, _, _ := strings.Cut(p.jobID, "/")
+	return pages.ConfirmRequestMsg{
+		Text:          fmt.Sprintf("Stop execution %s of %s?", execution.Name, p.jobName),
+		Resource:      p.jobName,
+		ResourceGroup: resourceGroup,
+	}
+}
+
+// Table creation methods
+
+// createJobExecutionsTable creates a table for displaying job executions
+func (p *JobExecutionsPage) createJobExecutionsTable(data []models.JobExecution) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("name", "Execution", 15, true).    // Dynamic width, min 15
+		AddColumn("status", "Status", 12, true).     // Fixed width
+		AddColumn("started", "Started", 20, false).  // Fixed width
+		AddColumn("ended", "Ended", 20, false).      // Fixed width
+		AddColumn("duration", "Duration", 10, false) // Fixed width
+
+	// Update dynamic column widths based on actual content
+	for _, exec := range data {
+		builder.UpdateWidthFromString("name", exec.Name)
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, exec := range data {
+			status := exec.Status
+			if status == "" {
+				status = "Unknown"
+			}
+
+			started := "-"
+			if !exec.StartTime.IsZero() {
+				started = exec.StartTime.Format("2006-01-02 15:04:05")
+			}
+
+			ended := "-"
+			if !exec.EndTime.IsZero() {
+				ended = exec.EndTime.Format("2006-01-02 15:04:05")
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"name":     exec.Name,
+				"status":   table.NewStyledCell(status, lipgloss.NewStyle().Foreground(pages.GetStatusColor(status))),
+				"started":  started,
+				"ended":    ended,
+				"duration": p.formatDuration(exec),
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config).SortByDesc("started")
+}
+
+// formatDuration returns how long an execution ran, or has been running so far
+func (p *JobExecutionsPage) formatDuration(exec models.JobExecution) string {
+	if exec.StartTime.IsZero() {
+		return "-"
+	}
+
+	end := exec.EndTime
+	if end.IsZero() {
+		end = p.now()
+	}
+
+	duration := end.Sub(exec.StartTime)
+	if duration < 0 {
+		return "-"
+	}
+	return duration.Round(time.Second).String()
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the job executions page
+func (p *JobExecutionsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// First, try base actionable page key handling
+	if cmd, handled := p.ActionablePage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	// Handle job executions-specific keys
+	switch msg.String() {
+	case "S":
+		if p.startJobFunc != nil {
+			return p.startJobFunc(), true
+		}
+		return nil, true
+	case "esc":
+		if p.backToJobsFunc != nil {
+			return p.backToJobsFunc(), true
+		}
+		return nil, true
+	case "?":
+		// Help toggle - let the parent handle this
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// GetHelpKeys returns the help keys for the job executions page
+func (p *JobExecutionsPage) GetHelpKeys() []key.Binding {
+	baseKeys := []key.Binding{
+		p.keys.Start,
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+
+	// Add action keys
+	actionKeys := p.GetActionKeys()
+	return append(baseKeys, actionKeys...)
+}
+
+// View rendering methods
+
+// View renders the job executions page
+func (p *JobExecutionsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeJobExecutions,
+	})
+}
+
+// ViewWithHelpContext renders the job executions page with help context
+func (p *JobExecutionsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeJobExecutions
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading job executions...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeJobExecutions,
+				ContextInfo: map[string]string{"job": p.jobName},
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeJobExecutions,
+				Error:       err,
+				ContextInfo: map[string]string{"job": p.jobName},
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:        layouts.ModeJobExecutions,
+			ContextInfo: map[string]string{"job": p.jobName},
+			Counters:    map[string]int{"count": len(p.GetData())},
+		},
+		helpContext,
+	)
+}
@@ -0,0 +1,154 @@
+package jobexecutions
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestExecutions() []models.JobExecution {
+	start := time.Date(2024, 1, 22, 10, 0, 0, 0, time.UTC)
+	return []models.JobExecution{
+		{
+			Name:      "nightly-report-prod-r4v7c0a",
+			Status:    "Running",
+			StartTime: start,
+		},
+		{
+			Name:      "nightly-report-prod-7hq3m2p",
+			Status:    "Failed",
+			StartTime: start.Add(-24 * time.Hour),
+			EndTime:   start.Add(-24*time.Hour + 90*time.Second),
+		},
+	}
+}
+
+// Test duration formatting for running and finished executions
+func TestJobExecutionsPageDuration(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewJobExecutionsPage(layoutSystem)
+	page.now = func() time.Time { return time.Date(2024, 1, 22, 10, 5, 0, 0, time.UTC) }
+
+	executions := createTestExecutions()
+
+	if got := page.formatDuration(executions[0]); got != "5m0s" {
+		t.Errorf("Expected running duration 5m0s, got %s", got)
+	}
+	if got := page.formatDuration(executions[1]); got != "1m30s" {
+		t.Errorf("Expected finished duration 1m30s, got %s", got)
+	}
+	if got := page.formatDuration(models.JobExecution{Name: "pending"}); got != "-" {
+		t.Errorf("Expected placeholder for execution without start time, got %s", got)
+	}
+}
+
+// Test action handling
+func TestJobExecutionsPageActions(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewJobExecutionsPage(layoutSystem)
+	page.SetData(createTestExecutions())
+
+	var stopped, rerun string
+	started := false
+	page.SetStartJobFunc(func() tea.Cmd {
+		started = true
+		return nil
+	})
+	page.SetStopExecutionFunc(func(execution models.JobExecution) tea.Cmd {
+		stopped = execution.Name
+		return nil
+	})
+	page.SetRerunExecutionFunc(func(execution models.JobExecution) tea.Cmd {
+		rerun = execution.Name
+		return nil
+	})
+
+	tests := []struct {
+		name string
+		key  string
+	}{
+		{name: "start key", key: "S"},
+		{name: "stop key", key: "X"},
+		{name: "re-run key", key: "R"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(tt.key)})
+			if !handled {
+				t.Errorf("Key %q should be handled", tt.key)
+			}
+		})
+	}
+
+	if !started {
+		t.Error("Start function should have been called")
+	}
+	if stopped != "" {
+		t.Errorf("Stop should wait for confirmation, got %q", stopped)
+	}
+	if rerun != "nightly-report-prod-r4v7c0a" {
+		t.Errorf("Expected re-run on the selected execution, got %q", rerun)
+	}
+}
+
+// Test that stopping an execution asks for confirmation first
+func TestJobExecutionsPageStopConfirmation(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewJobExecutionsPage(layoutSystem)
+	page.SetJobContext("nightly-report-prod", "rg-prod/nightly-report-prod")
+	page.SetData(createTestExecutions())
+
+	var stopped string
+	page.SetStopExecutionFunc(func(execution models.JobExecution) tea.Cmd {
+		stopped = execution.Name
+		return nil
+	})
+
+	cmd, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("X")})
+	if !handled || cmd == nil {
+		t.Fatal("Stop key should return a confirmation request")
+	}
+
+	request, ok := cmd().(pages.ConfirmRequestMsg)
+	if !ok {
+		t.Fatalf("Expected ConfirmRequestMsg, got %T", cmd())
+	}
+	if request.Resource != "nightly-report-prod" || request.ResourceGroup != "rg-prod" {
+		t.Errorf("Unexpected confirmation target %s/%s", request.ResourceGroup, request.Resource)
+	}
+	if !strings.Contains(request.Text, "nightly-report-prod-r4v7c0a") {
+		t.Errorf("Expected confirmation text to name the execution, got %q", request.Text)
+	}
+	if stopped != "" {
+		t.Fatal("Stop should not run before it is confirmed")
+	}
+
+	request.OnConfirm()
+	if stopped != "nightly-report-prod-r4v7c0a" {
+		t.Errorf("Expected stop on the running execution once confirmed, got %q", stopped)
+	}
+}
+
+// Test that starting works even when the job has no executions yet
+func TestJobExecutionsPageStartWithoutExecutions(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewJobExecutionsPage(layoutSystem)
+
+	started := false
+	page.SetStartJobFunc(func() tea.Cmd {
+		started = true
+		return nil
+	})
+
+	_, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("S")})
+	if !handled || !started {
+		t.Error("Start key should start a new execution on an empty page")
+	}
+}
@@ -0,0 +1,357 @@
+package jobs
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// JobsPage represents the container app jobs page using the new page interface system.
+// It displays container app jobs in an actionable table format.
+type JobsPage struct {
+	*pages.ActionablePage[models.Job]
+
+	// Navigation context
+	resourceGroupName string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys JobsKeyMap
+
+	// Action functions
+	startJobFunc func(models.Job) tea.Cmd
+
+	// Navigation functions
+	navigateToExecutionsFunc func(models.Job) tea.Cmd
+	backToResourceGroupsFunc func() tea.Cmd
+}
+
+// JobsKeyMap defines the key bindings for the jobs page
+type JobsKeyMap struct {
+	Enter       key.Binding
+	Start       key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewJobsPage creates a new jobs page
+func NewJobsPage(layoutSystem *layouts.LayoutSystem) *JobsPage {
+	// Create the base actionable page
+	basePage := pages.NewActionablePage[models.Job]("Filter container app jobs...")
+
+	// Create the jobs page
+	page := &JobsPage{
+		ActionablePage: basePage,
+		layoutSystem:   layoutSystem,
+		keys:           defaultJobsKeyMap(),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createJobsTable)
+
+	// Enable navigation
+	page.SetNavigationFunc(page.handleNavigation)
+
+	// Set up actions
+	page.setupActions()
+
+	return page
+}
+
+// defaultJobsKeyMap returns the default key bindings for jobs
+func defaultJobsKeyMap() JobsKeyMap {
+	return JobsKeyMap{
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "executions"),
+		),
+		Start: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "start execution"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetResourceGroupContext sets the resource group context for the jobs page
+func (p *JobsPage) SetResourceGroupContext(resourceGroupName string) {
+	p.resourceGroupName = resourceGroupName
+}
+
+// SetStartJobFunc sets the function to call for starting a job execution
+func (p *JobsPage) SetStartJobFunc(fn func(models.Job) tea.Cmd) {
+	p.startJobFunc = fn
+}
+
+// SetNavigateToExecutionsFunc sets the function to call when navigating to job executions
+func (p *JobsPage) SetNavigateToExecutionsFunc(fn func(models.Job) tea.Cmd) {
+	p.navigateToExecutionsFunc = fn
+}
+
+// SetBackToResourceGroupsFunc sets the function to call when going back to resource groups
+func (p *JobsPage) SetBackToResourceGroupsFunc(fn func() tea.Cmd) {
+	p.backToResourceGroupsFunc = fn
+	p.SetBackFunc(fn)
+}
+
+// Action setup
+
+// setupActions configures the available actions for the jobs page
+func (p *JobsPage) setupActions() {
+	// Add start action
+	p.AddAction("start", p.keys.Start, func(job models.Job) tea.Cmd {
+		if p.startJobFunc != nil {
+			return p.startJobFunc(job)
+		}
+		return nil
+	})
+}
+
+// Table creation methods
+
+// createJobsTable creates a table for displaying container app jobs
+func (p *JobsPage) createJobsTable(data []models.Job) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("name", "Name", 15, true).             // Dynamic width, min 15
+		AddColumn("trigger", "Trigger", 10, true).       // Fixed width
+		AddColumn("schedule", "Schedule", 15, false).    // Fixed width
+		AddColumn("status", "Status", 12, true).         // Fixed width
+		AddColumn("parallelism", "Parallel", 10, false). // Fixed width
+		AddColumn("retries", "Retries", 9, false).       // Fixed width
+		AddColumn("timeout", "Timeout", 9, false).       // Fixed width
+		AddColumn("resources", "Resources", 12, false).  // Fixed width
+		AddColumn("location", "Location", 15, true).     // Fixed width
+		AddColumn("image", "Image", 50, false)           // Fixed width (longest content)
+
+	// Update dynamic column widths based on actual content
+	for _, job := range data {
+		builder.UpdateWidthFromString("name", job.Name)
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, job := range data {
+			trigger := job.TriggerType
+			if trigger == "" {
+				trigger = "-"
+			}
+
+			schedule := job.CronExpression
+			if schedule == "" {
+				schedule = "-"
+			}
+
+			status := job.ProvisioningState
+			if status == "" {
+				status = "Unknown"
+			}
+
+			// Format parallelism and completion count
+			parallelism := fmt.Sprintf("%d/%d", job.Parallelism, job.CompletionCount)
+			if job.Parallelism == 0 && job.CompletionCount == 0 {
+				parallelism = "-"
+			}
+
+			// Format timeout
+			timeout := "-"
+			if job.ReplicaTimeout > 0 {
+				timeout = fmt.Sprintf("%ds", job.ReplicaTimeout)
+			}
+
+			// Format resources
+			resources := fmt.Sprintf("%.2gC/%.1s", job.CPU, job.Memory)
+			if job.CPU == 0 {
+				resources = "-"
+			}
+
+			image := job.Image
+			if image == "" {
+				image = "-"
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"name":        job.Name,
+				"trigger":     trigger,
+				"schedule":    schedule,
+				"status":      table.NewStyledCell(status, lipgloss.NewStyle().Foreground(pages.GetStatusColor(status))),
+				"parallelism": parallelism,
+				"retries":     fmt.Sprintf("%d", job.ReplicaRetryLimit),
+				"timeout":     timeout,
+				"resources":   resources,
+				"location":    job.Location,
+				"image":       image,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// Navigation methods
+
+// handleNavigation handles navigation to the selected job's executions
+func (p *JobsPage) handleNavigation(job models.Job) tea.Cmd {
+	if p.navigateToExecutionsFunc != nil {
+		return p.navigateToExecutionsFunc(job)
+	}
+	return nil
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the jobs page
+func (p *JobsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// First, try base actionable page key handling
+	if cmd, handled := p.ActionablePage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	// Handle jobs-specific keys
+	switch msg.String() {
+	case "esc":
+		if p.backToResourceGroupsFunc != nil {
+			return p.backToResourceGroupsFunc(), true
+		}
+		return nil, true
+	case "?":
+		// Help toggle - let the parent handle this
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// GetHelpKeys returns the help keys for the jobs page
+func (p *JobsPage) GetHelpKeys() []key.Binding {
+	baseKeys := []key.Binding{
+		p.keys.Enter,
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+
+	// Add action keys
+	actionKeys := p.GetActionKeys()
+	return append(baseKeys, actionKeys...)
+}
+
+// View rendering methods
+
+// View renders the jobs page
+func (p *JobsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeJobs,
+	})
+}
+
+// ViewWithHelpContext renders the jobs page with help context
+func (p *JobsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeJobs
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading container app jobs...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeJobs,
+				ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeJobs,
+				Error:       err,
+				ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:        layouts.ModeJobs,
+			ContextInfo: map[string]string{"resource_group": p.resourceGroupName},
+			Counters:    map[string]int{"count": len(p.GetData())},
+		},
+		helpContext,
+	)
+}
@@ -0,0 +1,165 @@
+package jobs
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestJobs() []models.Job {
+	return []models.Job{
+		{
+			Name:              "nightly-report-prod",
+			ResourceGroup:     "rg-production-eastus",
+			Location:          "East US",
+			TriggerType:       "Schedule",
+			CronExpression:    "0 2 * * *",
+			Parallelism:       1,
+			CompletionCount:   1,
+			ReplicaRetryLimit: 2,
+			ReplicaTimeout:    1800,
+			ProvisioningState: "Succeeded",
+			CPU:               0.5,
+			Memory:            "1Gi",
+		},
+		{
+			Name:              "db-migration-prod",
+			ResourceGroup:     "rg-production-eastus",
+			Location:          "East US",
+			TriggerType:       "Manual",
+			Parallelism:       1,
+			CompletionCount:   1,
+			ReplicaTimeout:    600,
+			ProvisioningState: "Succeeded",
+			CPU:               1.0,
+			Memory:            "2Gi",
+		},
+	}
+}
+
+// Test page creation and basic functionality
+func TestJobsPageBasics(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewJobsPage(layoutSystem)
+
+	if page == nil {
+		t.Fatal("Failed to create Jobs page")
+	}
+
+	// Test initial state
+	if page.IsLoading() {
+		t.Error("Page should not be loading initially")
+	}
+
+	if len(page.GetData()) != 0 {
+		t.Error("Page should have no data initially")
+	}
+
+	// Test resource group context
+	page.SetResourceGroupContext("rg-production-eastus")
+	if page.resourceGroupName != "rg-production-eastus" {
+		t.Error("Resource group context should be set")
+	}
+
+	// Test data loading
+	testData := createTestJobs()
+	page.SetData(testData)
+
+	if len(page.GetData()) != len(testData) {
+		t.Errorf("Expected %d jobs, got %d", len(testData), len(page.GetData()))
+	}
+
+	// Test table creation
+	table := page.GetTable()
+	if table.TotalRows() != len(testData) {
+		t.Errorf("Table should have %d rows, got %d", len(testData), table.TotalRows())
+	}
+}
+
+// Test navigation handling
+func TestJobsPageNavigation(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewJobsPage(layoutSystem)
+	page.SetData(createTestJobs())
+
+	// Test forward navigation targets the highlighted job
+	var selected models.Job
+	page.SetNavigateToExecutionsFunc(func(job models.Job) tea.Cmd {
+		selected = job
+		return nil
+	})
+
+	// Move the cursor to the second row before pressing Enter
+	table := page.GetTable()
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	page.SetTable(table)
+
+	enterMsg := tea.KeyMsg{Type: tea.KeyEnter}
+	cmd, handled := page.HandleKeyMsg(enterMsg)
+	if !handled {
+		t.Error("Enter key should be handled")
+	}
+	if cmd != nil {
+		cmd()
+	}
+	if selected.Name != "db-migration-prod" {
+		t.Errorf("Expected navigation to db-migration-prod, got %q", selected.Name)
+	}
+
+	// Test back navigation setup
+	backCalled := false
+	page.SetBackToResourceGroupsFunc(func() tea.Cmd {
+		backCalled = true
+		return nil
+	})
+
+	// Test ESC key
+	escMsg := tea.KeyMsg{Type: tea.KeyEsc}
+	cmd, handled = page.HandleKeyMsg(escMsg)
+	if !handled {
+		t.Error("ESC key should be handled")
+	}
+
+	// Execute the command to trigger the callback
+	if cmd != nil {
+		cmd()
+	}
+
+	if !backCalled {
+		t.Error("Back function should have been called")
+	}
+}
+
+// Test view rendering in different states
+func TestJobsPageViewRendering(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewJobsPage(layoutSystem)
+	page.SetResourceGroupContext("rg-production-eastus")
+
+	// Test loading state
+	page.SetLoading(true)
+	view := page.View()
+	if view == "" {
+		t.Error("Loading view should not be empty")
+	}
+
+	// Test error state
+	page.SetLoading(false)
+	page.SetError(fmt.Errorf("test error"))
+	view = page.View()
+	if view == "" {
+		t.Error("Error view should not be empty")
+	}
+
+	// Test normal state with data
+	page.SetError(nil)
+	page.SetData(createTestJobs())
+	view = page.View()
+	if view == "" {
+		t.Error("Normal view should not be empty")
+	}
+}
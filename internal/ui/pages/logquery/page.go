@@ -0,0 +1,431 @@
+package logquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// editorHeight is the number of query lines shown above the results
+const editorHeight = 6
+
+// maxCellWidth caps the characters shown of a cell, so long log lines do not
+// make their column wider than the screen
+const maxCellWidth = 120
+
+// minLastColumnWidth is the narrowest the last column gets on small screens
+const minLastColumnWidth = 30
+
+// LogQueryPage runs KQL queries against the Log Analytics workspace of the
+// managed environment, for the logs older than what the log stream shows.
+// Queries start from a template scoped to the app, revision, replica and
+// container the page was opened for, can be edited, and run over a selectable
+// time range. The rows returned are shown in a table with a column per field.
+type LogQueryPage struct {
+	*pages.ReadOnlyPage[map[string]string]
+
+	// Navigation context
+	scope models.LogSource
+
+	// Query state
+	template  int
+	timeRange models.LogQueryRange
+	editor    textarea.Model
+	columns   []string
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys LogQueryKeyMap
+
+	// Callback functions
+	runQueryFunc func(query string, timeRange models.LogQueryRange) tea.Cmd
+	backFunc     func() tea.Cmd
+}
+
+// LogQueryKeyMap defines the key bindings for the log query page
+type LogQueryKeyMap struct {
+	Edit      key.Binding
+	Run       key.Binding
+	Template  key.Binding
+	TimeRange key.Binding
+	Filter    key.Binding
+	Help      key.Binding
+	Back      key.Binding
+	Quit      key.Binding
+}
+
+// NewLogQueryPage creates a new log query page
+func NewLogQueryPage(layoutSystem *layouts.LayoutSystem) *LogQueryPage {
+	editor := textarea.New()
+	editor.Placeholder = "KQL query..."
+	editor.ShowLineNumbers = false
+	editor.CharLimit = 0
+	editor.SetHeight(editorHeight)
+
+	page := &LogQueryPage{
+		ReadOnlyPage: pages.NewReadOnlyPage[map[string]string]("Filter rows..."),
+		timeRange:    models.LogQueryRanges[3],
+		editor:       editor,
+		layoutSystem: layoutSystem,
+		keys:         defaultLogQueryKeyMap(),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createResultsTable)
+
+	return page
+}
+
+// defaultLogQueryKeyMap returns the default key bindings for log queries
+func defaultLogQueryKeyMap() LogQueryKeyMap {
+	return LogQueryKeyMap{
+		Edit:      key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit query")),
+		Run:       key.NewBinding(key.WithKeys("r", "enter", "ctrl+r"), key.WithHelp("r/ctrl+r", "run")),
+		Template:  key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "next template")),
+		TimeRange: key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "time range")),
+		Filter:    key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+		Help:      key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+		Back:      key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		Quit:      key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+// Configuration methods
+
+// SetScope sets the logs the templates query. The query is reset to the
+// template matching the type of logs unless the scope is unchanged.
+func (p *LogQueryPage) SetScope(scope models.LogSource) {
+	if scope == p.scope && p.editor.Value() != "" {
+		return
+	}
+	p.scope = scope
+	p.template = defaultTemplate(scope.Type)
+	p.editor.SetValue(Templates[p.template].Build(scope))
+}
+
+// GetScope returns the logs the templates query
+func (p *LogQueryPage) GetScope() models.LogSource {
+	return p.scope
+}
+
+// GetQuery returns the query as edited
+func (p *LogQueryPage) GetQuery() string {
+	return strings.TrimSpace(p.editor.Value())
+}
+
+// GetTimeRange returns how far back the query looks
+func (p *LogQueryPage) GetTimeRange() models.LogQueryRange {
+	return p.timeRange
+}
+
+// SetRunQueryFunc sets the function to call for running a query
+func (p *LogQueryPage) SetRunQueryFunc(fn func(query string, timeRange models.LogQueryRange) tea.Cmd) {
+	p.runQueryFunc = fn
+}
+
+// SetBackFunc sets the function to call when navigating back
+func (p *LogQueryPage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// Data management methods
+
+// SetResult shows the rows returned by a query
+func (p *LogQueryPage) SetResult(result models.LogQueryResult) {
+	p.columns = result.Columns
+	p.statusMessage = fmt.Sprintf("%d rows in the last %s", len(result.Rows), p.timeRange.Name)
+	p.SetData(result.Rows)
+}
+
+// ClearData removes the rows shown, keeping the query and the time range
+func (p *LogQueryPage) ClearData() {
+	p.ReadOnlyPage.ClearData()
+	p.columns = nil
+	p.statusMessage = ""
+	p.editor.Blur()
+}
+
+// IsEditing returns true while the query is being edited
+func (p *LogQueryPage) IsEditing() bool {
+	return p.editor.Focused()
+}
+
+// run runs the query as edited over the selected time range
+func (p *LogQueryPage) run() tea.Cmd {
+	query := p.GetQuery()
+	if query == "" {
+		p.statusMessage = "The query is empty, press 'e' to edit it or 't' for a template"
+		return nil
+	}
+	if p.runQueryFunc == nil {
+		return nil
+	}
+	return p.runQueryFunc(query, p.timeRange)
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the log query page
+func (p *LogQueryPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.editor.Focused() {
+		return p.handleEditorInput(msg)
+	}
+
+	// Handle log query specific keys before the read-only page
+	if !p.GetFilterInput().Focused() {
+		switch {
+		case key.Matches(msg, p.keys.Back):
+			if p.backFunc != nil {
+				return p.backFunc(), true
+			}
+			return nil, true
+		case key.Matches(msg, p.keys.Edit):
+			return p.editor.Focus(), true
+		case key.Matches(msg, p.keys.Run):
+			return p.run(), true
+		case key.Matches(msg, p.keys.Template):
+			p.template = (p.template + 1) % len(Templates)
+			p.editor.SetValue(Templates[p.template].Build(p.scope))
+			return p.run(), true
+		case key.Matches(msg, p.keys.TimeRange):
+			p.timeRange = nextTimeRange(p.timeRange)
+			return p.run(), true
+		case key.Matches(msg, p.keys.Help):
+			// Help toggle - let the parent handle this
+			return nil, false
+		}
+	}
+
+	return p.ReadOnlyPage.HandleKeyMsg(msg)
+}
+
+// handleEditorInput handles keys while the query is being edited
+func (p *LogQueryPage) handleEditorInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "ctrl+r":
+		p.editor.Blur()
+		return p.run(), true
+	case "esc":
+		p.editor.Blur()
+		return nil, true
+	case "ctrl+c":
+		return tea.Quit, true
+	default:
+		var cmd tea.Cmd
+		p.editor, cmd = p.editor.Update(msg)
+		return cmd, true
+	}
+}
+
+// GetHelpKeys returns the help keys for the log query page
+func (p *LogQueryPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Edit,
+		p.keys.Run,
+		p.keys.Template,
+		p.keys.TimeRange,
+		p.keys.Filter,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// Table creation methods
+
+// createResultsTable creates a table with a column per field the query returned
+func (p *LogQueryPage) createResultsTable(data []map[string]string) table.Model {
+	// Get content dimensions, leaving room for the query editor
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+	contentHeight = max(1, contentHeight-editorHeight-1)
+
+	// The last column, usually the log line, takes the width left by the others
+	// and their borders, so that it does not scroll off screen
+	builder := tablebuilder.NewDynamicColumnBuilder()
+	for _, column := range p.columns {
+		builder.AddColumn(column, columnTitle(column), 8, true)
+	}
+	for _, record := range data {
+		for _, column := range p.columns {
+			builder.UpdateWidthFromString(column, truncate(record[column], maxCellWidth))
+		}
+	}
+	columns := builder.Build()
+	lastWidth := maxCellWidth
+	if len(columns) > 1 {
+		used := len(columns) + 1
+		for _, column := range columns[:len(columns)-1] {
+			used += column.Width()
+		}
+		lastWidth = max(minLastColumnWidth, contentWidth-used)
+		last := columns[len(columns)-1]
+		columns[len(columns)-1] = table.NewColumn(last.Key(), last.Title(), lastWidth).WithFiltered(true)
+	}
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, record := range data {
+			rowData := table.RowData{}
+			for j, column := range p.columns {
+				width := maxCellWidth
+				if j == len(p.columns)-1 {
+					width = lastWidth
+				}
+				rowData[column] = truncate(record[column], width)
+			}
+			rows[i] = table.NewRow(rowData)
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// View rendering methods
+
+// View renders the log query page
+func (p *LogQueryPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeLogQuery,
+	})
+}
+
+// ViewWithHelpContext renders the log query page with help context
+func (p *LogQueryPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeLogQuery
+
+	contextInfo := p.contextInfo()
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			fmt.Sprintf("Querying the logs of the last %s...", p.timeRange.Name),
+			layouts.StatusContext{
+				Mode:        layouts.ModeLogQuery,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry, 'e' to edit the query or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeLogQuery,
+				Error:       err,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	statusMessage := p.statusMessage
+	if p.editor.Focused() {
+		statusMessage = "Editing query, ctrl+r to run, esc to stop editing"
+	}
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeLogQuery,
+		ContextInfo:   contextInfo,
+		FilterActive:  p.GetFilterInput().Focused(),
+		StatusMessage: statusMessage,
+	}
+
+	contentWidth, _ := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: statusContext,
+		HelpContext:   helpContext,
+	})
+	p.editor.SetWidth(max(1, contentWidth))
+
+	results := p.GetTable().View()
+	if len(p.columns) == 0 {
+		results = "Press 'r' to run the query"
+	}
+	return p.layoutSystem.CreateTableLayout(
+		lipgloss.JoinVertical(lipgloss.Left, p.editor.View(), "", results),
+		statusContext,
+		helpContext,
+	)
+}
+
+// contextInfo describes the scope, template and time range for the status bar
+func (p *LogQueryPage) contextInfo() map[string]string {
+	info := map[string]string{
+		"app":      p.scope.App.Name,
+		"template": Templates[p.template].Name,
+		"range":    p.timeRange.Name,
+	}
+	if p.scope.Revision != "" {
+		info["revision"] = p.scope.Revision
+	}
+	if p.scope.Replica != "" {
+		info["replica"] = p.scope.Replica
+	}
+	if p.scope.Container != "" {
+		info["container"] = p.scope.Container
+	}
+	return info
+}
+
+// Helper functions
+
+// nextTimeRange returns the time range following the selected one, wrapping around
+func nextTimeRange(timeRange models.LogQueryRange) models.LogQueryRange {
+	for i, r := range models.LogQueryRanges {
+		if r.Name == timeRange.Name {
+			return models.LogQueryRanges[(i+1)%len(models.LogQueryRanges)]
+		}
+	}
+	return models.LogQueryRanges[0]
+}
+
+// columnTitle returns the title of a column, without the type suffix Log
+// Analytics gives custom log fields, e.g. "RevisionName" for "RevisionName_s"
+func columnTitle(column string) string {
+	for _, suffix := range []string{"_s", "_d", "_b", "_g", "_t"} {
+		if trimmed, ok := strings.CutSuffix(column, suffix); ok && trimmed != "" {
+			return trimmed
+		}
+	}
+	return column
+}
+
+// truncate shortens a value to width characters on a single line
+func truncate(value string, width int) string {
+	value = strings.Join(strings.Fields(value), " ")
+	runes := []rune(value)
+	if len(runes) <= width {
+		return value
+	}
+	return string(runes[:width-1]) + "…"
+}
@@ -0,0 +1,102 @@
+package logquery
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runeKey creates the key message of a typed character
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// Test that templates are scoped to the logs the page was opened for
+func TestTemplatesScope(t *testing.T) {
+	scope := models.LogSource{
+		App:       models.ContainerApp{Name: "web-app"},
+		Revision:  "web-app--v2",
+		Replica:   "web-app--v2-abc",
+		Container: "main",
+	}
+
+	console := Templates[0].Build(scope)
+	for _, want := range []string{
+		"ContainerAppConsoleLogs_CL",
+		"ContainerAppName_s == 'web-app' and RevisionName_s == 'web-app--v2' and ContainerGroupName_s == 'web-app--v2-abc' and ContainerName_s == 'main'",
+		"| take 500",
+	} {
+		if !strings.Contains(console, want) {
+			t.Errorf("Console query should contain %q, got:\n%s", want, console)
+		}
+	}
+
+	// System logs have no container column
+	system := Templates[2].Build(scope)
+	if !strings.Contains(system, "ReplicaName_s == 'web-app--v2-abc'") || strings.Contains(system, "ContainerName_s") {
+		t.Errorf("Expected the system query narrowed down to the replica, got:\n%s", system)
+	}
+
+	if got := kqlString(`it's a \ test`); got != `'it\'s a \\ test'` {
+		t.Errorf("Expected quotes and backslashes escaped, got %s", got)
+	}
+}
+
+// Test running queries and showing their results
+func TestLogQueryPageRun(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(160, 30)
+	page := NewLogQueryPage(layoutSystem)
+	page.SetScope(models.LogSource{App: models.ContainerApp{Name: "web-app"}, Type: models.LogTypeSystem})
+
+	if !strings.HasPrefix(page.GetQuery(), "ContainerAppSystemLogs_CL") {
+		t.Errorf("Expected the system logs template for system logs, got:\n%s", page.GetQuery())
+	}
+
+	var runs []string
+	page.SetRunQueryFunc(func(query string, timeRange models.LogQueryRange) tea.Cmd {
+		runs = append(runs, strings.SplitN(query, "\n", 2)[0]+" "+timeRange.Name)
+		return nil
+	})
+
+	// Switching templates or time ranges runs the query again
+	for _, key := range []string{"r", "t", "w"} {
+		if _, handled := page.HandleKeyMsg(runeKey(key)); !handled {
+			t.Errorf("Key %q should be handled", key)
+		}
+	}
+	want := "ContainerAppSystemLogs_CL 24h,ContainerAppSystemLogs_CL 24h,ContainerAppSystemLogs_CL 3d"
+	if got := strings.Join(runs, ","); got != want {
+		t.Errorf("Expected runs %s, got %s", want, got)
+	}
+	if page.contextInfo()["template"] != "System warnings" {
+		t.Errorf("Expected the next template, got %s", page.contextInfo()["template"])
+	}
+
+	// Keys are typed into the query while editing, and ctrl+r runs it
+	page.HandleKeyMsg(runeKey("e"))
+	if !page.IsEditing() {
+		t.Fatal("Expected the query to be edited")
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlE})
+	page.HandleKeyMsg(runeKey(" | take 5"))
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlR})
+	if page.IsEditing() || !strings.HasSuffix(page.GetQuery(), "| take 5") || len(runs) != 4 {
+		t.Errorf("Expected the edited query to run, got:\n%s", page.GetQuery())
+	}
+
+	page.SetResult(models.LogQueryResult{
+		Columns: []string{"TimeGenerated", "Reason_s", "Log_s"},
+		Rows: []map[string]string{
+			{"TimeGenerated": "2024-01-20T10:00:00Z", "Reason_s": "ProbeFailed", "Log_s": "Probe of StartUp failed"},
+		},
+	})
+	view := page.View()
+	for _, want := range []string{"Reason", "ProbeFailed", "1 rows in the last 3d", "range: 3d"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View should contain %q", want)
+		}
+	}
+}
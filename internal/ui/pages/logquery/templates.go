@@ -0,0 +1,119 @@
+package logquery
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IAL32/az-tui/internal/models"
+)
+
+// Log Analytics tables Container Apps environments send their logs to
+const (
+	consoleTable = "ContainerAppConsoleLogs_CL"
+	systemTable  = "ContainerAppSystemLogs_CL"
+)
+
+// maxTemplateRows caps the rows the templates return, newest first
+const maxTemplateRows = 500
+
+// Template is a starting point for a query, scoped to the app, revision,
+// replica and container the logs were opened for
+type Template struct {
+	Name  string
+	Build func(scope models.LogSource) string
+}
+
+// Templates are the queries the template key cycles through
+var Templates = []Template{
+	{
+		Name: "Console logs",
+		Build: func(scope models.LogSource) string {
+			return logQuery(consoleTable, scope, "",
+				"project TimeGenerated, RevisionName_s, ContainerGroupName_s, ContainerName_s, Log_s")
+		},
+	},
+	{
+		Name: "Console errors",
+		Build: func(scope models.LogSource) string {
+			return logQuery(consoleTable, scope, `Log_s has_any ("error", "exception", "fatal", "panic")`,
+				"project TimeGenerated, RevisionName_s, ContainerGroupName_s, ContainerName_s, Log_s")
+		},
+	},
+	{
+		Name: "System logs",
+		Build: func(scope models.LogSource) string {
+			return logQuery(systemTable, scope, "",
+				"project TimeGenerated, RevisionName_s, ReplicaName_s, Type_s, Reason_s, Log_s")
+		},
+	},
+	{
+		Name: "System warnings",
+		Build: func(scope models.LogSource) string {
+			return logQuery(systemTable, scope, "Type_s == 'Warning'",
+				"project TimeGenerated, RevisionName_s, ReplicaName_s, Reason_s, Log_s")
+		},
+	},
+	{
+		Name: "Log volume",
+		Build: func(scope models.LogSource) string {
+			return strings.Join([]string{
+				consoleTable,
+				"| where " + scopeFilter(consoleTable, scope),
+				"| summarize Lines = count() by bin(TimeGenerated, 1h), RevisionName_s",
+				"| order by TimeGenerated desc",
+			}, "\n")
+		},
+	},
+}
+
+// defaultTemplate returns the index of the template matching the type of logs
+// the page was opened from
+func defaultTemplate(logType models.LogType) int {
+	if logType == models.LogTypeSystem {
+		return 2
+	}
+	return 0
+}
+
+// logQuery builds a query returning the newest log rows of a table within the
+// scope, narrowed down by an extra condition unless it is empty
+func logQuery(table string, scope models.LogSource, condition, project string) string {
+	where := scopeFilter(table, scope)
+	if condition != "" {
+		where += " and " + condition
+	}
+	return strings.Join([]string{
+		table,
+		"| where " + where,
+		"| " + project,
+		"| order by TimeGenerated desc",
+		fmt.Sprintf("| take %d", maxTemplateRows),
+	}, "\n")
+}
+
+// scopeFilter returns the condition selecting the rows of the scope. System logs
+// have no container column, so they are narrowed down to the replica at most.
+func scopeFilter(table string, scope models.LogSource) string {
+	conditions := []string{"ContainerAppName_s == " + kqlString(scope.App.Name)}
+	if scope.Revision != "" {
+		conditions = append(conditions, "RevisionName_s == "+kqlString(scope.Revision))
+	}
+	if table == systemTable {
+		if scope.Replica != "" {
+			conditions = append(conditions, "ReplicaName_s == "+kqlString(scope.Replica))
+		}
+		return strings.Join(conditions, " and ")
+	}
+	if scope.Replica != "" {
+		conditions = append(conditions, "ContainerGroupName_s == "+kqlString(scope.Replica))
+	}
+	if scope.Container != "" {
+		conditions = append(conditions, "ContainerName_s == "+kqlString(scope.Container))
+	}
+	return strings.Join(conditions, " and ")
+}
+
+// kqlString quotes a value as a KQL string literal
+func kqlString(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
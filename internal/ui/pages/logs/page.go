@@ -0,0 +1,771 @@
+package logs
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/components/logexport"
+	"github.com/IAL32/az-tui/internal/ui/components/logview"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// LogsPage streams the console or system logs of an app, revision, replica or
+// container, or both interleaved by time.
+// New lines are followed as they arrive unless the log is scrolled up or paused,
+// and only the newest logview.DefaultCapacity lines are kept. JSON lines are
+// shown as columns and can be filtered by field.
+//
+// The logs of several apps can be tailed together, interleaved by time, each
+// line tagged with the app, revision and container it came from. The lines of
+// each app can be muted.
+type LogsPage struct {
+	*pages.BasePage
+
+	// Navigation context, a source per app when tailing several apps
+	sources []models.LogSource
+
+	// Log state
+	viewer *logview.Viewer
+	ended  bool
+	rate   *logview.RateMeter
+	now    func() time.Time
+
+	// Search input shown while typing a pattern
+	searchInput textinput.Model
+
+	// Filter input shown while typing a field query
+	filterInput textinput.Model
+
+	// Prompt shown while choosing the file to save the logs to
+	exportPrompt logexport.Prompt
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys LogsKeyMap
+
+	// Action functions
+	backFunc      func() tea.Cmd
+	reconnectFunc func() tea.Cmd
+	logTypeFunc   func(models.LogType) tea.Cmd
+	historyFunc   func() tea.Cmd
+}
+
+// LogsKeyMap defines the key bindings for the logs page
+type LogsKeyMap struct {
+	Up        key.Binding
+	Down      key.Binding
+	Top       key.Binding
+	Bottom    key.Binding
+	PageUp    key.Binding
+	PageDown  key.Binding
+	Pause     key.Binding
+	Follow    key.Binding
+	Wrap      key.Binding
+	Columns   key.Binding
+	Search    key.Binding
+	Filter    key.Binding
+	Next      key.Binding
+	Prev      key.Binding
+	LogType   key.Binding
+	History   key.Binding
+	Save      key.Binding
+	Mute      key.Binding
+	UnmuteAll key.Binding
+	Reconnect key.Binding
+	Help      key.Binding
+}
+
+// NewLogsPage creates a new logs page
+func NewLogsPage(layoutSystem *layouts.LayoutSystem) *LogsPage {
+	searchInput := textinput.New()
+	searchInput.Placeholder = "Search logs (regular expression)..."
+	searchInput.Prompt = "/"
+
+	filterInput := textinput.New()
+	filterInput.Placeholder = "level=error traceId=abc* text..."
+	filterInput.Prompt = "filter: "
+
+	return &LogsPage{
+		BasePage:     pages.NewBasePage("Search logs..."),
+		viewer:       logview.NewViewer(logview.DefaultCapacity),
+		rate:         logview.NewRateMeter(logview.DefaultRateWindow),
+		now:          time.Now,
+		searchInput:  searchInput,
+		filterInput:  filterInput,
+		exportPrompt: logexport.NewPrompt(),
+		layoutSystem: layoutSystem,
+		keys:         defaultLogsKeyMap(),
+	}
+}
+
+// defaultLogsKeyMap returns the default key bindings for logs
+func defaultLogsKeyMap() LogsKeyMap {
+	return LogsKeyMap{
+		Up:        key.NewBinding(key.WithKeys("up", "k"), key.WithHelp("↑/k", "up")),
+		Down:      key.NewBinding(key.WithKeys("down", "j"), key.WithHelp("↓/j", "down")),
+		Top:       key.NewBinding(key.WithKeys("g", "home"), key.WithHelp("g", "oldest")),
+		Bottom:    key.NewBinding(key.WithKeys("G", "end"), key.WithHelp("G", "newest")),
+		PageUp:    key.NewBinding(key.WithKeys("pgup", "ctrl+u"), key.WithHelp("pgup", "page up")),
+		PageDown:  key.NewBinding(key.WithKeys("pgdown", "ctrl+d"), key.WithHelp("pgdn", "page down")),
+		Pause:     key.NewBinding(key.WithKeys("p", " "), key.WithHelp("p/space", "pause/resume")),
+		Follow:    key.NewBinding(key.WithKeys("f"), key.WithHelp("f", "follow")),
+		Wrap:      key.NewBinding(key.WithKeys("w"), key.WithHelp("w", "wrap")),
+		Columns:   key.NewBinding(key.WithKeys("c"), key.WithHelp("c", "columns")),
+		Search:    key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "search")),
+		Filter:    key.NewBinding(key.WithKeys("F"), key.WithHelp("F", "field filter")),
+		Next:      key.NewBinding(key.WithKeys("n"), key.WithHelp("n", "next match")),
+		Prev:      key.NewBinding(key.WithKeys("N"), key.WithHelp("N", "prev match")),
+		LogType:   key.NewBinding(key.WithKeys("t"), key.WithHelp("t", "console/system/combined")),
+		History:   key.NewBinding(key.WithKeys("h"), key.WithHelp("h", "query history")),
+		Save:      key.NewBinding(key.WithKeys("s"), key.WithHelp("s", "save to file")),
+		Mute:      key.NewBinding(key.WithKeys("1", "2", "3", "4", "5", "6", "7", "8", "9"), key.WithHelp("1-9", "mute app")),
+		UnmuteAll: key.NewBinding(key.WithKeys("0"), key.WithHelp("0", "unmute all")),
+		Reconnect: key.NewBinding(key.WithKeys("r"), key.WithHelp("r", "reconnect")),
+		Help:      key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+	}
+}
+
+// Configuration methods
+
+// SetSource sets the logs the page shows
+func (p *LogsPage) SetSource(source models.LogSource) {
+	p.SetSources([]models.LogSource{source})
+}
+
+// SetSources sets the logs the page shows, tailing the logs of several apps
+// together when given more than one source
+func (p *LogsPage) SetSources(sources []models.LogSource) {
+	p.sources = sources
+	p.viewer = p.newViewer(p.viewer)
+}
+
+// GetSource returns the logs the page shows, those of the first app when
+// tailing several apps
+func (p *LogsPage) GetSource() models.LogSource {
+	if len(p.sources) == 0 {
+		return models.LogSource{}
+	}
+	return p.sources[0]
+}
+
+// GetSources returns the logs the page shows, one source per app
+func (p *LogsPage) GetSources() []models.LogSource {
+	return p.sources
+}
+
+// IsAggregated returns true when the logs of several apps are tailed together
+func (p *LogsPage) IsAggregated() bool {
+	return len(p.sources) > 1
+}
+
+// Streams returns the sources of the log streams making up the logs, in the
+// order AppendStreamLines numbers them
+func (p *LogsPage) Streams() []models.LogSource {
+	var streams []models.LogSource
+	for _, source := range p.sources {
+		streams = append(streams, source.Streams()...)
+	}
+	return streams
+}
+
+// StreamName describes a log stream, e.g. "system" or "my-app/my-app--v2 console"
+func (p *LogsPage) StreamName(index int) string {
+	streams := p.Streams()
+	if index < 0 || index >= len(streams) {
+		return ""
+	}
+	if !p.IsAggregated() {
+		return string(streams[index].Type)
+	}
+	return fmt.Sprintf("%s %s", streams[index], streams[index].Type)
+}
+
+// SetBackFunc sets the function to call when leaving the page
+func (p *LogsPage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// SetReconnectFunc sets the function to call for restarting the log stream
+func (p *LogsPage) SetReconnectFunc(fn func() tea.Cmd) {
+	p.reconnectFunc = fn
+}
+
+// SetLogTypeFunc sets the function to call for switching between console,
+// system and combined logs
+func (p *LogsPage) SetLogTypeFunc(fn func(models.LogType) tea.Cmd) {
+	p.logTypeFunc = fn
+}
+
+// SetShowHistoryFunc sets the function to call for querying the logs older
+// than the stream shows
+func (p *LogsPage) SetShowHistoryFunc(fn func() tea.Cmd) {
+	p.historyFunc = fn
+}
+
+// SetFieldConfig sets the JSON fields the time, level, message and trace id
+// columns are read from
+func (p *LogsPage) SetFieldConfig(config logview.FieldConfig) {
+	p.viewer.SetFieldConfig(config)
+}
+
+// Data management methods
+
+// AppendLines adds lines received from the log stream
+func (p *LogsPage) AppendLines(lines []string) {
+	p.viewer.Append(lines...)
+}
+
+// AppendStreamLines adds lines received from one of the streams returned by
+// Streams. Combined logs show which stream each line came from, and the logs
+// of several apps which app.
+func (p *LogsPage) AppendStreamLines(index int, lines []string) {
+	p.rate.Add(len(lines), p.now())
+	streams := p.Streams()
+	if index < 0 || index >= len(streams) {
+		return
+	}
+	p.viewer.AppendFrom(p.streamTag(streams[index]), lines...)
+}
+
+// streamTag returns the tag shown next to the lines of a stream: the app,
+// revision and container for the logs of several apps, the type for combined
+// logs and none otherwise
+func (p *LogsPage) streamTag(stream models.LogSource) string {
+	switch {
+	case p.IsAggregated():
+		return stream.String()
+	case p.GetSource().Type == models.LogTypeCombined:
+		return string(stream.Type)
+	default:
+		return ""
+	}
+}
+
+// sourceTags returns the tags of the lines of each app when tailing several apps
+func (p *LogsPage) sourceTags() []string {
+	if !p.IsAggregated() {
+		return nil
+	}
+	tags := make([]string, len(p.sources))
+	for i, source := range p.sources {
+		tags[i] = p.streamTag(source)
+	}
+	return tags
+}
+
+// SetNow sets the clock the rate of lines is measured with
+func (p *LogsPage) SetNow(now func() time.Time) {
+	p.now = now
+}
+
+// Rate returns the lines per second received lately
+func (p *LogsPage) Rate() float64 {
+	return p.rate.Rate(p.now())
+}
+
+// SetSaved reports the lines saved to a file, or the error saving them failed with
+func (p *LogsPage) SetSaved(msg logexport.SavedMsg) {
+	if msg.Err != nil {
+		p.statusMessage = fmt.Sprintf("Saving logs failed: %v", msg.Err)
+		return
+	}
+	p.statusMessage = fmt.Sprintf("Saved %d lines to %s as %s", msg.Lines, msg.Path, msg.Format)
+}
+
+// SetEnded marks the log stream as ended, with the error it failed with if any
+func (p *LogsPage) SetEnded(err error) {
+	p.ended = true
+	if err != nil {
+		p.statusMessage = fmt.Sprintf("Log stream ended: %v", err)
+	} else {
+		p.statusMessage = "Log stream ended, press 'r' to reconnect"
+	}
+}
+
+// IsEnded returns true once the log stream has ended
+func (p *LogsPage) IsEnded() bool {
+	return p.ended
+}
+
+// GetViewer returns the log viewer
+func (p *LogsPage) GetViewer() *logview.Viewer {
+	return p.viewer
+}
+
+// ClearData removes the lines received, keeping the display settings, the
+// field filter and the muted apps
+func (p *LogsPage) ClearData() {
+	p.BasePage.ClearData()
+	p.viewer = p.newViewer(p.viewer)
+	p.rate.Reset()
+	p.ended = false
+	p.statusMessage = ""
+	p.searchInput.SetValue("")
+	p.searchInput.Blur()
+	p.filterInput.Blur()
+	p.exportPrompt.Close()
+}
+
+// newViewer creates a viewer for the sources, with the display settings, the
+// field filter and the muted apps of the previous one
+func (p *LogsPage) newViewer(previous *logview.Viewer) *logview.Viewer {
+	viewer := logview.NewViewer(logview.DefaultCapacity)
+	if previous.Wrapping() {
+		viewer.ToggleWrap()
+	}
+	viewer.SetColumns(previous.Columns())
+	viewer.SetSorted(p.IsAggregated() || p.GetSource().Type == models.LogTypeCombined)
+	viewer.SetSourceContainers(p.IsAggregated())
+	viewer.SetFieldConfig(previous.FieldConfig())
+	if filter, err := logview.ParseQuery(previous.Filter()); err == nil {
+		viewer.SetFilter(filter)
+	}
+	tags := p.sourceTags()
+	viewer.RegisterSources(tags...)
+	for _, tag := range tags {
+		viewer.SetMuted(tag, previous.Muted(tag))
+	}
+	return viewer
+}
+
+// IsSearching returns true while a search pattern, a filter or the file to
+// save the logs to is being typed
+func (p *LogsPage) IsSearching() bool {
+	return p.searchInput.Focused() || p.filterInput.Focused() || p.exportPrompt.Active()
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the logs page
+func (p *LogsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.searchInput.Focused() {
+		return p.handleSearchInput(msg)
+	}
+	if p.filterInput.Focused() {
+		return p.handleFilterInput(msg)
+	}
+	if p.exportPrompt.Active() {
+		return p.handleExportPrompt(msg)
+	}
+
+	switch msg.String() {
+	case "esc":
+		if p.backFunc != nil {
+			return p.backFunc(), true
+		}
+		return nil, true
+	case "ctrl+c", "q":
+		return tea.Quit, true
+	}
+
+	switch {
+	case key.Matches(msg, p.keys.Reconnect):
+		if p.reconnectFunc != nil {
+			return p.reconnectFunc(), true
+		}
+	case key.Matches(msg, p.keys.LogType):
+		if p.logTypeFunc != nil {
+			return p.logTypeFunc(nextLogType(p.GetSource().Type)), true
+		}
+	case key.Matches(msg, p.keys.History):
+		if p.IsAggregated() {
+			p.statusMessage = "Query history is only available for the logs of a single app"
+			return nil, true
+		}
+		if p.historyFunc != nil {
+			return p.historyFunc(), true
+		}
+	case key.Matches(msg, p.keys.Save):
+		return p.exportPrompt.Open(logexport.DefaultPath(p.exportName(), p.exportPrompt.Format(), p.now())), true
+	case key.Matches(msg, p.keys.Mute):
+		p.toggleMuted(int(msg.Runes[0] - '1'))
+	case key.Matches(msg, p.keys.UnmuteAll):
+		for _, tag := range p.sourceTags() {
+			p.viewer.SetMuted(tag, false)
+		}
+		if p.IsAggregated() {
+			p.statusMessage = "Showing every app"
+		}
+	case key.Matches(msg, p.keys.Up):
+		p.viewer.ScrollUp(1)
+	case key.Matches(msg, p.keys.Down):
+		p.viewer.ScrollDown(1)
+	case key.Matches(msg, p.keys.Top):
+		p.viewer.GotoTop()
+	case key.Matches(msg, p.keys.Bottom):
+		p.viewer.GotoBottom()
+	case key.Matches(msg, p.keys.PageUp):
+		p.viewer.PageUp()
+	case key.Matches(msg, p.keys.PageDown):
+		p.viewer.PageDown()
+	case key.Matches(msg, p.keys.Pause):
+		p.viewer.TogglePaused()
+	case key.Matches(msg, p.keys.Follow):
+		p.viewer.ToggleFollow()
+	case key.Matches(msg, p.keys.Wrap):
+		p.viewer.ToggleWrap()
+	case key.Matches(msg, p.keys.Columns):
+		p.viewer.ToggleColumns()
+	case key.Matches(msg, p.keys.Search):
+		p.searchInput.SetValue(p.viewer.Query())
+		p.searchInput.CursorEnd()
+		p.searchInput.Focus()
+		return textinput.Blink, true
+	case key.Matches(msg, p.keys.Filter):
+		p.filterInput.SetValue(p.viewer.Filter())
+		p.filterInput.CursorEnd()
+		p.filterInput.Focus()
+		return textinput.Blink, true
+	case key.Matches(msg, p.keys.Next):
+		p.moveToMatch(p.viewer.NextMatch)
+	case key.Matches(msg, p.keys.Prev):
+		p.moveToMatch(p.viewer.PrevMatch)
+	default:
+		return nil, false
+	}
+
+	return nil, true
+}
+
+// handleSearchInput handles key input while the search pattern is being typed
+func (p *LogsPage) handleSearchInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		p.searchInput.Blur()
+		pattern := p.searchInput.Value()
+		count, err := p.viewer.Search(pattern)
+		switch {
+		case err != nil:
+			p.statusMessage = fmt.Sprintf("Invalid pattern: %v", err)
+		case pattern == "":
+			p.statusMessage = "Search cleared"
+		case count == 0:
+			p.statusMessage = fmt.Sprintf("No matches for /%s/", pattern)
+		default:
+			p.setMatchStatus()
+		}
+		return nil, true
+	case "esc":
+		p.searchInput.Blur()
+		return nil, true
+	default:
+		var cmd tea.Cmd
+		p.searchInput, cmd = p.searchInput.Update(msg)
+		return cmd, true
+	}
+}
+
+// handleFilterInput handles key input while the field filter is being typed
+func (p *LogsPage) handleFilterInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		p.filterInput.Blur()
+		filter, err := logview.ParseQuery(p.filterInput.Value())
+		switch {
+		case err != nil:
+			p.statusMessage = fmt.Sprintf("Invalid filter: %v", err)
+		case filter.String() == "":
+			p.viewer.SetFilter(nil)
+			p.statusMessage = "Filter cleared"
+		default:
+			p.viewer.SetFilter(filter)
+			p.statusMessage = fmt.Sprintf("%d of %d lines match %q", p.viewer.Visible(), p.viewer.Len(), filter.String())
+		}
+		return nil, true
+	case "esc":
+		p.filterInput.Blur()
+		return nil, true
+	default:
+		var cmd tea.Cmd
+		p.filterInput, cmd = p.filterInput.Update(msg)
+		return cmd, true
+	}
+}
+
+// handleExportPrompt handles key input while the file to save the logs to is
+// being chosen, saving the lines shown once submitted
+func (p *LogsPage) handleExportPrompt(msg tea.KeyMsg) (tea.Cmd, bool) {
+	request, cmd := p.exportPrompt.HandleKey(msg)
+	if request == nil {
+		return cmd, true
+	}
+	entries := request.Range.Select(p.viewer.VisibleEntries(), p.now())
+	if len(entries) == 0 {
+		p.statusMessage = fmt.Sprintf("No lines to save in the %s", request.Range.Name)
+		return nil, true
+	}
+	p.statusMessage = fmt.Sprintf("Saving %d lines to %s...", len(entries), request.Path)
+	return logexport.SaveCmd(request.Path, entries, request.Format), true
+}
+
+// exportName names the logs in the files they are saved to by default
+func (p *LogsPage) exportName() string {
+	if p.IsAggregated() {
+		return fmt.Sprintf("%d-apps", len(p.sources))
+	}
+	return p.GetSource().String()
+}
+
+// toggleMuted mutes or unmutes the lines of the app at an index when tailing several apps
+func (p *LogsPage) toggleMuted(index int) {
+	tags := p.sourceTags()
+	if index < 0 || index >= len(tags) {
+		if p.IsAggregated() {
+			p.statusMessage = fmt.Sprintf("Only %d apps are tailed", len(tags))
+		}
+		return
+	}
+	muted := !p.viewer.Muted(tags[index])
+	p.viewer.SetMuted(tags[index], muted)
+	if muted {
+		p.statusMessage = fmt.Sprintf("Muted %s", tags[index])
+	} else {
+		p.statusMessage = fmt.Sprintf("Unmuted %s", tags[index])
+	}
+}
+
+// moveToMatch moves to another search match and reports the position
+func (p *LogsPage) moveToMatch(move func() bool) {
+	if !move() {
+		if p.viewer.Query() == "" {
+			p.statusMessage = "No active search, press / to search"
+		} else {
+			p.statusMessage = fmt.Sprintf("No matches for /%s/", p.viewer.Query())
+		}
+		return
+	}
+	p.setMatchStatus()
+}
+
+// setMatchStatus reports the current search match position
+func (p *LogsPage) setMatchStatus() {
+	current, total := p.viewer.MatchPosition()
+	p.statusMessage = fmt.Sprintf("Match %d/%d for /%s/", current, total, p.viewer.Query())
+}
+
+// GetHelpKeys returns the help keys for the logs page
+func (p *LogsPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Up,
+		p.keys.Down,
+		p.keys.Top,
+		p.keys.Bottom,
+		p.keys.PageUp,
+		p.keys.PageDown,
+		p.keys.Pause,
+		p.keys.Follow,
+		p.keys.Wrap,
+		p.keys.Columns,
+		p.keys.Search,
+		p.keys.Filter,
+		p.keys.Next,
+		p.keys.Prev,
+		p.keys.LogType,
+		p.keys.History,
+		p.keys.Save,
+		p.keys.Mute,
+		p.keys.UnmuteAll,
+		p.keys.Reconnect,
+		p.keys.Help,
+		pages.BackKey,
+		pages.QuitKey,
+	}
+}
+
+// View rendering methods
+
+// View renders the logs page
+func (p *LogsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeLogs,
+	})
+}
+
+// ViewWithHelpContext renders the logs page with help context
+func (p *LogsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeLogs
+
+	contextInfo := p.contextInfo()
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Starting log stream...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeLogs,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeLogs,
+				Error:       err,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	contextInfo["stream"] = p.streamState()
+	if filter := p.viewer.Filter(); filter != "" {
+		contextInfo["filter"] = filter
+	}
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeLogs,
+		ContextInfo:   contextInfo,
+		Counters:      map[string]int{"line": p.viewer.Visible()},
+		FilterActive:  p.IsSearching(),
+		StatusMessage: p.statusMessage,
+	}
+
+	// Size the log to the space left by the status and help bars
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: statusContext,
+		HelpContext:   helpContext,
+	})
+
+	return p.layoutSystem.CreateTableLayout(
+		p.renderLog(contentWidth, contentHeight),
+		statusContext,
+		helpContext,
+	)
+}
+
+// renderLog renders the log, below the legend of the apps when tailing
+// several apps, and the search, filter or save prompt while typing
+func (p *LogsPage) renderLog(width, height int) string {
+	var rows []string
+	if p.IsAggregated() {
+		rows = append(rows, p.renderLegend(width))
+	}
+	input := &p.searchInput
+	if p.filterInput.Focused() {
+		input = &p.filterInput
+	}
+
+	viewerHeight := height - len(rows)
+	if input.Focused() || p.exportPrompt.Active() {
+		viewerHeight--
+	}
+	p.viewer.SetSize(width, viewerHeight)
+	rows = append(rows, p.viewer.View())
+	switch {
+	case input.Focused():
+		input.Width = max(1, width-lipgloss.Width(input.Prompt)-1)
+		rows = append(rows, input.View())
+	case p.exportPrompt.Active():
+		rows = append(rows, p.exportPrompt.View(width))
+	}
+	return lipgloss.JoinVertical(lipgloss.Left, rows...)
+}
+
+// mutedStyle is the style of the muted apps in the legend
+var mutedStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#666666")).Strikethrough(true)
+
+// renderLegend renders the tags of the apps tailed in their colors, each after
+// the key muting it, the muted ones dimmed, then the lines per second received
+// unless the streams have ended
+func (p *LogsPage) renderLegend(width int) string {
+	var items []string
+	for i, tag := range p.sourceTags() {
+		style := p.viewer.SourceStyle(tag)
+		if p.viewer.Muted(tag) {
+			style = mutedStyle
+		}
+		item := style.Render("[" + tag + "]")
+		if i < 9 {
+			item = fmt.Sprintf("%d %s", i+1, item)
+		}
+		items = append(items, item)
+	}
+	if !p.ended {
+		items = append(items, fmt.Sprintf("%.1f lines/s", p.Rate()))
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(items, "  "))
+}
+
+// contextInfo describes the source of the logs for the status bar
+func (p *LogsPage) contextInfo() map[string]string {
+	source := p.GetSource()
+	if p.IsAggregated() {
+		return map[string]string{
+			"app":  fmt.Sprintf("%d apps", len(p.sources)),
+			"logs": string(logTypeOf(source)),
+		}
+	}
+
+	info := map[string]string{"app": source.App.Name}
+	if source.Revision != "" {
+		info["revision"] = source.Revision
+	}
+	if source.Replica != "" {
+		info["replica"] = source.Replica
+	}
+	if source.Container != "" {
+		info["container"] = source.Container
+	}
+	info["logs"] = string(logTypeOf(source))
+	return info
+}
+
+// logTypeOf returns the type of the logs of a source, console logs by default
+func logTypeOf(source models.LogSource) models.LogType {
+	logType := source.Type
+	if logType == "" {
+		logType = models.LogTypeConsole
+	}
+	return logType
+}
+
+// nextLogType returns the log type the log type key switches to
+func nextLogType(logType models.LogType) models.LogType {
+	switch logType {
+	case models.LogTypeSystem:
+		return models.LogTypeCombined
+	case models.LogTypeCombined:
+		return models.LogTypeConsole
+	default:
+		return models.LogTypeSystem
+	}
+}
+
+// streamState describes whether the log is following, paused, scrolled or has ended
+func (p *LogsPage) streamState() string {
+	var state string
+	switch {
+	case p.viewer.Paused():
+		state = fmt.Sprintf("paused, %d new", p.viewer.Pending())
+	case p.ended:
+		state = "ended"
+	case p.viewer.Following():
+		state = "following"
+	default:
+		state = "scrolled"
+	}
+	if p.viewer.Wrapping() {
+		state += ", wrap"
+	}
+	return state
+}
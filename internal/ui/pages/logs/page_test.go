@@ -0,0 +1,271 @@
+package logs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/components/logexport"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// typeSearch types a search pattern and submits it
+func typeSearch(page *LogsPage, pattern string) {
+	page.HandleKeyMsg(runeKey("/"))
+	for _, r := range pattern {
+		page.HandleKeyMsg(runeKey(string(r)))
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+// Test that streamed lines are shown with the source in the status bar
+func TestLogsPageLines(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewLogsPage(layoutSystem)
+	page.SetSource(models.LogSource{App: models.ContainerApp{Name: "web-app"}, Revision: "web-app--v2"})
+	page.AppendLines([]string{"INFO started", "ERROR connection refused"})
+
+	view := page.View()
+	for _, want := range []string{"connection refused", "web-app--v2", "stream: following", "2 lines"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View should contain %q", want)
+		}
+	}
+
+	page.SetEnded(errors.New("exit status 1"))
+	if !page.IsEnded() || !strings.Contains(page.View(), "stream: ended") {
+		t.Error("View should show the stream has ended")
+	}
+
+	page.ClearData()
+	if page.GetViewer().Len() != 0 || page.IsEnded() {
+		t.Error("ClearData should remove the lines and reset the stream state")
+	}
+}
+
+// Test pausing, searching and the page actions
+func TestLogsPageKeys(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewLogsPage(layoutSystem)
+	page.AppendLines([]string{"INFO started"})
+
+	page.HandleKeyMsg(runeKey("p"))
+	page.AppendLines([]string{"WARN slow request", "ERROR timeout"})
+	if !strings.Contains(page.View(), "paused, 2 new") {
+		t.Error("View should count the lines held back while paused")
+	}
+	page.HandleKeyMsg(runeKey(" "))
+	if page.GetViewer().Paused() || page.GetViewer().Len() != 3 {
+		t.Error("Space should resume and show the held back lines")
+	}
+
+	typeSearch(page, "slow|timeout")
+	if page.IsSearching() {
+		t.Error("Enter should end typing the search")
+	}
+	if current, total := page.GetViewer().MatchPosition(); current != 2 || total != 2 {
+		t.Errorf("Expected match 2/2, got %d/%d", current, total)
+	}
+
+	typeSearch(page, "[")
+	if !strings.Contains(page.View(), "Invalid pattern") {
+		t.Error("View should report an invalid pattern")
+	}
+
+	reconnected, back := false, false
+	page.SetReconnectFunc(func() tea.Cmd {
+		reconnected = true
+		return nil
+	})
+	page.SetBackFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+	if _, handled := page.HandleKeyMsg(runeKey("r")); !handled || !reconnected {
+		t.Error("r should call the reconnect function")
+	}
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
+
+// typeFilter replaces the field filter and submits it
+func typeFilter(page *LogsPage, query string) {
+	page.HandleKeyMsg(runeKey("F"))
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlU})
+	for _, r := range query {
+		page.HandleKeyMsg(runeKey(string(r)))
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+}
+
+// Test that structured lines are shown as columns and can be filtered by field
+func TestLogsPageFieldFilter(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewLogsPage(layoutSystem)
+	page.AppendLines([]string{
+		`{"TimeStamp":"2024-01-20T10:00:00Z","Log":"{\"level\":\"info\",\"msg\":\"request served\",\"traceId\":\"abc1\"}"}`,
+		`{"TimeStamp":"2024-01-20T10:00:01Z","Log":"{\"level\":\"error\",\"msg\":\"request failed\",\"traceId\":\"abc2\"}"}`,
+		`{"TimeStamp":"2024-01-20T10:00:02Z","Log":"{\"level\":\"error\",\"msg\":\"disk full\",\"traceId\":\"def3\"}"}`,
+	})
+
+	view := page.View()
+	if !strings.Contains(view, "MESSAGE") || !strings.Contains(view, "10:00:01.000 ERROR abc2") {
+		t.Error("View should show structured lines as columns")
+	}
+
+	typeFilter(page, "level=error traceId=abc*")
+	if page.IsSearching() {
+		t.Error("Enter should end typing the filter")
+	}
+	view = page.View()
+	if page.GetViewer().Visible() != 1 || strings.Contains(view, "disk full") {
+		t.Errorf("Expected only the matching line, got %d visible", page.GetViewer().Visible())
+	}
+	if !strings.Contains(view, "filter: level=error traceId=abc*") || !strings.Contains(view, "1 line") {
+		t.Error("View should show the filter and the number of matching lines")
+	}
+
+	// Reconnecting keeps the filter
+	page.ClearData()
+	if page.GetViewer().Filter() != "level=error traceId=abc*" {
+		t.Error("ClearData should keep the filter")
+	}
+
+	typeFilter(page, "=error")
+	if !strings.Contains(page.View(), "Invalid filter") {
+		t.Error("View should report an invalid filter")
+	}
+
+	page.HandleKeyMsg(runeKey("c"))
+	if page.GetViewer().Columns() {
+		t.Error("c should turn columns off")
+	}
+}
+
+// Test switching between console, system and combined logs
+func TestLogsPageLogType(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewLogsPage(layoutSystem)
+	page.SetSource(models.LogSource{App: models.ContainerApp{Name: "web-app"}})
+	if !strings.Contains(page.View(), "logs: console") {
+		t.Error("View should show console logs by default")
+	}
+
+	var switched []models.LogType
+	page.SetLogTypeFunc(func(logType models.LogType) tea.Cmd {
+		switched = append(switched, logType)
+		page.SetSource(models.LogSource{App: models.ContainerApp{Name: "web-app"}, Type: logType})
+		return nil
+	})
+	for range 3 {
+		page.HandleKeyMsg(runeKey("t"))
+	}
+	if len(switched) != 3 || switched[0] != models.LogTypeSystem || switched[1] != models.LogTypeCombined || switched[2] != models.LogTypeConsole {
+		t.Errorf("Expected t to cycle through system, combined and console, got %v", switched)
+	}
+
+	// Combined logs show the stream of each line
+	page.SetSource(models.LogSource{App: models.ContainerApp{Name: "web-app"}, Type: models.LogTypeCombined})
+	page.AppendStreamLines(1, []string{"2024-01-20T10:00:02Z provisioning failed"})
+	page.AppendStreamLines(0, []string{"2024-01-20T10:00:01Z started"})
+	view := page.View()
+	if !strings.Contains(view, "logs: combined") || !strings.Contains(view, "[console] 2024-01-20T10:00:01Z started") {
+		t.Error("View should show combined logs with the stream of each line")
+	}
+	if lines := page.GetViewer().Lines(); lines[0] != "2024-01-20T10:00:01Z started" {
+		t.Errorf("Expected the lines in order of time, got %v", lines)
+	}
+}
+
+// Test tailing the logs of several apps together, muting some of them
+func TestLogsPageAggregated(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(160, 24)
+	page := NewLogsPage(layoutSystem)
+	now := time.Date(2024, 1, 20, 10, 0, 5, 0, time.UTC)
+	page.SetNow(func() time.Time { return now })
+	page.SetSources([]models.LogSource{
+		{App: models.ContainerApp{Name: "web-app"}, Revision: "web-app--v2"},
+		{App: models.ContainerApp{Name: "worker"}, Revision: "worker--v1"},
+	})
+	page.GetViewer().SetColumns(false)
+	if !page.IsAggregated() || len(page.Streams()) != 2 || page.StreamName(1) != "worker/worker--v1 console" {
+		t.Fatalf("Expected a console stream per app, got %v", page.Streams())
+	}
+
+	page.AppendStreamLines(1, []string{"2024-01-20T10:00:02Z job picked up"})
+	page.AppendStreamLines(0, []string{`{"TimeStamp":"2024-01-20T10:00:01Z","Log":"request served","ContainerName":"api"}`})
+	view := page.View()
+	for _, want := range []string{"app: 2 apps", "1 [web-app/web-app--v2]", "[web-app/web-app--v2/api]", "[worker/worker--v1]", "2.0 lines/s"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View should contain %q", want)
+		}
+	}
+	if lines := page.GetViewer().Lines(); !strings.Contains(lines[0], "request served") {
+		t.Errorf("Expected the lines of both apps in order of time, got %v", lines)
+	}
+
+	// Muted apps stay muted when reconnecting
+	page.HandleKeyMsg(runeKey("2"))
+	page.ClearData()
+	page.AppendStreamLines(1, []string{"hidden"})
+	page.AppendStreamLines(0, []string{"shown"})
+	if page.GetViewer().Visible() != 1 {
+		t.Errorf("Expected the lines of the muted app hidden, got %d visible", page.GetViewer().Visible())
+	}
+	page.HandleKeyMsg(runeKey("0"))
+	if page.GetViewer().Visible() != 2 {
+		t.Errorf("Expected every line after unmuting all, got %d visible", page.GetViewer().Visible())
+	}
+
+	// The query history covers a single app
+	var opened bool
+	page.SetShowHistoryFunc(func() tea.Cmd { opened = true; return nil })
+	page.HandleKeyMsg(runeKey("h"))
+	if opened {
+		t.Error("Query history should not open for several apps")
+	}
+}
+
+// Test saving the lines shown to a file
+func TestLogsPageSave(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(160, 24)
+	page := NewLogsPage(layoutSystem)
+	page.SetNow(func() time.Time { return time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC) })
+	page.SetSource(models.LogSource{App: models.ContainerApp{Name: "web-app"}, Revision: "web-app--v2"})
+	page.AppendLines([]string{"2024-01-20T09:00:00Z INFO started", "2024-01-20T09:58:00Z ERROR failed"})
+
+	page.HandleKeyMsg(runeKey("s"))
+	if !page.IsSearching() || !strings.Contains(page.View(), "web-app_web-app--v2-20240120-100000.log") {
+		t.Fatal("Expected the save prompt with a default path")
+	}
+
+	// Only the lines of the time window chosen are saved
+	path := filepath.Join(t.TempDir(), "web.ndjson")
+	for range 4 {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyShiftTab})
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyCtrlU})
+	page.HandleKeyMsg(runeKey(path))
+	cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if cmd == nil || page.IsSearching() {
+		t.Fatal("Expected a command saving the logs")
+	}
+	page.SetSaved(cmd().(logexport.SavedMsg))
+
+	if want := "Saved 1 lines to " + path + " as ndjson"; page.statusMessage != want {
+		t.Errorf("Expected %q in the status bar, got %q", want, page.statusMessage)
+	}
+	if b, err := os.ReadFile(path); err != nil || !strings.Contains(string(b), `"message":"ERROR failed"`) || strings.Contains(string(b), "started") {
+		t.Errorf("Expected the lines of the last 15 minutes as NDJSON, got %q (%v)", b, err)
+	}
+}
@@ -0,0 +1,396 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/components/sparkline"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// MetricsPage represents the metrics page using the new page interface system.
+// It displays the CPU, memory, request and restart metrics of an app or revision
+// over a selectable time window, with a trend sparkline per metric.
+type MetricsPage struct {
+	*pages.ReadOnlyPage[models.MetricSeries]
+
+	// Navigation context
+	appName      string
+	appID        string
+	revisionName string
+
+	// Selected time window
+	window models.MetricsWindow
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys MetricsKeyMap
+
+	// Callback functions
+	windowChangedFunc func(models.MetricsWindow) tea.Cmd
+	backFunc          func() tea.Cmd
+}
+
+// MetricsKeyMap defines the key bindings for the metrics page
+type MetricsKeyMap struct {
+	Window     key.Binding
+	NextWindow key.Binding
+	Refresh    key.Binding
+	Help       key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+}
+
+// metricNames are the display names of the metrics, in display order
+var metricNames = []struct{ metric, name string }{
+	{models.MetricCPU, "CPU"},
+	{models.MetricMemory, "Memory"},
+	{models.MetricRequests, "Requests"},
+	{models.MetricRestarts, "Restarts"},
+}
+
+// NewMetricsPage creates a new metrics page
+func NewMetricsPage(layoutSystem *layouts.LayoutSystem) *MetricsPage {
+	// Create the base read-only page
+	basePage := pages.NewReadOnlyPage[models.MetricSeries]("Filter metrics...")
+
+	// Create the metrics page
+	page := &MetricsPage{
+		ReadOnlyPage: basePage,
+		layoutSystem: layoutSystem,
+		keys:         defaultMetricsKeyMap(),
+		window:       models.MetricsWindows[0],
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createMetricsTable)
+
+	return page
+}
+
+// defaultMetricsKeyMap returns the default key bindings for metrics
+func defaultMetricsKeyMap() MetricsKeyMap {
+	return MetricsKeyMap{
+		Window: key.NewBinding(
+			key.WithKeys("1", "2", "3", "4"),
+			key.WithHelp("1-4", "time window"),
+		),
+		NextWindow: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "next window"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetAppContext sets the app, and the revision unless it is empty, the metrics are shown for
+func (p *MetricsPage) SetAppContext(appName, appID, revisionName string) {
+	p.appName = appName
+	p.appID = appID
+	p.revisionName = revisionName
+}
+
+// GetAppID returns the ID of the app the metrics are shown for
+func (p *MetricsPage) GetAppID() string {
+	return p.appID
+}
+
+// GetRevisionName returns the revision the metrics are shown for, empty for the whole app
+func (p *MetricsPage) GetRevisionName() string {
+	return p.revisionName
+}
+
+// SetWindow sets the time window the metrics are shown over
+func (p *MetricsPage) SetWindow(window models.MetricsWindow) {
+	p.window = window
+}
+
+// GetWindow returns the time window the metrics are shown over
+func (p *MetricsPage) GetWindow() models.MetricsWindow {
+	return p.window
+}
+
+// SetWindowChangedFunc sets the function to call when another time window is selected
+func (p *MetricsPage) SetWindowChangedFunc(fn func(models.MetricsWindow) tea.Cmd) {
+	p.windowChangedFunc = fn
+}
+
+// SetBackFunc sets the function to call when navigating back
+func (p *MetricsPage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// contextInfo returns the navigation context shown in the status bar
+func (p *MetricsPage) contextInfo() map[string]string {
+	info := map[string]string{"app": p.appName, "window": p.window.Name}
+	if p.revisionName != "" {
+		info["revision"] = p.revisionName
+	}
+	return info
+}
+
+// selectWindow switches to another time window, doing nothing if it is already selected
+func (p *MetricsPage) selectWindow(window models.MetricsWindow) tea.Cmd {
+	if window.Name == p.window.Name {
+		return nil
+	}
+	p.window = window
+	if p.windowChangedFunc != nil {
+		return p.windowChangedFunc(window)
+	}
+	return nil
+}
+
+// nextWindow returns the time window following the selected one, wrapping around
+func (p *MetricsPage) nextWindow() models.MetricsWindow {
+	for i, window := range models.MetricsWindows {
+		if window.Name == p.window.Name {
+			return models.MetricsWindows[(i+1)%len(models.MetricsWindows)]
+		}
+	}
+	return models.MetricsWindows[0]
+}
+
+// Table creation methods
+
+// createMetricsTable creates a table for displaying metrics
+func (p *MetricsPage) createMetricsTable(data []models.MetricSeries) table.Model {
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// The trend takes the width left by the other columns and their borders,
+	// and at least one character per point
+	const statsWidth = 12 + 14*4 + 7
+	trendWidth := max(p.window.Points(), contentWidth-statsWidth)
+
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("metric", "Metric", 12, false).      // Fixed width
+		AddColumn("latest", "Latest", 14, false).      // Fixed width
+		AddColumn("min", "Min", 14, false).            // Fixed width
+		AddColumn("avg", "Avg", 14, false).            // Fixed width
+		AddColumn("max", "Max", 14, false).            // Fixed width
+		AddColumn("trend", "Trend", trendWidth, false) // Fixed width (remaining space)
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, series := range data {
+			latest, minimum, average, maximum := "-", "-", "-", "-"
+			trend := "-"
+			if len(series.Points) > 0 {
+				l, mn, avg, mx := series.Stats()
+				latest = formatMetricValue(series.Name, l)
+				minimum = formatMetricValue(series.Name, mn)
+				average = formatMetricValue(series.Name, avg)
+				maximum = formatMetricValue(series.Name, mx)
+				trend = sparkline.Render(stretch(series.Values(), trendWidth), trendWidth)
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"metric": metricDisplayName(series.Name),
+				"latest": latest,
+				"min":    minimum,
+				"avg":    average,
+				"max":    maximum,
+				"trend":  trend,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// SetData sets the metrics, ordered as CPU, memory, requests and restarts
+func (p *MetricsPage) SetData(metrics models.MetricSet) {
+	ordered := make([]models.MetricSeries, 0, len(metricNames))
+	for _, m := range metricNames {
+		ordered = append(ordered, metrics.Get(m.metric))
+	}
+	p.ReadOnlyPage.SetData(ordered)
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the metrics page
+func (p *MetricsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Handle metrics specific keys before the read-only page
+	if !p.GetFilterInput().Focused() {
+		switch msg.String() {
+		case "1", "2", "3", "4":
+			index := int(msg.String()[0] - '1')
+			return p.selectWindow(models.MetricsWindows[index]), true
+		case "w":
+			return p.selectWindow(p.nextWindow()), true
+		case "esc":
+			if p.backFunc != nil {
+				return p.backFunc(), true
+			}
+			return nil, true
+		case "?":
+			// Help toggle - let the parent handle this
+			return nil, false
+		}
+	}
+
+	return p.ReadOnlyPage.HandleKeyMsg(msg)
+}
+
+// GetHelpKeys returns the help keys for the metrics page
+func (p *MetricsPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Window,
+		p.keys.NextWindow,
+		p.keys.Refresh,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the metrics page
+func (p *MetricsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeMetrics,
+	})
+}
+
+// ViewWithHelpContext renders the metrics page with help context
+func (p *MetricsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeMetrics
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			fmt.Sprintf("Loading metrics of the last %s...", p.window.Name),
+			layouts.StatusContext{
+				Mode:        layouts.ModeMetrics,
+				ContextInfo: p.contextInfo(),
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeMetrics,
+				Error:       err,
+				ContextInfo: p.contextInfo(),
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:          layouts.ModeMetrics,
+			ContextInfo:   p.contextInfo(),
+			StatusMessage: fmt.Sprintf("Last %s, one point every %s", p.window.Name, formatInterval(p.window)),
+		},
+		helpContext,
+	)
+}
+
+// Helper functions
+
+// metricDisplayName returns the name a metric is shown with
+func metricDisplayName(metric string) string {
+	for _, m := range metricNames {
+		if m.metric == metric {
+			return m.name
+		}
+	}
+	return metric
+}
+
+// formatMetricValue formats a metric value in the unit it is best read in
+func formatMetricValue(metric string, value float64) string {
+	switch metric {
+	case models.MetricCPU:
+		return fmt.Sprintf("%.3f cores", value/1e9)
+	case models.MetricMemory:
+		const mib = 1024 * 1024
+		if value >= 1024*mib {
+			return fmt.Sprintf("%.2f GiB", value/(1024*mib))
+		}
+		return fmt.Sprintf("%.0f MiB", value/mib)
+	case models.MetricRequests, models.MetricRestarts:
+		return fmt.Sprintf("%.0f", value)
+	default:
+		return fmt.Sprintf("%.2f", value)
+	}
+}
+
+// stretch repeats every value as often as fits in width, so short series fill the trend column
+func stretch(values []float64, width int) []float64 {
+	repeat := width / max(1, len(values))
+	if repeat <= 1 {
+		return values
+	}
+	stretched := make([]float64, 0, len(values)*repeat)
+	for _, v := range values {
+		for range repeat {
+			stretched = append(stretched, v)
+		}
+	}
+	return stretched
+}
+
+// formatInterval returns the interval of the points of a window, e.g. "5m" or "6h"
+func formatInterval(window models.MetricsWindow) string {
+	if window.Interval%time.Hour == 0 {
+		return fmt.Sprintf("%dh", int(window.Interval.Hours()))
+	}
+	return fmt.Sprintf("%dm", int(window.Interval.Minutes()))
+}
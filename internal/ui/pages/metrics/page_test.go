@@ -0,0 +1,100 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestMetrics() models.MetricSet {
+	start := time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC)
+	series := func(name string, values ...float64) models.MetricSeries {
+		s := models.MetricSeries{Name: name}
+		for i, v := range values {
+			s.Points = append(s.Points, models.MetricPoint{Time: start.Add(time.Duration(i) * 5 * time.Minute), Value: v})
+		}
+		return s
+	}
+	// Requests are left out, as for an app without ingress
+	return models.MetricSet{
+		series(models.MetricMemory, 512*1024*1024, 1536*1024*1024),
+		series(models.MetricCPU, 0.25e9, 0.5e9, 0.75e9),
+		series(models.MetricRestarts, 0, 0, 1),
+	}
+}
+
+// Test metric formatting and ordering
+func TestMetricsPageData(t *testing.T) {
+	if got := formatMetricValue(models.MetricCPU, 0.25e9); got != "0.250 cores" {
+		t.Errorf("Expected cores, got %q", got)
+	}
+	if got := formatMetricValue(models.MetricMemory, 512*1024*1024); got != "512 MiB" {
+		t.Errorf("Expected MiB, got %q", got)
+	}
+	if got := formatMetricValue(models.MetricMemory, 1536*1024*1024); got != "1.50 GiB" {
+		t.Errorf("Expected GiB, got %q", got)
+	}
+
+	layoutSystem := layouts.NewLayoutSystem(160, 24)
+	page := NewMetricsPage(layoutSystem)
+	page.SetAppContext("web-app", "rg/web-app", "web-app--v2")
+	page.SetData(createTestMetrics())
+
+	data := page.GetData()
+	want := []string{models.MetricCPU, models.MetricMemory, models.MetricRequests, models.MetricRestarts}
+	if len(data) != len(want) {
+		t.Fatalf("Expected %d metrics, got %d", len(want), len(data))
+	}
+	for i, name := range want {
+		if data[i].Name != name {
+			t.Errorf("Expected %s at row %d, got %s", name, i, data[i].Name)
+		}
+	}
+
+	view := page.View()
+	for _, want := range []string{"0.500 cores", "1.50 GiB", "web-app--v2", "window: 1h"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View should contain %q", want)
+		}
+	}
+}
+
+// Test time window selection
+func TestMetricsPageWindows(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewMetricsPage(layoutSystem)
+
+	var selected []string
+	page.SetWindowChangedFunc(func(window models.MetricsWindow) tea.Cmd {
+		selected = append(selected, window.Name)
+		return nil
+	})
+
+	for _, key := range []string{"3", "3", "w", "w", "1"} {
+		if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)}); !handled {
+			t.Errorf("Key %q should be handled", key)
+		}
+	}
+
+	// Selecting the current window again does not reload
+	if got := strings.Join(selected, ","); got != "24h,7d,1h" {
+		t.Errorf("Expected windows 24h,7d,1h, got %s", got)
+	}
+	if page.GetWindow().Name != "1h" {
+		t.Errorf("Expected the 1h window, got %s", page.GetWindow().Name)
+	}
+
+	back := false
+	page.SetBackFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
@@ -0,0 +1,223 @@
+package operations
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// OperationsPage represents the operation log page using the new page interface system.
+// It displays the results of actions that changed Azure resources in a read-only table format.
+type OperationsPage struct {
+	*pages.ReadOnlyPage[models.Operation]
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys OperationsKeyMap
+
+	// Back navigation function
+	backFunc func() tea.Cmd
+}
+
+// OperationsKeyMap defines the key bindings for the operation log page
+type OperationsKeyMap struct {
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewOperationsPage creates a new operation log page
+func NewOperationsPage(layoutSystem *layouts.LayoutSystem) *OperationsPage {
+	// Create the base read-only page
+	basePage := pages.NewReadOnlyPage[models.Operation]("Filter operations...")
+
+	// Create the operations page
+	page := &OperationsPage{
+		ReadOnlyPage: basePage,
+		layoutSystem: layoutSystem,
+		keys:         defaultOperationsKeyMap(),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createOperationsTable)
+
+	return page
+}
+
+// defaultOperationsKeyMap returns the default key bindings for the operation log
+func defaultOperationsKeyMap() OperationsKeyMap {
+	return OperationsKeyMap{
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetBackFunc sets the function to call when navigating back
+func (p *OperationsPage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// Table creation methods
+
+// createOperationsTable creates a table for displaying the operation log
+func (p *OperationsPage) createOperationsTable(data []models.Operation) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("time", "Time", 20, false).          // Fixed width
+		AddColumn("operation", "Operation", 18, true). // Fixed width
+		AddColumn("resource", "Resource", 20, true).   // Dynamic width, min 20
+		AddColumn("target", "Target", 20, true).       // Dynamic width, min 20
+		AddColumn("result", "Result", 10, true).       // Fixed width
+		AddColumn("message", "Message", 60, false)     // Fixed width (longest content)
+
+	// Update dynamic column widths based on actual content
+	for _, op := range data {
+		builder.UpdateWidthFromString("resource", op.Resource)
+		builder.UpdateWidthFromString("target", op.Target)
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, op := range data {
+			result := "Succeeded"
+			if !op.Succeeded {
+				result = "Failed"
+			}
+
+			target := op.Target
+			if target == "" {
+				target = "-"
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"time":      op.Time.Format("2006-01-02 15:04:05"),
+				"operation": op.Name,
+				"resource":  op.Resource,
+				"target":    target,
+				"result":    table.NewStyledCell(result, lipgloss.NewStyle().Foreground(pages.GetStatusColor(result))),
+				"message":   op.Message,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config).SortByDesc("time")
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the operation log page
+func (p *OperationsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Handle operation log specific keys before the read-only page
+	if !p.GetFilterInput().Focused() {
+		switch msg.String() {
+		case "esc":
+			if p.backFunc != nil {
+				return p.backFunc(), true
+			}
+			return nil, true
+		case "r":
+			// The log is updated as operations complete, there is nothing to reload
+			return nil, true
+		case "?":
+			// Help toggle - let the parent handle this
+			return nil, false
+		case "j", "k", "up", "down":
+			// Navigation keys - let the parent handle these
+			return nil, false
+		}
+	}
+
+	return p.ReadOnlyPage.HandleKeyMsg(msg)
+}
+
+// GetHelpKeys returns the help keys for the operation log page
+func (p *OperationsPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the operation log page
+func (p *OperationsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeOperations,
+	})
+}
+
+// ViewWithHelpContext renders the operation log page with help context
+func (p *OperationsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeOperations
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:     layouts.ModeOperations,
+			Counters: map[string]int{"operation": len(p.GetData())},
+		},
+		helpContext,
+	)
+}
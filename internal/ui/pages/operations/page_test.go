@@ -0,0 +1,77 @@
+package operations
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestOperations() []models.Operation {
+	start := time.Date(2024, 1, 22, 10, 0, 0, 0, time.UTC)
+	return []models.Operation{
+		{
+			Time:      start,
+			Name:      "restart revision",
+			Resource:  "rg-prod/web-frontend",
+			Target:    "web-frontend--abc123",
+			Succeeded: true,
+			Message:   "Restarted revision web-frontend--abc123.",
+		},
+		{
+			Time:      start.Add(time.Minute),
+			Name:      "stop execution",
+			Resource:  "rg-prod/nightly-report",
+			Target:    "nightly-report-7hq3m2p",
+			Succeeded: false,
+			Message:   "Failed to stop execution nightly-report-7hq3m2p: execution is not running",
+		},
+	}
+}
+
+// Test that operations are shown newest first
+func TestOperationsPageData(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(200, 24)
+	page := NewOperationsPage(layoutSystem)
+	page.SetData(createTestOperations())
+
+	table := page.GetTable()
+	if table.TotalRows() != 2 {
+		t.Fatalf("Expected 2 rows, got %d", table.TotalRows())
+	}
+
+	view := page.View()
+	first := strings.Index(view, "nightly-report-7hq3m2p")
+	second := strings.Index(view, "web-frontend--abc123")
+	if first < 0 || second < 0 || first > second {
+		t.Error("Expected the newest operation to be listed first")
+	}
+}
+
+// Test back navigation and refresh handling
+func TestOperationsPageKeys(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(80, 24)
+	page := NewOperationsPage(layoutSystem)
+	page.SetData(createTestOperations())
+
+	back := false
+	page.SetBackFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")}); !handled {
+		t.Error("Refresh key 'r' should be handled")
+	}
+	if page.IsLoading() {
+		t.Error("Refreshing the operation log should not leave it loading")
+	}
+
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
@@ -0,0 +1,255 @@
+package portforwards
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// PortForwardsPage represents the port forwards page using the new page interface system.
+// It displays the loopback ports forwarded to the ingress of apps, with the
+// traffic they forwarded, in an actionable table format.
+type PortForwardsPage struct {
+	*pages.ActionablePage[models.PortForward]
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys PortForwardsKeyMap
+
+	// Action functions
+	stopFunc func(models.PortForward) tea.Cmd
+
+	// now returns the current time used for uptimes; overridable for tests
+	now func() time.Time
+}
+
+// PortForwardsKeyMap defines the key bindings for the port forwards page
+type PortForwardsKeyMap struct {
+	Stop        key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewPortForwardsPage creates a new port forwards page
+func NewPortForwardsPage(layoutSystem *layouts.LayoutSystem) *PortForwardsPage {
+	// Create the base actionable page
+	basePage := pages.NewActionablePage[models.PortForward]("Filter port forwards...")
+
+	// Create the port forwards page
+	page := &PortForwardsPage{
+		ActionablePage: basePage,
+		layoutSystem:   layoutSystem,
+		keys:           defaultPortForwardsKeyMap(),
+		now:            time.Now,
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createPortForwardsTable)
+
+	// Set up actions
+	page.setupActions()
+
+	return page
+}
+
+// defaultPortForwardsKeyMap returns the default key bindings for port forwards
+func defaultPortForwardsKeyMap() PortForwardsKeyMap {
+	return PortForwardsKeyMap{
+		Stop: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "stop"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetStopFunc sets the function to call for stopping a port forward
+func (p *PortForwardsPage) SetStopFunc(fn func(models.PortForward) tea.Cmd) {
+	p.stopFunc = fn
+}
+
+// SetForwards updates the port forwards shown, keeping the highlighted row
+func (p *PortForwardsPage) SetForwards(forwards []models.PortForward) {
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.SetData(forwards)
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+	// The forwards are read in place, there is nothing left to load
+	p.SetLoading(false)
+}
+
+// SetStatusMessage sets the feedback shown in the status bar
+func (p *PortForwardsPage) SetStatusMessage(message string) {
+	p.statusMessage = message
+}
+
+// Action setup
+
+// setupActions configures the available actions for the port forwards page
+func (p *PortForwardsPage) setupActions() {
+	// Add stop action, the port can be forwarded again from the apps
+	p.AddAction("stop", p.keys.Stop, func(forward models.PortForward) tea.Cmd {
+		if p.stopFunc != nil {
+			return p.stopFunc(forward)
+		}
+		return nil
+	})
+}
+
+// Table creation methods
+
+// createPortForwardsTable creates a table for displaying port forwards
+func (p *PortForwardsPage) createPortForwardsTable(data []models.PortForward) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("app", "App", 15, true).            // Dynamic width, min 15
+		AddColumn("local", "Local", 22, false).       // Fixed width
+		AddColumn("remote", "Remote", 30, true).      // Dynamic width, min 30
+		AddColumn("status", "Status", 10, true).      // Fixed width
+		AddColumn("connections", "Conns", 9, false).  // Fixed width
+		AddColumn("sent", "Sent", 10, false).         // Fixed width
+		AddColumn("received", "Received", 10, false). // Fixed width
+		AddColumn("uptime", "Uptime", 10, false)      // Fixed width
+
+	// Update dynamic column widths based on actual content
+	for _, forward := range data {
+		builder.UpdateWidthFromString("app", forward.App.Name)
+		builder.UpdateWidthFromString("remote", forward.Remote)
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, forward := range data {
+			status := "Running"
+			if forward.Err != nil {
+				status = "Failed"
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"app":         forward.App.Name,
+				"local":       "http://" + forward.LocalAddr,
+				"remote":      forward.Remote,
+				"status":      table.NewStyledCell(status, lipgloss.NewStyle().Foreground(pages.GetStatusColor(status))),
+				"connections": fmt.Sprintf("%d/%d", forward.Active, forward.Connections),
+				"sent":        pages.FormatBytes(forward.BytesSent),
+				"received":    pages.FormatBytes(forward.BytesReceived),
+				"uptime":      p.now().Sub(forward.StartedAt).Round(time.Second).String(),
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// Event handling methods
+
+// GetHelpKeys returns the help keys for the port forwards page
+func (p *PortForwardsPage) GetHelpKeys() []key.Binding {
+	baseKeys := []key.Binding{
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+
+	// Add action keys
+	actionKeys := p.GetActionKeys()
+	return append(baseKeys, actionKeys...)
+}
+
+// View rendering methods
+
+// View renders the port forwards page
+func (p *PortForwardsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModePortForwards,
+	})
+}
+
+// ViewWithHelpContext renders the port forwards page with help context
+func (p *PortForwardsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModePortForwards
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:          layouts.ModePortForwards,
+			Counters:      map[string]int{"forward": len(p.GetData())},
+			StatusMessage: p.statusMessage,
+		},
+		helpContext,
+	)
+}
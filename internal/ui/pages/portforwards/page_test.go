@@ -0,0 +1,126 @@
+package portforwards
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+var testNow = time.Date(2024, 1, 22, 10, 0, 0, 0, time.UTC)
+
+// Simple test data
+func createTestForwards() []models.PortForward {
+	return []models.PortForward{
+		{
+			ID:            1,
+			App:           models.ContainerApp{Name: "web-frontend", ResourceGroup: "rg-prod"},
+			LocalAddr:     "127.0.0.1:8080",
+			Remote:        "https://web-frontend.example.azurecontainerapps.io",
+			StartedAt:     testNow.Add(-90 * time.Second),
+			Connections:   3,
+			Active:        1,
+			BytesSent:     512,
+			BytesReceived: 1536,
+		},
+		{
+			ID:        2,
+			App:       models.ContainerApp{Name: "api-backend", ResourceGroup: "rg-prod"},
+			LocalAddr: "127.0.0.1:3000",
+			Remote:    "https://api-backend.example.azurecontainerapps.io",
+			StartedAt: testNow.Add(-time.Minute),
+			Err:       errors.New("accept tcp: use of closed network connection"),
+		},
+	}
+}
+
+// createTestPage creates a page with a fixed clock
+func createTestPage() *PortForwardsPage {
+	page := NewPortForwardsPage(layouts.NewLayoutSystem(200, 24))
+	page.now = func() time.Time { return testNow }
+	page.SetForwards(createTestForwards())
+	return page
+}
+
+// Test that forwards are listed with their traffic and status
+func TestPortForwardsPageData(t *testing.T) {
+	page := createTestPage()
+
+	table := page.GetTable()
+	if table.TotalRows() != 2 {
+		t.Fatalf("Expected 2 rows, got %d", table.TotalRows())
+	}
+
+	view := page.View()
+	for _, want := range []string{"http://127.0.0.1:8080", "1/3", "512 B", "1.5 KiB", "1m30s", "Running", "Failed"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to contain %q", want)
+		}
+	}
+}
+
+// Test the stop action and back navigation
+func TestPortForwardsPageKeys(t *testing.T) {
+	page := createTestPage()
+
+	var stopped []models.PortForward
+	page.SetStopFunc(func(forward models.PortForward) tea.Cmd {
+		stopped = append(stopped, forward)
+		return nil
+	})
+	back := false
+	page.SetBackFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("x")}); !handled {
+		t.Error("Stop key 'x' should be handled")
+	}
+	if len(stopped) != 1 || stopped[0].ID != 1 {
+		t.Errorf("Expected the selected forward to be stopped, got %v", stopped)
+	}
+
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
+
+// Test that updating the forwards keeps the highlighted row
+func TestPortForwardsPageKeepsSelection(t *testing.T) {
+	page := createTestPage()
+	page.SetTable(page.GetTable().WithHighlightedRow(1))
+
+	forwards := createTestForwards()
+	forwards[1].Connections = 5
+	page.SetForwards(forwards)
+
+	selected, ok := page.GetSelectedItem()
+	if !ok || selected.ID != 2 {
+		t.Errorf("Expected the second forward to stay selected, got %+v", selected)
+	}
+	if page.IsLoading() {
+		t.Error("Updating the forwards should not leave the page loading")
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := map[int64]string{
+		0:                  "0 B",
+		1023:               "1023 B",
+		1024:               "1.0 KiB",
+		5 * 1024 * 1024:    "5.0 MiB",
+		3 << 30:            "3.0 GiB",
+		1536 * 1024 * 1024: "1.5 GiB",
+	}
+	for n, want := range tests {
+		if got := pages.FormatBytes(n); got != want {
+			t.Errorf("FormatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
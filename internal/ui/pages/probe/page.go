@@ -0,0 +1,643 @@
+package probe
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// historyLimit is the most probes kept in the history
+const historyLimit = 100
+
+// formHeight is the number of lines of the request form, with the blank line below it
+const formHeight = 4
+
+// Methods are the HTTP methods cycled through with the method key
+var Methods = []string{
+	http.MethodGet,
+	http.MethodHead,
+	http.MethodPost,
+	http.MethodPut,
+	http.MethodPatch,
+	http.MethodDelete,
+	http.MethodOptions,
+}
+
+// Fields of the request form, in the order tab moves through them
+const (
+	fieldMethod = iota
+	fieldPath
+	fieldHeaders
+	fieldCount
+)
+
+// ProbePage sends HTTP requests to the ingress of an app or of one of its
+// revisions. The request is edited in a form above the history of the probes
+// sent, and the status, latency, headers and body of the probe selected in
+// the history are shown below it.
+type ProbePage struct {
+	*pages.ReadOnlyPage[models.Probe]
+
+	// Navigation context
+	app      string
+	revision string
+	fqdn     string
+
+	// Request form
+	inputs  [fieldCount]textinput.Model
+	editing bool
+	field   int
+
+	// Response pane state
+	shown  int // ID of the probe whose response is shown
+	offset int // lines of the response scrolled past
+
+	// Probes sent and not answered yet
+	pending int
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys ProbeKeyMap
+
+	// Callback functions
+	sendFunc func(target string, request models.ProbeRequest) tea.Cmd
+	backFunc func() tea.Cmd
+}
+
+// ProbeKeyMap defines the key bindings for the probe page
+type ProbeKeyMap struct {
+	Edit       key.Binding
+	Send       key.Binding
+	Method     key.Binding
+	Reuse      key.Binding
+	ScrollDown key.Binding
+	ScrollUp   key.Binding
+	Filter     key.Binding
+	Help       key.Binding
+	Back       key.Binding
+	Quit       key.Binding
+}
+
+// NewProbePage creates a new probe page
+func NewProbePage(layoutSystem *layouts.LayoutSystem) *ProbePage {
+	page := &ProbePage{
+		ReadOnlyPage: pages.NewReadOnlyPage[models.Probe]("Filter probes..."),
+		field:        fieldPath,
+		layoutSystem: layoutSystem,
+		keys:         defaultProbeKeyMap(),
+	}
+	for i, placeholder := range []string{"GET", "/", "Name: value; Name: value"} {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		page.inputs[i] = input
+	}
+	page.inputs[fieldMethod].SetValue(http.MethodGet)
+	page.inputs[fieldPath].SetValue("/")
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createHistoryTable)
+
+	return page
+}
+
+// defaultProbeKeyMap returns the default key bindings for probes
+func defaultProbeKeyMap() ProbeKeyMap {
+	return ProbeKeyMap{
+		Edit:       key.NewBinding(key.WithKeys("e"), key.WithHelp("e", "edit request")),
+		Send:       key.NewBinding(key.WithKeys("enter", "r", "ctrl+r"), key.WithHelp("enter/r", "send")),
+		Method:     key.NewBinding(key.WithKeys("m"), key.WithHelp("m", "next method")),
+		Reuse:      key.NewBinding(key.WithKeys("u"), key.WithHelp("u", "reuse request")),
+		ScrollDown: key.NewBinding(key.WithKeys("ctrl+d"), key.WithHelp("ctrl+d", "scroll response down")),
+		ScrollUp:   key.NewBinding(key.WithKeys("ctrl+u"), key.WithHelp("ctrl+u", "scroll response up")),
+		Filter:     key.NewBinding(key.WithKeys("/"), key.WithHelp("/", "filter")),
+		Help:       key.NewBinding(key.WithKeys("?"), key.WithHelp("?", "toggle help")),
+		Back:       key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "back")),
+		Quit:       key.NewBinding(key.WithKeys("q", "ctrl+c"), key.WithHelp("q", "quit")),
+	}
+}
+
+// Configuration methods
+
+// SetTarget sets the app probed, or one of its revisions when revision is
+// set, and the FQDN of its ingress. The request as edited and the history are kept.
+func (p *ProbePage) SetTarget(app, revision, fqdn string) {
+	p.app = app
+	p.revision = revision
+	p.fqdn = fqdn
+	p.statusMessage = ""
+}
+
+// GetTarget returns the app or revision probed
+func (p *ProbePage) GetTarget() string {
+	if p.revision != "" {
+		return p.revision
+	}
+	return p.app
+}
+
+// SetSendFunc sets the function to call for sending a request
+func (p *ProbePage) SetSendFunc(fn func(target string, request models.ProbeRequest) tea.Cmd) {
+	p.sendFunc = fn
+}
+
+// SetBackFunc sets the function to call when navigating back
+func (p *ProbePage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// Data management methods
+
+// AddProbe adds an answered probe to the top of the history and shows its response
+func (p *ProbePage) AddProbe(probe models.Probe) {
+	p.pending = max(0, p.pending-1)
+	history := append([]models.Probe{probe}, p.GetData()...)
+	if len(history) > historyLimit {
+		history = history[:historyLimit]
+	}
+	p.SetData(history)
+	p.SetTable(p.GetTable().WithHighlightedRow(0))
+
+	if probe.Err != nil {
+		p.statusMessage = fmt.Sprintf("%s %s failed: %v", probe.Request.Method, probe.Request.URL, probe.Err)
+	} else {
+		p.statusMessage = fmt.Sprintf("%s %s: %s in %s", probe.Request.Method, probe.Request.URL, probe.Response.Status, formatLatency(probe.Response.Latency))
+	}
+}
+
+// ClearData keeps the history, which is not reloaded, and stops editing
+func (p *ProbePage) ClearData() {
+	p.SetError(nil)
+	p.blur()
+}
+
+// IsEditing returns true while the request is being edited
+func (p *ProbePage) IsEditing() bool {
+	return p.editing
+}
+
+// Request returns the request as edited, sent to the ingress of the target
+func (p *ProbePage) Request() (models.ProbeRequest, error) {
+	if p.fqdn == "" {
+		return models.ProbeRequest{}, fmt.Errorf("%s has no ingress", p.GetTarget())
+	}
+	method := strings.ToUpper(strings.TrimSpace(p.inputs[fieldMethod].Value()))
+	if method == "" {
+		method = http.MethodGet
+	}
+	headers, err := ParseHeaders(p.inputs[fieldHeaders].Value())
+	if err != nil {
+		return models.ProbeRequest{}, err
+	}
+	path := strings.TrimSpace(p.inputs[fieldPath].Value())
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return models.ProbeRequest{
+		Method:  method,
+		URL:     "https://" + p.fqdn + path,
+		Headers: headers,
+	}, nil
+}
+
+// send sends the request as edited to the target
+func (p *ProbePage) send() tea.Cmd {
+	request, err := p.Request()
+	if err != nil {
+		p.statusMessage = err.Error()
+		return nil
+	}
+	if p.sendFunc == nil {
+		return nil
+	}
+	p.pending++
+	p.statusMessage = fmt.Sprintf("Sending %s %s...", request.Method, request.URL)
+	return p.sendFunc(p.GetTarget(), request)
+}
+
+// reuse loads the method, path and headers of the selected probe into the form
+func (p *ProbePage) reuse() {
+	probe, ok := p.GetSelectedItem()
+	if !ok {
+		return
+	}
+	path := requestPath(probe.Request)
+	p.inputs[fieldMethod].SetValue(probe.Request.Method)
+	p.inputs[fieldPath].SetValue(path)
+	p.inputs[fieldHeaders].SetValue(FormatHeaders(probe.Request.Headers))
+	p.statusMessage = fmt.Sprintf("Loaded %s %s, press 'enter' to send it to %s", probe.Request.Method, path, p.GetTarget())
+}
+
+// nextMethod sets the method following the one in the form
+func (p *ProbePage) nextMethod() {
+	method := strings.ToUpper(strings.TrimSpace(p.inputs[fieldMethod].Value()))
+	next := Methods[(slices.Index(Methods, method)+1)%len(Methods)]
+	p.inputs[fieldMethod].SetValue(next)
+}
+
+// focus starts editing a field of the form
+func (p *ProbePage) focus(field int) tea.Cmd {
+	p.blur()
+	p.editing = true
+	p.field = field
+	p.inputs[field].CursorEnd()
+	return p.inputs[field].Focus()
+}
+
+// blur stops editing the form
+func (p *ProbePage) blur() {
+	p.editing = false
+	for i := range p.inputs {
+		p.inputs[i].Blur()
+	}
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the probe page
+func (p *ProbePage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.editing {
+		return p.handleFormInput(msg)
+	}
+
+	// Handle probe specific keys before the read-only page
+	if !p.GetFilterInput().Focused() {
+		switch {
+		case key.Matches(msg, p.keys.Back):
+			if p.backFunc != nil {
+				return p.backFunc(), true
+			}
+			return nil, true
+		case key.Matches(msg, p.keys.Edit):
+			return p.focus(p.field), true
+		case key.Matches(msg, p.keys.Send):
+			return p.send(), true
+		case key.Matches(msg, p.keys.Method):
+			p.nextMethod()
+			return nil, true
+		case key.Matches(msg, p.keys.Reuse):
+			p.reuse()
+			return nil, true
+		case key.Matches(msg, p.keys.ScrollDown):
+			p.offset += p.responseHeight() / 2
+			return nil, true
+		case key.Matches(msg, p.keys.ScrollUp):
+			p.offset = max(0, p.offset-p.responseHeight()/2)
+			return nil, true
+		case key.Matches(msg, p.keys.Help):
+			// Help toggle - let the parent handle this
+			return nil, false
+		}
+	}
+
+	return p.ReadOnlyPage.HandleKeyMsg(msg)
+}
+
+// handleFormInput handles keys while the request is being edited
+func (p *ProbePage) handleFormInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "tab", "down":
+		return p.focus((p.field + 1) % fieldCount), true
+	case "shift+tab", "up":
+		return p.focus((p.field + fieldCount - 1) % fieldCount), true
+	case "enter", "ctrl+r":
+		p.blur()
+		return p.send(), true
+	case "esc":
+		p.blur()
+		return nil, true
+	case "ctrl+c":
+		return tea.Quit, true
+	default:
+		var cmd tea.Cmd
+		p.inputs[p.field], cmd = p.inputs[p.field].Update(msg)
+		return cmd, true
+	}
+}
+
+// GetHelpKeys returns the help keys for the probe page
+func (p *ProbePage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Edit,
+		p.keys.Send,
+		p.keys.Method,
+		p.keys.Reuse,
+		p.keys.ScrollDown,
+		p.keys.ScrollUp,
+		p.keys.Filter,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// Table creation methods
+
+// createHistoryTable creates a table for displaying the probes sent, newest first
+func (p *ProbePage) createHistoryTable(data []models.Probe) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("time", "Time", 8, false).       // Fixed width
+		AddColumn("target", "Target", 15, true).   // Dynamic width, min 15
+		AddColumn("method", "Method", 7, true).    // Fixed width
+		AddColumn("path", "Path", 20, true).       // Dynamic width, min 20
+		AddColumn("status", "Status", 12, true).   // Dynamic width, min 12
+		AddColumn("latency", "Latency", 8, false). // Fixed width
+		AddColumn("size", "Size", 10, false)       // Fixed width
+
+	// Update dynamic column widths based on actual content
+	for _, probe := range data {
+		builder.UpdateWidthFromString("target", probe.Target)
+		builder.UpdateWidthFromString("path", requestPath(probe.Request))
+		builder.UpdateWidthFromString("status", probeStatus(probe))
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, probe := range data {
+			latency, size := "-", "-"
+			if probe.Err == nil {
+				latency = formatLatency(probe.Response.Latency)
+				size = pages.FormatBytes(probe.Response.Size)
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"time":    probe.SentAt.Format("15:04:05"),
+				"target":  probe.Target,
+				"method":  probe.Request.Method,
+				"path":    requestPath(probe.Request),
+				"status":  table.NewStyledCell(probeStatus(probe), lipgloss.NewStyle().Foreground(statusColor(probe))),
+				"latency": latency,
+				"size":    size,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions, leaving the history a third of the space below the form
+	contentWidth, _ := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   p.historyHeight(),
+	}
+
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// historyHeight is the height of the history table, a third of the space
+// left by the form
+func (p *ProbePage) historyHeight() int {
+	_, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+	return max(5, (contentHeight-formHeight)/3)
+}
+
+// responseHeight is the number of lines of the response pane
+func (p *ProbePage) responseHeight() int {
+	_, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+	return max(3, contentHeight-formHeight-p.historyHeight()-1)
+}
+
+// View rendering methods
+
+// View renders the probe page
+func (p *ProbePage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeProbe,
+	})
+}
+
+// ViewWithHelpContext renders the probe page with help context
+func (p *ProbePage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeProbe
+
+	statusMessage := p.statusMessage
+	if p.editing {
+		statusMessage = "Editing request, tab: next field, enter: send, esc: stop editing"
+	}
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeProbe,
+		Loading:       p.pending > 0,
+		ContextInfo:   p.contextInfo(),
+		Counters:      map[string]int{"probe": len(p.GetData())},
+		FilterActive:  p.GetFilterInput().Focused(),
+		StatusMessage: statusMessage,
+	}
+
+	contentWidth, _ := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: statusContext,
+		HelpContext:   helpContext,
+	})
+
+	history := p.GetTable().View()
+	if len(p.GetData()) == 0 {
+		history = "Press 'enter' to send the request, 'e' to edit it"
+	}
+	return p.layoutSystem.CreateTableLayout(
+		lipgloss.JoinVertical(lipgloss.Left,
+			p.renderForm(contentWidth),
+			history,
+			"",
+			p.renderResponse(contentWidth, p.responseHeight()),
+		),
+		statusContext,
+		helpContext,
+	)
+}
+
+// contextInfo describes the app and revision probed for the status bar
+func (p *ProbePage) contextInfo() map[string]string {
+	info := map[string]string{"app": p.app}
+	if p.revision != "" {
+		info["revision"] = p.revision
+	}
+	return info
+}
+
+// renderForm renders the method, URL and headers of the request
+func (p *ProbePage) renderForm(width int) string {
+	accent := p.layoutSystem.GetStyle("accent")
+	base := "https://" + p.fqdn
+	labels := [fieldCount]string{"Method  ", "URL     " + base, "Headers "}
+	lines := make([]string, 0, formHeight)
+	for i, label := range labels {
+		if p.editing && i == p.field {
+			label = accent.Render(label)
+		}
+		p.inputs[i].Width = max(1, width-lipgloss.Width(label)-1)
+		lines = append(lines, label+p.inputs[i].View())
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(append(lines, ""), "\n"))
+}
+
+// renderResponse renders the status line, headers and body of the selected
+// probe, scrolled by the offset and clipped to height lines
+func (p *ProbePage) renderResponse(width, height int) string {
+	probe, ok := p.GetSelectedItem()
+	if !ok {
+		return ""
+	}
+	if probe.ID != p.shown {
+		p.shown, p.offset = probe.ID, 0
+	}
+
+	summary := fmt.Sprintf("%s %s → ", probe.Request.Method, probe.Request.URL)
+	if probe.Err != nil {
+		summary += p.layoutSystem.GetStyle("error").Render("error: " + probe.Err.Error())
+		return lipgloss.NewStyle().MaxWidth(width).Render(summary)
+	}
+	summary += lipgloss.NewStyle().Foreground(statusColor(probe)).Render(probe.Response.Status)
+	summary += fmt.Sprintf(" · %s · %s · %s", formatLatency(probe.Response.Latency), pages.FormatBytes(probe.Response.Size), probe.SentAt.Format("15:04:05"))
+
+	lines := responseLines(probe.Response)
+	p.offset = min(p.offset, max(0, len(lines)-(height-1)))
+	lines = lines[p.offset:]
+	if len(lines) > height-1 {
+		lines = append(lines[:height-2], fmt.Sprintf("… %d more lines, ctrl+d to scroll", len(lines)-(height-2)))
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(append([]string{summary}, lines...), "\n"))
+}
+
+// Helper functions
+
+// ParseHeaders reads headers from a specification such as
+// "Accept: application/json; X-Debug: 1"
+func ParseHeaders(spec string) (http.Header, error) {
+	headers := http.Header{}
+	for _, part := range strings.Split(spec, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(part, ":")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" || strings.ContainsAny(name, " \t") {
+			return nil, fmt.Errorf("invalid header %q, expected Name: value", part)
+		}
+		headers.Add(name, strings.TrimSpace(value))
+	}
+	return headers, nil
+}
+
+// FormatHeaders formats headers as read by ParseHeaders, sorted by name
+func FormatHeaders(headers http.Header) string {
+	var parts []string
+	for _, name := range sortedNames(headers) {
+		for _, value := range headers[name] {
+			parts = append(parts, name+": "+value)
+		}
+	}
+	return strings.Join(parts, "; ")
+}
+
+// responseLines returns the headers of a response, sorted by name, a blank
+// line and its body, indented when it is JSON
+func responseLines(resp models.ProbeResponse) []string {
+	var lines []string
+	for _, name := range sortedNames(resp.Headers) {
+		for _, value := range resp.Headers[name] {
+			lines = append(lines, name+": "+value)
+		}
+	}
+	lines = append(lines, "")
+
+	body := resp.Body
+	switch {
+	case body == "":
+		lines = append(lines, "(empty body)")
+		return lines
+	case !utf8.ValidString(body):
+		lines = append(lines, fmt.Sprintf("(%s of binary data)", pages.FormatBytes(resp.Size)))
+		return lines
+	case strings.Contains(resp.Headers.Get("Content-Type"), "json"):
+		var indented bytes.Buffer
+		if json.Indent(&indented, []byte(body), "", "  ") == nil {
+			body = indented.String()
+		}
+	}
+	lines = append(lines, strings.Split(strings.TrimRight(body, "\n"), "\n")...)
+	if resp.Truncated {
+		lines = append(lines, fmt.Sprintf("… showing the first %s of %s", pages.FormatBytes(int64(len(resp.Body))), pages.FormatBytes(resp.Size)))
+	}
+	return lines
+}
+
+// sortedNames returns the names of headers in order
+func sortedNames(headers http.Header) []string {
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// requestPath returns the path and query a request was sent to
+func requestPath(request models.ProbeRequest) string {
+	rest := strings.TrimPrefix(request.URL, "https://")
+	if i := strings.Index(rest, "/"); i >= 0 {
+		return rest[i:]
+	}
+	return "/"
+}
+
+// probeStatus describes the outcome of a probe for the history
+func probeStatus(probe models.Probe) string {
+	if probe.Err != nil {
+		return "Error"
+	}
+	return probe.Response.Status
+}
+
+// statusColor colors a probe by its status class: successes green, redirects
+// yellow, client and server errors red
+func statusColor(probe models.Probe) lipgloss.Color {
+	switch code := probe.Response.StatusCode; {
+	case probe.Err != nil || code >= 400:
+		return pages.GetStatusColor("failed")
+	case code >= 300:
+		return pages.GetStatusColor("pending")
+	default:
+		return pages.GetStatusColor("succeeded")
+	}
+}
+
+// formatLatency formats a latency in milliseconds below a second, e.g. "42ms"
+func formatLatency(latency time.Duration) string {
+	if latency < time.Second {
+		return latency.Round(time.Millisecond).String()
+	}
+	return latency.Round(10 * time.Millisecond).String()
+}
@@ -0,0 +1,197 @@
+package probe
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// sentCall records a call of the send function
+type sentCall struct {
+	target  string
+	request models.ProbeRequest
+}
+
+// createTestPage creates a page probing an app and recording the requests sent
+func createTestPage() (*ProbePage, *[]sentCall) {
+	page := NewProbePage(layouts.NewLayoutSystem(160, 40))
+	page.SetTarget("web-frontend", "", "web-frontend.example.azurecontainerapps.io")
+	sent := &[]sentCall{}
+	page.SetSendFunc(func(target string, request models.ProbeRequest) tea.Cmd {
+		*sent = append(*sent, sentCall{target, request})
+		return nil
+	})
+	return page, sent
+}
+
+// createTestProbe creates an answered probe of the app
+func createTestProbe(id int, path string, code int) models.Probe {
+	return models.Probe{
+		ID:     id,
+		Target: "web-frontend",
+		SentAt: time.Date(2024, 1, 22, 10, 0, id, 0, time.UTC),
+		Request: models.ProbeRequest{
+			Method:  http.MethodGet,
+			URL:     "https://web-frontend.example.azurecontainerapps.io" + path,
+			Headers: http.Header{"Accept": {"application/json"}},
+		},
+		Response: models.ProbeResponse{
+			Status:     fmt.Sprintf("%d %s", code, http.StatusText(code)),
+			StatusCode: code,
+			Headers:    http.Header{"Content-Type": {"application/json"}},
+			Body:       `{"status":"ok","checks":{"db":"up"}}`,
+			Size:       36,
+			Latency:    42 * time.Millisecond,
+		},
+	}
+}
+
+func typeText(page *ProbePage, text string) {
+	for _, r := range text {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+// Test that the request is built from the form as edited
+func TestProbePageSend(t *testing.T) {
+	page, sent := createTestPage()
+
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	if !page.IsEditing() {
+		t.Fatal("Expected 'e' to edit the request")
+	}
+	typeText(page, "healthz?full=1")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyTab})
+	typeText(page, "X-Debug: 1; Host: api.example.com")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if page.IsEditing() {
+		t.Error("Expected enter to stop editing")
+	}
+	if len(*sent) != 1 {
+		t.Fatalf("Expected a request to be sent, got %d", len(*sent))
+	}
+	call := (*sent)[0]
+	if call.target != "web-frontend" || call.request.Method != http.MethodGet {
+		t.Errorf("Expected a GET to web-frontend, got %s to %s", call.request.Method, call.target)
+	}
+	if call.request.URL != "https://web-frontend.example.azurecontainerapps.io/healthz?full=1" {
+		t.Errorf("Unexpected URL %s", call.request.URL)
+	}
+	if call.request.Headers.Get("X-Debug") != "1" || call.request.Headers.Get("Host") != "api.example.com" {
+		t.Errorf("Unexpected headers %v", call.request.Headers)
+	}
+
+	// The method cycles, and the request is sent again as is
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("m")})
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if len(*sent) != 2 || (*sent)[1].request.Method != http.MethodHead {
+		t.Errorf("Expected 'm' then 'r' to send a HEAD request, got %v", *sent)
+	}
+}
+
+// Test that invalid headers are reported instead of sent
+func TestProbePageInvalidHeaders(t *testing.T) {
+	page, sent := createTestPage()
+	page.inputs[fieldHeaders].SetValue("X-Debug 1")
+
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(*sent) != 0 {
+		t.Error("Expected no request to be sent")
+	}
+	if !strings.Contains(page.View(), "invalid header") {
+		t.Error("Expected the invalid header to be reported")
+	}
+}
+
+// Test that answered probes are listed newest first and the newest is shown
+func TestProbePageHistory(t *testing.T) {
+	page, _ := createTestPage()
+	page.AddProbe(createTestProbe(1, "/healthz", http.StatusOK))
+	failed := createTestProbe(2, "/ready", 0)
+	failed.Err = errors.New("dial tcp: connection refused")
+	page.AddProbe(failed)
+	page.AddProbe(createTestProbe(3, "/api/orders", http.StatusServiceUnavailable))
+
+	table := page.GetTable()
+	if table.TotalRows() != 3 {
+		t.Fatalf("Expected 3 probes, got %d", table.TotalRows())
+	}
+	selected, ok := page.GetSelectedItem()
+	if !ok || selected.ID != 3 {
+		t.Errorf("Expected the newest probe to be selected, got %+v", selected)
+	}
+
+	view := page.View()
+	for _, want := range []string{"/api/orders", "Service Unavailable", "42ms", "Error", "Content-Type: application/json", `"db": "up"`} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to contain %q", want)
+		}
+	}
+}
+
+// Test that a request of the history is loaded into the form
+func TestProbePageReuse(t *testing.T) {
+	page, sent := createTestPage()
+	page.AddProbe(createTestProbe(1, "/healthz?full=1", http.StatusOK))
+	page.SetTarget("web-frontend", "web-frontend--v2", "web-frontend--v2.example.azurecontainerapps.io")
+
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("u")})
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if len(*sent) != 1 {
+		t.Fatalf("Expected a request to be sent, got %d", len(*sent))
+	}
+	call := (*sent)[0]
+	if call.target != "web-frontend--v2" || call.request.URL != "https://web-frontend--v2.example.azurecontainerapps.io/healthz?full=1" {
+		t.Errorf("Expected the request to be sent to the revision, got %s to %s", call.request.URL, call.target)
+	}
+	if call.request.Headers.Get("Accept") != "application/json" {
+		t.Errorf("Expected the headers to be reused, got %v", call.request.Headers)
+	}
+}
+
+// Test back navigation
+func TestProbePageBack(t *testing.T) {
+	page, _ := createTestPage()
+	back := false
+	page.SetBackFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("e")})
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	if back || page.IsEditing() {
+		t.Error("Esc should stop editing before going back")
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	if !back {
+		t.Error("Esc should call the back function")
+	}
+}
+
+func TestParseHeaders(t *testing.T) {
+	headers, err := ParseHeaders(" Accept: text/plain ; X-Trace: a:b;; ")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if headers.Get("Accept") != "text/plain" || headers.Get("X-Trace") != "a:b" {
+		t.Errorf("Unexpected headers %v", headers)
+	}
+	if got := FormatHeaders(headers); got != "Accept: text/plain; X-Trace: a:b" {
+		t.Errorf("Unexpected formatted headers %q", got)
+	}
+
+	for _, spec := range []string{"Accept", ": value", "Bad Name: value"} {
+		if _, err := ParseHeaders(spec); err == nil {
+			t.Errorf("Expected %q to be invalid", spec)
+		}
+	}
+}
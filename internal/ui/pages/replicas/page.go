@@ -0,0 +1,383 @@
+package replicas
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// ReplicasPage represents the replicas page using the new page interface system.
+// It displays the replicas of a revision and the health of their containers,
+// with logs and exec actions scoped to the selected replica.
+type ReplicasPage struct {
+	*pages.ActionablePage[models.Replica]
+
+	// Navigation context
+	appName      string
+	appID        string
+	revisionName string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys ReplicasKeyMap
+
+	// Action functions
+	showLogsFunc        func(models.Replica) tea.Cmd
+	execIntoReplicaFunc func(models.Replica) tea.Cmd
+
+	// Navigation functions
+	navigateToContainersFunc func(models.Replica) tea.Cmd
+	backToRevisionsFunc      func() tea.Cmd
+}
+
+// ReplicasKeyMap defines the key bindings for the replicas page
+type ReplicasKeyMap struct {
+	Enter       key.Binding
+	Logs        key.Binding
+	Exec        key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewReplicasPage creates a new replicas page
+func NewReplicasPage(layoutSystem *layouts.LayoutSystem) *ReplicasPage {
+	// Create the base actionable page
+	basePage := pages.NewActionablePage[models.Replica]("Filter replicas...")
+
+	// Create the replicas page
+	page := &ReplicasPage{
+		ActionablePage: basePage,
+		layoutSystem:   layoutSystem,
+		keys:           defaultReplicasKeyMap(),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createReplicasTable)
+
+	// Enable navigation
+	page.SetNavigationFunc(page.handleNavigation)
+
+	// Set up actions
+	page.setupActions()
+
+	return page
+}
+
+// defaultReplicasKeyMap returns the default key bindings for replicas
+func defaultReplicasKeyMap() ReplicasKeyMap {
+	return ReplicasKeyMap{
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "containers"),
+		),
+		Logs: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "logs"),
+		),
+		Exec: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "exec"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetRevisionContext sets the revision context for the replicas page
+func (p *ReplicasPage) SetRevisionContext(appName, appID, revisionName string) {
+	p.appName = appName
+	p.appID = appID
+	p.revisionName = revisionName
+}
+
+// SetShowLogsFunc sets the function to call for showing the logs of a replica
+func (p *ReplicasPage) SetShowLogsFunc(fn func(models.Replica) tea.Cmd) {
+	p.showLogsFunc = fn
+}
+
+// SetExecIntoReplicaFunc sets the function to call for exec into a replica
+func (p *ReplicasPage) SetExecIntoReplicaFunc(fn func(models.Replica) tea.Cmd) {
+	p.execIntoReplicaFunc = fn
+}
+
+// SetNavigateToContainersFunc sets the function to call when navigating to the containers of a replica
+func (p *ReplicasPage) SetNavigateToContainersFunc(fn func(models.Replica) tea.Cmd) {
+	p.navigateToContainersFunc = fn
+}
+
+// SetBackToRevisionsFunc sets the function to call when going back to revisions
+func (p *ReplicasPage) SetBackToRevisionsFunc(fn func() tea.Cmd) {
+	p.backToRevisionsFunc = fn
+	p.SetBackFunc(fn)
+}
+
+// Action setup
+
+// setupActions configures the available actions for the replicas page
+func (p *ReplicasPage) setupActions() {
+	// Add logs action
+	p.AddAction("logs", p.keys.Logs, func(replica models.Replica) tea.Cmd {
+		if p.showLogsFunc != nil {
+			return p.showLogsFunc(replica)
+		}
+		return nil
+	})
+
+	// Add exec action
+	p.AddAction("exec", p.keys.Exec, func(replica models.Replica) tea.Cmd {
+		if p.execIntoReplicaFunc != nil {
+			return p.execIntoReplicaFunc(replica)
+		}
+		return nil
+	})
+}
+
+// Table creation methods
+
+// createReplicasTable creates a table for displaying replicas
+func (p *ReplicasPage) createReplicasTable(data []models.Replica) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("name", "Replica", 20, true).          // Dynamic width, min 20
+		AddColumn("state", "State", 12, true).           // Fixed width
+		AddColumn("ready", "Ready", 7, false).           // Fixed width
+		AddColumn("restarts", "Restarts", 10, false).    // Fixed width
+		AddColumn("containers", "Containers", 20, true). // Dynamic width, min 20
+		AddColumn("created", "Created", 20, false)       // Fixed width
+
+	// Update dynamic column widths based on actual content
+	for _, replica := range data {
+		builder.UpdateWidthFromString("name", replica.Name)
+		builder.UpdateWidthFromString("containers", formatContainerReadiness(replica.Containers))
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, replica := range data {
+			state := replica.RunningState
+			if state == "" {
+				state = "Unknown"
+			}
+
+			created := "-"
+			if !replica.CreatedAt.IsZero() {
+				created = replica.CreatedAt.Format("2006-01-02 15:04")
+			}
+
+			ready := fmt.Sprintf("%d/%d", replica.ReadyCount(), len(replica.Containers))
+			readyColor := pages.GetStatusColor("Running")
+			if replica.ReadyCount() < len(replica.Containers) {
+				readyColor = pages.GetStatusColor("Failed")
+			}
+
+			restarts := replica.RestartCount()
+			restartsStyle := lipgloss.NewStyle()
+			if restarts > 0 {
+				restartsStyle = restartsStyle.Foreground(pages.GetStatusColor("Pending"))
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"name":       replica.Name,
+				"state":      table.NewStyledCell(state, lipgloss.NewStyle().Foreground(pages.GetStatusColor(state))),
+				"ready":      table.NewStyledCell(ready, lipgloss.NewStyle().Foreground(readyColor)),
+				"restarts":   table.NewStyledCell(fmt.Sprintf("%d", restarts), restartsStyle),
+				"containers": formatContainerReadiness(replica.Containers),
+				"created":    created,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config).SortByAsc("name")
+}
+
+// Navigation methods
+
+// handleNavigation handles navigation to the selected replica's containers
+func (p *ReplicasPage) handleNavigation(replica models.Replica) tea.Cmd {
+	if p.navigateToContainersFunc != nil {
+		return p.navigateToContainersFunc(replica)
+	}
+	return nil
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the replicas page
+func (p *ReplicasPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// First, try base actionable page key handling
+	if cmd, handled := p.ActionablePage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	// Handle replicas-specific keys
+	switch msg.String() {
+	case "esc":
+		if p.backToRevisionsFunc != nil {
+			return p.backToRevisionsFunc(), true
+		}
+		return nil, true
+	case "?":
+		// Help toggle - let the parent handle this
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// GetHelpKeys returns the help keys for the replicas page
+func (p *ReplicasPage) GetHelpKeys() []key.Binding {
+	baseKeys := []key.Binding{
+		p.keys.Enter,
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+
+	// Add action keys (includes logs and exec)
+	actionKeys := p.GetActionKeys()
+	return append(baseKeys, actionKeys...)
+}
+
+// View rendering methods
+
+// View renders the replicas page
+func (p *ReplicasPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeReplicas,
+	})
+}
+
+// ViewWithHelpContext renders the replicas page with help context
+func (p *ReplicasPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeReplicas
+
+	contextInfo := map[string]string{
+		"app":      p.appName,
+		"revision": p.revisionName,
+	}
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading replicas...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeReplicas,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeReplicas,
+				Error:       err,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:        layouts.ModeReplicas,
+			ContextInfo: contextInfo,
+			Counters:    map[string]int{"count": len(p.GetData())},
+		},
+		helpContext,
+	)
+}
+
+// Helper functions for replica formatting
+
+// formatContainerReadiness lists the containers of a replica with their ready status
+func formatContainerReadiness(containers []models.ReplicaContainer) string {
+	if len(containers) == 0 {
+		return "-"
+	}
+
+	parts := make([]string, len(containers))
+	for i, c := range containers {
+		mark := "✓"
+		if !c.Ready {
+			mark = "✗"
+		}
+		parts[i] = fmt.Sprintf("%s %s", c.Name, mark)
+	}
+	return strings.Join(parts, ", ")
+}
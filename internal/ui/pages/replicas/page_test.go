@@ -0,0 +1,107 @@
+package replicas
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestReplicas() []models.Replica {
+	return []models.Replica{
+		{
+			Name:         "app--v1-5d9c8b7f6-a1b2c",
+			RunningState: "Running",
+			CreatedAt:    time.Date(2024, 1, 20, 10, 0, 0, 0, time.UTC),
+			Containers: []models.ReplicaContainer{
+				{Name: "api", Ready: true, Started: true, RunningState: "Running"},
+			},
+		},
+		{
+			Name:         "app--v1-5d9c8b7f6-d3e4f",
+			RunningState: "NotRunning",
+			CreatedAt:    time.Date(2024, 1, 20, 10, 5, 0, 0, time.UTC),
+			Containers: []models.ReplicaContainer{
+				{Name: "api", Ready: true, Started: true, RestartCount: 1, RunningState: "Running"},
+				{Name: "proxy", Ready: false, Started: false, RestartCount: 4, RunningState: "Waiting"},
+			},
+		},
+	}
+}
+
+// Test replica formatting helpers
+func TestReplicaFormatting(t *testing.T) {
+	replicas := createTestReplicas()
+
+	if got := formatContainerReadiness(replicas[1].Containers); got != "api ✓, proxy ✗" {
+		t.Errorf("Expected per-container readiness, got %q", got)
+	}
+	if got := formatContainerReadiness(nil); got != "-" {
+		t.Errorf("Expected a placeholder without containers, got %q", got)
+	}
+
+	layoutSystem := layouts.NewLayoutSystem(200, 24)
+	page := NewReplicasPage(layoutSystem)
+	page.SetRevisionContext("app", "rg/app", "app--v1")
+	page.SetData(replicas)
+	view := page.View()
+	for _, want := range []string{"1/2", "proxy ✗", "2024-01-20 10:05"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("View should contain %q", want)
+		}
+	}
+}
+
+// Test navigation and replica-scoped actions
+func TestReplicasPageNavigation(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewReplicasPage(layoutSystem)
+	page.SetData(createTestReplicas())
+
+	var selected, logs, exec string
+	page.SetNavigateToContainersFunc(func(replica models.Replica) tea.Cmd {
+		selected = replica.Name
+		return nil
+	})
+	page.SetShowLogsFunc(func(replica models.Replica) tea.Cmd {
+		logs = replica.Name
+		return nil
+	})
+	page.SetExecIntoReplicaFunc(func(replica models.Replica) tea.Cmd {
+		exec = replica.Name
+		return nil
+	})
+
+	// Move the cursor to the second row
+	table := page.GetTable()
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	page.SetTable(table)
+
+	for _, msg := range []tea.KeyMsg{
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRunes, Runes: []rune{'l'}},
+		{Type: tea.KeyRunes, Runes: []rune{'s'}},
+	} {
+		if _, handled := page.HandleKeyMsg(msg); !handled {
+			t.Errorf("Key %q should be handled", msg.String())
+		}
+	}
+
+	want := "app--v1-5d9c8b7f6-d3e4f"
+	if selected != want || logs != want || exec != want {
+		t.Errorf("Expected every action on %s, got containers=%q logs=%q exec=%q", want, selected, logs, exec)
+	}
+
+	back := false
+	page.SetBackToRevisionsFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
@@ -29,25 +29,31 @@ const (
 type ResourceGroupsPage struct {
 	*pages.NavigablePage[models.ResourceGroup]
 
+	// Navigation context
+	subscriptionName string
+
 	// Layout system
 	layoutSystem *layouts.LayoutSystem
 
 	// Key bindings
 	keys ResourceGroupsKeyMap
 
-	// Navigation function
-	navigateToAppsFunc func(models.ResourceGroup) tea.Cmd
+	// Navigation functions
+	navigateToAppsFunc         func(models.ResourceGroup) tea.Cmd
+	navigateToEnvironmentsFunc func(models.ResourceGroup) tea.Cmd
 }
 
 // ResourceGroupsKeyMap defines the key bindings for the resource groups page
 type ResourceGroupsKeyMap struct {
-	Enter       key.Binding
-	Refresh     key.Binding
-	Filter      key.Binding
-	ScrollLeft  key.Binding
-	ScrollRight key.Binding
-	Help        key.Binding
-	Quit        key.Binding
+	Enter        key.Binding
+	Environments key.Binding
+	Refresh      key.Binding
+	Filter       key.Binding
+	ScrollLeft   key.Binding
+	ScrollRight  key.Binding
+	Help         key.Binding
+	Back         key.Binding
+	Quit         key.Binding
 }
 
 // NewResourceGroupsPage creates a new resource groups page
@@ -81,6 +87,10 @@ func defaultResourceGroupsKeyMap() ResourceGroupsKeyMap {
 			key.WithKeys("enter"),
 			key.WithHelp("enter", "select"),
 		),
+		Environments: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "environments"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -101,6 +111,10 @@ func defaultResourceGroupsKeyMap() ResourceGroupsKeyMap {
 			key.WithKeys("?"),
 			key.WithHelp("?", "toggle help"),
 		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "subscriptions"),
+		),
 		Quit: key.NewBinding(
 			key.WithKeys("q", "ctrl+c"),
 			key.WithHelp("q", "quit"),
@@ -110,11 +124,27 @@ func defaultResourceGroupsKeyMap() ResourceGroupsKeyMap {
 
 // Configuration methods
 
+// SetSubscriptionContext sets the name of the subscription the resource groups belong to,
+// empty for the az CLI default subscription
+func (p *ResourceGroupsPage) SetSubscriptionContext(subscriptionName string) {
+	p.subscriptionName = subscriptionName
+}
+
+// SetBackToSubscriptionsFunc sets the function to call when going back to subscriptions
+func (p *ResourceGroupsPage) SetBackToSubscriptionsFunc(fn func() tea.Cmd) {
+	p.SetBackFunc(fn)
+}
+
 // SetNavigateToAppsFunc sets the function to call when navigating to apps
 func (p *ResourceGroupsPage) SetNavigateToAppsFunc(fn func(models.ResourceGroup) tea.Cmd) {
 	p.navigateToAppsFunc = fn
 }
 
+// SetNavigateToEnvironmentsFunc sets the function to call when navigating to managed environments
+func (p *ResourceGroupsPage) SetNavigateToEnvironmentsFunc(fn func(models.ResourceGroup) tea.Cmd) {
+	p.navigateToEnvironmentsFunc = fn
+}
+
 // Table creation methods
 
 // createResourceGroupsTable creates a table for displaying resource groups
@@ -151,6 +181,7 @@ func (p *ResourceGroupsPage) createResourceGroupsTable(data []models.ResourceGro
 				"state":    table.NewStyledCell(state, lipgloss.NewStyle().Foreground(pages.GetStatusColor(state))),
 				"tags":     tags,
 			})
+			rows[i].Data[pages.RowIndexKey] = i
 		}
 	}
 
@@ -194,6 +225,12 @@ func (p *ResourceGroupsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 	case "r":
 		// Refresh
 		return p.Refresh(), true
+	case "e":
+		// Managed environments of the selected resource group
+		if rg, ok := p.GetSelectedItem(); ok && p.navigateToEnvironmentsFunc != nil {
+			return p.navigateToEnvironmentsFunc(rg), true
+		}
+		return nil, true
 	}
 
 	// Don't handle any other keys - let them bubble up
@@ -204,11 +241,13 @@ func (p *ResourceGroupsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 func (p *ResourceGroupsPage) GetHelpKeys() []key.Binding {
 	return []key.Binding{
 		p.keys.Enter,
+		p.keys.Environments,
 		p.keys.Refresh,
 		p.keys.Filter,
 		p.keys.ScrollLeft,
 		p.keys.ScrollRight,
 		p.keys.Help,
+		p.keys.Back,
 		p.keys.Quit,
 	}
 }
@@ -254,12 +293,16 @@ func (p *ResourceGroupsPage) ViewWithHelpContext(helpContext layouts.HelpContext
 
 	// Render the table view
 	tableView := p.GetTable().View()
+	statusContext := layouts.StatusContext{
+		Mode:     layouts.ModeResourceGroups,
+		Counters: map[string]int{"count": len(p.GetData())},
+	}
+	if p.subscriptionName != "" {
+		statusContext.ContextInfo = map[string]string{"subscription": p.subscriptionName}
+	}
 	return p.layoutSystem.CreateTableLayout(
 		tableView,
-		layouts.StatusContext{
-			Mode:     layouts.ModeResourceGroups,
-			Counters: map[string]int{"count": len(p.GetData())},
-		},
+		statusContext,
 		helpContext,
 	)
 }
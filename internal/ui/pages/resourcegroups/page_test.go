@@ -152,6 +152,14 @@ func TestKeyHandling(t *testing.T) {
 			},
 			shouldHandle: true,
 		},
+		{
+			name: "environments key",
+			key: tea.KeyMsg{
+				Type:  tea.KeyRunes,
+				Runes: []rune("e"),
+			},
+			shouldHandle: true,
+		},
 		{
 			name: "help key (should not handle)",
 			key: tea.KeyMsg{
@@ -2,8 +2,11 @@ package revisions
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/evertras/bubble-table/table"
@@ -14,14 +17,32 @@ import (
 	"github.com/IAL32/az-tui/internal/ui/pages"
 )
 
+// headerHeight is the number of lines above the table while a label is edited:
+// the label form and a blank line
+const headerHeight = 2
+
 // RevisionsPage represents the revisions page using the new page interface system.
-// It displays revisions in an actionable table format with restart, logs, and exec actions.
+// It displays revisions in an actionable table format with restart, activation,
+// label, logs, and exec actions, and switches the revision mode of the app.
 type RevisionsPage struct {
 	*pages.ActionablePage[models.Revision]
 
 	// Navigation context
-	appName string
-	appID   string
+	appName      string
+	appID        string
+	revisionMode string
+
+	// Label form, for the revision labelled
+	labelInput    textinput.Model
+	editing       bool
+	editRevision  string
+	statusMessage string
+
+	// Inline metrics of the revisions, by revision name
+	metrics map[string]models.MetricSet
+
+	// Revision to highlight once the revisions are loaded
+	selectName string
 
 	// Layout system
 	layoutSystem *layouts.LayoutSystem
@@ -31,20 +52,38 @@ type RevisionsPage struct {
 
 	// Action functions
 	restartRevisionFunc  func(models.Revision) tea.Cmd
+	activateFunc         func(models.Revision) tea.Cmd
+	deactivateFunc       func(models.Revision) tea.Cmd
+	addLabelFunc         func(rev models.Revision, label string) tea.Cmd
+	removeLabelFunc      func(label string) tea.Cmd
+	setRevisionModeFunc  func(mode string) tea.Cmd
 	showLogsFunc         func(models.Revision) tea.Cmd
 	execIntoRevisionFunc func(models.Revision) tea.Cmd
+	showMetricsFunc      func(models.Revision) tea.Cmd
+	probeFunc            func(models.Revision) tea.Cmd
 
 	// Navigation functions
+	navigateToReplicasFunc   func(models.Revision) tea.Cmd
 	navigateToContainersFunc func(models.Revision) tea.Cmd
+	editTrafficFunc          func() tea.Cmd
 	backToAppsFunc           func() tea.Cmd
 }
 
 // RevisionsKeyMap defines the key bindings for the revisions page
 type RevisionsKeyMap struct {
 	Enter       key.Binding
+	Containers  key.Binding
 	Restart     key.Binding
+	Activate    key.Binding
+	Deactivate  key.Binding
+	AddLabel    key.Binding
+	RemoveLabel key.Binding
+	Mode        key.Binding
+	Traffic     key.Binding
 	Logs        key.Binding
 	Exec        key.Binding
+	Metrics     key.Binding
+	Probe       key.Binding
 	Refresh     key.Binding
 	Filter      key.Binding
 	ScrollLeft  key.Binding
@@ -64,7 +103,11 @@ func NewRevisionsPage(layoutSystem *layouts.LayoutSystem) *RevisionsPage {
 		ActionablePage: basePage,
 		layoutSystem:   layoutSystem,
 		keys:           defaultRevisionsKeyMap(),
+		metrics:        make(map[string]models.MetricSet),
 	}
+	page.labelInput = textinput.New()
+	page.labelInput.Prompt = ""
+	page.labelInput.Placeholder = "label"
 
 	// Set the table creation function
 	page.SetCreateTableFunc(page.createRevisionsTable)
@@ -83,12 +126,40 @@ func defaultRevisionsKeyMap() RevisionsKeyMap {
 	return RevisionsKeyMap{
 		Enter: key.NewBinding(
 			key.WithKeys("enter"),
-			key.WithHelp("enter", "containers"),
+			key.WithHelp("enter", "replicas"),
+		),
+		Containers: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "containers"),
 		),
 		Restart: key.NewBinding(
 			key.WithKeys("R"),
 			key.WithHelp("R", "restart"),
 		),
+		Activate: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "activate"),
+		),
+		Deactivate: key.NewBinding(
+			key.WithKeys("d"),
+			key.WithHelp("d", "deactivate"),
+		),
+		AddLabel: key.NewBinding(
+			key.WithKeys("+"),
+			key.WithHelp("+", "add label"),
+		),
+		RemoveLabel: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", "remove labels"),
+		),
+		Mode: key.NewBinding(
+			key.WithKeys("M"),
+			key.WithHelp("M", "switch revision mode"),
+		),
+		Traffic: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "edit traffic"),
+		),
 		Logs: key.NewBinding(
 			key.WithKeys("l"),
 			key.WithHelp("l", "logs"),
@@ -97,6 +168,14 @@ func defaultRevisionsKeyMap() RevisionsKeyMap {
 			key.WithKeys("s"),
 			key.WithHelp("s", "exec"),
 		),
+		Metrics: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "metrics"),
+		),
+		Probe: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "http probe"),
+		),
 		Refresh: key.NewBinding(
 			key.WithKeys("r"),
 			key.WithHelp("r", "refresh"),
@@ -132,8 +211,41 @@ func defaultRevisionsKeyMap() RevisionsKeyMap {
 
 // SetAppContext sets the app context for the revisions page
 func (p *RevisionsPage) SetAppContext(appName, appID string) {
+	if appID != p.appID {
+		p.metrics = make(map[string]models.MetricSet)
+	}
 	p.appName = appName
 	p.appID = appID
+	p.statusMessage = ""
+	p.blur()
+}
+
+// SetRevisionMode sets the revision mode of the app, single or multiple
+func (p *RevisionsPage) SetRevisionMode(mode string) {
+	p.revisionMode = mode
+}
+
+// SelectRevision highlights a revision once the revisions are loaded
+func (p *RevisionsPage) SelectRevision(name string) {
+	p.selectName = name
+}
+
+// SetRevisions sets the revisions, highlighting the revision to select
+func (p *RevisionsPage) SetRevisions(revisions []models.Revision) {
+	p.SetData(revisions)
+	if p.selectName == "" {
+		return
+	}
+
+	// Rows are sorted by traffic, so look the revision up among the rows shown
+	revisionsTable := p.GetTable()
+	for i, row := range revisionsTable.GetVisibleRows() {
+		if row.Data["name"] == p.selectName {
+			p.SetTable(revisionsTable.WithHighlightedRow(i))
+			break
+		}
+	}
+	p.selectName = ""
 }
 
 // SetRestartRevisionFunc sets the function to call for restarting a revision
@@ -141,6 +253,31 @@ func (p *RevisionsPage) SetRestartRevisionFunc(fn func(models.Revision) tea.Cmd)
 	p.restartRevisionFunc = fn
 }
 
+// SetActivateFunc sets the function to call for activating a revision
+func (p *RevisionsPage) SetActivateFunc(fn func(models.Revision) tea.Cmd) {
+	p.activateFunc = fn
+}
+
+// SetDeactivateFunc sets the function to call for deactivating a revision
+func (p *RevisionsPage) SetDeactivateFunc(fn func(models.Revision) tea.Cmd) {
+	p.deactivateFunc = fn
+}
+
+// SetAddLabelFunc sets the function to call for labelling a revision
+func (p *RevisionsPage) SetAddLabelFunc(fn func(rev models.Revision, label string) tea.Cmd) {
+	p.addLabelFunc = fn
+}
+
+// SetRemoveLabelFunc sets the function to call for removing a label
+func (p *RevisionsPage) SetRemoveLabelFunc(fn func(label string) tea.Cmd) {
+	p.removeLabelFunc = fn
+}
+
+// SetRevisionModeFunc sets the function to call for switching the revision mode
+func (p *RevisionsPage) SetRevisionModeFunc(fn func(mode string) tea.Cmd) {
+	p.setRevisionModeFunc = fn
+}
+
 // SetShowLogsFunc sets the function to call for showing logs
 func (p *RevisionsPage) SetShowLogsFunc(fn func(models.Revision) tea.Cmd) {
 	p.showLogsFunc = fn
@@ -151,11 +288,41 @@ func (p *RevisionsPage) SetExecIntoRevisionFunc(fn func(models.Revision) tea.Cmd
 	p.execIntoRevisionFunc = fn
 }
 
+// SetShowMetricsFunc sets the function to call for showing revision metrics
+func (p *RevisionsPage) SetShowMetricsFunc(fn func(models.Revision) tea.Cmd) {
+	p.showMetricsFunc = fn
+}
+
+// SetProbeFunc sets the function to call for sending HTTP requests to a revision
+func (p *RevisionsPage) SetProbeFunc(fn func(models.Revision) tea.Cmd) {
+	p.probeFunc = fn
+}
+
+// SetMetrics sets the metrics shown inline for a revision, keeping the highlighted row
+func (p *RevisionsPage) SetMetrics(revName string, metrics models.MetricSet) {
+	p.metrics[revName] = metrics
+
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.UpdateTableWithData()
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+}
+
+// SetNavigateToReplicasFunc sets the function to call when navigating to replicas
+func (p *RevisionsPage) SetNavigateToReplicasFunc(fn func(models.Revision) tea.Cmd) {
+	p.navigateToReplicasFunc = fn
+}
+
 // SetNavigateToContainersFunc sets the function to call when navigating to containers
 func (p *RevisionsPage) SetNavigateToContainersFunc(fn func(models.Revision) tea.Cmd) {
 	p.navigateToContainersFunc = fn
 }
 
+// SetEditTrafficFunc sets the function to call when opening the traffic split editor
+func (p *RevisionsPage) SetEditTrafficFunc(fn func() tea.Cmd) {
+	p.editTrafficFunc = fn
+}
+
 // SetBackToAppsFunc sets the function to call when going back to apps
 func (p *RevisionsPage) SetBackToAppsFunc(fn func() tea.Cmd) {
 	p.backToAppsFunc = fn
@@ -166,8 +333,8 @@ func (p *RevisionsPage) SetBackToAppsFunc(fn func() tea.Cmd) {
 
 // setupActions configures the available actions for the revisions page
 func (p *RevisionsPage) setupActions() {
-	// Add restart action
-	p.AddAction("restart", p.keys.Restart, func(rev models.Revision) tea.Cmd {
+	// Add restart action, confirmed first since it interrupts the revision's replicas
+	p.AddDestructiveAction("restart", p.keys.Restart, p.confirmRestart, func(rev models.Revision) tea.Cmd {
 		if p.restartRevisionFunc != nil {
 			return p.restartRevisionFunc(rev)
 		}
@@ -189,6 +356,208 @@ func (p *RevisionsPage) setupActions() {
 		}
 		return nil
 	})
+
+	// Add metrics action
+	p.AddAction("metrics", p.keys.Metrics, func(rev models.Revision) tea.Cmd {
+		if p.showMetricsFunc != nil {
+			return p.showMetricsFunc(rev)
+		}
+		return nil
+	})
+
+	// Add HTTP probe action
+	p.AddAction("probe", p.keys.Probe, func(rev models.Revision) tea.Cmd {
+		if p.probeFunc != nil {
+			return p.probeFunc(rev)
+		}
+		return nil
+	})
+
+	// Add containers action, skipping the replicas of the revision
+	p.AddAction("containers", p.keys.Containers, func(rev models.Revision) tea.Cmd {
+		if p.navigateToContainersFunc != nil {
+			return p.navigateToContainersFunc(rev)
+		}
+		return nil
+	})
+}
+
+// confirmRestart builds the confirmation request for restarting a revision
+func (p *RevisionsPage) confirmRestart(rev models.Revision) pages.ConfirmRequestMsg {
+	resourceGroup, _, _ := strings.Cut(p.appID, "/")
+	return pages.ConfirmRequestMsg{
+		Text:          fmt.Sprintf("Restart revision %s of %s?", rev.Name, p.appName),
+		Resource:      p.appName,
+		ResourceGroup: resourceGroup,
+	}
+}
+
+// confirm returns a command asking for confirmation before running action
+func (p *RevisionsPage) confirm(text string, action func() tea.Cmd) tea.Cmd {
+	resourceGroup, _, _ := strings.Cut(p.appID, "/")
+	request := pages.ConfirmRequestMsg{
+		Text:          text,
+		Resource:      p.appName,
+		ResourceGroup: resourceGroup,
+		OnConfirm:     action,
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
+// activate asks for confirmation of activating the highlighted revision
+func (p *RevisionsPage) activate() tea.Cmd {
+	rev, ok := p.GetSelectedItem()
+	if !ok {
+		return nil
+	}
+	if rev.Active {
+		p.statusMessage = fmt.Sprintf("Revision %s is already active", rev.Name)
+		return nil
+	}
+	return p.confirm(fmt.Sprintf("Activate revision %s of %s?", rev.Name, p.appName), func() tea.Cmd {
+		if p.activateFunc != nil {
+			return p.activateFunc(rev)
+		}
+		return nil
+	})
+}
+
+// deactivate asks for confirmation of deactivating the highlighted revision,
+// which has to be without traffic
+func (p *RevisionsPage) deactivate() tea.Cmd {
+	rev, ok := p.GetSelectedItem()
+	if !ok {
+		return nil
+	}
+	if !rev.Active {
+		p.statusMessage = fmt.Sprintf("Revision %s is already inactive", rev.Name)
+		return nil
+	}
+	if rev.Traffic > 0 {
+		p.statusMessage = fmt.Sprintf("Revision %s receives %d%% of the traffic - shift it away with t first", rev.Name, rev.Traffic)
+		return nil
+	}
+	return p.confirm(fmt.Sprintf("Deactivate revision %s of %s? Its replicas are stopped.", rev.Name, p.appName), func() tea.Cmd {
+		if p.deactivateFunc != nil {
+			return p.deactivateFunc(rev)
+		}
+		return nil
+	})
+}
+
+// removeLabels asks for confirmation of removing the labels of the highlighted revision
+func (p *RevisionsPage) removeLabels() tea.Cmd {
+	rev, ok := p.GetSelectedItem()
+	if !ok {
+		return nil
+	}
+	if len(rev.Labels) == 0 {
+		p.statusMessage = fmt.Sprintf("Revision %s has no labels", rev.Name)
+		return nil
+	}
+	labels := slices.Clone(rev.Labels)
+	text := fmt.Sprintf("Remove label %s from revision %s of %s?", strings.Join(labels, ", "), rev.Name, p.appName)
+	return p.confirm(text, func() tea.Cmd {
+		if p.removeLabelFunc == nil {
+			return nil
+		}
+		cmds := make([]tea.Cmd, len(labels))
+		for i, label := range labels {
+			cmds[i] = p.removeLabelFunc(label)
+		}
+		return tea.Sequence(cmds...)
+	})
+}
+
+// switchRevisionMode asks for confirmation of switching the app to the other revision mode
+func (p *RevisionsPage) switchRevisionMode() tea.Cmd {
+	mode, text := models.RevisionModeMultiple, fmt.Sprintf("Switch %s to multiple revision mode?", p.appName)
+	if strings.EqualFold(p.revisionMode, models.RevisionModeMultiple) {
+		mode = models.RevisionModeSingle
+		text = fmt.Sprintf("Switch %s to single revision mode? Only the latest revision stays active and receives the traffic.", p.appName)
+	}
+	return p.confirm(text, func() tea.Cmd {
+		if p.setRevisionModeFunc != nil {
+			return p.setRevisionModeFunc(mode)
+		}
+		return nil
+	})
+}
+
+// Label form methods
+
+// IsEditing returns true while a label is being edited
+func (p *RevisionsPage) IsEditing() bool {
+	return p.editing
+}
+
+// startEditingLabel opens the label form for the highlighted revision
+func (p *RevisionsPage) startEditingLabel() tea.Cmd {
+	rev, ok := p.GetSelectedItem()
+	if !ok {
+		return nil
+	}
+	p.editRevision = rev.Name
+	p.statusMessage = ""
+	p.labelInput.SetValue("")
+	p.editing = true
+	p.resize()
+	return p.labelInput.Focus()
+}
+
+// submitLabel asks for confirmation of labelling the revision, moving the
+// label from the revision it is on
+func (p *RevisionsPage) submitLabel() tea.Cmd {
+	label := strings.TrimSpace(p.labelInput.Value())
+	if err := models.ValidateRevisionLabel(label); err != nil {
+		p.statusMessage = err.Error()
+		return nil
+	}
+	rev, ok := p.FindItemByPredicate(func(r models.Revision) bool {
+		return r.Name == p.editRevision
+	})
+	if !ok {
+		p.statusMessage = fmt.Sprintf("Revision %s is no longer listed", p.editRevision)
+		return nil
+	}
+	if slices.Contains(rev.Labels, label) {
+		p.statusMessage = fmt.Sprintf("Revision %s is already labelled %s", rev.Name, label)
+		return nil
+	}
+	p.blur()
+	p.statusMessage = ""
+
+	text := fmt.Sprintf("Add label %s to revision %s of %s?", label, rev.Name, p.appName)
+	if current, labelled := p.FindItemByPredicate(func(r models.Revision) bool {
+		return slices.Contains(r.Labels, label)
+	}); labelled {
+		text = fmt.Sprintf("Move label %s of %s from revision %s to %s?", label, p.appName, current.Name, rev.Name)
+	}
+	return p.confirm(text, func() tea.Cmd {
+		if p.addLabelFunc != nil {
+			return p.addLabelFunc(rev, label)
+		}
+		return nil
+	})
+}
+
+// blur closes the label form
+func (p *RevisionsPage) blur() {
+	if p.editing {
+		p.editing = false
+		p.resize()
+	}
+	p.labelInput.Blur()
+}
+
+// resize rebuilds the table when the label form appears or disappears
+func (p *RevisionsPage) resize() {
+	revisionsTable := p.GetTable()
+	row := revisionsTable.GetHighlightedRowIndex()
+	p.UpdateTableWithData()
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
 }
 
 // Table creation methods
@@ -197,21 +566,26 @@ func (p *RevisionsPage) setupActions() {
 func (p *RevisionsPage) createRevisionsTable(data []models.Revision) table.Model {
 	// Create dynamic column builder
 	builder := tablebuilder.NewDynamicColumnBuilder().
-		AddColumn("name", "Revision", 15, true).        // Dynamic width, min 15
-		AddColumn("active", "Active", 8, false).        // Fixed width
-		AddColumn("traffic", "Traffic", 10, false).     // Fixed width
-		AddColumn("replicas", "Replicas", 10, false).   // Fixed width
-		AddColumn("scaling", "Scaling", 12, false).     // Fixed width
-		AddColumn("resources", "Resources", 15, false). // Fixed width
-		AddColumn("health", "Health", 12, true).        // Fixed width
-		AddColumn("running", "Running", 15, true).      // Fixed width
-		AddColumn("created", "Created", 20, false).     // Fixed width
-		AddColumn("status", "Status", 15, true).        // Fixed width
-		AddColumn("fqdn", "FQDN", 60, false)            // Fixed width (longest content)
+		AddColumn("name", "Revision", 15, true).         // Dynamic width, min 15
+		AddColumn("active", "Active", 8, false).         // Fixed width
+		AddColumn("traffic", "Traffic", 10, false).      // Fixed width
+		AddColumn("labels", "Labels", 8, true).          // Dynamic width, min 8
+		AddColumn("replicas", "Replicas", 10, false).    // Fixed width
+		AddColumn("cpu", "CPU 1h", 12, false).           // Fixed width (sparkline)
+		AddColumn("memory", "Memory 1h", 12, false).     // Fixed width (sparkline)
+		AddColumn("requests", "Requests 1h", 12, false). // Fixed width (sparkline)
+		AddColumn("scaling", "Scaling", 12, false).      // Fixed width
+		AddColumn("resources", "Resources", 15, false).  // Fixed width
+		AddColumn("health", "Health", 12, true).         // Fixed width
+		AddColumn("running", "Running", 15, true).       // Fixed width
+		AddColumn("created", "Created", 20, false).      // Fixed width
+		AddColumn("status", "Status", 15, true).         // Fixed width
+		AddColumn("fqdn", "FQDN", 60, false)             // Fixed width (longest content)
 
 	// Update dynamic column widths based on actual content
 	for _, rev := range data {
 		builder.UpdateWidthFromString("name", rev.Name)
+		builder.UpdateWidthFromString("labels", strings.Join(rev.Labels, ", "))
 	}
 
 	// Build columns with calculated widths
@@ -276,11 +650,23 @@ func (p *RevisionsPage) createRevisionsTable(data []models.Revision) table.Model
 				fqdn = "-"
 			}
 
+			// Labels of the traffic split
+			labels := strings.Join(rev.Labels, ", ")
+			if labels == "" {
+				labels = "-"
+			}
+
+			metrics := p.metrics[rev.Name]
+
 			rows[i] = table.NewRow(table.RowData{
 				"name":      rev.Name,
 				"active":    table.NewStyledCell(activeMark, lipgloss.NewStyle().Align(lipgloss.Center)),
 				"traffic":   fmt.Sprintf("%d%%", rev.Traffic),
+				"labels":    labels,
 				"replicas":  replicas,
+				"cpu":       pages.MetricSparkline(metrics, models.MetricCPU),
+				"memory":    pages.MetricSparkline(metrics, models.MetricMemory),
+				"requests":  pages.MetricSparkline(metrics, models.MetricRequests),
 				"scaling":   scaling,
 				"resources": resources,
 				"health":    table.NewStyledCell(health, lipgloss.NewStyle().Foreground(pages.GetStatusColor(health))),
@@ -289,11 +675,15 @@ func (p *RevisionsPage) createRevisionsTable(data []models.Revision) table.Model
 				"status":    table.NewStyledCell(status, lipgloss.NewStyle().Foreground(pages.GetStatusColor(status))),
 				"fqdn":      fqdn,
 			})
+			rows[i].Data[pages.RowIndexKey] = i
 		}
 	}
 
-	// Get content dimensions
+	// Get content dimensions, leaving room for the label form
 	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+	if p.editing {
+		contentHeight = max(5, contentHeight-headerHeight)
+	}
 
 	// Create the table using the unified table builder with theme styling
 	config := tablebuilder.UnifiedTableConfig{
@@ -310,10 +700,10 @@ func (p *RevisionsPage) createRevisionsTable(data []models.Revision) table.Model
 
 // Navigation methods
 
-// handleNavigation handles navigation to the selected revision's containers
+// handleNavigation handles navigation to the selected revision's replicas
 func (p *RevisionsPage) handleNavigation(rev models.Revision) tea.Cmd {
-	if p.navigateToContainersFunc != nil {
-		return p.navigateToContainersFunc(rev)
+	if p.navigateToReplicasFunc != nil {
+		return p.navigateToReplicasFunc(rev)
 	}
 	return nil
 }
@@ -322,6 +712,27 @@ func (p *RevisionsPage) handleNavigation(rev models.Revision) tea.Cmd {
 
 // HandleKeyMsg handles key messages for the revisions page
 func (p *RevisionsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.editing {
+		return p.handleFormInput(msg)
+	}
+
+	// Handle the revision keys that check the revision before confirming
+	if !p.GetFilterInput().Focused() {
+		p.statusMessage = ""
+		switch {
+		case key.Matches(msg, p.keys.Activate):
+			return p.activate(), true
+		case key.Matches(msg, p.keys.Deactivate):
+			return p.deactivate(), true
+		case key.Matches(msg, p.keys.AddLabel):
+			return p.startEditingLabel(), true
+		case key.Matches(msg, p.keys.RemoveLabel):
+			return p.removeLabels(), true
+		case key.Matches(msg, p.keys.Mode):
+			return p.switchRevisionMode(), true
+		}
+	}
+
 	// First, try base actionable page key handling
 	if cmd, handled := p.ActionablePage.HandleKeyMsg(msg); handled {
 		return cmd, handled
@@ -329,6 +740,12 @@ func (p *RevisionsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 
 	// Handle revisions-specific keys
 	switch msg.String() {
+	case "t":
+		// The traffic split covers every revision, so no selection is needed
+		if p.editTrafficFunc != nil {
+			return p.editTrafficFunc(), true
+		}
+		return nil, true
 	case "esc":
 		if p.backToAppsFunc != nil {
 			return p.backToAppsFunc(), true
@@ -342,10 +759,34 @@ func (p *RevisionsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 	return nil, false
 }
 
+// handleFormInput handles keys while the label form is open
+func (p *RevisionsPage) handleFormInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "enter":
+		return p.submitLabel(), true
+	case "esc":
+		p.blur()
+		p.statusMessage = ""
+		return nil, true
+	case "ctrl+c":
+		return tea.Quit, true
+	default:
+		var cmd tea.Cmd
+		p.labelInput, cmd = p.labelInput.Update(msg)
+		return cmd, true
+	}
+}
+
 // GetHelpKeys returns the help keys for the revisions page
 func (p *RevisionsPage) GetHelpKeys() []key.Binding {
 	baseKeys := []key.Binding{
 		p.keys.Enter,
+		p.keys.Activate,
+		p.keys.Deactivate,
+		p.keys.AddLabel,
+		p.keys.RemoveLabel,
+		p.keys.Mode,
+		p.keys.Traffic,
 		p.keys.Refresh,
 		p.keys.Filter,
 		p.keys.ScrollLeft,
@@ -355,7 +796,7 @@ func (p *RevisionsPage) GetHelpKeys() []key.Binding {
 		p.keys.Quit,
 	}
 
-	// Add action keys (includes restart, logs, and exec)
+	// Add action keys (includes restart, logs, exec, metrics, and containers)
 	actionKeys := p.GetActionKeys()
 	return append(baseKeys, actionKeys...)
 }
@@ -375,13 +816,18 @@ func (p *RevisionsPage) ViewWithHelpContext(helpContext layouts.HelpContext) str
 	// Ensure the mode is set correctly
 	helpContext.Mode = layouts.ModeRevisions
 
+	contextInfo := map[string]string{"app": p.appName}
+	if p.revisionMode != "" {
+		contextInfo["revisions"] = strings.ToLower(p.revisionMode)
+	}
+
 	// Handle loading state
 	if p.IsLoading() {
 		return p.layoutSystem.CreateLoadingLayout(
 			"Loading revisions...",
 			layouts.StatusContext{
 				Mode:        layouts.ModeRevisions,
-				ContextInfo: map[string]string{"app": p.appName},
+				ContextInfo: contextInfo,
 			},
 			helpContext,
 		)
@@ -395,23 +841,47 @@ func (p *RevisionsPage) ViewWithHelpContext(helpContext layouts.HelpContext) str
 			layouts.StatusContext{
 				Mode:        layouts.ModeRevisions,
 				Error:       err,
-				ContextInfo: map[string]string{"app": p.appName},
+				ContextInfo: contextInfo,
 			},
 			helpContext,
 		)
 	}
 
-	// Render the table view
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeRevisions,
+		ContextInfo:   contextInfo,
+		Counters:      map[string]int{"count": len(p.GetData())},
+		StatusMessage: p.currentStatusMessage(),
+	}
+
+	// Render the label form above the table while editing
 	tableView := p.GetTable().View()
-	return p.layoutSystem.CreateTableLayout(
-		tableView,
-		layouts.StatusContext{
-			Mode:        layouts.ModeRevisions,
-			ContextInfo: map[string]string{"app": p.appName},
-			Counters:    map[string]int{"count": len(p.GetData())},
-		},
-		helpContext,
-	)
+	if p.editing {
+		contentWidth, _ := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+			StatusContext: statusContext,
+			HelpContext:   helpContext,
+		})
+		tableView = lipgloss.JoinVertical(lipgloss.Left, p.renderForm(contentWidth), tableView)
+	}
+	return p.layoutSystem.CreateTableLayout(tableView, statusContext, helpContext)
+}
+
+// currentStatusMessage reports the form keys while editing, or why an action was refused
+func (p *RevisionsPage) currentStatusMessage() string {
+	if p.statusMessage != "" {
+		return p.statusMessage
+	}
+	if p.editing {
+		return "Labelling " + p.editRevision + ", enter: add, esc: cancel"
+	}
+	return ""
+}
+
+// renderForm renders the label input for the revision labelled
+func (p *RevisionsPage) renderForm(width int) string {
+	label := p.layoutSystem.GetStyle("accent").Render("Label for " + p.editRevision + " ")
+	p.labelInput.Width = max(1, width-lipgloss.Width(label)-1)
+	return lipgloss.NewStyle().MaxWidth(width).Render(label + p.labelInput.View() + "\n")
 }
 
 // Helper functions
@@ -0,0 +1,187 @@
+package revisions
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// createTestPage creates a page showing a stable revision, a labelled canary and an inactive revision
+func createTestPage() *RevisionsPage {
+	page := NewRevisionsPage(layouts.NewLayoutSystem(200, 24))
+	page.SetAppContext("web", "rg-test/web")
+	page.SetRevisionMode(models.RevisionModeMultiple)
+	page.SetRevisions([]models.Revision{
+		{Name: "web--v2", Active: true, Traffic: 90, Labels: []string{"stable"}},
+		{Name: "web--v3", Active: true, Traffic: 10, Labels: []string{"canary"}},
+		{Name: "web--v1", Active: false},
+	})
+	return page
+}
+
+func typeText(page *RevisionsPage, text string) {
+	for _, r := range text {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func pressKey(page *RevisionsPage, k string) tea.Cmd {
+	cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return cmd
+}
+
+// highlight highlights the row of a revision
+func highlight(t *testing.T, page *RevisionsPage, name string) {
+	t.Helper()
+	revisionsTable := page.GetTable()
+	for i, row := range revisionsTable.GetVisibleRows() {
+		if row.Data["name"] == name {
+			page.SetTable(revisionsTable.WithHighlightedRow(i))
+			return
+		}
+	}
+	t.Fatalf("Revision %s not listed", name)
+}
+
+// Test that the labels and revision mode are shown
+func TestRevisionsPageView(t *testing.T) {
+	page := createTestPage()
+
+	view := page.View()
+	for _, want := range []string{"Labels", "stable", "canary", "multiple"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to contain %q", want)
+		}
+	}
+}
+
+// Test activating and deactivating revisions, refusing revisions in the wrong state
+func TestRevisionsPageActivation(t *testing.T) {
+	page := createTestPage()
+
+	var activated, deactivated []string
+	page.SetActivateFunc(func(rev models.Revision) tea.Cmd {
+		activated = append(activated, rev.Name)
+		return nil
+	})
+	page.SetDeactivateFunc(func(rev models.Revision) tea.Cmd {
+		deactivated = append(deactivated, rev.Name)
+		return nil
+	})
+
+	// A revision receiving traffic is neither activated nor deactivated
+	highlight(t, page, "web--v3")
+	if pressKey(page, "a") != nil || page.statusMessage == "" {
+		t.Error("Expected an active revision not to be activated")
+	}
+	if pressKey(page, "d") != nil || !strings.Contains(page.currentStatusMessage(), "10% of the traffic") {
+		t.Errorf("Expected a revision with traffic not to be deactivated, got %q", page.statusMessage)
+	}
+
+	highlight(t, page, "web--v1")
+	cmd := pressKey(page, "a")
+	if cmd == nil {
+		t.Fatal("Expected a to request confirmation")
+	}
+	request := cmd().(pages.ConfirmRequestMsg)
+	if request.Text != "Activate revision web--v1 of web?" || request.Resource != "web" || request.ResourceGroup != "rg-test" {
+		t.Errorf("Unexpected confirmation %+v", request)
+	}
+	request.OnConfirm()
+	if len(activated) != 1 || activated[0] != "web--v1" || len(deactivated) != 0 {
+		t.Errorf("Expected web--v1 to be activated, got %v and %v", activated, deactivated)
+	}
+}
+
+// Test adding, moving and removing labels
+func TestRevisionsPageLabels(t *testing.T) {
+	page := createTestPage()
+
+	var added, removed []string
+	page.SetAddLabelFunc(func(rev models.Revision, label string) tea.Cmd {
+		added = append(added, rev.Name+"="+label)
+		return nil
+	})
+	page.SetRemoveLabelFunc(func(label string) tea.Cmd {
+		removed = append(removed, label)
+		return nil
+	})
+
+	// Invalid and duplicate labels are rejected
+	highlight(t, page, "web--v3")
+	for _, label := range []string{"", "Canary", "1st", "next-", "canary"} {
+		pressKey(page, "+")
+		if !page.IsEditing() {
+			t.Fatal("Expected + to open the label form")
+		}
+		typeText(page, label)
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+		if !page.IsEditing() || page.statusMessage == "" {
+			t.Errorf("Expected label %q to be rejected", label)
+		}
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	}
+	if page.IsEditing() {
+		t.Fatal("Expected esc to close the form")
+	}
+
+	// A label on another revision is moved
+	pressKey(page, "+")
+	typeText(page, "stable")
+	cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if page.IsEditing() || cmd == nil {
+		t.Fatalf("Expected enter to request confirmation, got %q", page.statusMessage)
+	}
+	request := cmd().(pages.ConfirmRequestMsg)
+	if request.Text != "Move label stable of web from revision web--v2 to web--v3?" {
+		t.Errorf("Expected the confirmation to move the label, got %q", request.Text)
+	}
+	request.OnConfirm()
+	if len(added) != 1 || added[0] != "web--v3=stable" {
+		t.Errorf("Expected stable to be added to web--v3, got %v", added)
+	}
+
+	// Revisions without labels have nothing to remove
+	highlight(t, page, "web--v1")
+	if pressKey(page, "-") != nil {
+		t.Error("Expected no removal for a revision without labels")
+	}
+
+	highlight(t, page, "web--v2")
+	cmd = pressKey(page, "-")
+	if cmd == nil {
+		t.Fatal("Expected - to request confirmation")
+	}
+	cmd().(pages.ConfirmRequestMsg).OnConfirm()
+	if len(removed) != 1 || removed[0] != "stable" {
+		t.Errorf("Expected stable to be removed, got %v", removed)
+	}
+}
+
+// Test switching between multiple and single revision mode
+func TestRevisionsPageRevisionMode(t *testing.T) {
+	page := createTestPage()
+
+	var modes []string
+	page.SetRevisionModeFunc(func(mode string) tea.Cmd {
+		modes = append(modes, mode)
+		return nil
+	})
+
+	request := pressKey(page, "M")().(pages.ConfirmRequestMsg)
+	if !strings.Contains(request.Text, "single revision mode") {
+		t.Errorf("Expected a switch to single revision mode, got %q", request.Text)
+	}
+	request.OnConfirm()
+
+	page.SetRevisionMode(models.RevisionModeSingle)
+	pressKey(page, "M")().(pages.ConfirmRequestMsg).OnConfirm()
+
+	if len(modes) != 2 || modes[0] != models.RevisionModeSingle || modes[1] != models.RevisionModeMultiple {
+		t.Errorf("Expected the mode to switch to single then multiple, got %v", modes)
+	}
+}
@@ -0,0 +1,731 @@
+package scale
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// headerHeight is the number of lines above the table: the replica bounds or
+// the form, with the blank line below them
+const headerHeight = 3
+
+// metadataSeparator separates the key=value pairs of rule metadata
+const metadataSeparator = "; "
+
+// Fields of the forms. The replicas form moves through min and max with tab,
+// the metadata form only has the metadata of the rule edited.
+const (
+	fieldMin = iota
+	fieldMax
+	fieldMetadata
+	fieldCount
+)
+
+// ScalePage represents the scale page using the new page interface system.
+// It shows the replica bounds of an app above its KEDA scale rules. The bounds
+// and the metadata of the rules are edited in an inline form and the staged
+// changes are applied at once, which creates a new revision of the app.
+type ScalePage struct {
+	*pages.ReadOnlyPage[models.ScaleRule]
+
+	// Navigation context
+	appName string
+	appID   string
+
+	// Scale as loaded and with the staged changes
+	original models.Scale
+	staged   models.Scale
+	loaded   bool
+
+	// Form; editRule is the rule whose metadata is edited, empty when editing
+	// the replica bounds
+	inputs   [fieldCount]textinput.Model
+	editing  bool
+	field    int
+	editRule string
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys ScaleKeyMap
+
+	// Action function
+	applyFunc func(models.Scale) tea.Cmd
+
+	// Back navigation function
+	backFunc func() tea.Cmd
+}
+
+// ScaleKeyMap defines the key bindings for the scale page
+type ScaleKeyMap struct {
+	Replicas    key.Binding
+	Edit        key.Binding
+	Undo        key.Binding
+	Apply       key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewScalePage creates a new scale page
+func NewScalePage(layoutSystem *layouts.LayoutSystem) *ScalePage {
+	// Create the base read-only page
+	basePage := pages.NewReadOnlyPage[models.ScaleRule]("Filter scale rules...")
+
+	// Create the scale page
+	page := &ScalePage{
+		ReadOnlyPage: basePage,
+		layoutSystem: layoutSystem,
+		keys:         defaultScaleKeyMap(),
+	}
+	for i, placeholder := range []string{"0", "10", "key=value; key=value"} {
+		input := textinput.New()
+		input.Prompt = ""
+		input.Placeholder = placeholder
+		page.inputs[i] = input
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createScaleTable)
+
+	return page
+}
+
+// defaultScaleKeyMap returns the default key bindings for the scale page
+func defaultScaleKeyMap() ScaleKeyMap {
+	return ScaleKeyMap{
+		Replicas: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "min/max replicas"),
+		),
+		Edit: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit rule metadata"),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo changes"),
+		),
+		Apply: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "apply changes"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetAppContext sets the app whose scale is shown, discarding any unapplied changes
+func (p *ScalePage) SetAppContext(appName, appID string) {
+	p.appName = appName
+	p.appID = appID
+	p.original = models.Scale{}
+	p.staged = models.Scale{}
+	p.loaded = false
+	p.statusMessage = ""
+	p.blur()
+}
+
+// GetAppID returns the ID of the app whose scale is shown
+func (p *ScalePage) GetAppID() string {
+	return p.appID
+}
+
+// SetApplyFunc sets the function to call for applying the staged scale
+func (p *ScalePage) SetApplyFunc(fn func(models.Scale) tea.Cmd) {
+	p.applyFunc = fn
+}
+
+// SetBackFunc sets the function to call when navigating back
+func (p *ScalePage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// Data methods
+
+// SetScale sets the scale as loaded. The staged changes are kept when the
+// scale is reloaded unchanged, and discarded otherwise.
+func (p *ScalePage) SetScale(scale models.Scale) {
+	if !p.loaded || !scalesEqual(scale, p.original) {
+		p.staged = scale.Clone()
+		p.statusMessage = ""
+	}
+	p.original = scale.Clone()
+	p.loaded = true
+	p.rebuild()
+}
+
+// GetScale returns the scale with the staged changes
+func (p *ScalePage) GetScale() models.Scale {
+	return p.staged.Clone()
+}
+
+// rebuild shows the rules with the staged changes, keeping the highlighted row
+func (p *ScalePage) rebuild() {
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.SetData(p.staged.Rules)
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+}
+
+// Staging methods
+
+// HasChanges reports whether any change is staged
+func (p *ScalePage) HasChanges() bool {
+	return p.loaded && !scalesEqual(p.original, p.staged)
+}
+
+// ruleChanged reports whether the metadata of a rule differs from the loaded one
+func (p *ScalePage) ruleChanged(rule models.ScaleRule) bool {
+	original, ok := p.originalRule(rule.Name)
+	return ok && !maps.Equal(original.Metadata, rule.Metadata)
+}
+
+// originalRule returns a rule as loaded
+func (p *ScalePage) originalRule(name string) (models.ScaleRule, bool) {
+	for _, rule := range p.original.Rules {
+		if rule.Name == name {
+			return rule, true
+		}
+	}
+	return models.ScaleRule{}, false
+}
+
+// undo restores the scale as loaded
+func (p *ScalePage) undo() {
+	p.staged = p.original.Clone()
+	p.statusMessage = ""
+	p.rebuild()
+}
+
+// apply asks for confirmation of the staged changes, showing their diff
+func (p *ScalePage) apply() tea.Cmd {
+	if !p.HasChanges() {
+		p.statusMessage = "No changes to apply"
+		return nil
+	}
+	if err := p.staged.Validate(); err != nil {
+		p.statusMessage = err.Error()
+		return nil
+	}
+
+	var diff []string
+	if p.original.MinReplicas != p.staged.MinReplicas {
+		diff = append(diff, fmt.Sprintf("  ~ min replicas = %d → %d", p.original.MinReplicas, p.staged.MinReplicas))
+	}
+	if p.original.MaxReplicas != p.staged.MaxReplicas {
+		diff = append(diff, fmt.Sprintf("  ~ max replicas = %d → %d", p.original.MaxReplicas, p.staged.MaxReplicas))
+	}
+	for _, rule := range p.staged.Rules {
+		if original, ok := p.originalRule(rule.Name); ok && p.ruleChanged(rule) {
+			diff = append(diff, fmt.Sprintf("  ~ %s: %s → %s", rule.Name,
+				formatMetadata(original.Metadata), formatMetadata(rule.Metadata)))
+		}
+	}
+
+	scale := p.GetScale()
+	resourceGroup, _, _ := strings.Cut(p.appID, "/")
+	request := pages.ConfirmRequestMsg{
+		Text: fmt.Sprintf("Apply these scale changes to %s? This creates a new revision.\n\n%s",
+			p.appName, strings.Join(diff, "\n")),
+		Resource:      p.appName,
+		ResourceGroup: resourceGroup,
+		OnConfirm: func() tea.Cmd {
+			if p.applyFunc != nil {
+				return p.applyFunc(scale)
+			}
+			return nil
+		},
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
+// Form methods
+
+// IsEditing returns true while the replica bounds or a rule are being edited
+func (p *ScalePage) IsEditing() bool {
+	return p.editing
+}
+
+// startEditingReplicas opens the form for the replica bounds
+func (p *ScalePage) startEditingReplicas() tea.Cmd {
+	if !p.loaded {
+		return nil
+	}
+	p.editRule = ""
+	p.inputs[fieldMin].SetValue(strconv.Itoa(p.staged.MinReplicas))
+	p.inputs[fieldMax].SetValue(strconv.Itoa(p.staged.MaxReplicas))
+	return p.focus(fieldMin)
+}
+
+// startEditingRule opens the form for the metadata of the highlighted rule
+func (p *ScalePage) startEditingRule() tea.Cmd {
+	rule, ok := p.GetSelectedItem()
+	if !ok {
+		return nil
+	}
+	p.editRule = rule.Name
+	p.inputs[fieldMetadata].SetValue(formatMetadata(rule.Metadata))
+	return p.focus(fieldMetadata)
+}
+
+// submit stages the values of the form when the resulting scale is valid
+func (p *ScalePage) submit() {
+	scale := p.staged.Clone()
+	if p.editRule == "" {
+		minReplicas, errMin := strconv.Atoi(strings.TrimSpace(p.inputs[fieldMin].Value()))
+		maxReplicas, errMax := strconv.Atoi(strings.TrimSpace(p.inputs[fieldMax].Value()))
+		if errMin != nil || errMax != nil {
+			p.statusMessage = "Replicas must be whole numbers"
+			return
+		}
+		scale.MinReplicas, scale.MaxReplicas = minReplicas, maxReplicas
+	} else {
+		metadata, err := parseMetadata(p.inputs[fieldMetadata].Value())
+		if err != nil {
+			p.statusMessage = err.Error()
+			return
+		}
+		for i := range scale.Rules {
+			if scale.Rules[i].Name == p.editRule {
+				scale.Rules[i].Metadata = metadata
+			}
+		}
+	}
+	if err := scale.Validate(); err != nil {
+		p.statusMessage = err.Error()
+		return
+	}
+
+	p.blur()
+	p.staged = scale
+	p.statusMessage = ""
+	p.rebuild()
+}
+
+// focus starts editing a field of the form, keeping to the fields of the
+// replica bounds or of the rule edited
+func (p *ScalePage) focus(field int) tea.Cmd {
+	if p.editRule != "" {
+		field = fieldMetadata
+	} else if field == fieldMetadata {
+		field = fieldMin
+	}
+	p.blur()
+	p.editing = true
+	p.field = field
+	p.inputs[field].CursorEnd()
+	return p.inputs[field].Focus()
+}
+
+// blur stops editing the form
+func (p *ScalePage) blur() {
+	p.editing = false
+	for i := range p.inputs {
+		p.inputs[i].Blur()
+	}
+}
+
+// Table creation methods
+
+// createScaleTable creates a table for displaying scale rules
+func (p *ScalePage) createScaleTable(data []models.ScaleRule) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("change", " ", 1, false).          // Fixed width
+		AddColumn("name", "Name", 15, true).         // Min width 15, with filter
+		AddColumn("trigger", "Trigger", 10, true).   // Min width 10, with filter
+		AddColumn("metadata", "Metadata", 20, true). // Min width 20, with filter
+		AddColumn("auth", "Auth", 10, true)
+
+	var rows []table.Row
+
+	// Create rows from the rules, updating column widths based on actual content
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, rule := range data {
+			metadata := formatMetadata(rule.Metadata)
+			auth := formatAuth(rule.Auth)
+			builder.UpdateWidthFromString("name", rule.Name)
+			builder.UpdateWidthFromString("trigger", rule.Trigger())
+			builder.UpdateWidthFromString("metadata", metadata)
+			builder.UpdateWidthFromString("auth", auth)
+
+			var change any = ""
+			if p.ruleChanged(rule) {
+				change = table.NewStyledCell("~", lipgloss.NewStyle().Foreground(pages.GetStatusColor("pending")))
+			}
+			rows[i] = table.NewRow(table.RowData{
+				"change":   change,
+				"name":     rule.Name,
+				"trigger":  rule.Trigger(),
+				"metadata": metadata,
+				"auth":     auth,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Without rules, apps scale on HTTP traffic between their bounds
+	if len(rows) == 0 {
+		rows = []table.Row{
+			table.NewRow(table.RowData{
+				"name":    "No scale rules",
+				"trigger": "",
+			}),
+		}
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	// Get content dimensions, leaving room for the replica bounds
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   max(5, contentHeight-headerHeight),
+	}
+
+	// Rules keep the order of the revision template
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the scale page
+func (p *ScalePage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if p.editing {
+		return p.handleFormInput(msg)
+	}
+
+	// Handle scale specific keys FIRST (before base page)
+	if !p.GetFilterInput().Focused() {
+		switch {
+		case key.Matches(msg, p.keys.Back):
+			if p.backFunc != nil {
+				return p.backFunc(), true
+			}
+			return nil, true
+		case key.Matches(msg, p.keys.Replicas):
+			return p.startEditingReplicas(), true
+		case key.Matches(msg, p.keys.Edit):
+			return p.startEditingRule(), true
+		case key.Matches(msg, p.keys.Undo):
+			p.undo()
+			return nil, true
+		case key.Matches(msg, p.keys.Apply):
+			return p.apply(), true
+		}
+	}
+
+	switch msg.String() {
+	case "?":
+		// Help toggle - let the parent handle this
+		return nil, false
+	case "j", "k", "up", "down":
+		// Navigation keys - let the parent handle these
+		return nil, false
+	}
+
+	// Now try base page key handling for other keys (like filtering and refresh)
+	if cmd, handled := p.ReadOnlyPage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	return nil, false
+}
+
+// handleFormInput handles keys while the form is open
+func (p *ScalePage) handleFormInput(msg tea.KeyMsg) (tea.Cmd, bool) {
+	switch msg.String() {
+	case "tab", "down", "shift+tab", "up":
+		// Only the replicas form has two fields to move between
+		return p.focus(fieldMax - p.field), true
+	case "enter":
+		p.submit()
+		return nil, true
+	case "esc":
+		p.blur()
+		p.statusMessage = ""
+		return nil, true
+	case "ctrl+c":
+		return tea.Quit, true
+	default:
+		var cmd tea.Cmd
+		p.inputs[p.field], cmd = p.inputs[p.field].Update(msg)
+		return cmd, true
+	}
+}
+
+// GetHelpKeys returns the help keys for the scale page
+func (p *ScalePage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Replicas,
+		p.keys.Edit,
+		p.keys.Undo,
+		p.keys.Apply,
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the scale page
+func (p *ScalePage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeScale,
+	})
+}
+
+// ViewWithHelpContext renders the scale page with help context
+func (p *ScalePage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeScale
+
+	contextInfo := map[string]string{"app": p.appName}
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading scale rules...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeScale,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeScale,
+				Error:       err,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	statusContext := layouts.StatusContext{
+		Mode:          layouts.ModeScale,
+		ContextInfo:   contextInfo,
+		Counters:      map[string]int{"count": len(p.GetData())},
+		StatusMessage: p.currentStatusMessage(),
+	}
+	contentWidth, _ := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{
+		StatusContext: statusContext,
+		HelpContext:   helpContext,
+	})
+
+	// Render the replica bounds, or the form while editing, above the table
+	header := p.renderReplicas(contentWidth)
+	if p.editing {
+		header = p.renderForm(contentWidth)
+	}
+	tableView := lipgloss.JoinVertical(lipgloss.Left, header, p.GetTable().View())
+	return p.layoutSystem.CreateTableLayout(tableView, statusContext, helpContext)
+}
+
+// currentStatusMessage reports the form keys while editing and the staged
+// changes otherwise, leaving the status bar to the global message while
+// nothing is staged
+func (p *ScalePage) currentStatusMessage() string {
+	if p.statusMessage != "" {
+		return p.statusMessage
+	}
+	if p.editing && p.editRule == "" {
+		return "Editing replicas, tab: next field, enter: stage, esc: cancel"
+	}
+	if p.editing {
+		return "Editing " + p.editRule + ", enter: stage, esc: cancel"
+	}
+	if !p.HasChanges() {
+		return ""
+	}
+	changed := 0
+	for _, rule := range p.staged.Rules {
+		if p.ruleChanged(rule) {
+			changed++
+		}
+	}
+	replicas := "unchanged"
+	if p.original.MinReplicas != p.staged.MinReplicas || p.original.MaxReplicas != p.staged.MaxReplicas {
+		replicas = "changed"
+	}
+	return fmt.Sprintf("Replicas %s, %d rules changed - press A to apply or u to undo", replicas, changed)
+}
+
+// renderReplicas renders the staged replica bounds, with the loaded ones they change
+func (p *ScalePage) renderReplicas(width int) string {
+	accent := p.layoutSystem.GetStyle("accent")
+	bound := func(original, staged int) string {
+		if original == staged {
+			return strconv.Itoa(staged)
+		}
+		return fmt.Sprintf("%d → %s", original, accent.Render(strconv.Itoa(staged)))
+	}
+	line := fmt.Sprintf("Replicas  min %s  max %s",
+		bound(p.original.MinReplicas, p.staged.MinReplicas),
+		bound(p.original.MaxReplicas, p.staged.MaxReplicas))
+	if !p.loaded {
+		line = "Replicas  -"
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(line + "\n\n")
+}
+
+// renderForm renders the replica bounds or the metadata of the rule edited
+func (p *ScalePage) renderForm(width int) string {
+	accent := p.layoutSystem.GetStyle("accent")
+	fields := []int{fieldMin, fieldMax}
+	if p.editRule != "" {
+		fields = []int{fieldMetadata}
+	}
+	labels := [fieldCount]string{"Min replicas ", "Max replicas ", p.editRule + " "}
+	lines := make([]string, 0, headerHeight)
+	for _, field := range fields {
+		label := labels[field]
+		if field == p.field {
+			label = accent.Render(label)
+		}
+		p.inputs[field].Width = max(1, width-lipgloss.Width(label)-1)
+		lines = append(lines, label+p.inputs[field].View())
+	}
+	for len(lines) < headerHeight {
+		lines = append(lines, "")
+	}
+	return lipgloss.NewStyle().MaxWidth(width).Render(strings.Join(lines, "\n"))
+}
+
+// Reset resets the page state
+func (p *ScalePage) Reset() {
+	p.ReadOnlyPage.Reset()
+	p.appName = ""
+	p.appID = ""
+	p.original = models.Scale{}
+	p.staged = models.Scale{}
+	p.loaded = false
+	p.statusMessage = ""
+	p.blur()
+}
+
+// Helper functions for scale rule formatting
+
+// formatMetadata formats metadata as key=value pairs sorted by key
+func formatMetadata(metadata map[string]string) string {
+	pairs := make([]string, 0, len(metadata))
+	for _, name := range slices.Sorted(maps.Keys(metadata)) {
+		pairs = append(pairs, name+"="+metadata[name])
+	}
+	return strings.Join(pairs, metadataSeparator)
+}
+
+// parseMetadata parses key=value pairs separated by semicolons
+func parseMetadata(text string) (map[string]string, error) {
+	metadata := make(map[string]string)
+	for _, pair := range strings.Split(text, ";") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid metadata %q: use key=value; key=value", pair)
+		}
+		if _, duplicate := metadata[name]; duplicate {
+			return nil, fmt.Errorf("metadata key %s is set twice", name)
+		}
+		metadata[name] = strings.TrimSpace(value)
+	}
+	return metadata, nil
+}
+
+// formatAuth formats the secrets passed to a rule as trigger parameters
+func formatAuth(auth []models.ScaleRuleAuth) string {
+	if len(auth) == 0 {
+		return "-"
+	}
+	params := make([]string, len(auth))
+	for i, a := range auth {
+		params[i] = fmt.Sprintf("%s→%s", a.TriggerParameter, a.SecretRef)
+	}
+	return strings.Join(params, ", ")
+}
+
+// scalesEqual reports whether two scales have the same bounds and rule metadata
+func scalesEqual(a, b models.Scale) bool {
+	return a.MinReplicas == b.MinReplicas && a.MaxReplicas == b.MaxReplicas &&
+		slices.EqualFunc(a.Rules, b.Rules, func(x, y models.ScaleRule) bool {
+			return x.Name == y.Name && x.Type == y.Type && x.CustomType == y.CustomType &&
+				maps.Equal(x.Metadata, y.Metadata) && slices.Equal(x.Auth, y.Auth)
+		})
+}
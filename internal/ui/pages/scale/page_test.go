@@ -0,0 +1,181 @@
+package scale
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// createTestPage creates a page showing an app scaling on HTTP traffic and a queue
+func createTestPage() *ScalePage {
+	page := NewScalePage(layouts.NewLayoutSystem(200, 24))
+	page.SetAppContext("worker", "rg-test/worker")
+	page.SetScale(models.Scale{
+		MinReplicas: 1,
+		MaxReplicas: 5,
+		Rules: []models.ScaleRule{
+			{Name: "http-scaler", Type: models.ScaleRuleHTTP, Metadata: map[string]string{"concurrentRequests": "100"}},
+			{
+				Name:     "queue-depth",
+				Type:     models.ScaleRuleQueue,
+				Metadata: map[string]string{"queueName": "work-items", "queueLength": "25"},
+				Auth:     []models.ScaleRuleAuth{{SecretRef: "queue-connection", TriggerParameter: "connection"}},
+			},
+		},
+	})
+	return page
+}
+
+func typeText(page *ScalePage, text string) {
+	for _, r := range text {
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+}
+
+func pressKey(page *ScalePage, k string) tea.Cmd {
+	cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)})
+	return cmd
+}
+
+// clearInput empties the focused field of the form
+func clearInput(page *ScalePage) {
+	page.inputs[page.field].SetValue("")
+}
+
+// Test that the replica bounds and the rules with their triggers and auth are shown
+func TestScalePageView(t *testing.T) {
+	page := createTestPage()
+
+	table := page.GetTable()
+	if table.TotalRows() != 2 {
+		t.Fatalf("Expected 2 rules, got %d", table.TotalRows())
+	}
+
+	view := page.View()
+	for _, want := range []string{"min 1", "max 5", "azure-queue", "concurrentRequests=100", "queueLength=25; queueName=work-items", "connection→queue-connection"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to contain %q", want)
+		}
+	}
+}
+
+// Test staging new replica bounds and rule metadata, then applying them
+func TestScalePageStageAndApply(t *testing.T) {
+	page := createTestPage()
+
+	var applied []models.Scale
+	page.SetApplyFunc(func(scale models.Scale) tea.Cmd {
+		applied = append(applied, scale)
+		return nil
+	})
+
+	// Change the replica bounds, moving to the max field with tab
+	pressKey(page, "m")
+	if !page.IsEditing() {
+		t.Fatal("Expected m to open the replicas form")
+	}
+	clearInput(page)
+	typeText(page, "2")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyTab})
+	clearInput(page)
+	typeText(page, "8")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if page.IsEditing() {
+		t.Fatalf("Expected enter to stage the bounds, got %q", page.statusMessage)
+	}
+
+	// Change the queue length of the second rule
+	page.SetTable(page.GetTable().WithHighlightedRow(1))
+	pressKey(page, "e")
+	clearInput(page)
+	typeText(page, "queueName=work-items; queueLength=50")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+
+	if !page.HasChanges() {
+		t.Fatal("Expected changes to be staged")
+	}
+	if !strings.Contains(page.View(), "1 → 2") {
+		t.Error("Expected the view to show the changed min replicas")
+	}
+
+	cmd := pressKey(page, "A")
+	if cmd == nil {
+		t.Fatal("Expected apply to request confirmation")
+	}
+	request, ok := cmd().(pages.ConfirmRequestMsg)
+	if !ok {
+		t.Fatalf("Expected a confirmation request, got %T", cmd())
+	}
+	for _, want := range []string{"min replicas = 1 → 2", "max replicas = 5 → 8", "queueLength=25; queueName=work-items → queueLength=50; queueName=work-items"} {
+		if !strings.Contains(request.Text, want) {
+			t.Errorf("Expected the confirmation to contain %q, got:\n%s", want, request.Text)
+		}
+	}
+	if request.Resource != "worker" || request.ResourceGroup != "rg-test" {
+		t.Errorf("Expected the confirmation to name the app, got %q in %q", request.Resource, request.ResourceGroup)
+	}
+
+	request.OnConfirm()
+	if len(applied) != 1 {
+		t.Fatalf("Expected the scale to be applied once, got %d", len(applied))
+	}
+	scale := applied[0]
+	if scale.MinReplicas != 2 || scale.MaxReplicas != 8 || scale.Rules[1].Metadata["queueLength"] != "50" {
+		t.Errorf("Unexpected scale %+v", scale)
+	}
+	if len(scale.Rules[1].Auth) != 1 || scale.Rules[0].Metadata["concurrentRequests"] != "100" {
+		t.Errorf("Expected the unchanged parts of the rules to be kept, got %+v", scale.Rules)
+	}
+}
+
+// Test that invalid values are rejected and that undo restores the scale as loaded
+func TestScalePageValidationAndUndo(t *testing.T) {
+	page := createTestPage()
+
+	// Min replicas above max replicas
+	pressKey(page, "m")
+	clearInput(page)
+	typeText(page, "9")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if !page.IsEditing() || page.statusMessage == "" {
+		t.Error("Expected min replicas above max replicas to be rejected")
+	}
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+
+	// Malformed metadata, then a queue length that is not a number
+	page.SetTable(page.GetTable().WithHighlightedRow(1))
+	for _, metadata := range []string{"queueName", "queueName=work-items; queueLength=many"} {
+		pressKey(page, "e")
+		clearInput(page)
+		typeText(page, metadata)
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+		if !page.IsEditing() || page.statusMessage == "" {
+			t.Errorf("Expected metadata %q to be rejected", metadata)
+		}
+		page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc})
+	}
+	if page.HasChanges() {
+		t.Fatal("Expected no changes to be staged")
+	}
+	if cmd := pressKey(page, "A"); cmd != nil {
+		t.Error("Expected apply without changes to do nothing")
+	}
+
+	// Undo drops a staged change
+	pressKey(page, "m")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyTab})
+	clearInput(page)
+	typeText(page, "7")
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if !page.HasChanges() {
+		t.Fatal("Expected max replicas to be staged")
+	}
+	pressKey(page, "u")
+	if page.HasChanges() || page.GetScale().MaxReplicas != 5 {
+		t.Errorf("Expected undo to restore the scale, got %+v", page.GetScale())
+	}
+}
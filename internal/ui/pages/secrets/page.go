@@ -0,0 +1,395 @@
+package secrets
+
+import (
+	"sort"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// maskedValue is shown in place of the values that have not been revealed
+const maskedValue = "••••••••"
+
+// SecretsPage represents the secrets page using the new page interface system.
+// It lists the secrets of an app with their Key Vault references, keeping
+// their values masked until they are revealed one at a time.
+type SecretsPage struct {
+	*pages.ActionablePage[models.Secret]
+
+	// Navigation context
+	appName string
+	appID   string
+
+	// Values of the revealed secrets, by secret name
+	revealed map[string]string
+
+	// Secret to highlight once the secrets are loaded
+	selectName string
+
+	// Feedback shown in the status bar
+	statusMessage string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys SecretsKeyMap
+
+	// Action functions
+	revealFunc func(models.Secret) tea.Cmd
+
+	// Back navigation function
+	backFunc func() tea.Cmd
+}
+
+// SecretsKeyMap defines the key bindings for the secrets page
+type SecretsKeyMap struct {
+	Reveal      key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewSecretsPage creates a new secrets page
+func NewSecretsPage(layoutSystem *layouts.LayoutSystem) *SecretsPage {
+	// Create the base actionable page
+	basePage := pages.NewActionablePage[models.Secret]("Filter secrets...")
+
+	// Create the secrets page
+	page := &SecretsPage{
+		ActionablePage: basePage,
+		layoutSystem:   layoutSystem,
+		keys:           defaultSecretsKeyMap(),
+		revealed:       make(map[string]string),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createSecretsTable)
+
+	// Set up actions
+	page.setupActions()
+
+	return page
+}
+
+// defaultSecretsKeyMap returns the default key bindings for secrets
+func defaultSecretsKeyMap() SecretsKeyMap {
+	return SecretsKeyMap{
+		Reveal: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "reveal/hide value"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetAppContext sets the app whose secrets are shown, masking the values
+// revealed for another app
+func (p *SecretsPage) SetAppContext(appName, appID string) {
+	if appID != p.appID {
+		p.revealed = make(map[string]string)
+	}
+	p.appName = appName
+	p.appID = appID
+	p.statusMessage = ""
+}
+
+// GetAppID returns the ID of the app whose secrets are shown
+func (p *SecretsPage) GetAppID() string {
+	return p.appID
+}
+
+// SelectSecret highlights a secret once the secrets are loaded
+func (p *SecretsPage) SelectSecret(name string) {
+	p.selectName = name
+}
+
+// SetRevealFunc sets the function to call for showing the value of a secret
+func (p *SecretsPage) SetRevealFunc(fn func(models.Secret) tea.Cmd) {
+	p.revealFunc = fn
+}
+
+// SetBackFunc sets the function to call when navigating back
+func (p *SecretsPage) SetBackFunc(fn func() tea.Cmd) {
+	p.backFunc = fn
+}
+
+// SetStatusMessage sets the feedback shown in the status bar
+func (p *SecretsPage) SetStatusMessage(message string) {
+	p.statusMessage = message
+}
+
+// Data methods
+
+// SetSecrets sets the secrets sorted by name, highlighting the secret to select
+func (p *SecretsPage) SetSecrets(secrets []models.Secret) {
+	sorted := make([]models.Secret, len(secrets))
+	copy(sorted, secrets)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Name < sorted[j].Name })
+
+	p.SetData(sorted)
+	if p.selectName != "" {
+		for i, secret := range sorted {
+			if secret.Name == p.selectName {
+				p.SetTable(p.GetTable().WithHighlightedRow(i))
+				break
+			}
+		}
+		p.selectName = ""
+	}
+}
+
+// SetSecretValue reveals the value of a secret, keeping the highlighted row
+func (p *SecretsPage) SetSecretValue(name, value string) {
+	p.revealed[name] = value
+	p.statusMessage = ""
+	p.refreshTable()
+}
+
+// IsRevealed returns whether the value of a secret is shown
+func (p *SecretsPage) IsRevealed(name string) bool {
+	_, ok := p.revealed[name]
+	return ok
+}
+
+// refreshTable rebuilds the table, keeping the highlighted row
+func (p *SecretsPage) refreshTable() {
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.UpdateTableWithData()
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+}
+
+// Action setup
+
+// setupActions configures the available actions for the secrets page
+func (p *SecretsPage) setupActions() {
+	// Add reveal action, which hides the value again when it is shown
+	p.AddAction("reveal", p.keys.Reveal, func(secret models.Secret) tea.Cmd {
+		if p.IsRevealed(secret.Name) {
+			delete(p.revealed, secret.Name)
+			p.refreshTable()
+			return nil
+		}
+		if p.revealFunc != nil {
+			p.statusMessage = "Reading " + secret.Name + "..."
+			return p.revealFunc(secret)
+		}
+		return nil
+	})
+}
+
+// Table creation methods
+
+// createSecretsTable creates a table for displaying secrets
+func (p *SecretsPage) createSecretsTable(data []models.Secret) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("name", "Name", 20, true).              // Dynamic width, min 20
+		AddColumn("value", "Value", 12, true).            // Dynamic width, min 12
+		AddColumn("keyvault", "Key Vault URL", 16, true). // Dynamic width, min 16
+		AddColumn("identity", "Identity", 10, true)       // Dynamic width, min 10
+
+	maskedStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("#666666"))
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, secret := range data {
+			value, revealed := p.revealed[secret.Name]
+			keyVaultURL := secret.KeyVaultURL
+			if keyVaultURL == "" {
+				keyVaultURL = "-"
+			}
+			identity := secret.Identity
+			if identity == "" {
+				identity = "-"
+			}
+
+			builder.UpdateWidthFromString("name", secret.Name)
+			builder.UpdateWidthFromString("keyvault", keyVaultURL)
+			builder.UpdateWidthFromString("identity", identity)
+
+			var valueCell any = table.NewStyledCell(maskedValue, maskedStyle)
+			if revealed {
+				if value == "" {
+					value = "-"
+				}
+				builder.UpdateWidthFromString("value", value)
+				valueCell = value
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"name":     secret.Name,
+				"value":    valueCell,
+				"keyvault": keyVaultURL,
+				"identity": identity,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the secrets page
+func (p *SecretsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Let an active filter take esc before going back
+	if msg.String() == "esc" && !p.GetFilterInput().Focused() {
+		if p.backFunc != nil {
+			return p.backFunc(), true
+		}
+		return nil, true
+	}
+
+	// Then try base actionable page key handling
+	if cmd, handled := p.ActionablePage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	if msg.String() == "?" {
+		// Help toggle - let the parent handle this
+		return nil, false
+	}
+
+	return nil, false
+}
+
+// GetHelpKeys returns the help keys for the secrets page
+func (p *SecretsPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Reveal,
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the secrets page
+func (p *SecretsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeSecrets,
+	})
+}
+
+// ViewWithHelpContext renders the secrets page with help context
+func (p *SecretsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeSecrets
+
+	contextInfo := map[string]string{"app": p.appName}
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading secrets...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeSecrets,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeSecrets,
+				Error:       err,
+				ContextInfo: contextInfo,
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:          layouts.ModeSecrets,
+			StatusMessage: p.statusMessage,
+			ContextInfo:   contextInfo,
+			Counters:      map[string]int{"count": len(p.GetData())},
+		},
+		helpContext,
+	)
+}
+
+// Reset resets the page state
+func (p *SecretsPage) Reset() {
+	p.ActionablePage.Reset()
+	p.appName = ""
+	p.appID = ""
+	p.revealed = make(map[string]string)
+	p.selectName = ""
+	p.statusMessage = ""
+}
@@ -0,0 +1,118 @@
+package secrets
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestSecrets() []models.Secret {
+	return []models.Secret{
+		{Name: "registry-password"},
+		{
+			Name:        "azure-client-secret",
+			KeyVaultURL: "https://kv-prod.vault.azure.net/secrets/client-secret",
+			Identity:    "system",
+		},
+		{Name: "jwt-secret"},
+	}
+}
+
+// createTestPage creates a page showing the secrets of an app
+func createTestPage() *SecretsPage {
+	page := NewSecretsPage(layouts.NewLayoutSystem(200, 24))
+	page.SetAppContext("api-backend", "rg-prod/api-backend")
+	page.SetSecrets(createTestSecrets())
+	return page
+}
+
+// Test that secrets are listed by name with their Key Vault references and masked values
+func TestSecretsPageData(t *testing.T) {
+	page := createTestPage()
+
+	table := page.GetTable()
+	if table.TotalRows() != 3 {
+		t.Fatalf("Expected 3 rows, got %d", table.TotalRows())
+	}
+	selected, ok := page.GetSelectedItem()
+	if !ok || selected.Name != "azure-client-secret" {
+		t.Errorf("Expected the secrets to be sorted by name, got %+v first", selected)
+	}
+
+	view := page.View()
+	for _, want := range []string{"jwt-secret", "https://kv-prod.vault.azure.net", "system", maskedValue} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the view to contain %q", want)
+		}
+	}
+}
+
+// Test that a value is only read when revealed, and hidden again
+func TestSecretsPageReveal(t *testing.T) {
+	page := createTestPage()
+
+	var revealed []string
+	page.SetRevealFunc(func(secret models.Secret) tea.Cmd {
+		revealed = append(revealed, secret.Name)
+		return nil
+	})
+
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if len(revealed) != 1 || revealed[0] != "azure-client-secret" {
+		t.Fatalf("Expected the selected secret to be read, got %v", revealed)
+	}
+	if page.IsRevealed("azure-client-secret") {
+		t.Error("Expected the value to stay masked until it is read")
+	}
+
+	page.SetSecretValue("azure-client-secret", "kQ8~xT2.mN5v")
+	if !strings.Contains(page.View(), "kQ8~xT2.mN5v") {
+		t.Error("Expected the revealed value to be shown")
+	}
+
+	page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("v")})
+	if len(revealed) != 1 || page.IsRevealed("azure-client-secret") {
+		t.Error("Expected 'v' to hide a revealed value without reading it again")
+	}
+	if strings.Contains(page.View(), "kQ8~xT2.mN5v") {
+		t.Error("Expected the hidden value not to be shown")
+	}
+
+	// Revealed values are not kept for another app
+	page.SetSecretValue("jwt-secret", "c8f1e2d4")
+	page.SetAppContext("web-frontend", "rg-prod/web-frontend")
+	if page.IsRevealed("jwt-secret") {
+		t.Error("Expected the values to be masked again for another app")
+	}
+}
+
+// Test that the secret referenced by an env var is highlighted once loaded
+func TestSecretsPageSelectSecret(t *testing.T) {
+	page := NewSecretsPage(layouts.NewLayoutSystem(200, 24))
+	page.SetAppContext("api-backend", "rg-prod/api-backend")
+	page.SelectSecret("jwt-secret")
+	page.SetSecrets(createTestSecrets())
+
+	selected, ok := page.GetSelectedItem()
+	if !ok || selected.Name != "jwt-secret" {
+		t.Errorf("Expected jwt-secret to be selected, got %+v", selected)
+	}
+}
+
+// Test back navigation
+func TestSecretsPageBack(t *testing.T) {
+	page := createTestPage()
+	back := false
+	page.SetBackFunc(func() tea.Cmd {
+		back = true
+		return nil
+	})
+
+	if _, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEsc}); !handled || !back {
+		t.Error("Esc should call the back function")
+	}
+}
@@ -0,0 +1,278 @@
+package subscriptions
+
+import (
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+const currentMarker = "●"
+
+// SubscriptionsPage represents the subscriptions page using the new page interface system.
+// It displays the subscriptions of the signed-in account in a navigable table format.
+type SubscriptionsPage struct {
+	*pages.NavigablePage[models.Subscription]
+
+	// Subscription every az command currently runs against, empty for the az CLI default
+	currentSubscription string
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys SubscriptionsKeyMap
+
+	// Navigation function
+	navigateToResourceGroupsFunc func(models.Subscription) tea.Cmd
+}
+
+// SubscriptionsKeyMap defines the key bindings for the subscriptions page
+type SubscriptionsKeyMap struct {
+	Enter       key.Binding
+	Refresh     key.Binding
+	Filter      key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Quit        key.Binding
+}
+
+// NewSubscriptionsPage creates a new subscriptions page
+func NewSubscriptionsPage(layoutSystem *layouts.LayoutSystem) *SubscriptionsPage {
+	// Create the base navigable page
+	basePage := pages.NewNavigablePage[models.Subscription]("Filter subscriptions...")
+
+	// Create the subscriptions page
+	page := &SubscriptionsPage{
+		NavigablePage: basePage,
+		layoutSystem:  layoutSystem,
+		keys:          defaultSubscriptionsKeyMap(),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createSubscriptionsTable)
+
+	// Enable navigation
+	page.SetNavigationFunc(page.handleNavigation)
+
+	// Set help keys for the base page
+	page.SetHelpKeys(page.GetHelpKeys())
+
+	return page
+}
+
+// defaultSubscriptionsKeyMap returns the default key bindings for subscriptions
+func defaultSubscriptionsKeyMap() SubscriptionsKeyMap {
+	return SubscriptionsKeyMap{
+		Enter: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "select"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		Filter: key.NewBinding(
+			key.WithKeys("/"),
+			key.WithHelp("/", "filter"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetCurrentSubscription sets the subscription marked as current, empty for the az CLI default
+func (p *SubscriptionsPage) SetCurrentSubscription(subscriptionID string) {
+	p.currentSubscription = subscriptionID
+	p.UpdateTableWithData()
+}
+
+// SetNavigateToResourceGroupsFunc sets the function to call when a subscription is selected
+func (p *SubscriptionsPage) SetNavigateToResourceGroupsFunc(fn func(models.Subscription) tea.Cmd) {
+	p.navigateToResourceGroupsFunc = fn
+}
+
+// Table creation methods
+
+// isCurrent reports whether az commands currently run against the subscription
+func (p *SubscriptionsPage) isCurrent(sub models.Subscription) bool {
+	if p.currentSubscription == "" {
+		return sub.IsDefault
+	}
+	return sub.ID == p.currentSubscription
+}
+
+// createSubscriptionsTable creates a table for displaying subscriptions
+func (p *SubscriptionsPage) createSubscriptionsTable(data []models.Subscription) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("current", "", 3, false).            // Fixed width
+		AddColumn("name", "Name", 30, true).           // Dynamic width, min 30
+		AddColumn("id", "Subscription ID", 36, false). // Fixed width (GUID)
+		AddColumn("state", "State", 12, true).         // Fixed width
+		AddColumn("tenant", "Tenant ID", 36, false)    // Fixed width (GUID)
+
+	// Update dynamic column widths based on actual content
+	for _, sub := range data {
+		builder.UpdateWidthFromString("name", sub.Name)
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, sub := range data {
+			current := ""
+			if p.isCurrent(sub) {
+				current = currentMarker
+			}
+
+			state := sub.State
+			if state == "" {
+				state = "Unknown"
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"current": current,
+				"name":    sub.Name,
+				"id":      sub.ID,
+				"state":   table.NewStyledCell(state, lipgloss.NewStyle().Foreground(pages.GetStatusColor(state))),
+				"tenant":  sub.TenantID,
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	return tablebuilder.CreateUnifiedTable(config).SortByAsc("name")
+}
+
+// Navigation methods
+
+// handleNavigation handles navigation to the selected subscription
+func (p *SubscriptionsPage) handleNavigation(sub models.Subscription) tea.Cmd {
+	if p.navigateToResourceGroupsFunc != nil {
+		return p.navigateToResourceGroupsFunc(sub)
+	}
+	return nil
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the subscriptions page
+func (p *SubscriptionsPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// First, try base navigable page key handling
+	if cmd, handled := p.NavigablePage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	// Handle subscriptions-specific keys
+	switch msg.String() {
+	case "r":
+		// Refresh
+		return p.Refresh(), true
+	}
+
+	// Don't handle any other keys - let them bubble up
+	return nil, false
+}
+
+// GetHelpKeys returns the help keys for the subscriptions page
+func (p *SubscriptionsPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Enter,
+		p.keys.Refresh,
+		p.keys.Filter,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the subscriptions page
+func (p *SubscriptionsPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeSubscriptions,
+	})
+}
+
+// ViewWithHelpContext renders the subscriptions page with help context
+func (p *SubscriptionsPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly, but preserve other context values
+	helpContext.Mode = layouts.ModeSubscriptions
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading subscriptions...",
+			layouts.StatusContext{
+				Mode: layouts.ModeSubscriptions,
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'q' to quit",
+			layouts.StatusContext{
+				Mode:  layouts.ModeSubscriptions,
+				Error: err,
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:     layouts.ModeSubscriptions,
+			Counters: map[string]int{"subscription": len(p.GetData())},
+		},
+		helpContext,
+	)
+}
@@ -6,6 +6,10 @@ import (
 	"github.com/evertras/bubble-table/table"
 )
 
+// RowIndexKey is the row data key pages use to record the data index of each
+// table row. It is not bound to a column, so it is never rendered.
+const RowIndexKey = "__index"
+
 // BaseTablePage provides a generic implementation of TablePage interface.
 // It embeds BasePage and adds table-specific functionality with type safety.
 type BaseTablePage[T any] struct {
@@ -102,15 +106,16 @@ func (btp *BaseTablePage[T]) GetSelectedIndex() int {
 	}
 
 	// Get the currently highlighted row index
-	// Note: This is a simplified implementation - in practice, you might need
-	// to track the selected index more carefully depending on filtering/sorting
 	selectedRow := btp.table.HighlightedRow()
 	if selectedRow.Data == nil {
 		return -1
 	}
 
-	// For now, return 0 as a placeholder - this would need to be implemented
-	// based on the specific table implementation and how row selection works
+	// Rows tagged with RowIndexKey map back to their data index regardless of
+	// filtering and sorting; untagged rows fall back to the first item
+	if index, ok := selectedRow.Data[RowIndexKey].(int); ok {
+		return index
+	}
 	return 0
 }
 
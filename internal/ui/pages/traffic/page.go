@@ -0,0 +1,440 @@
+package traffic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/evertras/bubble-table/table"
+
+	"github.com/IAL32/az-tui/internal/models"
+	tablebuilder "github.com/IAL32/az-tui/internal/ui/components/table"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+)
+
+// weightStep is how much a single key press changes a weight
+const weightStep = 5
+
+// TrafficPage represents the traffic split editor using the new page interface system.
+// It displays the traffic weights of an app's revisions and lets them be adjusted
+// before applying the new split in one operation.
+type TrafficPage struct {
+	*pages.NavigablePage[models.TrafficWeight]
+
+	// Navigation context
+	appName string
+	appID   string
+
+	// Traffic split as loaded, aligned with the edited data
+	original []models.TrafficWeight
+
+	// Layout system
+	layoutSystem *layouts.LayoutSystem
+
+	// Key bindings
+	keys TrafficKeyMap
+
+	// Action function
+	applyFunc func([]models.TrafficWeight) tea.Cmd
+}
+
+// TrafficKeyMap defines the key bindings for the traffic split editor
+type TrafficKeyMap struct {
+	Increase    key.Binding
+	Decrease    key.Binding
+	Undo        key.Binding
+	Apply       key.Binding
+	Refresh     key.Binding
+	ScrollLeft  key.Binding
+	ScrollRight key.Binding
+	Help        key.Binding
+	Back        key.Binding
+	Quit        key.Binding
+}
+
+// NewTrafficPage creates a new traffic split editor page
+func NewTrafficPage(layoutSystem *layouts.LayoutSystem) *TrafficPage {
+	// Create the base navigable page
+	basePage := pages.NewNavigablePage[models.TrafficWeight]("Filter revisions...")
+
+	// Create the traffic page
+	page := &TrafficPage{
+		NavigablePage: basePage,
+		layoutSystem:  layoutSystem,
+		keys:          defaultTrafficKeyMap(),
+	}
+
+	// Set the table creation function
+	page.SetCreateTableFunc(page.createTrafficTable)
+
+	// Set help keys for the base page
+	page.SetHelpKeys(page.GetHelpKeys())
+
+	return page
+}
+
+// defaultTrafficKeyMap returns the default key bindings for the traffic split editor
+func defaultTrafficKeyMap() TrafficKeyMap {
+	return TrafficKeyMap{
+		Increase: key.NewBinding(
+			key.WithKeys("+", "="),
+			key.WithHelp("+", fmt.Sprintf("weight +%d", weightStep)),
+		),
+		Decrease: key.NewBinding(
+			key.WithKeys("-"),
+			key.WithHelp("-", fmt.Sprintf("weight -%d", weightStep)),
+		),
+		Undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo changes"),
+		),
+		Apply: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "apply"),
+		),
+		Refresh: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "refresh"),
+		),
+		ScrollLeft: key.NewBinding(
+			key.WithKeys("shift+left"),
+			key.WithHelp("shift+←", "scroll left"),
+		),
+		ScrollRight: key.NewBinding(
+			key.WithKeys("shift+right"),
+			key.WithHelp("shift+→", "scroll right"),
+		),
+		Help: key.NewBinding(
+			key.WithKeys("?"),
+			key.WithHelp("?", "toggle help"),
+		),
+		Back: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "back"),
+		),
+		Quit: key.NewBinding(
+			key.WithKeys("q", "ctrl+c"),
+			key.WithHelp("q", "quit"),
+		),
+	}
+}
+
+// Configuration methods
+
+// SetAppContext sets the app context for the traffic page
+func (p *TrafficPage) SetAppContext(appName, appID string) {
+	p.appName = appName
+	p.appID = appID
+}
+
+// GetAppID returns the ID of the app whose traffic split is shown
+func (p *TrafficPage) GetAppID() string {
+	return p.appID
+}
+
+// SetApplyFunc sets the function to call once a new traffic split is confirmed
+func (p *TrafficPage) SetApplyFunc(fn func([]models.TrafficWeight) tea.Cmd) {
+	p.applyFunc = fn
+}
+
+// SetBackToRevisionsFunc sets the function to call when going back to revisions
+func (p *TrafficPage) SetBackToRevisionsFunc(fn func() tea.Cmd) {
+	p.SetBackFunc(fn)
+}
+
+// Data methods
+
+// SetData sets the loaded traffic split, discarding any unapplied changes
+func (p *TrafficPage) SetData(weights []models.TrafficWeight) {
+	p.original = weights
+	edited := make([]models.TrafficWeight, len(weights))
+	copy(edited, weights)
+	p.NavigablePage.SetData(edited)
+}
+
+// Total returns the sum of the edited weights
+func (p *TrafficPage) Total() int {
+	total := 0
+	for _, weight := range p.GetData() {
+		total += weight.Weight
+	}
+	return total
+}
+
+// HasChanges reports whether any weight differs from the loaded split
+func (p *TrafficPage) HasChanges() bool {
+	for i, weight := range p.GetData() {
+		if weight.Weight != p.original[i].Weight {
+			return true
+		}
+	}
+	return false
+}
+
+// PendingWeights returns the split to apply. Revisions that neither had nor
+// get any traffic are left out, unless a label keeps them in the split.
+func (p *TrafficPage) PendingWeights() []models.TrafficWeight {
+	var weights []models.TrafficWeight
+	for i, weight := range p.GetData() {
+		if weight.Weight > 0 || p.original[i].Weight > 0 || weight.Label != "" {
+			weights = append(weights, weight)
+		}
+	}
+	return weights
+}
+
+// adjustSelected changes the weight of the highlighted revision, keeping it within 0-100
+func (p *TrafficPage) adjustSelected(delta int) {
+	index := p.GetSelectedIndex()
+	if index < 0 {
+		return
+	}
+
+	data := p.GetData()
+	data[index].Weight = max(0, min(100, data[index].Weight+delta))
+
+	// Rebuild the table without losing the highlighted row
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.UpdateTableWithData()
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+}
+
+// undo restores the loaded traffic split
+func (p *TrafficPage) undo() {
+	highlighted := p.GetTable()
+	row := highlighted.GetHighlightedRowIndex()
+	p.SetData(p.original)
+	p.SetTable(p.GetTable().WithHighlightedRow(row))
+}
+
+// apply asks for confirmation of the edited split, showing the old and new weights
+func (p *TrafficPage) apply() tea.Cmd {
+	if !p.HasChanges() || p.Total() != 100 {
+		return nil
+	}
+
+	var diff []string
+	for i, weight := range p.GetData() {
+		before := p.original[i].Weight
+		line := fmt.Sprintf("  %s  %d%% → %d%%", formatTarget(weight), before, weight.Weight)
+		if before == weight.Weight {
+			line = fmt.Sprintf("  %s  %d%% (unchanged)", formatTarget(weight), before)
+		}
+		diff = append(diff, line)
+	}
+
+	weights := p.PendingWeights()
+	resourceGroup, _, _ := strings.Cut(p.appID, "/")
+	request := pages.ConfirmRequestMsg{
+		Text:          fmt.Sprintf("Apply this traffic split to %s?\n\n%s", p.appName, strings.Join(diff, "\n")),
+		Resource:      p.appName,
+		ResourceGroup: resourceGroup,
+		OnConfirm: func() tea.Cmd {
+			if p.applyFunc != nil {
+				return p.applyFunc(weights)
+			}
+			return nil
+		},
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
+// Table creation methods
+
+// createTrafficTable creates a table for displaying the traffic split
+func (p *TrafficPage) createTrafficTable(data []models.TrafficWeight) table.Model {
+	// Create dynamic column builder
+	builder := tablebuilder.NewDynamicColumnBuilder().
+		AddColumn("revision", "Revision", 15, true). // Dynamic width, min 15
+		AddColumn("label", "Label", 10, true).       // Dynamic width, min 10
+		AddColumn("current", "Current", 9, false).   // Fixed width
+		AddColumn("new", "New", 9, false).           // Fixed width
+		AddColumn("change", "Change", 9, false)      // Fixed width
+
+	// Update dynamic column widths based on actual content
+	for _, weight := range data {
+		builder.UpdateWidthFromString("revision", weight.Target())
+		builder.UpdateWidthFromString("label", weight.Label)
+	}
+
+	// Build columns with calculated widths
+	columns := builder.Build()
+
+	var rows []table.Row
+	if len(data) > 0 {
+		rows = make([]table.Row, len(data))
+		for i, weight := range data {
+			label := weight.Label
+			if label == "" {
+				label = "-"
+			}
+
+			rows[i] = table.NewRow(table.RowData{
+				"revision": weight.Target(),
+				"label":    label,
+				"current":  fmt.Sprintf("%d%%", p.original[i].Weight),
+				"new":      fmt.Sprintf("%d%%", weight.Weight),
+				"change":   formatChange(weight.Weight - p.original[i].Weight),
+			})
+			rows[i].Data[pages.RowIndexKey] = i
+		}
+	}
+
+	// Get content dimensions
+	contentWidth, contentHeight := p.layoutSystem.GetContentDimensions(layouts.LayoutOptions{})
+
+	// Create the table using the unified table builder with theme styling
+	config := tablebuilder.UnifiedTableConfig{
+		Columns:     columns,
+		Rows:        rows,
+		FilterInput: p.GetFilterInput(),
+		BaseStyle:   p.layoutSystem.GetStyle("tableBase"),
+		MaxWidth:    contentWidth,
+		MaxHeight:   contentHeight,
+	}
+
+	// Rows keep the order of the split so the cursor stays put while editing
+	return tablebuilder.CreateUnifiedTable(config)
+}
+
+// Event handling methods
+
+// HandleKeyMsg handles key messages for the traffic page
+func (p *TrafficPage) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
+	// Handle editing keys before the base page claims enter
+	switch {
+	case key.Matches(msg, p.keys.Increase):
+		p.adjustSelected(weightStep)
+		return nil, true
+	case key.Matches(msg, p.keys.Decrease):
+		p.adjustSelected(-weightStep)
+		return nil, true
+	case key.Matches(msg, p.keys.Undo):
+		p.undo()
+		return nil, true
+	case key.Matches(msg, p.keys.Apply):
+		return p.apply(), true
+	case msg.String() == "/":
+		// The split is short enough to not need filtering
+		return nil, true
+	}
+
+	// Then try base navigable page key handling
+	if cmd, handled := p.NavigablePage.HandleKeyMsg(msg); handled {
+		return cmd, handled
+	}
+
+	// Don't handle any other keys - let them bubble up
+	return nil, false
+}
+
+// GetHelpKeys returns the help keys for the traffic page
+func (p *TrafficPage) GetHelpKeys() []key.Binding {
+	return []key.Binding{
+		p.keys.Increase,
+		p.keys.Decrease,
+		p.keys.Undo,
+		p.keys.Apply,
+		p.keys.Refresh,
+		p.keys.ScrollLeft,
+		p.keys.ScrollRight,
+		p.keys.Help,
+		p.keys.Back,
+		p.keys.Quit,
+	}
+}
+
+// View rendering methods
+
+// View renders the traffic page
+func (p *TrafficPage) View() string {
+	// Use default help context (ShowAll = false)
+	return p.ViewWithHelpContext(layouts.HelpContext{
+		Mode: layouts.ModeTraffic,
+	})
+}
+
+// ViewWithHelpContext renders the traffic page with help context
+func (p *TrafficPage) ViewWithHelpContext(helpContext layouts.HelpContext) string {
+	// Ensure the mode is set correctly
+	helpContext.Mode = layouts.ModeTraffic
+
+	// Handle loading state
+	if p.IsLoading() {
+		return p.layoutSystem.CreateLoadingLayout(
+			"Loading traffic split...",
+			layouts.StatusContext{
+				Mode:        layouts.ModeTraffic,
+				ContextInfo: map[string]string{"app": p.appName},
+			},
+			helpContext,
+		)
+	}
+
+	// Handle error state
+	if err := p.GetError(); err != nil {
+		return p.layoutSystem.CreateErrorLayout(
+			err.Error(),
+			"Press 'r' to retry or 'esc' to go back",
+			layouts.StatusContext{
+				Mode:        layouts.ModeTraffic,
+				Error:       err,
+				ContextInfo: map[string]string{"app": p.appName},
+			},
+			helpContext,
+		)
+	}
+
+	// Render the table view
+	tableView := p.GetTable().View()
+	return p.layoutSystem.CreateTableLayout(
+		tableView,
+		layouts.StatusContext{
+			Mode:          layouts.ModeTraffic,
+			ContextInfo:   map[string]string{"app": p.appName},
+			Counters:      map[string]int{"revision": len(p.GetData())},
+			StatusMessage: p.statusMessage(),
+		},
+		helpContext,
+	)
+}
+
+// statusMessage reports the total of an edited split, leaving the status bar
+// to the global message while nothing has changed
+func (p *TrafficPage) statusMessage() string {
+	if !p.HasChanges() {
+		return ""
+	}
+	total := p.Total()
+	if total != 100 {
+		return fmt.Sprintf("Total %d%% - weights must add up to 100%%", total)
+	}
+	return "Total 100% - press enter to apply or u to undo"
+}
+
+// Helper functions for traffic formatting
+
+// formatTarget describes the revision a weight applies to, including its label
+func formatTarget(weight models.TrafficWeight) string {
+	if weight.Label != "" {
+		return fmt.Sprintf("%s (%s)", weight.Target(), weight.Label)
+	}
+	return weight.Target()
+}
+
+// formatChange formats the difference between the new and the current weight
+func formatChange(delta int) string {
+	switch {
+	case delta > 0:
+		return fmt.Sprintf("+%d%%", delta)
+	case delta < 0:
+		return fmt.Sprintf("%d%%", delta)
+	default:
+		return "-"
+	}
+}
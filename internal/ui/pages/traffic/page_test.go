@@ -0,0 +1,125 @@
+package traffic
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/layouts"
+	"github.com/IAL32/az-tui/internal/ui/pages"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Simple test data
+func createTestWeights() []models.TrafficWeight {
+	return []models.TrafficWeight{
+		{RevisionName: "app--v2", Label: "stable", Weight: 80},
+		{RevisionName: "app--v1", Label: "canary", Weight: 20},
+		{RevisionName: "app--v0"},
+	}
+}
+
+// keyPress returns the key message of a single rune
+func keyPress(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+// Test editing weights with +/- and undoing the changes
+func TestTrafficPageEditing(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewTrafficPage(layoutSystem)
+	page.SetData(createTestWeights())
+
+	// Take 10% from the stable revision
+	page.HandleKeyMsg(keyPress('-'))
+	page.HandleKeyMsg(keyPress('-'))
+	if got := page.GetData()[0].Weight; got != 70 {
+		t.Fatalf("Expected stable weight 70, got %d", got)
+	}
+	if !page.HasChanges() || page.Total() != 90 {
+		t.Errorf("Expected pending changes adding up to 90, got %d", page.Total())
+	}
+	if msg := page.statusMessage(); !strings.Contains(msg, "must add up to 100%") {
+		t.Errorf("Expected the status to explain the invalid total, got %q", msg)
+	}
+
+	// Applying an invalid split does nothing
+	if cmd, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}); !handled || cmd != nil {
+		t.Error("Enter should be handled without applying an invalid split")
+	}
+
+	// The cursor stays on the edited row, so + restores the weight
+	page.HandleKeyMsg(keyPress('+'))
+	page.HandleKeyMsg(keyPress('='))
+	if page.HasChanges() {
+		t.Errorf("Expected no changes after restoring the weight, got %+v", page.GetData())
+	}
+
+	// Weights never drop below zero
+	for range 30 {
+		page.HandleKeyMsg(keyPress('-'))
+	}
+	if got := page.GetData()[0].Weight; got != 0 {
+		t.Errorf("Expected stable weight clamped to 0, got %d", got)
+	}
+
+	page.HandleKeyMsg(keyPress('u'))
+	if page.HasChanges() || page.GetData()[0].Weight != 80 {
+		t.Errorf("Expected undo to restore the loaded split, got %+v", page.GetData())
+	}
+}
+
+// Test applying a valid split through the confirmation dialog
+func TestTrafficPageApply(t *testing.T) {
+	layoutSystem := layouts.NewLayoutSystem(120, 24)
+	page := NewTrafficPage(layoutSystem)
+	page.SetAppContext("app", "rg-prod/app")
+	page.SetData(createTestWeights())
+
+	var applied []models.TrafficWeight
+	page.SetApplyFunc(func(weights []models.TrafficWeight) tea.Cmd {
+		applied = weights
+		return nil
+	})
+
+	// Nothing to apply without changes
+	if cmd, _ := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter}); cmd != nil {
+		t.Error("Enter should not apply an unchanged split")
+	}
+
+	// Shift 20% from the stable to the canary revision
+	for range 4 {
+		page.HandleKeyMsg(keyPress('-'))
+	}
+	table := page.GetTable()
+	table, _ = table.Update(tea.KeyMsg{Type: tea.KeyDown})
+	page.SetTable(table)
+	for range 4 {
+		page.HandleKeyMsg(keyPress('+'))
+	}
+
+	cmd, handled := page.HandleKeyMsg(tea.KeyMsg{Type: tea.KeyEnter})
+	if !handled || cmd == nil {
+		t.Fatal("Enter should request confirmation of a valid split")
+	}
+	request, ok := cmd().(pages.ConfirmRequestMsg)
+	if !ok {
+		t.Fatal("Expected a confirmation request")
+	}
+	if request.Resource != "app" || request.ResourceGroup != "rg-prod" {
+		t.Errorf("Expected confirmation for app in rg-prod, got %q in %q", request.Resource, request.ResourceGroup)
+	}
+	for _, line := range []string{"app--v2 (stable)  80% → 60%", "app--v1 (canary)  20% → 40%"} {
+		if !strings.Contains(request.Text, line) {
+			t.Errorf("Expected the diff to contain %q, got:\n%s", line, request.Text)
+		}
+	}
+
+	request.OnConfirm()
+	if len(applied) != 2 {
+		t.Fatalf("Expected the revision without traffic to be left out, got %+v", applied)
+	}
+	if applied[0].Weight != 60 || applied[1].Weight != 40 {
+		t.Errorf("Expected a 60/40 split, got %+v", applied)
+	}
+}
@@ -86,6 +86,18 @@ type ActionablePage[T any] struct {
 	// Action handling
 	actions    map[string]func(T) tea.Cmd
 	actionKeys map[string]key.Binding
+
+	// Confirmation prompts of destructive actions
+	confirmations map[string]func(T) ConfirmRequestMsg
+}
+
+// ConfirmRequestMsg asks the main model to confirm a destructive action before running it.
+// When the resource group is tagged as production the user has to type the resource name.
+type ConfirmRequestMsg struct {
+	Text          string         // question shown in the dialog
+	Resource      string         // name of the affected app or job
+	ResourceGroup string         // resource group of the affected app or job
+	OnConfirm     func() tea.Cmd // runs the action once confirmed
 }
 
 // NewActionablePage creates a new ActionablePage with action capabilities.
@@ -94,6 +106,7 @@ func NewActionablePage[T any](filterPlaceholder string) *ActionablePage[T] {
 		NavigablePage: NewNavigablePage[T](filterPlaceholder),
 		actions:       make(map[string]func(T) tea.Cmd),
 		actionKeys:    make(map[string]key.Binding),
+		confirmations: make(map[string]func(T) ConfirmRequestMsg),
 	}
 }
 
@@ -121,9 +134,17 @@ func (ap *ActionablePage[T]) AddAction(name string, keyBinding key.Binding, acti
 	ap.actionKeys[name] = keyBinding
 }
 
+// AddDestructiveAction adds an action that has to be confirmed before it runs.
+// Pressing its key returns the ConfirmRequestMsg built by confirmFunc instead of running the action.
+func (ap *ActionablePage[T]) AddDestructiveAction(name string, keyBinding key.Binding, confirmFunc func(T) ConfirmRequestMsg, actionFunc func(T) tea.Cmd) {
+	ap.AddAction(name, keyBinding, actionFunc)
+	ap.confirmations[name] = confirmFunc
+}
+
 func (ap *ActionablePage[T]) RemoveAction(name string) {
 	delete(ap.actions, name)
 	delete(ap.actionKeys, name)
+	delete(ap.confirmations, name)
 }
 
 func (ap *ActionablePage[T]) GetActionKeys() []key.Binding {
@@ -165,6 +186,9 @@ func (ap *ActionablePage[T]) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 		for _, bindingKey := range keyBinding.Keys() {
 			if msgKey == bindingKey {
 				if item, ok := ap.GetSelectedItem(); ok {
+					if confirmFunc, destructive := ap.confirmations[actionName]; destructive {
+						return ap.requestConfirmation(actionName, item, confirmFunc), true
+					}
 					return ap.HandleAction(actionName, item), true
 				}
 				return nil, true
@@ -175,6 +199,17 @@ func (ap *ActionablePage[T]) HandleKeyMsg(msg tea.KeyMsg) (tea.Cmd, bool) {
 	return nil, false
 }
 
+// requestConfirmation returns a command asking for confirmation of the action on item
+func (ap *ActionablePage[T]) requestConfirmation(action string, item T, confirmFunc func(T) ConfirmRequestMsg) tea.Cmd {
+	request := confirmFunc(item)
+	request.OnConfirm = func() tea.Cmd {
+		return ap.HandleAction(action, item)
+	}
+	return func() tea.Msg {
+		return request
+	}
+}
+
 // ReadOnlyPage is a specialized page for displaying read-only data.
 // It extends BaseTablePage but removes navigation and actions.
 type ReadOnlyPage[T any] struct {
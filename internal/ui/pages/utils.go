@@ -1,8 +1,11 @@
 package pages
 
 import (
+	"fmt"
 	"strings"
 
+	"github.com/IAL32/az-tui/internal/models"
+	"github.com/IAL32/az-tui/internal/ui/components/sparkline"
 	"github.com/charmbracelet/bubbles/key"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -365,9 +368,34 @@ func GetStatusColor(status string) lipgloss.Color {
 		return lipgloss.Color("#32CD32") // Success green
 	case "failed", "error", "unhealthy":
 		return lipgloss.Color("#FF6B6B") // Error red
-	case "pending", "creating", "updating":
+	case "pending", "creating", "updating", "provisioning", "activating":
 		return lipgloss.Color("#FFB347") // Warning yellow
 	default:
 		return lipgloss.Color("#808080") // Gray
 	}
 }
+
+// SparklineWidth is the width of the inline metric sparklines of table pages
+const SparklineWidth = 12
+
+// MetricSparkline renders a metric of a set as an inline sparkline, "-" if it was not collected
+func MetricSparkline(metrics models.MetricSet, name string) string {
+	if line := sparkline.Render(metrics.Get(name).Values(), SparklineWidth); line != "" {
+		return line
+	}
+	return "-"
+}
+
+// FormatBytes formats a byte count with a binary unit, e.g. "1.5 KiB"
+func FormatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGTPE"[exp])
+}